@@ -2,7 +2,6 @@
 package shared
 
 import (
-	"errors"
 	"fmt"
 	"math"
 	"time"
@@ -40,7 +39,18 @@ func GetHelloGrainClient(c *cluster.Cluster, id string) *HelloGrainClient {
 	if id == "" {
 		panic(fmt.Errorf("empty id"))
 	}
-	return &HelloGrainClient{Identity: id, cluster: c}
+	return &HelloGrainClient{Identity: id, caller: c}
+}
+
+// NewHelloGrainClient instantiates a new HelloGrainClient with given Identity, calling the grain through caller
+func NewHelloGrainClient(caller cluster.GrainCaller, id string) *HelloGrainClient {
+	if caller == nil {
+		panic(fmt.Errorf("nil grain caller"))
+	}
+	if id == "" {
+		panic(fmt.Errorf("empty id"))
+	}
+	return &HelloGrainClient{Identity: id, caller: caller}
 }
 
 // GetHelloKind instantiates a new cluster.Kind for Hello
@@ -77,7 +87,7 @@ type Hello interface {
 // HelloGrainClient holds the base data for the HelloGrain
 type HelloGrainClient struct {
 	Identity string
-	cluster  *cluster.Cluster
+	caller   cluster.GrainCaller
 }
 
 // SayHello requests the execution on to the cluster with CallOptions
@@ -86,24 +96,16 @@ func (g *HelloGrainClient) SayHello(r *HelloRequest, opts ...cluster.GrainCallOp
 	if err != nil {
 		return nil, err
 	}
-	reqMsg := &cluster.GrainRequest{MethodIndex: 0, MessageData: bytes}
-	resp, err := g.cluster.Call(g.Identity, "Hello", reqMsg, opts...)
+	resp, err := g.caller.CallGrain(g.Identity, "Hello", 0, bytes, opts...)
 	if err != nil {
 		return nil, err
 	}
-	switch msg := resp.(type) {
-	case *cluster.GrainResponse:
-		result := &HelloResponse{}
-		err = proto.Unmarshal(msg.MessageData, result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
-	case *cluster.GrainErrorResponse:
-		return nil, errors.New(msg.Err)
-	default:
-		return nil, errors.New("unknown response")
+	result := &HelloResponse{}
+	err = proto.Unmarshal(resp, result)
+	if err != nil {
+		return nil, err
 	}
+	return result, nil
 }
 
 // HelloActor represents the actor structure
@@ -138,7 +140,7 @@ func (a *HelloActor) Receive(ctx actor.Context) {
 			req := &HelloRequest{}
 			err := proto.Unmarshal(msg.MessageData, req)
 			if err != nil {
-				plog.Error("SayHello(HelloRequest) proto.Unmarshal failed.", logmod.Error(err))
+				a.ctx.Cluster().Logger().Error("SayHello(HelloRequest) proto.Unmarshal failed.", logmod.Error(err))
 				resp := &cluster.GrainErrorResponse{Err: err.Error()}
 				ctx.Respond(resp)
 				return
@@ -151,7 +153,7 @@ func (a *HelloActor) Receive(ctx actor.Context) {
 			}
 			bytes, err := proto.Marshal(r0)
 			if err != nil {
-				plog.Error("SayHello(HelloRequest) proto.Marshal failed", logmod.Error(err))
+				a.ctx.Cluster().Logger().Error("SayHello(HelloRequest) proto.Marshal failed", logmod.Error(err))
 				resp := &cluster.GrainErrorResponse{Err: err.Error()}
 				ctx.Respond(resp)
 				return
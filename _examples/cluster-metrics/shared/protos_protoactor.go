@@ -2,7 +2,6 @@
 package shared
 
 import (
-	"errors"
 	"fmt"
 	"math"
 	"time"
@@ -40,7 +39,18 @@ func GetHelloGrainClient(c *cluster.Cluster, id string) *HelloGrainClient {
 	if id == "" {
 		panic(fmt.Errorf("empty id"))
 	}
-	return &HelloGrainClient{Identity: id, cluster: c}
+	return &HelloGrainClient{Identity: id, caller: c}
+}
+
+// NewHelloGrainClient instantiates a new HelloGrainClient with given Identity, calling the grain through caller
+func NewHelloGrainClient(caller cluster.GrainCaller, id string) *HelloGrainClient {
+	if caller == nil {
+		panic(fmt.Errorf("nil grain caller"))
+	}
+	if id == "" {
+		panic(fmt.Errorf("empty id"))
+	}
+	return &HelloGrainClient{Identity: id, caller: caller}
 }
 
 // GetHelloKind instantiates a new cluster.Kind for Hello
@@ -79,7 +89,7 @@ type Hello interface {
 // HelloGrainClient holds the base data for the HelloGrain
 type HelloGrainClient struct {
 	Identity string
-	cluster  *cluster.Cluster
+	caller   cluster.GrainCaller
 }
 
 // SayHello requests the execution on to the cluster with CallOptions
@@ -88,24 +98,16 @@ func (g *HelloGrainClient) SayHello(r *HelloRequest, opts ...cluster.GrainCallOp
 	if err != nil {
 		return nil, err
 	}
-	reqMsg := &cluster.GrainRequest{MethodIndex: 0, MessageData: bytes}
-	resp, err := g.cluster.Call(g.Identity, "Hello", reqMsg, opts...)
+	resp, err := g.caller.CallGrain(g.Identity, "Hello", 0, bytes, opts...)
 	if err != nil {
 		return nil, err
 	}
-	switch msg := resp.(type) {
-	case *cluster.GrainResponse:
-		result := &HelloResponse{}
-		err = proto.Unmarshal(msg.MessageData, result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
-	case *cluster.GrainErrorResponse:
-		return nil, errors.New(msg.Err)
-	default:
-		return nil, errors.New("unknown response")
+	result := &HelloResponse{}
+	err = proto.Unmarshal(resp, result)
+	if err != nil {
+		return nil, err
 	}
+	return result, nil
 }
 
 // Add requests the execution on to the cluster with CallOptions
@@ -114,24 +116,16 @@ func (g *HelloGrainClient) Add(r *AddRequest, opts ...cluster.GrainCallOption) (
 	if err != nil {
 		return nil, err
 	}
-	reqMsg := &cluster.GrainRequest{MethodIndex: 1, MessageData: bytes}
-	resp, err := g.cluster.Call(g.Identity, "Hello", reqMsg, opts...)
+	resp, err := g.caller.CallGrain(g.Identity, "Hello", 1, bytes, opts...)
 	if err != nil {
 		return nil, err
 	}
-	switch msg := resp.(type) {
-	case *cluster.GrainResponse:
-		result := &AddResponse{}
-		err = proto.Unmarshal(msg.MessageData, result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
-	case *cluster.GrainErrorResponse:
-		return nil, errors.New(msg.Err)
-	default:
-		return nil, errors.New("unknown response")
+	result := &AddResponse{}
+	err = proto.Unmarshal(resp, result)
+	if err != nil {
+		return nil, err
 	}
+	return result, nil
 }
 
 // VoidFunc requests the execution on to the cluster with CallOptions
@@ -140,24 +134,16 @@ func (g *HelloGrainClient) VoidFunc(r *AddRequest, opts ...cluster.GrainCallOpti
 	if err != nil {
 		return nil, err
 	}
-	reqMsg := &cluster.GrainRequest{MethodIndex: 2, MessageData: bytes}
-	resp, err := g.cluster.Call(g.Identity, "Hello", reqMsg, opts...)
+	resp, err := g.caller.CallGrain(g.Identity, "Hello", 2, bytes, opts...)
 	if err != nil {
 		return nil, err
 	}
-	switch msg := resp.(type) {
-	case *cluster.GrainResponse:
-		result := &Unit{}
-		err = proto.Unmarshal(msg.MessageData, result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
-	case *cluster.GrainErrorResponse:
-		return nil, errors.New(msg.Err)
-	default:
-		return nil, errors.New("unknown response")
+	result := &Unit{}
+	err = proto.Unmarshal(resp, result)
+	if err != nil {
+		return nil, err
 	}
+	return result, nil
 }
 
 // HelloActor represents the actor structure
@@ -192,7 +178,7 @@ func (a *HelloActor) Receive(ctx actor.Context) {
 			req := &HelloRequest{}
 			err := proto.Unmarshal(msg.MessageData, req)
 			if err != nil {
-				plog.Error("SayHello(HelloRequest) proto.Unmarshal failed.", logmod.Error(err))
+				a.ctx.Cluster().Logger().Error("SayHello(HelloRequest) proto.Unmarshal failed.", logmod.Error(err))
 				resp := &cluster.GrainErrorResponse{Err: err.Error()}
 				ctx.Respond(resp)
 				return
@@ -205,7 +191,7 @@ func (a *HelloActor) Receive(ctx actor.Context) {
 			}
 			bytes, err := proto.Marshal(r0)
 			if err != nil {
-				plog.Error("SayHello(HelloRequest) proto.Marshal failed", logmod.Error(err))
+				a.ctx.Cluster().Logger().Error("SayHello(HelloRequest) proto.Marshal failed", logmod.Error(err))
 				resp := &cluster.GrainErrorResponse{Err: err.Error()}
 				ctx.Respond(resp)
 				return
@@ -216,7 +202,7 @@ func (a *HelloActor) Receive(ctx actor.Context) {
 			req := &AddRequest{}
 			err := proto.Unmarshal(msg.MessageData, req)
 			if err != nil {
-				plog.Error("Add(AddRequest) proto.Unmarshal failed.", logmod.Error(err))
+				a.ctx.Cluster().Logger().Error("Add(AddRequest) proto.Unmarshal failed.", logmod.Error(err))
 				resp := &cluster.GrainErrorResponse{Err: err.Error()}
 				ctx.Respond(resp)
 				return
@@ -229,7 +215,7 @@ func (a *HelloActor) Receive(ctx actor.Context) {
 			}
 			bytes, err := proto.Marshal(r0)
 			if err != nil {
-				plog.Error("Add(AddRequest) proto.Marshal failed", logmod.Error(err))
+				a.ctx.Cluster().Logger().Error("Add(AddRequest) proto.Marshal failed", logmod.Error(err))
 				resp := &cluster.GrainErrorResponse{Err: err.Error()}
 				ctx.Respond(resp)
 				return
@@ -240,7 +226,7 @@ func (a *HelloActor) Receive(ctx actor.Context) {
 			req := &AddRequest{}
 			err := proto.Unmarshal(msg.MessageData, req)
 			if err != nil {
-				plog.Error("VoidFunc(AddRequest) proto.Unmarshal failed.", logmod.Error(err))
+				a.ctx.Cluster().Logger().Error("VoidFunc(AddRequest) proto.Unmarshal failed.", logmod.Error(err))
 				resp := &cluster.GrainErrorResponse{Err: err.Error()}
 				ctx.Respond(resp)
 				return
@@ -253,14 +239,13 @@ func (a *HelloActor) Receive(ctx actor.Context) {
 			}
 			bytes, err := proto.Marshal(r0)
 			if err != nil {
-				plog.Error("VoidFunc(AddRequest) proto.Marshal failed", logmod.Error(err))
+				a.ctx.Cluster().Logger().Error("VoidFunc(AddRequest) proto.Marshal failed", logmod.Error(err))
 				resp := &cluster.GrainErrorResponse{Err: err.Error()}
 				ctx.Respond(resp)
 				return
 			}
 			resp := &cluster.GrainResponse{MessageData: bytes}
 			ctx.Respond(resp)
-
 		}
 	default:
 		a.inner.ReceiveDefault(a.ctx)
@@ -2,7 +2,6 @@
 package main
 
 import (
-	"errors"
 	"fmt"
 	"math"
 	"time"
@@ -40,7 +39,18 @@ func GetUserActorGrainClient(c *cluster.Cluster, id string) *UserActorGrainClien
 	if id == "" {
 		panic(fmt.Errorf("empty id"))
 	}
-	return &UserActorGrainClient{Identity: id, cluster: c}
+	return &UserActorGrainClient{Identity: id, caller: c}
+}
+
+// NewUserActorGrainClient instantiates a new UserActorGrainClient with given Identity, calling the grain through caller
+func NewUserActorGrainClient(caller cluster.GrainCaller, id string) *UserActorGrainClient {
+	if caller == nil {
+		panic(fmt.Errorf("nil grain caller"))
+	}
+	if id == "" {
+		panic(fmt.Errorf("empty id"))
+	}
+	return &UserActorGrainClient{Identity: id, caller: caller}
 }
 
 // GetUserActorKind instantiates a new cluster.Kind for UserActor
@@ -77,7 +87,7 @@ type UserActor interface {
 // UserActorGrainClient holds the base data for the UserActorGrain
 type UserActorGrainClient struct {
 	Identity string
-	cluster  *cluster.Cluster
+	caller   cluster.GrainCaller
 }
 
 // Connect requests the execution on to the cluster with CallOptions
@@ -86,24 +96,16 @@ func (g *UserActorGrainClient) Connect(r *Empty, opts ...cluster.GrainCallOption
 	if err != nil {
 		return nil, err
 	}
-	reqMsg := &cluster.GrainRequest{MethodIndex: 0, MessageData: bytes}
-	resp, err := g.cluster.Call(g.Identity, "UserActor", reqMsg, opts...)
+	resp, err := g.caller.CallGrain(g.Identity, "UserActor", 0, bytes, opts...)
 	if err != nil {
 		return nil, err
 	}
-	switch msg := resp.(type) {
-	case *cluster.GrainResponse:
-		result := &Empty{}
-		err = proto.Unmarshal(msg.MessageData, result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
-	case *cluster.GrainErrorResponse:
-		return nil, errors.New(msg.Err)
-	default:
-		return nil, errors.New("unknown response")
+	result := &Empty{}
+	err = proto.Unmarshal(resp, result)
+	if err != nil {
+		return nil, err
 	}
+	return result, nil
 }
 
 // UserActorActor represents the actor structure
@@ -138,7 +140,7 @@ func (a *UserActorActor) Receive(ctx actor.Context) {
 			req := &Empty{}
 			err := proto.Unmarshal(msg.MessageData, req)
 			if err != nil {
-				plog.Error("Connect(Empty) proto.Unmarshal failed.", logmod.Error(err))
+				a.ctx.Cluster().Logger().Error("Connect(Empty) proto.Unmarshal failed.", logmod.Error(err))
 				resp := &cluster.GrainErrorResponse{Err: err.Error()}
 				ctx.Respond(resp)
 				return
@@ -151,7 +153,7 @@ func (a *UserActorActor) Receive(ctx actor.Context) {
 			}
 			bytes, err := proto.Marshal(r0)
 			if err != nil {
-				plog.Error("Connect(Empty) proto.Marshal failed", logmod.Error(err))
+				a.ctx.Cluster().Logger().Error("Connect(Empty) proto.Marshal failed", logmod.Error(err))
 				resp := &cluster.GrainErrorResponse{Err: err.Error()}
 				ctx.Respond(resp)
 				return
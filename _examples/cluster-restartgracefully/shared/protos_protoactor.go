@@ -2,7 +2,6 @@
 package shared
 
 import (
-	"errors"
 	"fmt"
 	"math"
 	"time"
@@ -40,7 +39,18 @@ func GetCalculatorGrainClient(c *cluster.Cluster, id string) *CalculatorGrainCli
 	if id == "" {
 		panic(fmt.Errorf("empty id"))
 	}
-	return &CalculatorGrainClient{Identity: id, cluster: c}
+	return &CalculatorGrainClient{Identity: id, caller: c}
+}
+
+// NewCalculatorGrainClient instantiates a new CalculatorGrainClient with given Identity, calling the grain through caller
+func NewCalculatorGrainClient(caller cluster.GrainCaller, id string) *CalculatorGrainClient {
+	if caller == nil {
+		panic(fmt.Errorf("nil grain caller"))
+	}
+	if id == "" {
+		panic(fmt.Errorf("empty id"))
+	}
+	return &CalculatorGrainClient{Identity: id, caller: caller}
 }
 
 // GetCalculatorKind instantiates a new cluster.Kind for Calculator
@@ -79,7 +89,7 @@ type Calculator interface {
 // CalculatorGrainClient holds the base data for the CalculatorGrain
 type CalculatorGrainClient struct {
 	Identity string
-	cluster  *cluster.Cluster
+	caller   cluster.GrainCaller
 }
 
 // Add requests the execution on to the cluster with CallOptions
@@ -88,24 +98,16 @@ func (g *CalculatorGrainClient) Add(r *NumberRequest, opts ...cluster.GrainCallO
 	if err != nil {
 		return nil, err
 	}
-	reqMsg := &cluster.GrainRequest{MethodIndex: 0, MessageData: bytes}
-	resp, err := g.cluster.Call(g.Identity, "Calculator", reqMsg, opts...)
+	resp, err := g.caller.CallGrain(g.Identity, "Calculator", 0, bytes, opts...)
 	if err != nil {
 		return nil, err
 	}
-	switch msg := resp.(type) {
-	case *cluster.GrainResponse:
-		result := &CountResponse{}
-		err = proto.Unmarshal(msg.MessageData, result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
-	case *cluster.GrainErrorResponse:
-		return nil, errors.New(msg.Err)
-	default:
-		return nil, errors.New("unknown response")
+	result := &CountResponse{}
+	err = proto.Unmarshal(resp, result)
+	if err != nil {
+		return nil, err
 	}
+	return result, nil
 }
 
 // Subtract requests the execution on to the cluster with CallOptions
@@ -114,24 +116,16 @@ func (g *CalculatorGrainClient) Subtract(r *NumberRequest, opts ...cluster.Grain
 	if err != nil {
 		return nil, err
 	}
-	reqMsg := &cluster.GrainRequest{MethodIndex: 1, MessageData: bytes}
-	resp, err := g.cluster.Call(g.Identity, "Calculator", reqMsg, opts...)
+	resp, err := g.caller.CallGrain(g.Identity, "Calculator", 1, bytes, opts...)
 	if err != nil {
 		return nil, err
 	}
-	switch msg := resp.(type) {
-	case *cluster.GrainResponse:
-		result := &CountResponse{}
-		err = proto.Unmarshal(msg.MessageData, result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
-	case *cluster.GrainErrorResponse:
-		return nil, errors.New(msg.Err)
-	default:
-		return nil, errors.New("unknown response")
+	result := &CountResponse{}
+	err = proto.Unmarshal(resp, result)
+	if err != nil {
+		return nil, err
 	}
+	return result, nil
 }
 
 // GetCurrent requests the execution on to the cluster with CallOptions
@@ -140,24 +134,16 @@ func (g *CalculatorGrainClient) GetCurrent(r *Void, opts ...cluster.GrainCallOpt
 	if err != nil {
 		return nil, err
 	}
-	reqMsg := &cluster.GrainRequest{MethodIndex: 2, MessageData: bytes}
-	resp, err := g.cluster.Call(g.Identity, "Calculator", reqMsg, opts...)
+	resp, err := g.caller.CallGrain(g.Identity, "Calculator", 2, bytes, opts...)
 	if err != nil {
 		return nil, err
 	}
-	switch msg := resp.(type) {
-	case *cluster.GrainResponse:
-		result := &CountResponse{}
-		err = proto.Unmarshal(msg.MessageData, result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
-	case *cluster.GrainErrorResponse:
-		return nil, errors.New(msg.Err)
-	default:
-		return nil, errors.New("unknown response")
+	result := &CountResponse{}
+	err = proto.Unmarshal(resp, result)
+	if err != nil {
+		return nil, err
 	}
+	return result, nil
 }
 
 // CalculatorActor represents the actor structure
@@ -192,7 +178,7 @@ func (a *CalculatorActor) Receive(ctx actor.Context) {
 			req := &NumberRequest{}
 			err := proto.Unmarshal(msg.MessageData, req)
 			if err != nil {
-				plog.Error("Add(NumberRequest) proto.Unmarshal failed.", logmod.Error(err))
+				a.ctx.Cluster().Logger().Error("Add(NumberRequest) proto.Unmarshal failed.", logmod.Error(err))
 				resp := &cluster.GrainErrorResponse{Err: err.Error()}
 				ctx.Respond(resp)
 				return
@@ -205,7 +191,7 @@ func (a *CalculatorActor) Receive(ctx actor.Context) {
 			}
 			bytes, err := proto.Marshal(r0)
 			if err != nil {
-				plog.Error("Add(NumberRequest) proto.Marshal failed", logmod.Error(err))
+				a.ctx.Cluster().Logger().Error("Add(NumberRequest) proto.Marshal failed", logmod.Error(err))
 				resp := &cluster.GrainErrorResponse{Err: err.Error()}
 				ctx.Respond(resp)
 				return
@@ -216,7 +202,7 @@ func (a *CalculatorActor) Receive(ctx actor.Context) {
 			req := &NumberRequest{}
 			err := proto.Unmarshal(msg.MessageData, req)
 			if err != nil {
-				plog.Error("Subtract(NumberRequest) proto.Unmarshal failed.", logmod.Error(err))
+				a.ctx.Cluster().Logger().Error("Subtract(NumberRequest) proto.Unmarshal failed.", logmod.Error(err))
 				resp := &cluster.GrainErrorResponse{Err: err.Error()}
 				ctx.Respond(resp)
 				return
@@ -229,7 +215,7 @@ func (a *CalculatorActor) Receive(ctx actor.Context) {
 			}
 			bytes, err := proto.Marshal(r0)
 			if err != nil {
-				plog.Error("Subtract(NumberRequest) proto.Marshal failed", logmod.Error(err))
+				a.ctx.Cluster().Logger().Error("Subtract(NumberRequest) proto.Marshal failed", logmod.Error(err))
 				resp := &cluster.GrainErrorResponse{Err: err.Error()}
 				ctx.Respond(resp)
 				return
@@ -240,7 +226,7 @@ func (a *CalculatorActor) Receive(ctx actor.Context) {
 			req := &Void{}
 			err := proto.Unmarshal(msg.MessageData, req)
 			if err != nil {
-				plog.Error("GetCurrent(Void) proto.Unmarshal failed.", logmod.Error(err))
+				a.ctx.Cluster().Logger().Error("GetCurrent(Void) proto.Unmarshal failed.", logmod.Error(err))
 				resp := &cluster.GrainErrorResponse{Err: err.Error()}
 				ctx.Respond(resp)
 				return
@@ -253,14 +239,13 @@ func (a *CalculatorActor) Receive(ctx actor.Context) {
 			}
 			bytes, err := proto.Marshal(r0)
 			if err != nil {
-				plog.Error("GetCurrent(Void) proto.Marshal failed", logmod.Error(err))
+				a.ctx.Cluster().Logger().Error("GetCurrent(Void) proto.Marshal failed", logmod.Error(err))
 				resp := &cluster.GrainErrorResponse{Err: err.Error()}
 				ctx.Respond(resp)
 				return
 			}
 			resp := &cluster.GrainResponse{MessageData: bytes}
 			ctx.Respond(resp)
-
 		}
 	default:
 		a.inner.ReceiveDefault(a.ctx)
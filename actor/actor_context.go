@@ -10,7 +10,6 @@ import (
 	"github.com/asynkron/protoactor-go/ctxext"
 	"github.com/asynkron/protoactor-go/log"
 	"github.com/asynkron/protoactor-go/metrics"
-	"github.com/emirpasic/gods/stacks/linkedliststack"
 	"go.opentelemetry.io/otel/attribute"
 )
 
@@ -25,10 +24,30 @@ type actorContextExtras struct {
 	children            PIDSet
 	receiveTimeoutTimer *time.Timer
 	rs                  *RestartStatistics
-	stash               *linkedliststack.Stack
+	stash               []interface{}
 	watchers            PIDSet
 	context             Context
 	extensions          *ctxext.ContextExtensions
+	// receiveTimeoutOnce is set while a one-shot receive timeout applies, receiveTimeoutRestore is the timeout
+	// restored after the next message. receiveTimeoutOnceGen identifies each call to SetReceiveTimeoutOnce
+	receiveTimeoutOnce    bool
+	receiveTimeoutRestore time.Duration
+	receiveTimeoutOnceGen uint64
+	// timers are the timers set with SetTimer and SetRecurringTimer by key
+	timers map[string]*actorTimer
+	// failedMessage is the user message the actor failed on, kept for its OnRestartMessagePolicy until
+	// the actor restarts or processes another user message
+	failedMessage interface{}
+	// pendingReentrancies counts the continuations awaited with ReenterAfter, draining is set by PoisonDrain
+	// until they ran
+	pendingReentrancies int
+	draining            bool
+	// notReady is set from the start of an actor spawned WithReadinessTimeout until it calls SetReady,
+	// notReadyMessages are the user messages held meanwhile
+	notReady         bool
+	notReadyMessages []interface{}
+	readinessGen     uint64
+	readinessTimer   *time.Timer
 }
 
 func newActorContextExtras(context Context) *actorContextExtras {
@@ -105,6 +124,17 @@ type actorContext struct {
 	receiveTimeout    time.Duration
 	messageOrEnvelope interface{}
 	state             int32
+	stopReason        TerminatedReason
+
+	// messages received since the last one measured, and the labels of the measures
+	unsampled    int
+	metricLabels []attribute.KeyValue
+
+	// scopeEnvelope is the copy of the message being processed the RequestScope was set on
+	scopeEnvelope *MessageEnvelope
+
+	// debugMailbox is set when the mailbox of the actor is decorated, see Config.DebugMailboxHistory
+	debugMailbox *debugMailbox
 }
 
 var (
@@ -189,11 +219,27 @@ func (ctx *actorContext) Respond(response interface{}) {
 
 func (ctx *actorContext) Stash() {
 	extra := ctx.ensureExtras()
-	if extra.stash == nil {
-		extra.stash = linkedliststack.New()
+	message := ctx.Message()
+
+	if size := ctx.props.stashSize; size > 0 && len(extra.stash) >= size {
+		switch ctx.props.stashOverflow {
+		case StashDropOldest:
+			ctx.actorSystem.Logger().Warn("Stash is full, dropping oldest message", log.Stringer("pid", ctx.self), log.TypeOf("type", extra.stash[0]))
+			extra.stash = append(extra.stash[:0], extra.stash[1:]...)
+		case StashDropNewest:
+			ctx.actorSystem.Logger().Warn("Stash is full, dropping message", log.Stringer("pid", ctx.self), log.TypeOf("type", message))
+			return
+		case StashDeadLetter:
+			ctx.actorSystem.EventStream.Publish(&DeadLetterEvent{
+				PID:     ctx.self,
+				Message: message,
+				Sender:  ctx.Sender(),
+			})
+			return
+		}
 	}
 
-	extra.stash.Push(ctx.Message())
+	extra.stash = append(extra.stash, message)
 }
 
 func (ctx *actorContext) Watch(who *PID) {
@@ -202,6 +248,13 @@ func (ctx *actorContext) Watch(who *PID) {
 	})
 }
 
+func (ctx *actorContext) WatchDurable(who *PID) {
+	who.sendSystemMessage(ctx.actorSystem, &Watch{
+		Watcher: ctx.self,
+		Durable: true,
+	})
+}
+
 func (ctx *actorContext) Unwatch(who *PID) {
 	who.sendSystemMessage(ctx.actorSystem, &Unwatch{
 		Watcher: ctx.self,
@@ -213,6 +266,33 @@ func (ctx *actorContext) SetReceiveTimeout(d time.Duration) {
 		panic("Duration must be greater than zero")
 	}
 
+	if ctx.extras != nil {
+		ctx.extras.receiveTimeoutOnce = false
+	}
+
+	ctx.setReceiveTimeout(d)
+}
+
+func (ctx *actorContext) SetReceiveTimeoutOnce(d time.Duration) {
+	if d <= 0 {
+		panic("Duration must be greater than zero")
+	}
+
+	extras := ctx.ensureExtras()
+	previous := ctx.receiveTimeout
+	if extras.receiveTimeoutOnce {
+		// a one-shot replacing another one-shot still reverts to the timeout set before the first one
+		previous = extras.receiveTimeoutRestore
+	}
+
+	ctx.setReceiveTimeout(d)
+
+	extras.receiveTimeoutOnce = true
+	extras.receiveTimeoutRestore = previous
+	extras.receiveTimeoutOnceGen++
+}
+
+func (ctx *actorContext) setReceiveTimeout(d time.Duration) {
 	if d == ctx.receiveTimeout {
 		return
 	}
@@ -237,6 +317,14 @@ func (ctx *actorContext) SetReceiveTimeout(d time.Duration) {
 }
 
 func (ctx *actorContext) CancelReceiveTimeout() {
+	if ctx.extras != nil {
+		ctx.extras.receiveTimeoutOnce = false
+	}
+
+	ctx.cancelReceiveTimeoutTimer()
+}
+
+func (ctx *actorContext) cancelReceiveTimeoutTimer() {
 	if ctx.extras == nil || ctx.extras.receiveTimeoutTimer == nil {
 		return
 	}
@@ -245,9 +333,30 @@ func (ctx *actorContext) CancelReceiveTimeout() {
 	ctx.receiveTimeout = 0
 }
 
+// restoreReceiveTimeout reverts a one-shot receive timeout to the timeout set before it
+func (ctx *actorContext) restoreReceiveTimeout() {
+	previous := ctx.extras.receiveTimeoutRestore
+	ctx.extras.receiveTimeoutOnce = false
+	ctx.extras.receiveTimeoutRestore = 0
+
+	if previous <= 0 {
+		ctx.cancelReceiveTimeoutTimer()
+
+		return
+	}
+
+	ctx.receiveTimeout = previous
+	if ctx.extras.receiveTimeoutTimer == nil {
+		ctx.extras.initReceiveTimeoutTimer(time.AfterFunc(previous, ctx.receiveTimeoutHandler))
+	} else {
+		ctx.extras.resetReceiveTimeoutTimer(previous)
+	}
+}
+
 func (ctx *actorContext) receiveTimeoutHandler() {
 	if ctx.extras != nil && ctx.extras.receiveTimeoutTimer != nil {
-		ctx.CancelReceiveTimeout()
+		// a one-shot timeout is not consumed by firing, it reverts on the next message
+		ctx.cancelReceiveTimeoutTimer()
 		ctx.Send(ctx.self, receiveTimeoutMessage)
 	}
 }
@@ -255,7 +364,7 @@ func (ctx *actorContext) receiveTimeoutHandler() {
 func (ctx *actorContext) Forward(pid *PID) {
 	if msg, ok := ctx.messageOrEnvelope.(SystemMessage); ok {
 		// SystemMessage cannot be forwarded
-		plog.Error("SystemMessage cannot be forwarded", log.Message(msg))
+		ctx.actorSystem.Logger().Error("SystemMessage cannot be forwarded", log.Message(msg))
 
 		return
 	}
@@ -264,7 +373,9 @@ func (ctx *actorContext) Forward(pid *PID) {
 }
 
 func (ctx *actorContext) ReenterAfter(f *Future, cont func(res interface{}, err error)) {
+	ctx.beginReentrancy()
 	wrapper := func() {
+		defer ctx.endReentrancy()
 		cont(f.result, f.err)
 	}
 
@@ -288,14 +399,23 @@ func (ctx *actorContext) Message() interface{} {
 }
 
 func (ctx *actorContext) MessageHeader() ReadonlyMessageHeader {
-	return UnwrapEnvelopeHeader(ctx.messageOrEnvelope)
+	if header := UnwrapEnvelopeHeader(ctx.messageOrEnvelope); header != nil {
+		return header
+	}
+
+	return EmptyMessageHeader
 }
 
 func (ctx *actorContext) Send(pid *PID, message interface{}) {
 	ctx.sendUserMessage(pid, message)
 }
 
+func (ctx *actorContext) SendWithHeaders(pid *PID, message interface{}, headers ReadonlyMessageHeader) {
+	ctx.sendUserMessage(pid, envelopeWithHeaders(message, headers))
+}
+
 func (ctx *actorContext) sendUserMessage(pid *PID, message interface{}) {
+	message = ctx.withRequestScope(message)
 	if ctx.props.senderMiddlewareChain != nil {
 		ctx.props.senderMiddlewareChain(ctx.ensureExtras().context, pid, WrapEnvelope(message))
 	} else {
@@ -304,34 +424,19 @@ func (ctx *actorContext) sendUserMessage(pid *PID, message interface{}) {
 }
 
 func (ctx *actorContext) Request(pid *PID, message interface{}) {
-	env := &MessageEnvelope{
-		Header:  nil,
-		Message: message,
-		Sender:  ctx.Self(),
-	}
-
-	ctx.sendUserMessage(pid, env)
+	request(ctx.actorSystem, ctx.MessageHeader(), pid, message, ctx.Self(), ctx.sendUserMessage)
 }
 
 func (ctx *actorContext) RequestWithCustomSender(pid *PID, message interface{}, sender *PID) {
-	env := &MessageEnvelope{
-		Header:  nil,
-		Message: message,
-		Sender:  sender,
-	}
-	ctx.sendUserMessage(pid, env)
+	request(ctx.actorSystem, ctx.MessageHeader(), pid, message, sender, ctx.sendUserMessage)
 }
 
 func (ctx *actorContext) RequestFuture(pid *PID, message interface{}, timeout time.Duration) *Future {
-	future := NewFuture(ctx.actorSystem, timeout)
-	env := &MessageEnvelope{
-		Header:  nil,
-		Message: message,
-		Sender:  future.PID(),
-	}
-	ctx.sendUserMessage(pid, env)
+	return requestFuture(ctx.actorSystem, ctx.MessageHeader(), pid, message, timeout, ctx.sendUserMessage)
+}
 
-	return future
+func (ctx *actorContext) RequestFutureCtx(pid *PID, message interface{}, goCtx context.Context) *Future {
+	return requestFutureCtx(ctx.actorSystem, ctx.MessageHeader(), pid, message, goCtx, ctx.sendUserMessage)
 }
 
 //
@@ -349,6 +454,9 @@ func (ctx *actorContext) defaultReceive() {
 	case *PoisonPill:
 		ctx.Stop(ctx.self)
 
+	case *drainPill:
+		ctx.handleDrainPill()
+
 	case AutoRespond:
 		if ctx.props.contextDecoratorChain != nil {
 			ctx.actor.Receive(ctx.ensureExtras().context)
@@ -423,6 +531,11 @@ func (ctx *actorContext) SpawnNamed(props *Props, name string) (*PID, error) {
 
 // Stop will stop actor immediately regardless of existing user messages in mailbox.
 func (ctx *actorContext) Stop(pid *PID) {
+	ctx.countStopped()
+	pid.ref(ctx.actorSystem).Stop(pid)
+}
+
+func (ctx *actorContext) countStopped() {
 	if ctx.actorSystem.Config.MetricsProvider != nil {
 		metricsSystem, ok := ctx.actorSystem.Extensions.Get(extensionId).(*Metrics)
 		if ok && metricsSystem.enabled {
@@ -432,8 +545,6 @@ func (ctx *actorContext) Stop(pid *PID) {
 			}
 		}
 	}
-
-	pid.ref(ctx.actorSystem).Stop(pid)
 }
 
 // StopFuture will stop actor immediately regardless of existing user messages in mailbox, and return its future.
@@ -461,28 +572,57 @@ func (ctx *actorContext) PoisonFuture(pid *PID) *Future {
 	return future
 }
 
+// PoisonDrain will tell actor to stop after processing current user messages in mailbox and the continuations
+// it awaits with ReenterAfter, and return its future. The actor is stopped once timeout elapsed
+func (ctx *actorContext) PoisonDrain(pid *PID, timeout time.Duration) *Future {
+	return poisonDrain(ctx.actorSystem, pid, timeout)
+}
+
 //
 // Interface: MessageInvoker
 //
 
 func (ctx *actorContext) InvokeUserMessage(md interface{}) {
 	if atomic.LoadInt32(&ctx.state) == stateStopped {
-		// already stopped
+		// already stopped, the messages left in the mailbox are dead letters so that requests do not time out
+		ctx.actorSystem.DeadLetter.SendUserMessage(ctx.self, md)
+
+		return
+	}
+
+	if ctx.extras != nil && ctx.extras.draining && isUserMessage(md) {
+		// messages sent after PoisonDrain are not processed, like messages sent after a PoisonPill
+		ctx.actorSystem.DeadLetter.SendUserMessage(ctx.self, md)
+
 		return
 	}
 
+	if ctx.holdUntilReady(md) {
+		return
+	}
+
+	if ctx.extras != nil && ctx.extras.failedMessage != nil && isUserMessage(md) {
+		// the actor was resumed, the message it failed on is not handled on restart
+		ctx.extras.failedMessage = nil
+	}
+
+	oneShot, oneShotGen := false, uint64(0)
+	if ctx.extras != nil && ctx.extras.receiveTimeoutOnce {
+		oneShot, oneShotGen = true, ctx.extras.receiveTimeoutOnceGen
+	}
+
 	influenceTimeout := true
-	if ctx.receiveTimeout > 0 {
+	if ctx.receiveTimeout > 0 || oneShot {
 		_, influenceTimeout = md.(NotInfluenceReceiveTimeout)
 		influenceTimeout = !influenceTimeout
 
-		if influenceTimeout {
+		if influenceTimeout && ctx.receiveTimeout > 0 {
 			ctx.extras.stopReceiveTimeoutTimer()
 		}
 	}
 
 	systemMetrics, ok := ctx.actorSystem.Extensions.Get(extensionId).(*Metrics)
-	if ok && systemMetrics.enabled {
+	if ok && systemMetrics.enabled && ctx.sampleMessage() {
 		t := time.Now()
 
 		ctx.processMessage(md)
@@ -492,23 +632,66 @@ func (ctx *actorContext) InvokeUserMessage(md interface{}) {
 
 		if instruments := systemMetrics.metrics.Get(metrics.InternalActorMetrics); instruments != nil {
 			histogram := instruments.ActorMessageReceiveHistogram
-
-			labels := append(
-				systemMetrics.CommonLabels(ctx),
-				attribute.String("messagetype", fmt.Sprintf("%T", md)),
-			)
-			histogram.Record(_ctx, delta.Seconds(), labels...)
+			histogram.Record(_ctx, delta.Seconds(), systemMetrics.messageLabels(ctx, md)...)
 		}
 	} else {
 		ctx.processMessage(md)
 	}
 
-	if ctx.receiveTimeout > 0 && influenceTimeout {
+	ctx.checkBehaviorDepth()
+
+	// the one-shot applies until the next message, unless it was set again while processing this one
+	if oneShot && influenceTimeout && md != receiveTimeoutMessage &&
+		ctx.extras.receiveTimeoutOnce && ctx.extras.receiveTimeoutOnceGen == oneShotGen {
+		ctx.restoreReceiveTimeout()
+	} else if ctx.receiveTimeout > 0 && influenceTimeout {
 		ctx.extras.resetReceiveTimeoutTimer(ctx.receiveTimeout)
 	}
 }
 
+// BehaviorStackSize returns the number of behaviors on the stack of the Behavior the actor received its last message with
+func (ctx *actorContext) BehaviorStackSize() int {
+	if ctx.extras == nil {
+		return 0
+	}
+
+	if ext, ok := ctx.extras.extensions.Get(behaviorExtensionID).(*behaviorExtension); ok {
+		return ext.behavior.StackSize()
+	}
+
+	return 0
+}
+
+// checkBehaviorDepth fails the actor when its behavior stack grew beyond the max behavior depth of its props,
+// so that behaviors pushed without being popped do not leak
+func (ctx *actorContext) checkBehaviorDepth() {
+	max := ctx.props.maxBehaviorDepth
+	if max <= 0 {
+		return
+	}
+
+	if depth := ctx.BehaviorStackSize(); depth > max {
+		ctx.actorSystem.Logger().Error("behavior stack exceeded max depth", log.Stringer("pid", ctx.self), log.Int("depth", depth), log.Int("max", max))
+		panic(fmt.Errorf("behavior stack depth %d exceeds max %d", depth, max))
+	}
+}
+
+// sampleMessage tells whether the duration of the current message is measured, one in every MetricsSampleRate
+func (ctx *actorContext) sampleMessage() bool {
+	ctx.unsampled++
+	if ctx.unsampled < ctx.actorSystem.Config.MetricsSampleRate {
+		return false
+	}
+	ctx.unsampled = 0
+
+	return true
+}
+
 func (ctx *actorContext) processMessage(m interface{}) {
+	if ctx.debugMailbox != nil {
+		ctx.debugMailbox.receiving(m)
+	}
+
 	if ctx.props.receiverMiddlewareChain != nil {
 		ctx.props.receiverMiddlewareChain(ctx.ensureExtras().context, WrapEnvelope(m))
 
@@ -546,14 +729,25 @@ func (ctx *actorContext) InvokeSystemMessage(message interface{}) {
 		msg.f()                             // invoke the continuation in the current actor context
 
 		ctx.messageOrEnvelope = nil // release the message
+	case *timerFired:
+		ctx.handleTimerFired(msg)
+	case *redeliver:
+		ctx.InvokeUserMessage(msg.message)
+	case *ready:
+		ctx.replayNotReadyMessages()
+	case *readinessTimeout:
+		ctx.handleReadinessTimeout(msg)
 	case *Started:
+		ctx.awaitReadiness()
 		ctx.InvokeUserMessage(msg) // forward
 	case *Watch:
 		ctx.handleWatch(msg)
 	case *Unwatch:
 		ctx.handleUnwatch(msg)
 	case *Stop:
-		ctx.handleStop()
+		ctx.handleStop(TerminatedReason_Stopped)
+	case *kill:
+		ctx.handleStop(TerminatedReason_Killed)
 	case *Terminated:
 		ctx.handleTerminated(msg)
 	case *Failure:
@@ -561,7 +755,7 @@ func (ctx *actorContext) InvokeSystemMessage(message interface{}) {
 	case *Restart:
 		ctx.handleRestart()
 	default:
-		plog.Error("unknown system message", log.Message(msg))
+		ctx.actorSystem.Logger().Error("unknown system message", log.Message(msg))
 	}
 }
 
@@ -573,6 +767,7 @@ func (ctx *actorContext) handleWatch(msg *Watch) {
 	if atomic.LoadInt32(&ctx.state) >= stateStopping {
 		msg.Watcher.sendSystemMessage(ctx.actorSystem, &Terminated{
 			Who: ctx.self,
+			Why: ctx.stopReason,
 		})
 	} else {
 		ctx.ensureExtras().watch(msg.Watcher)
@@ -590,6 +785,7 @@ func (ctx *actorContext) handleUnwatch(msg *Unwatch) {
 func (ctx *actorContext) handleRestart() {
 	atomic.StoreInt32(&ctx.state, stateRestarting)
 	ctx.InvokeUserMessage(restartingMessage)
+	ctx.cancelTimers()
 	ctx.stopAllChildren()
 	ctx.tryRestartOrTerminate()
 
@@ -602,16 +798,18 @@ func (ctx *actorContext) handleRestart() {
 	}
 }
 
-// I am stopping.
-func (ctx *actorContext) handleStop() {
+// I am stopping, the reason tells the watchers why I terminated.
+func (ctx *actorContext) handleStop(reason TerminatedReason) {
 	if atomic.LoadInt32(&ctx.state) >= stateStopping {
 		// already stopping or stopped
 		return
 	}
 
+	ctx.stopReason = reason
 	atomic.StoreInt32(&ctx.state, stateStopping)
 
 	ctx.InvokeUserMessage(stoppingMessage)
+	ctx.cancelTimers()
 	ctx.stopAllChildren()
 	ctx.tryRestartOrTerminate()
 }
@@ -643,10 +841,26 @@ func (ctx *actorContext) stopAllChildren() {
 	}
 
 	ctx.extras.children.ForEach(func(_ int, pid *PID) {
-		ctx.Stop(pid)
+		ctx.kill(pid)
 	})
 }
 
+// kill stops a child on behalf of this actor, the child terminates with TerminatedReason_Killed
+func (ctx *actorContext) kill(pid *PID) {
+	ctx.countStopped()
+
+	ref := pid.ref(ctx.actorSystem)
+	if process, ok := ref.(*ActorProcess); ok {
+		atomic.StoreInt32(&process.dead, 1)
+		process.SendSystemMessage(pid, killMessage)
+
+		return
+	}
+
+	// other processes, such as routers, only know how to stop
+	ref.Stop(pid)
+}
+
 func (ctx *actorContext) tryRestartOrTerminate() {
 	if ctx.extras != nil && !ctx.extras.children.Empty() {
 		return
@@ -663,23 +877,65 @@ func (ctx *actorContext) tryRestartOrTerminate() {
 }
 
 func (ctx *actorContext) restart() {
+	var failed interface{}
+	if ctx.extras != nil {
+		failed, ctx.extras.failedMessage = ctx.extras.failedMessage, nil
+	}
+
 	ctx.incarnateActor()
 	ctx.self.sendSystemMessage(ctx.actorSystem, resumeMailboxMessage)
+	ctx.awaitReadiness()
 	ctx.InvokeUserMessage(startedMessage)
 
-	if ctx.extras != nil && ctx.extras.stash != nil {
-		for !ctx.extras.stash.Empty() {
-			msg, _ := ctx.extras.stash.Pop()
+	// stashed messages are reprocessed last in, first out
+	if ctx.extras != nil {
+		for len(ctx.extras.stash) > 0 {
+			last := len(ctx.extras.stash) - 1
+			msg := ctx.extras.stash[last]
+			ctx.extras.stash[last] = nil
+			ctx.extras.stash = ctx.extras.stash[:last]
 			ctx.InvokeUserMessage(msg)
 		}
 	}
+
+	if failed != nil {
+		ctx.handleFailedMessage(failed)
+	}
+}
+
+// handleFailedMessage applies the OnRestartMessagePolicy to the message the actor failed on before restarting
+func (ctx *actorContext) handleFailedMessage(message interface{}) {
+	switch ctx.props.onRestartMessagePolicy {
+	case OnRestartRedeliverOnce:
+		// system messages are processed ahead of the user messages in the mailbox
+		ctx.self.sendSystemMessage(ctx.actorSystem, &redeliver{message: message})
+	case OnRestartDeadLetter:
+		_, msg, sender := UnwrapEnvelope(message)
+		ctx.actorSystem.EventStream.Publish(&DeadLetterEvent{
+			PID:     ctx.self,
+			Message: msg,
+			Sender:  sender,
+		})
+	}
+}
+
+// isUserMessage tells whether md is a message sent to the actor, rather than a system or lifecycle message
+func isUserMessage(md interface{}) bool {
+	switch md.(type) {
+	case SystemMessage, AutoReceiveMessage:
+		return false
+	}
+
+	return true
 }
 
 func (ctx *actorContext) finalizeStop() {
 	ctx.actorSystem.ProcessRegistry.Remove(ctx.self)
 	ctx.InvokeUserMessage(stoppedMessage)
+	ctx.cancelTimers()
+	ctx.deadLetterNotReadyMessages()
 
-	otherStopped := &Terminated{Who: ctx.self}
+	otherStopped := &Terminated{Who: ctx.self, Why: ctx.stopReason}
 	// Notify watchers
 	if ctx.extras != nil {
 		ctx.extras.watchers.ForEach(func(i int, pid *PID) {
@@ -702,7 +958,7 @@ func (ctx *actorContext) EscalateFailure(reason interface{}, message interface{}
 	// debug setting, allows to output supervision failures in console/error level
 	if ctx.actorSystem.Config.DeveloperSupervisionLogging {
 		fmt.Println("[Supervision] Actor:", ctx.self, " failed with message:", message, " exception:", reason)
-		plog.Error("[Supervision]", log.Stringer("actor", ctx.self), log.Object("message", message), log.Object("exception", reason))
+		ctx.actorSystem.Logger().Error("[Supervision]", log.Stringer("actor", ctx.self), log.Object("message", message), log.Object("exception", reason))
 	}
 
 	metricsSystem, ok := ctx.actorSystem.Extensions.Get(extensionId).(*Metrics)
@@ -713,6 +969,13 @@ func (ctx *actorContext) EscalateFailure(reason interface{}, message interface{}
 		}
 	}
 
+	if r, ok := message.(*redeliver); ok {
+		// the redelivered message failed again, it is not redelivered twice
+		message = r.message
+	} else if ctx.props.onRestartMessagePolicy != OnRestartDiscard && message != nil && isUserMessage(message) {
+		ctx.ensureExtras().failedMessage = message
+	}
+
 	failure := &Failure{Reason: reason, Who: ctx.self, RestartStats: ctx.ensureExtras().restartStats(), Message: message}
 
 	ctx.self.sendSystemMessage(ctx.actorSystem, suspendMailboxMessage)
@@ -732,7 +995,7 @@ func (ctx *actorContext) RestartChildren(pids ...*PID) {
 
 func (ctx *actorContext) StopChildren(pids ...*PID) {
 	for _, pid := range pids {
-		pid.sendSystemMessage(ctx.actorSystem, stopMessage)
+		ctx.kill(pid)
 	}
 }
 
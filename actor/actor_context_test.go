@@ -3,6 +3,7 @@ package actor
 import (
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -307,3 +308,307 @@ func TestActorContextAutoRespondTouchedMessage(t *testing.T) {
 	assert.IsType(t, &Touched{}, res)
 	assert.True(t, res2.Who.Equal(pid))
 }
+
+type notInfluencing struct{}
+
+func (*notInfluencing) NotInfluenceReceiveTimeout() {}
+
+func TestActorContext_SetReceiveTimeoutOnce(t *testing.T) {
+	timeouts := make(chan time.Duration, 10)
+	pid := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		switch ctx.Message().(type) {
+		case *Started:
+			ctx.SetReceiveTimeout(time.Minute)
+		case string:
+			ctx.SetReceiveTimeoutOnce(10 * time.Millisecond)
+		case *ReceiveTimeout:
+			timeouts <- ctx.ReceiveTimeout()
+		case *notInfluencing, int:
+			timeouts <- ctx.ReceiveTimeout()
+		}
+	}))
+	defer rootContext.Stop(pid)
+
+	rootContext.Send(pid, "one-shot")
+	// does not consume the one-shot
+	rootContext.Send(pid, &notInfluencing{})
+	assert.Equal(t, 10*time.Millisecond, <-timeouts)
+
+	select {
+	case <-timeouts:
+	case <-time.After(time.Second):
+		t.Fatal("one-shot receive timeout did not fire")
+	}
+
+	// the next message restores the previous timeout
+	rootContext.Send(pid, 1)
+	assert.Equal(t, time.Duration(0), <-timeouts)
+	rootContext.Send(pid, 2)
+	assert.Equal(t, time.Minute, <-timeouts)
+}
+
+func TestActorContext_StashSize(t *testing.T) {
+	for name, tc := range map[string]struct {
+		policy      StashOverflowPolicy
+		replayed    []int
+		deadLetters []int
+	}{
+		"drop oldest": {StashDropOldest, []int{3, 2}, nil},
+		"drop newest": {StashDropNewest, []int{2, 1}, nil},
+		"dead letter": {StashDeadLetter, []int{2, 1}, []int{3}},
+	} {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			system := NewActorSystem()
+
+			var mu sync.Mutex
+			var deadLetters []int
+			system.EventStream.Subscribe(func(evt interface{}) {
+				if dl, ok := evt.(*DeadLetterEvent); ok {
+					if n, ok := dl.Message.(int); ok {
+						mu.Lock()
+						deadLetters = append(deadLetters, n)
+						mu.Unlock()
+					}
+				}
+			})
+
+			replayed := make(chan int, 10)
+			restarted := false
+			pid := system.Root.Spawn(PropsFromFunc(func(ctx Context) {
+				switch msg := ctx.Message().(type) {
+				case int:
+					if restarted {
+						replayed <- msg
+						return
+					}
+					ctx.Stash()
+				case string:
+					restarted = true
+					panic(msg)
+				}
+			}, WithStashSize(2, tc.policy)))
+
+			for i := 1; i <= 3; i++ {
+				system.Root.Send(pid, i)
+			}
+			system.Root.Send(pid, "restart")
+
+			for _, expected := range tc.replayed {
+				assert.Equal(t, expected, <-replayed)
+			}
+			mu.Lock()
+			assert.Equal(t, tc.deadLetters, deadLetters)
+			mu.Unlock()
+		})
+	}
+}
+
+func TestActorContextRequestWithRetry(t *testing.T) {
+	t.Parallel()
+
+	var attempts int32
+	target := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		// ignore the first attempts so they time out
+		if ctx.Message() == "request" && atomic.AddInt32(&attempts, 1) == 3 {
+			ctx.Respond("done")
+		}
+	}))
+
+	var calls int32
+	pid := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		switch ctx.Message() {
+		case "start":
+			opts := RetryOptions{
+				MaxAttempts: 3,
+				Timeout:     50 * time.Millisecond,
+				Backoff:     ExponentialRetryBackoff(10*time.Millisecond, 20*time.Millisecond),
+			}
+			ctx.RequestWithRetry(target, "request", opts, func(res interface{}, err error) {
+				atomic.AddInt32(&calls, 1)
+				ctx.Respond(res)
+			})
+		case "ping":
+			ctx.Respond("pong")
+		}
+	}))
+
+	f := rootContext.RequestFuture(pid, "start", 2*time.Second)
+	// the actor is not blocked while the attempts are pending
+	res, err := rootContext.RequestFuture(pid, "ping", 40*time.Millisecond).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, "pong", res)
+
+	res, err = f.Result()
+	assert.NoError(t, err)
+	assert.Equal(t, "done", res)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&attempts))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestActorContextRequestWithRetryExhausted(t *testing.T) {
+	t.Parallel()
+
+	system := NewActorSystem()
+	registered := func() int {
+		count := 0
+		for _, bucket := range system.ProcessRegistry.LocalPIDs.LocalPIDs {
+			count += bucket.Count()
+		}
+
+		return count
+	}
+	target := system.Root.Spawn(PropsFromFunc(func(ctx Context) {}))
+	pid := system.Root.Spawn(PropsFromFunc(func(ctx Context) {
+		if ctx.Message() == "start" {
+			opts := RetryOptions{MaxAttempts: 2, Timeout: 20 * time.Millisecond}
+			ctx.RequestWithRetry(target, "request", opts, func(res interface{}, err error) {
+				ctx.Respond(err)
+			})
+		}
+	}))
+	before := registered()
+
+	res, err := system.Root.RequestFuture(pid, "start", time.Second).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, ErrTimeout, res)
+
+	// the futures of the attempts left the registry
+	assert.Equal(t, before, registered())
+}
+
+func TestExponentialRetryBackoff(t *testing.T) {
+	backoff := ExponentialRetryBackoff(10*time.Millisecond, 50*time.Millisecond)
+
+	assert.Equal(t, 10*time.Millisecond, backoff(1))
+	assert.Equal(t, 20*time.Millisecond, backoff(2))
+	assert.Equal(t, 40*time.Millisecond, backoff(3))
+	assert.Equal(t, 50*time.Millisecond, backoff(4))
+}
+
+func TestActorContextPoisonDrainWaitsForContinuations(t *testing.T) {
+	responder := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		if _, ok := ctx.Message().(string); ok {
+			time.Sleep(100 * time.Millisecond)
+			ctx.Respond("response")
+		}
+	}))
+	defer rootContext.Stop(responder)
+
+	var continued int32
+	pid := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		if msg, ok := ctx.Message().(string); ok && msg == "request" {
+			f := ctx.RequestFuture(responder, "request", time.Second)
+			ctx.ReenterAfter(f, func(res interface{}, err error) {
+				atomic.StoreInt32(&continued, 1)
+			})
+		}
+	}))
+
+	rootContext.Send(pid, "request")
+	err := rootContext.PoisonDrain(pid, 5*time.Second).Wait()
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&continued))
+}
+
+func TestActorContextPoisonDrainAfterPanickingContinuation(t *testing.T) {
+	responder := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		if _, ok := ctx.Message().(string); ok {
+			time.Sleep(50 * time.Millisecond)
+			ctx.Respond("response")
+		}
+	}))
+	defer rootContext.Stop(responder)
+
+	pid := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		if msg, ok := ctx.Message().(string); ok && msg == "request" {
+			f := ctx.RequestFuture(responder, "request", time.Second)
+			ctx.ReenterAfter(f, func(res interface{}, err error) {
+				panic("continuation failed")
+			})
+		}
+	}))
+
+	// the panicking continuation is no longer awaited, the actor stops before the drain timeout
+	rootContext.Send(pid, "request")
+	start := time.Now()
+	err := rootContext.PoisonDrain(pid, 5*time.Second).Wait()
+	assert.NoError(t, err)
+	assert.Less(t, time.Since(start), 2*time.Second)
+}
+
+func TestActorContextPoisonDrainStopsAfterTimeout(t *testing.T) {
+	silent := rootContext.Spawn(PropsFromFunc(func(ctx Context) {}))
+	defer rootContext.Stop(silent)
+
+	var continued int32
+	pid := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		if msg, ok := ctx.Message().(string); ok && msg == "request" {
+			f := ctx.RequestFuture(silent, "request", 10*time.Second)
+			ctx.ReenterAfter(f, func(res interface{}, err error) {
+				atomic.StoreInt32(&continued, 1)
+			})
+		}
+	}))
+
+	rootContext.Send(pid, "request")
+	start := time.Now()
+	err := rootContext.PoisonDrain(pid, 100*time.Millisecond).Wait()
+	assert.NoError(t, err)
+	assert.Less(t, time.Since(start), 5*time.Second)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&continued))
+}
+
+func TestActorContextSetReadyReplaysHeldMessages(t *testing.T) {
+	responder := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		if _, ok := ctx.Message().(string); ok {
+			time.Sleep(50 * time.Millisecond)
+			ctx.Respond("connected")
+		}
+	}))
+	defer rootContext.Stop(responder)
+
+	var received []string
+	pid := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		switch msg := ctx.Message().(type) {
+		case *Started:
+			ctx.ReenterAfter(ctx.RequestFuture(responder, "connect", time.Second), func(res interface{}, err error) {
+				received = append(received, res.(string))
+				ctx.SetReady()
+			})
+		case string:
+			received = append(received, msg)
+			if msg == "c" {
+				ctx.Respond(received)
+			}
+		}
+	}, WithReadinessTimeout(time.Second)))
+	defer rootContext.Stop(pid)
+
+	rootContext.Send(pid, "a")
+	rootContext.Send(pid, "b")
+	res, err := rootContext.RequestFuture(pid, "c", time.Second).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"connected", "a", "b", "c"}, res)
+}
+
+func TestActorContextReadinessTimeoutEscalates(t *testing.T) {
+	reasons := make(chan interface{}, 1)
+	supervisor := NewOneForOneStrategy(1, time.Second, func(reason interface{}) Directive {
+		reasons <- reason
+		return StopDirective
+	})
+	parent := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		if _, ok := ctx.Message().(*Started); ok {
+			ctx.Spawn(PropsFromFunc(func(ctx Context) {}, WithReadinessTimeout(50*time.Millisecond)))
+		}
+	}, WithSupervisor(supervisor)))
+	defer rootContext.Stop(parent)
+
+	select {
+	case reason := <-reasons:
+		assert.ErrorIs(t, reason.(error), ErrReadinessTimeout)
+	case <-time.After(time.Second):
+		t.Fatal("readiness timeout was not escalated")
+	}
+}
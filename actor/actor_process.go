@@ -7,6 +7,11 @@ import (
 type ActorProcess struct {
 	mailbox Mailbox
 	dead    int32
+
+	// attribute.Set labeling the mailbox length metric, unset when metrics are disabled
+	metricLabels atomic.Value
+	// actorType is the type name of the actor, set once it is produced
+	actorType atomic.Value
 }
 
 var _ Process = &ActorProcess{}
@@ -29,3 +34,15 @@ func (ref *ActorProcess) Stop(pid *PID) {
 	atomic.StoreInt32(&ref.dead, 1)
 	ref.SendSystemMessage(pid, stopMessage)
 }
+
+// ActorType returns the type name of the actor, empty if it is not known
+func (ref *ActorProcess) ActorType() string {
+	name, _ := ref.actorType.Load().(string)
+
+	return name
+}
+
+// UserMessageCount returns the number of user messages queued in the mailbox
+func (ref *ActorProcess) UserMessageCount() int {
+	return ref.mailbox.UserMessageCount()
+}
@@ -1,8 +1,12 @@
 package actor
 
 import (
+	"context"
+	"errors"
 	"net"
 	"strconv"
+	"sync/atomic"
+	"time"
 
 	"github.com/asynkron/protoactor-go/eventstream"
 	"github.com/asynkron/protoactor-go/extensions"
@@ -20,6 +24,28 @@ type ActorSystem struct {
 	Config          *Config
 	ID              string
 	stopper         chan struct{}
+	shuttingDown    int32
+}
+
+// ErrActorSystemShuttingDown is returned when spawning from the root context after ShutdownGracefully was called
+var ErrActorSystemShuttingDown = errors.New("spawn: actor system is shutting down")
+
+// GracefulShutdowner is implemented by extensions which flush their work during ShutdownGracefully,
+// such as remote endpoints sending their queued messages
+type GracefulShutdowner interface {
+	ShutdownGracefully(ctx context.Context) error
+}
+
+// ShutdownSummary describes the actors stopped by ShutdownGracefully
+type ShutdownSummary struct {
+	// Stopped is the number of actors stopped with an empty mailbox
+	Stopped int
+	// ForceKilled are the actors stopped with user messages still in their mailbox
+	ForceKilled []*PID
+	// DroppedMessages is the number of user messages left in the mailboxes of ForceKilled actors
+	DroppedMessages int
+	// Errors are returned by extensions which failed to shut down
+	Errors []error
 }
 
 func (as *ActorSystem) NewLocalPID(id string) *PID {
@@ -48,9 +74,70 @@ func (as *ActorSystem) GetHostPort() (host string, port int, err error) {
 }
 
 func (as *ActorSystem) Shutdown() {
+	GetMetrics(as).stop()
+	as.EventStream.Close()
 	close(as.stopper)
 }
 
+// ShutdownGracefully stops the actor system once the actors processed the messages in their mailboxes.
+// New actors can no longer be spawned from the root context. Once all mailboxes are empty, or ctx is done,
+// extensions implementing GracefulShutdowner are flushed and the remaining actors are stopped
+func (as *ActorSystem) ShutdownGracefully(ctx context.Context) *ShutdownSummary {
+	atomic.StoreInt32(&as.shuttingDown, 1)
+
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+
+drain:
+	for as.pendingUserMessages() > 0 {
+		select {
+		case <-ctx.Done():
+			break drain
+		case <-ticker.C:
+		}
+	}
+
+	summary := &ShutdownSummary{}
+	as.Extensions.ForEach(func(extension extensions.Extension) {
+		if shutdowner, ok := extension.(GracefulShutdowner); ok {
+			if err := shutdowner.ShutdownGracefully(ctx); err != nil {
+				summary.Errors = append(summary.Errors, err)
+			}
+		}
+	})
+
+	var pids []*PID
+	as.ProcessRegistry.forEachActorProcess(func(pid *PID, process *ActorProcess) {
+		pids = append(pids, pid)
+		if pending := process.UserMessageCount(); pending > 0 {
+			summary.ForceKilled = append(summary.ForceKilled, pid)
+			summary.DroppedMessages += pending
+		} else {
+			summary.Stopped++
+		}
+	})
+	for _, pid := range pids {
+		as.Root.Stop(pid)
+	}
+
+	as.Shutdown()
+
+	return summary
+}
+
+func (as *ActorSystem) pendingUserMessages() int {
+	pending := 0
+	as.ProcessRegistry.forEachActorProcess(func(_ *PID, process *ActorProcess) {
+		pending += process.UserMessageCount()
+	})
+
+	return pending
+}
+
+func (as *ActorSystem) isShuttingDown() bool {
+	return atomic.LoadInt32(&as.shuttingDown) == 1
+}
+
 func (as *ActorSystem) IsStopped() bool {
 	select {
 	case <-as.stopper:
@@ -73,11 +160,16 @@ func NewActorSystemWithConfig(config *Config) *ActorSystem {
 	system.ProcessRegistry = NewProcessRegistry(system)
 	system.Root = NewRootContext(system, EmptyMessageHeader)
 	system.Guardians = NewGuardians(system)
-	system.EventStream = eventstream.NewEventStream()
+	system.EventStream = eventstream.NewEventStream(eventstream.WithDispatchPool(config.EventStreamWorkers, config.EventStreamQueueSize))
 	system.DeadLetter = NewDeadLetter(system)
 	system.Extensions = extensions.NewExtensions()
 	SubscribeSupervision(system)
 	system.Extensions.Register(NewMetrics(config.MetricsProvider))
+	if m := GetMetrics(system); m.enabled {
+		m.log = system.Logger()
+		m.observeMailboxLength(system)
+		m.observeProcessCache(system)
+	}
 
 	system.ProcessRegistry.Add(NewEventStreamProcess(system), "eventstream")
 	system.stopper = make(chan struct{})
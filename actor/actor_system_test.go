@@ -0,0 +1,112 @@
+package actor
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/asynkron/protoactor-go/log"
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingLogger struct {
+	messages chan string
+}
+
+func (l *recordingLogger) Debug(msg string, _ ...log.Field) { l.messages <- msg }
+func (l *recordingLogger) Info(msg string, _ ...log.Field)  { l.messages <- msg }
+func (l *recordingLogger) Warn(msg string, _ ...log.Field)  { l.messages <- msg }
+func (l *recordingLogger) Error(msg string, _ ...log.Field) { l.messages <- msg }
+
+func TestActorSystem_WithLogger(t *testing.T) {
+	logger := &recordingLogger{messages: make(chan string, 10)}
+	system := NewActorSystem(WithLogger(logger))
+	assert.Same(t, logger, system.Logger())
+
+	system.Root.Send(system.NewLocalPID("missing"), "hello")
+
+	select {
+	case msg := <-logger.messages:
+		assert.Equal(t, "[DeadLetter]", msg)
+	case <-time.After(time.Second):
+		t.Fatal("the dead letter was not logged with the configured logger")
+	}
+}
+
+func TestActorSystem_ShutdownGracefully_DrainsMailboxes(t *testing.T) {
+	system := NewActorSystem()
+	var processed int32
+	pid := system.Root.Spawn(PropsFromFunc(func(ctx Context) {
+		if _, ok := ctx.Message().(int); ok {
+			time.Sleep(time.Millisecond)
+			atomic.AddInt32(&processed, 1)
+		}
+	}))
+	for i := 0; i < 20; i++ {
+		system.Root.Send(pid, i)
+	}
+
+	summary := system.ShutdownGracefully(context.Background())
+
+	assert.Equal(t, int32(20), atomic.LoadInt32(&processed))
+	assert.Empty(t, summary.ForceKilled)
+	assert.True(t, system.IsStopped())
+
+	_, err := system.Root.SpawnNamed(PropsFromFunc(func(ctx Context) {}), "late")
+	assert.Equal(t, ErrActorSystemShuttingDown, err)
+}
+
+func TestActorSystem_ShutdownGracefully_SpawnReturnsDeadPID(t *testing.T) {
+	system := NewActorSystem()
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	pid := system.Root.Spawn(PropsFromFunc(func(ctx Context) {
+		if ctx.Message() == "block" {
+			started <- struct{}{}
+			<-release
+		}
+	}))
+	system.Root.Send(pid, "block")
+	<-started
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		system.ShutdownGracefully(context.Background())
+	}()
+	assert.Eventually(t, system.isShuttingDown, time.Second, time.Millisecond)
+
+	var late *PID
+	assert.NotPanics(t, func() {
+		late = system.Root.Spawn(PropsFromFunc(func(ctx Context) {}))
+	})
+	assert.NotPanics(t, func() {
+		system.Root.SpawnPrefix(PropsFromFunc(func(ctx Context) {}), "late")
+	})
+	_, ok := system.ProcessRegistry.GetLocal(late.Id)
+	assert.False(t, ok)
+
+	close(release)
+	<-done
+}
+
+func TestActorSystem_ShutdownGracefully_ForceKills(t *testing.T) {
+	system := NewActorSystem()
+	release := make(chan struct{})
+	defer close(release)
+	pid := system.Root.Spawn(PropsFromFunc(func(ctx Context) {
+		if ctx.Message() == "block" {
+			<-release
+		}
+	}))
+	system.Root.Send(pid, "block")
+	system.Root.Send(pid, "queued")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	summary := system.ShutdownGracefully(ctx)
+
+	assert.Equal(t, []*PID{pid}, summary.ForceKilled)
+	assert.Equal(t, 1, summary.DroppedMessages)
+}
@@ -0,0 +1,169 @@
+package actor
+
+import (
+	"hash/fnv"
+	"runtime"
+	"sync"
+)
+
+// AffinityDispatcher processes the mailbox of each actor on one of a fixed set of workers, chosen by a stable hash
+// of its PID, so that the state of CPU bound actors stays in the cache of the core running their worker. Each worker
+// is a goroutine locked to its OS thread.
+//
+// The workers take turns between the mailboxes assigned to them: a mailbox yields its worker after throughput
+// messages, so the system messages of the other actors of the worker wait at most one turn of each busy mailbox.
+// Scheduling never blocks, actors can message each other on the same dispatcher, but an actor blocking on a response
+// from an actor of the same worker deadlocks
+type AffinityDispatcher struct {
+	throughput int
+	workers    []*affinityWorker
+}
+
+var (
+	_ Dispatcher    = &AffinityDispatcher{}
+	_ pidDispatcher = &AffinityDispatcher{}
+)
+
+// pidDispatcher is implemented by dispatchers which schedule the mailbox of an actor depending on its PID,
+// the mailbox of the actor is registered with the dispatcher returned by forPID
+type pidDispatcher interface {
+	forPID(pid *PID) Dispatcher
+}
+
+// requeueingDispatcher is implemented by dispatchers whose mailboxes yield by being scheduled again, after
+// throughput messages, instead of yielding their goroutine
+type requeueingDispatcher interface {
+	requeues()
+}
+
+// NewAffinityDispatcher starts the given number of workers, at least one. Stop them with Stop
+func NewAffinityDispatcher(workers, throughput int) *AffinityDispatcher {
+	if workers < 1 {
+		workers = 1
+	}
+
+	d := &AffinityDispatcher{
+		throughput: throughput,
+		workers:    make([]*affinityWorker, workers),
+	}
+	for i := range d.workers {
+		w := &affinityWorker{
+			dispatcher: d,
+			wake:       make(chan struct{}, 1),
+			done:       make(chan struct{}),
+		}
+		d.workers[i] = w
+		go w.run()
+	}
+
+	return d
+}
+
+// Schedule runs fn on the first worker, the mailboxes of actors spawned with the dispatcher run on the worker of
+// their PID
+func (d *AffinityDispatcher) Schedule(fn func()) {
+	d.workers[0].Schedule(fn)
+}
+
+func (d *AffinityDispatcher) Throughput() int {
+	return d.throughput
+}
+
+func (d *AffinityDispatcher) forPID(pid *PID) Dispatcher {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(pid.Address))
+	_, _ = h.Write([]byte(pid.Id))
+
+	return d.workers[h.Sum32()%uint32(len(d.workers))]
+}
+
+// QueueDepths returns the number of mailboxes waiting for each worker, to diagnose unbalanced workers
+func (d *AffinityDispatcher) QueueDepths() []int {
+	depths := make([]int, len(d.workers))
+	for i, w := range d.workers {
+		w.mu.Lock()
+		depths[i] = len(w.queue)
+		w.mu.Unlock()
+	}
+
+	return depths
+}
+
+// Stop stops the workers once their queues are empty. Mailboxes scheduled afterwards run on their own goroutine
+func (d *AffinityDispatcher) Stop() {
+	for _, w := range d.workers {
+		w.stopOnce.Do(func() {
+			close(w.done)
+		})
+	}
+}
+
+type affinityWorker struct {
+	dispatcher *AffinityDispatcher
+	wake       chan struct{}
+	done       chan struct{}
+	stopOnce   sync.Once
+
+	mu      sync.Mutex
+	queue   []func()
+	stopped bool
+}
+
+func (w *affinityWorker) Schedule(fn func()) {
+	w.mu.Lock()
+	if w.stopped {
+		w.mu.Unlock()
+		go fn()
+
+		return
+	}
+	w.queue = append(w.queue, fn)
+	w.mu.Unlock()
+
+	select {
+	case w.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (w *affinityWorker) Throughput() int {
+	return w.dispatcher.throughput
+}
+
+func (w *affinityWorker) requeues() {}
+
+func (w *affinityWorker) run() {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	for {
+		w.mu.Lock()
+		if len(w.queue) == 0 {
+			// release the memory of a burst
+			w.queue = nil
+			w.mu.Unlock()
+
+			select {
+			case <-w.wake:
+				continue
+			case <-w.done:
+			}
+
+			w.mu.Lock()
+			if len(w.queue) > 0 {
+				w.mu.Unlock()
+				continue
+			}
+			w.stopped = true
+			w.mu.Unlock()
+
+			return
+		}
+		fn := w.queue[0]
+		w.queue[0] = nil
+		w.queue = w.queue[1:]
+		w.mu.Unlock()
+
+		fn()
+	}
+}
@@ -1,6 +1,9 @@
 package actor
 
-import "github.com/asynkron/protoactor-go/log"
+import (
+	"github.com/asynkron/protoactor-go/ctxext"
+	"github.com/asynkron/protoactor-go/log"
+)
 
 type Behavior []ReceiveFunc
 
@@ -21,12 +24,36 @@ func (b *Behavior) UnbecomeStacked() {
 	b.pop()
 }
 
+// StackSize returns the number of behaviors on the stack
+func (b *Behavior) StackSize() int {
+	return b.len()
+}
+
+var behaviorExtensionID = ctxext.NextContextExtensionID()
+
+// behaviorExtension holds the Behavior an actor receives its messages with, for Context.BehaviorStackSize
+type behaviorExtension struct {
+	behavior *Behavior
+}
+
+func (*behaviorExtension) ExtensionID() ctxext.ContextExtensionID {
+	return behaviorExtensionID
+}
+
+// Receive calls the current behavior, and makes the behavior stack known to the context.
+// See WithMaxBehaviorDepth
 func (b *Behavior) Receive(context Context) {
+	if ext, ok := context.Get(behaviorExtensionID).(*behaviorExtension); ok {
+		ext.behavior = b
+	} else {
+		context.Set(&behaviorExtension{behavior: b})
+	}
+
 	behavior, ok := b.peek()
 	if ok {
 		behavior(context)
 	} else {
-		plog.Error("empty behavior called", log.Stringer("pid", context.Self()))
+		context.ActorSystem().Logger().Error("empty behavior called", log.Stringer("pid", context.Self()))
 	}
 }
 
@@ -1,7 +1,11 @@
 package actor
 
 import (
+	"fmt"
 	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
 )
 
 type BehaviorMessage struct{}
@@ -61,3 +65,96 @@ func TestActorCanPopBehavior(t *testing.T) {
 	fut := rootContext.RequestFuture(a, EchoRequest{}, testTimeout)
 	assertFutureSuccess(fut, t)
 }
+
+func TestBehaviorStackSize(t *testing.T) {
+	b := NewBehavior()
+	b.Become(func(Context) {})
+	b.BecomeStacked(func(Context) {})
+	assert.Equal(t, 2, b.StackSize())
+
+	b.UnbecomeStacked()
+	assert.Equal(t, 1, b.StackSize())
+}
+
+func TestBehaviorMaxDepthFailsActor(t *testing.T) {
+	failures := make(chan interface{}, 1)
+	supervisor := NewOneForOneStrategy(0, 0, func(reason interface{}) Directive {
+		failures <- reason
+
+		return StopDirective
+	})
+
+	parent := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		if _, ok := ctx.Message().(*Started); ok {
+			behavior := NewBehavior()
+			// every message pushes a behavior without popping it
+			var leak ReceiveFunc
+			leak = func(Context) { behavior.BecomeStacked(leak) }
+			behavior.Become(leak)
+
+			child := ctx.Spawn(PropsFromFunc(behavior.Receive, WithMaxBehaviorDepth(2)))
+			// Started pushes the second behavior, the message exceeds the depth
+			ctx.Send(child, "leak")
+		}
+	}, WithSupervisor(supervisor)))
+	defer rootContext.Stop(parent)
+
+	select {
+	case reason := <-failures:
+		assert.Contains(t, fmt.Sprint(reason), "exceeds max 2")
+	case <-time.After(time.Second):
+		assert.Fail(t, "the actor did not fail")
+	}
+}
+
+type decoratedContext struct {
+	Context
+}
+
+func TestBehaviorMaxDepthThroughContextDecorator(t *testing.T) {
+	depths := make(chan int, 1)
+	failures := make(chan interface{}, 1)
+	supervisor := NewOneForOneStrategy(0, 0, func(reason interface{}) Directive {
+		failures <- reason
+
+		return StopDirective
+	})
+
+	decorator := func(next ContextDecoratorFunc) ContextDecoratorFunc {
+		return func(ctx Context) Context {
+			return &decoratedContext{next(ctx)}
+		}
+	}
+
+	parent := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		if _, ok := ctx.Message().(*Started); ok {
+			behavior := NewBehavior()
+			var leak ReceiveFunc
+			leak = func(ctx Context) {
+				behavior.BecomeStacked(leak)
+				if _, ok := ctx.Message().(string); ok {
+					depths <- ctx.BehaviorStackSize()
+				}
+			}
+			behavior.Become(leak)
+
+			child := ctx.Spawn(PropsFromFunc(behavior.Receive, WithMaxBehaviorDepth(2), WithContextDecorator(decorator)))
+			ctx.Send(child, "leak")
+		}
+	}, WithSupervisor(supervisor)))
+	defer rootContext.Stop(parent)
+
+	select {
+	case depth := <-depths:
+		assert.Equal(t, 3, depth)
+	case <-time.After(time.Second):
+		assert.Fail(t, "the actor did not receive the message")
+	}
+
+	select {
+	case reason := <-failures:
+		assert.Contains(t, fmt.Sprint(reason), "exceeds max 2")
+	case <-time.After(time.Second):
+		assert.Fail(t, "the decorated actor did not fail")
+	}
+}
@@ -1,6 +1,8 @@
 package actor
 
 import (
+	"sync"
+
 	rbqueue "github.com/Workiva/go-datastructures/queue"
 	"github.com/asynkron/protoactor-go/internal/queue/mpsc"
 )
@@ -8,12 +10,16 @@ import (
 type boundedMailboxQueue struct {
 	userMailbox *rbqueue.RingBuffer
 	dropping    bool
+	// dropped is passed the messages dropped to make room, it may be nil
+	dropped func(message interface{})
 }
 
 func (q *boundedMailboxQueue) Push(m interface{}) {
 	if q.dropping {
 		if q.userMailbox.Len() > 0 && q.userMailbox.Cap()-1 == q.userMailbox.Len() {
-			_, _ = q.userMailbox.Get()
+			if dropped, err := q.userMailbox.Get(); err == nil && q.dropped != nil {
+				q.dropped(dropped)
+			}
 		}
 	}
 
@@ -36,6 +42,7 @@ func Bounded(size int, mailboxStats ...MailboxMiddleware) MailboxProducer {
 }
 
 // BoundedDropping returns a producer which creates a bounded mailbox of the specified size that drops front element on push.
+// Dropped messages are sent to dead letters
 func BoundedDropping(size int, mailboxStats ...MailboxMiddleware) MailboxProducer {
 	return bounded(size, true, mailboxStats...)
 }
@@ -47,10 +54,120 @@ func bounded(size int, dropping bool, mailboxStats ...MailboxMiddleware) Mailbox
 			dropping:    dropping,
 		}
 
-		return &defaultMailbox{
+		m := &defaultMailbox{
 			systemMailbox: mpsc.New(),
 			userMailbox:   q,
 			middlewares:   mailboxStats,
 		}
+		if dropping {
+			q.dropped = m.dropUserMessage
+		}
+
+		return m
+	}
+}
+
+// BoundedPolicy decides what happens to a message posted to a full bounded mailbox
+type BoundedPolicy int
+
+const (
+	// BoundedBlock blocks the sender until the mailbox has room.
+	// Only the actor itself makes room, so an actor must not send to its own full mailbox, directly or through
+	// a cycle of actors with blocking mailboxes: the send blocks the actor forever. Use a dropping policy for those
+	BoundedBlock BoundedPolicy = iota
+	// BoundedDropNewest drops the posted message
+	BoundedDropNewest
+	// BoundedDropOldest drops the oldest queued message to make room for the posted one
+	BoundedDropOldest
+)
+
+// boundedPolicyQueue holds at most size messages, applying policy once full.
+// Dropped messages are passed to dropped
+type boundedPolicyQueue struct {
+	mu      sync.Mutex
+	notFull *sync.Cond
+	items   []interface{}
+	head    int
+	count   int
+	policy  BoundedPolicy
+	dropped func(message interface{})
+}
+
+func newBoundedPolicyQueue(size int, policy BoundedPolicy) *boundedPolicyQueue {
+	if size < 1 {
+		size = 1
+	}
+
+	q := &boundedPolicyQueue{
+		items:  make([]interface{}, size),
+		policy: policy,
+	}
+	q.notFull = sync.NewCond(&q.mu)
+
+	return q
+}
+
+func (q *boundedPolicyQueue) Push(m interface{}) {
+	q.mu.Lock()
+
+	var dropped interface{}
+	if q.count == len(q.items) {
+		switch q.policy {
+		case BoundedDropNewest:
+			q.mu.Unlock()
+			q.dropped(m)
+			return
+		case BoundedDropOldest:
+			dropped = q.items[q.head]
+			q.items[q.head] = nil
+			q.head = (q.head + 1) % len(q.items)
+			q.count--
+		default:
+			for q.count == len(q.items) {
+				q.notFull.Wait()
+			}
+		}
+	}
+
+	q.items[(q.head+q.count)%len(q.items)] = m
+	q.count++
+	q.mu.Unlock()
+
+	if dropped != nil {
+		q.dropped(dropped)
+	}
+}
+
+func (q *boundedPolicyQueue) Pop() interface{} {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.count == 0 {
+		return nil
+	}
+
+	m := q.items[q.head]
+	q.items[q.head] = nil
+	q.head = (q.head + 1) % len(q.items)
+	q.count--
+	q.notFull.Signal()
+
+	return m
+}
+
+// BoundedWithPolicy returns a producer which creates a bounded mailbox of the specified size, applying policy once full.
+// Dropped messages are sent to dead letters. System messages are never dropped and do not count against the size.
+// See BoundedBlock for the sends which deadlock with a blocking mailbox
+func BoundedWithPolicy(size int, policy BoundedPolicy, mailboxStats ...MailboxMiddleware) MailboxProducer {
+	return func() Mailbox {
+		q := newBoundedPolicyQueue(size, policy)
+		m := &defaultMailbox{
+			systemMailbox: mpsc.New(),
+			userMailbox:   q,
+			middlewares:   mailboxStats,
+		}
+		q.dropped = m.dropUserMessage
+
+		return m
 	}
 }
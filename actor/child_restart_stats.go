@@ -7,11 +7,15 @@ import (
 // RestartStatistics keeps track of how many times an actor have restarted and when
 type RestartStatistics struct {
 	failureTimes []time.Time
+
+	// backoffAttempts and backoffRestartAt track the restart delay of the backoff decorator
+	backoffAttempts  int
+	backoffRestartAt time.Time
 }
 
 // NewRestartStatistics construct a RestartStatistics
 func NewRestartStatistics() *RestartStatistics {
-	return &RestartStatistics{[]time.Time{}}
+	return &RestartStatistics{failureTimes: []time.Time{}}
 }
 
 // FailureCount returns failure count
@@ -29,6 +33,21 @@ func (rs *RestartStatistics) Reset() {
 	rs.failureTimes = []time.Time{}
 }
 
+// lastRestart returns when the child was last restarted, later than its last failure when the restart was delayed
+// by a backoff. It is zero when the child did not fail
+func (rs *RestartStatistics) lastRestart() time.Time {
+	if len(rs.failureTimes) == 0 {
+		return time.Time{}
+	}
+
+	last := rs.failureTimes[len(rs.failureTimes)-1]
+	if rs.backoffRestartAt.After(last) {
+		return rs.backoffRestartAt
+	}
+
+	return last
+}
+
 // NumberOfFailures returns number of failures within a given duration
 func (rs *RestartStatistics) NumberOfFailures(withinDuration time.Duration) int {
 	if withinDuration == 0 {
@@ -117,6 +117,66 @@ func TestActorReceivesTerminatedFromWatched(t *testing.T) {
 	assertFutureSuccess(future, t)
 }
 
+// watchTermination spawns an actor watching pid which sends the reason pid terminated with to the returned future
+func watchTermination(t *testing.T, pid *PID) *Future {
+	future := NewFuture(system, testTimeout)
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	rootContext.Spawn(PropsFromFunc(func(c Context) {
+		switch msg := c.Message().(type) {
+		case *Started:
+			c.Watch(pid)
+			wg.Done()
+		case *Terminated:
+			c.Send(future.PID(), msg.Why)
+		}
+	}))
+	wg.Wait()
+
+	return future
+}
+
+func TestActorTerminatedReason(t *testing.T) {
+	t.Run("stopped", func(t *testing.T) {
+		pid := rootContext.Spawn(PropsFromFunc(nullReceive))
+		future := watchTermination(t, pid)
+		rootContext.Stop(pid)
+
+		assert.Equal(t, TerminatedReason_Stopped, assertFutureSuccess(future, t))
+	})
+
+	t.Run("killed by stopping parent", func(t *testing.T) {
+		children := make(chan *PID, 1)
+		parent := rootContext.Spawn(PropsFromFunc(func(c Context) {
+			if _, ok := c.Message().(*Started); ok {
+				children <- c.Spawn(PropsFromFunc(nullReceive))
+			}
+		}))
+		future := watchTermination(t, <-children)
+		rootContext.Stop(parent)
+
+		assert.Equal(t, TerminatedReason_Killed, assertFutureSuccess(future, t))
+	})
+
+	t.Run("killed by restarting parent", func(t *testing.T) {
+		children := make(chan *PID, 2)
+		parent := rootContext.Spawn(PropsFromFunc(func(c Context) {
+			switch c.Message().(type) {
+			case *Started:
+				children <- c.Spawn(PropsFromFunc(nullReceive))
+			case string:
+				panic("restart")
+			}
+		}))
+		defer rootContext.Stop(parent)
+		future := watchTermination(t, <-children)
+		rootContext.Send(parent, "fail")
+
+		assert.Equal(t, TerminatedReason_Killed, assertFutureSuccess(future, t))
+	})
+}
+
 func TestFutureDoesTimeout(t *testing.T) {
 	pid := rootContext.Spawn(PropsFromFunc(nullReceive))
 	_, err := rootContext.RequestFuture(pid, "", time.Millisecond).Result()
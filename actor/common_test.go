@@ -1,6 +1,7 @@
 package actor
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -77,18 +78,42 @@ func (m *mockContext) Stash() {
 	m.Called()
 }
 
+func (m *mockContext) SetReady() {
+	m.Called()
+}
+
 func (m *mockContext) Watch(pid *PID) {
 	m.Called(pid)
 }
 
+func (m *mockContext) WatchDurable(pid *PID) {
+	m.Called(pid)
+}
+
 func (m *mockContext) Unwatch(pid *PID) {
 	m.Called(pid)
 }
 
+func (m *mockContext) SetTimer(key string, d time.Duration, message interface{}) {
+	m.Called(key, d, message)
+}
+
+func (m *mockContext) SetRecurringTimer(key string, interval time.Duration, message interface{}) {
+	m.Called(key, interval, message)
+}
+
+func (m *mockContext) CancelTimer(key string) {
+	m.Called(key)
+}
+
 func (m *mockContext) SetReceiveTimeout(d time.Duration) {
 	m.Called(d)
 }
 
+func (m *mockContext) SetReceiveTimeoutOnce(d time.Duration) {
+	m.Called(d)
+}
+
 func (m *mockContext) CancelReceiveTimeout() {
 	m.Called()
 }
@@ -101,6 +126,20 @@ func (m *mockContext) ReenterAfter(f *Future, cont func(res interface{}, err err
 	m.Called(f, cont)
 }
 
+func (m *mockContext) BehaviorStackSize() int {
+	args := m.Called()
+	return args.Int(0)
+}
+
+func (m *mockContext) RequestScope() RequestScope {
+	args := m.Called()
+	return args.Get(0).(RequestScope)
+}
+
+func (m *mockContext) RequestWithRetry(pid *PID, message interface{}, opts RetryOptions, cont func(res interface{}, err error)) {
+	m.Called(pid, message, opts, cont)
+}
+
 //
 // Interface: SenderContext
 //
@@ -121,6 +160,10 @@ func (m *mockContext) Send(_ *PID, _ interface{}) {
 	m.Called()
 }
 
+func (m *mockContext) SendWithHeaders(pid *PID, message interface{}, headers ReadonlyMessageHeader) {
+	m.Called(pid, message, headers)
+}
+
 func (m *mockContext) Request(pid *PID, message interface{}) {
 	args := m.Called()
 
@@ -151,6 +194,12 @@ func (m *mockContext) RequestFuture(_ *PID, _ interface{}, _ time.Duration) *Fut
 	return args.Get(0).(*Future)
 }
 
+func (m *mockContext) RequestFutureCtx(_ *PID, _ interface{}, _ context.Context) *Future {
+	args := m.Called()
+
+	return args.Get(0).(*Future)
+}
+
 //
 // Interface: ReceiverContext
 //
@@ -18,29 +18,47 @@ type Config struct {
 	DeadLetterThrottleInterval  time.Duration      // throttle deadletter logging after this interval
 	DeadLetterThrottleCount     int32              // throttle deadletter logging after this count
 	DeadLetterRequestLogging    bool               // do not log dead-letters with sender
+	DeadLetterResponse          bool               // answer dead-letters with sender with a DeadLetterResponse, so futures fail with ErrDeadLetter instead of timing out
+	DeadLetterAggregationWindow time.Duration      // log dead-letters once per sender, target and message type within this window, zero logs each one
+	DeadLetterAggregationKeys   int                // max distinct dead-letter keys aggregated at once, further keys are only counted
 	DeveloperSupervisionLogging bool               // console log and promote supervision logs to Warning level
 	DiagnosticsSerializer       func(Actor) string // extract diagnostics from actor and return as string
 	MetricsProvider             metric.MeterProvider
+	MetricsLabels               []MetricsLabel // optional labels of the mailbox length and message duration metrics
+	MetricsSampleRate           int            // measure the duration of one in every MetricsSampleRate messages of an actor
+	ProcessCacheSize            int            // number of processes resolved for PIDs kept in a LRU cache, zero disables it
+	Logger                      log.Interface  // logger of the actor system and its subsystems, the package logger when nil
+	EventStreamWorkers          int            // workers dispatching the pooled event stream subscriptions, zero dispatches synchronously
+	EventStreamQueueSize        int            // events queued per pooled event stream subscription
+	SupervisionEvents           bool           // publish a SupervisorEvent on the event stream for each directive applied by a supervisor
+	DebugMailboxHistory         int            // decorate the mailboxes to record the last DebugMailboxHistory messages of each actor and publish a MailboxAnomaly on invariant violations, zero disables it
 }
 
 func defaultConfig() *Config {
 	return &Config{
 		MetricsProvider:             nil,
+		MetricsLabels:               []MetricsLabel{ActorTypeLabel},
+		MetricsSampleRate:           1,
 		DeadLetterThrottleInterval:  1 * time.Second,
 		DeadLetterThrottleCount:     3,
 		DeadLetterRequestLogging:    true,
+		DeadLetterResponse:          true,
+		DeadLetterAggregationWindow: 0,
+		DeadLetterAggregationKeys:   1000,
 		DeveloperSupervisionLogging: false,
+		SupervisionEvents:           true,
+		DebugMailboxHistory:         debugMailboxHistoryFromEnv(),
 		DiagnosticsSerializer: func(actor Actor) string {
 			return ""
 		},
 	}
 }
 
-func defaultPrometheusProvider(port int) metric.MeterProvider {
+func defaultPrometheusProvider(port int, logger log.Interface) metric.MeterProvider {
 	exporter, err := prometheus.New()
 	if err != nil {
 		err = fmt.Errorf("failed to initialize prometheus exporter: %w", err)
-		plog.Error(err.Error(), log.Error(err))
+		logger.Error(err.Error(), log.Error(err))
 
 		return nil
 	}
@@ -55,7 +73,7 @@ func defaultPrometheusProvider(port int) metric.MeterProvider {
 		_ = http.ListenAndServe(_port, nil)
 	}()
 
-	plog.Debug(fmt.Sprintf("Prometheus server running on %s", _port))
+	logger.Debug(fmt.Sprintf("Prometheus server running on %s", _port))
 
 	return provider
 }
@@ -3,6 +3,7 @@ package actor
 import (
 	"time"
 
+	"github.com/asynkron/protoactor-go/log"
 	"go.opentelemetry.io/otel/metric"
 )
 
@@ -35,12 +36,46 @@ func WithDeadLetterRequestLogging(enabled bool) ConfigOption {
 	}
 }
 
+// WithDeadLetterResponse sets whether requests which reach dead letters are answered with a DeadLetterResponse,
+// which resolves a future immediately with ErrDeadLetter. When disabled the future times out instead
+func WithDeadLetterResponse(enabled bool) ConfigOption {
+	return func(config *Config) {
+		config.DeadLetterResponse = enabled
+	}
+}
+
+// WithDeadLetterAggregation logs dead-letters once per sender, target and message type within window,
+// with the number of dead-letters. At most maxKeys distinct keys are tracked at once
+func WithDeadLetterAggregation(window time.Duration, maxKeys int) ConfigOption {
+	return func(config *Config) {
+		config.DeadLetterAggregationWindow = window
+		config.DeadLetterAggregationKeys = maxKeys
+	}
+}
+
 func WithDeveloperSupervisionLogging(enabled bool) ConfigOption {
 	return func(config *Config) {
 		config.DeveloperSupervisionLogging = enabled
 	}
 }
 
+// WithSupervisionEvents sets whether supervisors publish a SupervisorEvent for each directive they apply.
+// Disabling them saves an allocation per failure on systems with a high churn, and silences the supervision logs
+func WithSupervisionEvents(enabled bool) ConfigOption {
+	return func(config *Config) {
+		config.SupervisionEvents = enabled
+	}
+}
+
+// WithDebugMailboxes decorates the mailboxes of the actors to detect messages lost or processed out of order,
+// they publish a MailboxAnomaly on the event stream with the last history messages processed by the actor.
+// The default is taken from the PROTO_ACTOR_DEBUG_MAILBOX environment variable. Meant for development, zero disables it
+func WithDebugMailboxes(history int) ConfigOption {
+	return func(config *Config) {
+		config.DebugMailboxHistory = history
+	}
+}
+
 func WithDiagnosticsSerializer(serializer func(Actor) string) ConfigOption {
 	return func(config *Config) {
 		config.DiagnosticsSerializer = serializer
@@ -53,11 +88,60 @@ func WithMetricProviders(provider metric.MeterProvider) ConfigOption {
 	}
 }
 
+// WithMetricsLabels sets the labels of the mailbox length and message duration metrics of the actors, besides the
+// address. Only ActorTypeLabel is set by default, as each label multiplies the number of series
+func WithMetricsLabels(labels ...MetricsLabel) ConfigOption {
+	return func(config *Config) {
+		config.MetricsLabels = labels
+	}
+}
+
+// WithMetricsSampleRate measures the duration of one in every rate messages of each actor, rather than of every message
+func WithMetricsSampleRate(rate int) ConfigOption {
+	return func(config *Config) {
+		config.MetricsSampleRate = rate
+	}
+}
+
+// WithProcessCache keeps the processes resolved for the last size PIDs, so that hot paths resolving new PID values,
+// such as the senders of remote messages, skip the address resolvers and the registry
+func WithProcessCache(size int) ConfigOption {
+	return func(config *Config) {
+		config.ProcessCacheSize = size
+	}
+}
+
+// WithEventStreamDispatchPool dispatches the event stream subscriptions made with SubscribePooled on workers
+// goroutines, each subscription queueing up to queueSize events and dropping the events published while its queue is
+// full. Subscriptions made with Subscribe are still handled synchronously by the publisher
+func WithEventStreamDispatchPool(workers, queueSize int) ConfigOption {
+	return func(config *Config) {
+		config.EventStreamWorkers = workers
+		config.EventStreamQueueSize = queueSize
+	}
+}
+
+// WithLogger sets the logger of the actor system, used by its subsystems instead of the package logger.
+// Use it to route the logs of each actor system separately, or to attach fields such as the system id to them
+func WithLogger(logger log.Interface) ConfigOption {
+	return func(config *Config) {
+		config.Logger = logger
+	}
+}
+
+// WithDefaultPrometheusProvider serves the metrics for Prometheus on port, 2222 by default.
+// It logs with the logger of a WithLogger option preceding it
 func WithDefaultPrometheusProvider(port ...int) ConfigOption {
 	_port := 2222
 	if len(port) > 0 {
 		_port = port[0]
 	}
 
-	return WithMetricProviders(defaultPrometheusProvider(_port))
+	return func(config *Config) {
+		logger := config.Logger
+		if logger == nil {
+			logger = plog
+		}
+		config.MetricsProvider = defaultPrometheusProvider(_port, logger)
+	}
 }
@@ -1,6 +1,7 @@
 package actor
 
 import (
+	"context"
 	"time"
 
 	"github.com/asynkron/protoactor-go/ctxext"
@@ -72,9 +73,20 @@ type basePart interface {
 	// Stash stashes the current message on a stack for reprocessing when the actor restarts
 	Stash()
 
+	// SetReady tells that an actor spawned WithReadinessTimeout finished starting, the user messages it received
+	// since it started are then processed in order, ahead of its mailbox
+	SetReady()
+
 	// Watch registers the actor as a monitor for the specified PID
 	Watch(pid *PID)
 
+	// WatchDurable registers the actor as a monitor for the specified PID, a remote PID is not reported terminated
+	// when its endpoint terminates. The watch is sent again when the endpoint reconnects, the actor then receives
+	// Terminated if the PID is gone, and nothing if it is still alive. A PID whose endpoint does not reconnect within
+	// the reconnect attempts of the remote configuration is reported terminated with AddressTerminated.
+	// Local PIDs are watched as with Watch
+	WatchDurable(pid *PID)
+
 	// Unwatch unregisters the actor as a monitor for the specified PID
 	Unwatch(pid *PID)
 
@@ -85,12 +97,43 @@ type basePart interface {
 	// the NotInfluenceReceiveTimeout interface, the timer will not be reset
 	SetReceiveTimeout(d time.Duration)
 
+	// SetReceiveTimeoutOnce sets an inactivity timeout which only applies until the next message is received,
+	// the previous timeout is then restored. Messages conforming to the NotInfluenceReceiveTimeout interface
+	// and the ReceiveTimeout message itself do not restore it
+	SetReceiveTimeoutOnce(d time.Duration)
+
 	CancelReceiveTimeout()
 
-	// Forward forwards current message to the given PID
+	// SetTimer sends message to the actor once d elapsed. The actor owns its timers by key, setting a timer with the
+	// key of another replaces it, and all of them are cancelled when the actor stops or restarts.
+	// A cancelled or replaced timer never delivers its message, even if it already fired
+	SetTimer(key string, d time.Duration, message interface{})
+
+	// SetRecurringTimer sends message to the actor every interval, until the timer is cancelled or replaced,
+	// or the actor stops or restarts. See SetTimer
+	SetRecurringTimer(key string, interval time.Duration, message interface{})
+
+	// CancelTimer cancels the timer set with key, if any
+	CancelTimer(key string)
+
+	// Forward forwards current message to the given PID, keeping its sender and headers. Across remote
+	// endpoints the sender keeps the address of its own node, so replies go to the original sender
 	Forward(pid *PID)
 
 	ReenterAfter(f *Future, continuation func(res interface{}, err error))
+
+	// BehaviorStackSize returns the number of behaviors on the stack of the Behavior the actor receives its messages
+	// with, zero if it does not use a Behavior
+	BehaviorStackSize() int
+
+	// RequestScope returns the values of the logical request the message being processed belongs to, they are
+	// carried by the messages sent while processing it. See RequestScope
+	RequestScope() RequestScope
+
+	// RequestWithRetry requests the given PID like RequestFuture, retrying failed attempts as configured by opts,
+	// and calls continuation once in the actor context with the response or the error of the last attempt.
+	// The actor keeps processing messages between attempts
+	RequestWithRetry(pid *PID, message interface{}, opts RetryOptions, continuation func(res interface{}, err error))
 }
 
 type messagePart interface {
@@ -108,6 +151,10 @@ type senderPart interface {
 	// Send sends a message to the given PID
 	Send(pid *PID, message interface{})
 
+	// SendWithHeaders sends a message to the given PID with a copy of headers, e.g. the headers of the
+	// current message from MessageHeader. Headers are carried along to remote nodes
+	SendWithHeaders(pid *PID, message interface{}, headers ReadonlyMessageHeader)
+
 	// Request sends a message to the given PID
 	Request(pid *PID, message interface{})
 
@@ -116,6 +163,10 @@ type senderPart interface {
 
 	// RequestFuture sends a message to a given PID and returns a Future
 	RequestFuture(pid *PID, message interface{}, timeout time.Duration) *Future
+
+	// RequestFutureCtx sends a message to a given PID and returns a Future,
+	// which resolves with the error of ctx if it is done before a response is received
+	RequestFutureCtx(pid *PID, message interface{}, ctx context.Context) *Future
 }
 
 type receiverPart interface {
@@ -131,7 +182,8 @@ type spawnerPart interface {
 
 	// SpawnNamed starts a new child actor based on props and named using the specified name
 	//
-	// ErrNameExists will be returned if id already exists
+	// ErrNameExists will be returned along with the PID of the existing process if id already exists,
+	// concurrent spawns of the same id spawn a single actor and all the others get ErrNameExists
 	//
 	// Please do not use name sharing same pattern with system actors, for example "YourPrefix$1", "Remote$1", "future$1"
 	SpawnNamed(props *Props, id string) (*PID, error)
@@ -142,6 +194,9 @@ type stopperPart interface {
 	Stop(pid *PID)
 
 	// StopFuture will stop actor immediately regardless of existing user messages in mailbox, and return its future.
+	// The future completes with the Terminated message of the actor, once its Stopped handler ran and its children
+	// stopped. A remote actor completes it once its node confirmed the stop, or with TerminatedReason_AddressTerminated
+	// once the endpoint to its node terminated
 	StopFuture(pid *PID) *Future
 
 	// Poison will tell actor to stop after processing current user messages in mailbox.
@@ -149,4 +204,9 @@ type stopperPart interface {
 
 	// PoisonFuture will tell actor to stop after processing current user messages in mailbox, and return its future.
 	PoisonFuture(pid *PID) *Future
+
+	// PoisonDrain will tell actor to stop after processing current user messages in mailbox and the continuations
+	// it awaits with ReenterAfter, and return its future. The actor is stopped regardless once timeout elapsed.
+	// Only local actors wait for their continuations, remote actors are stopped once timeout elapsed
+	PoisonDrain(pid *PID, timeout time.Duration) *Future
 }
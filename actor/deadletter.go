@@ -22,16 +22,31 @@ func NewDeadLetter(actorSystem *ActorSystem) *deadLetterProcess {
 	}
 
 	shouldThrottle := NewThrottle(actorSystem.Config.DeadLetterThrottleCount, actorSystem.Config.DeadLetterThrottleInterval, func(i int32) {
-		plog.Info("[DeadLetter]", log.Int64("throttled", int64(i)))
+		actorSystem.Logger().Info("[DeadLetter]", log.Int64("throttled", int64(i)))
 	})
 
+	logDeadLetter := func(deadLetter *DeadLetterEvent) {
+		if shouldThrottle() == Open {
+			actorSystem.Logger().Debug("[DeadLetter]", log.Stringer("pid", deadLetter.PID), log.TypeOf("msg", deadLetter.Message), log.Stringer("sender", deadLetter.Sender))
+		}
+	}
+
+	if window := actorSystem.Config.DeadLetterAggregationWindow; window > 0 {
+		aggregator := newDeadLetterAggregator(window, actorSystem.Config.DeadLetterAggregationKeys, func(key deadLetterKey, count int) {
+			actorSystem.Logger().Debug("[DeadLetter]", log.String("pid", key.target), log.String("msg", key.msgType), log.String("sender", key.sender), log.Int("count", count))
+		}, func(count int) {
+			actorSystem.Logger().Info("[DeadLetter]", log.Int("untracked", count))
+		})
+		logDeadLetter = aggregator.add
+	}
+
 	actorSystem.ProcessRegistry.Add(dp, "deadletter")
 	_ = actorSystem.EventStream.Subscribe(func(msg interface{}) {
 		if deadLetter, ok := msg.(*DeadLetterEvent); ok {
 
 			// send back a response instead of timeout.
-			if deadLetter.Sender != nil {
-				actorSystem.Root.Send(deadLetter.Sender, &DeadLetterResponse{})
+			if deadLetter.Sender != nil && actorSystem.Config.DeadLetterResponse {
+				actorSystem.Root.Send(deadLetter.Sender, &DeadLetterResponse{Target: deadLetter.PID})
 			}
 
 			// bail out if sender is set and deadletter request logging is false
@@ -40,9 +55,7 @@ func NewDeadLetter(actorSystem *ActorSystem) *deadLetterProcess {
 			}
 
 			if _, isIgnoreDeadLetter := deadLetter.Message.(IgnoreDeadLetterLogging); !isIgnoreDeadLetter {
-				if shouldThrottle() == Open {
-					plog.Debug("[DeadLetter]", log.Stringer("pid", deadLetter.PID), log.TypeOf("msg", deadLetter.Message), log.Stringer("sender", deadLetter.Sender))
-				}
+				logDeadLetter(deadLetter)
 			}
 		}
 	})
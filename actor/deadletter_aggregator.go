@@ -0,0 +1,91 @@
+package actor
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// deadLetterKey identifies dead letters which are logged together
+type deadLetterKey struct {
+	sender  string
+	target  string
+	msgType string
+}
+
+// deadLetterAggregator counts dead letters per sender, target and message type, and reports each key once per window.
+// Once maxKeys keys are tracked, dead letters with new keys are only counted as overflow
+type deadLetterAggregator struct {
+	mu             sync.Mutex
+	window         time.Duration
+	maxKeys        int
+	counts         map[deadLetterKey]int
+	overflow       int
+	report         func(key deadLetterKey, count int)
+	reportOverflow func(count int)
+}
+
+func newDeadLetterAggregator(window time.Duration, maxKeys int, report func(key deadLetterKey, count int), reportOverflow func(count int)) *deadLetterAggregator {
+	return &deadLetterAggregator{
+		window:         window,
+		maxKeys:        maxKeys,
+		counts:         make(map[deadLetterKey]int),
+		report:         report,
+		reportOverflow: reportOverflow,
+	}
+}
+
+func (a *deadLetterAggregator) add(deadLetter *DeadLetterEvent) {
+	key := deadLetterKey{
+		sender:  deadLetterPID(deadLetter.Sender),
+		target:  deadLetterPID(deadLetter.PID),
+		msgType: fmt.Sprintf("%T", deadLetter.Message),
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if count, ok := a.counts[key]; ok {
+		a.counts[key] = count + 1
+		return
+	}
+
+	if a.maxKeys > 0 && len(a.counts) >= a.maxKeys {
+		if a.overflow == 0 {
+			time.AfterFunc(a.window, a.flushOverflow)
+		}
+		a.overflow++
+		return
+	}
+
+	a.counts[key] = 1
+	time.AfterFunc(a.window, func() {
+		a.flush(key)
+	})
+}
+
+func (a *deadLetterAggregator) flush(key deadLetterKey) {
+	a.mu.Lock()
+	count := a.counts[key]
+	delete(a.counts, key)
+	a.mu.Unlock()
+
+	a.report(key, count)
+}
+
+func (a *deadLetterAggregator) flushOverflow() {
+	a.mu.Lock()
+	count := a.overflow
+	a.overflow = 0
+	a.mu.Unlock()
+
+	a.reportOverflow(count)
+}
+
+func deadLetterPID(pid *PID) string {
+	if pid == nil {
+		return ""
+	}
+
+	return pid.Key()
+}
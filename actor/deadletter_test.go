@@ -2,6 +2,7 @@ package actor
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -35,3 +36,62 @@ func TestDeadLetterWatchRespondsWithTerminate(t *testing.T) {
 	pid.sendSystemMessage(system, &Watch{Watcher: f.PID()})
 	assertFutureSuccess(f, t)
 }
+
+func TestDeadLetterAggregator(t *testing.T) {
+	reported := make(chan int, 10)
+	overflow := make(chan int, 10)
+	aggregator := newDeadLetterAggregator(50*time.Millisecond, 2, func(key deadLetterKey, count int) {
+		reported <- count
+	}, func(count int) {
+		overflow <- count
+	})
+
+	target := NewPID("local", "target")
+	for i := 0; i < 5; i++ {
+		aggregator.add(&DeadLetterEvent{PID: target, Message: "hello"})
+	}
+	aggregator.add(&DeadLetterEvent{PID: target, Message: 1})
+	aggregator.add(&DeadLetterEvent{PID: NewPID("local", "other"), Message: "hello"})
+
+	counts := []int{<-reported, <-reported}
+	assert.ElementsMatch(t, []int{5, 1}, counts)
+	assert.Equal(t, 1, <-overflow)
+}
+
+func TestDeadLetterResponse(t *testing.T) {
+	missing := NewPID(system.Address(), "missing")
+
+	start := time.Now()
+	_, err := rootContext.RequestFuture(missing, "hello", 5*time.Second).Result()
+	assert.Equal(t, ErrDeadLetter, err)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestDeadLetterResponse_QueuedBehindStop(t *testing.T) {
+	release := make(chan struct{})
+	pid := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		switch ctx.Message() {
+		case "block":
+			<-release
+		case "request":
+			ctx.Respond("response")
+		}
+	}))
+
+	rootContext.Send(pid, "block")
+	future := rootContext.RequestFuture(pid, "request", 5*time.Second)
+	rootContext.Stop(pid)
+	close(release)
+
+	// the request left in the mailbox of the stopped actor fails rather than timing out
+	_, err := future.Result()
+	assert.Equal(t, ErrDeadLetter, err)
+}
+
+func TestDeadLetterResponse_Disabled(t *testing.T) {
+	system := NewActorSystem(WithDeadLetterResponse(false))
+	defer system.Shutdown()
+
+	_, err := system.Root.RequestFuture(NewPID(system.Address(), "missing"), "hello", 50*time.Millisecond).Result()
+	assert.Equal(t, ErrTimeout, err)
+}
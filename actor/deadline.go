@@ -0,0 +1,136 @@
+package actor
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+)
+
+// DeadlineHeader is the message header carrying the deadline of a request, in nanoseconds since the unix epoch.
+// The requests made while processing a message with a deadline inherit it: their timeout is shortened to it, and they
+// are rejected with ErrDeadlineExceeded once it has passed. Remote endpoints do not send the messages whose deadline
+// has passed
+const DeadlineHeader = "x-deadline"
+
+// ErrDeadlineExceeded is the error of the futures of requests made after the deadline of the message being processed
+var ErrDeadlineExceeded = errors.New("future: deadline exceeded")
+
+// SetDeadline sets the deadline of the messages sent with header
+func SetDeadline(header MessageHeader, deadline time.Time) {
+	header.Set(DeadlineHeader, strconv.FormatInt(deadline.UnixNano(), 10))
+}
+
+// GetDeadline returns the deadline carried by header, if any
+func GetDeadline(header ReadonlyMessageHeader) (time.Time, bool) {
+	if header == nil {
+		return time.Time{}, false
+	}
+
+	value := header.Get(DeadlineHeader)
+	if value == "" {
+		return time.Time{}, false
+	}
+
+	nanos, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return time.Unix(0, nanos), true
+}
+
+// DeadlineExceeded tells whether header carries a deadline which has passed
+func DeadlineExceeded(header ReadonlyMessageHeader) bool {
+	deadline, ok := GetDeadline(header)
+
+	return ok && !time.Now().Before(deadline)
+}
+
+// inheritDeadline returns the header propagating the deadline of header to a request, and the time remaining
+// until it. expired is true when the deadline has passed, the header is nil when there is no deadline
+func inheritDeadline(header ReadonlyMessageHeader) (inherited messageHeader, remaining time.Duration, expired bool) {
+	deadline, ok := GetDeadline(header)
+	if !ok {
+		return nil, 0, false
+	}
+
+	remaining = time.Until(deadline)
+	if remaining <= 0 {
+		return nil, 0, true
+	}
+
+	return messageHeader{DeadlineHeader: header.Get(DeadlineHeader)}, remaining, false
+}
+
+// requestFuture sends a request on behalf of a future, within the deadline of the header of the sending context
+func requestFuture(actorSystem *ActorSystem, header ReadonlyMessageHeader, pid *PID, message interface{}, timeout time.Duration, send func(*PID, interface{})) *Future {
+	inherited, remaining, expired := inheritDeadline(header)
+	if expired {
+		ref := newFutureProcess(actorSystem, -1)
+		ref.fail(ErrDeadlineExceeded)
+
+		return &ref.Future
+	}
+	if inherited != nil && (timeout < 0 || remaining < timeout) {
+		timeout = remaining
+	}
+
+	future := NewFuture(actorSystem, timeout)
+	send(pid, &MessageEnvelope{
+		Header:  inherited,
+		Message: message,
+		Sender:  future.PID(),
+	})
+
+	return future
+}
+
+// requestFutureCtx sends a request on behalf of a future bounded by ctx, within the deadline of the header of the
+// sending context
+func requestFutureCtx(actorSystem *ActorSystem, header ReadonlyMessageHeader, pid *PID, message interface{}, ctx context.Context, send func(*PID, interface{})) *Future {
+	inherited, _, expired := inheritDeadline(header)
+	if expired {
+		ref := newFutureProcess(actorSystem, -1)
+		ref.fail(ErrDeadlineExceeded)
+
+		return &ref.Future
+	}
+
+	var future *Future
+	if inherited != nil {
+		deadline, _ := GetDeadline(inherited)
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, deadline)
+		future = NewFutureCtx(actorSystem, ctx)
+		future.continueWith(func(interface{}, error) { cancel() })
+	} else {
+		future = NewFutureCtx(actorSystem, ctx)
+	}
+
+	send(pid, &MessageEnvelope{
+		Header:  inherited,
+		Message: message,
+		Sender:  future.PID(),
+	})
+
+	return future
+}
+
+// request sends a request within the deadline of the header of the sending context, it is dead lettered when the
+// deadline has passed
+func request(actorSystem *ActorSystem, header ReadonlyMessageHeader, pid *PID, message interface{}, sender *PID, send func(*PID, interface{})) {
+	inherited, _, expired := inheritDeadline(header)
+	env := &MessageEnvelope{
+		Header:  inherited,
+		Message: message,
+		Sender:  sender,
+	}
+	if expired {
+		actorSystem.DeadLetter.SendUserMessage(pid, env)
+
+		return
+	}
+
+	send(pid, env)
+}
@@ -0,0 +1,75 @@
+package actor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestFuture_InheritsDeadline(t *testing.T) {
+	echo := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		if _, ok := ctx.Message().(string); ok {
+			deadline, _ := GetDeadline(ctx.MessageHeader())
+			ctx.Respond(deadline)
+		}
+	}))
+	defer rootContext.Stop(echo)
+	silent := rootContext.Spawn(PropsFromFunc(func(ctx Context) {}))
+	defer rootContext.Stop(silent)
+
+	type result struct {
+		res interface{}
+		err error
+	}
+	results := make(chan result, 1)
+	handler := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		switch ctx.Message().(type) {
+		case string:
+			res, err := ctx.RequestFuture(echo, "deadline", time.Minute).Result()
+			results <- result{res, err}
+		case int:
+			// the request times out with the deadline of the message, long before its own timeout
+			_, err := ctx.RequestFuture(silent, "ignored", time.Minute).Result()
+			results <- result{nil, err}
+		}
+	}))
+	defer rootContext.Stop(handler)
+
+	deadline := time.Now().Add(200 * time.Millisecond)
+	header := NewMessageHeader()
+	SetDeadline(header, deadline)
+
+	rootContext.SendWithHeaders(handler, "request", header)
+	r := <-results
+	assert.NoError(t, r.err)
+	assert.True(t, deadline.Equal(r.res.(time.Time)))
+
+	start := time.Now()
+	rootContext.SendWithHeaders(handler, 1, header)
+	r = <-results
+	assert.Equal(t, ErrTimeout, r.err)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestRequestFuture_RejectsPassedDeadline(t *testing.T) {
+	header := NewMessageHeader()
+	SetDeadline(header, time.Now().Add(-time.Millisecond))
+	root := rootContext.Copy().WithHeaders(header.ToMap())
+
+	received := make(chan struct{}, 1)
+	pid := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		if _, ok := ctx.Message().(string); ok {
+			received <- struct{}{}
+		}
+	}))
+	defer rootContext.Stop(pid)
+
+	_, err := root.RequestFuture(pid, "request", time.Second).Result()
+	assert.Equal(t, ErrDeadlineExceeded, err)
+	select {
+	case <-received:
+		t.Fatal("the request was sent past its deadline")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
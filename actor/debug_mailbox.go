@@ -0,0 +1,252 @@
+package actor
+
+import (
+	"os"
+	"reflect"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/asynkron/protoactor-go/log"
+)
+
+// DebugMailboxEnv is the environment variable setting the default Config.DebugMailboxHistory
+const DebugMailboxEnv = "PROTO_ACTOR_DEBUG_MAILBOX"
+
+// MailboxAnomalyKind is the invariant of a mailbox a MailboxAnomaly breaks
+type MailboxAnomalyKind int
+
+const (
+	// MailboxProcessedTwice is a message processed more times than it was posted
+	MailboxProcessedTwice MailboxAnomalyKind = iota
+	// MailboxProcessedAfterStop is a message received by the actor after it stopped. The messages dequeued after the
+	// actor stopped are dead lettered, they are not anomalies
+	MailboxProcessedAfterStop
+	// MailboxProcessedConcurrently is a message dequeued while the actor was processing another one
+	MailboxProcessedConcurrently
+)
+
+func (k MailboxAnomalyKind) String() string {
+	switch k {
+	case MailboxProcessedTwice:
+		return "processed twice"
+	case MailboxProcessedAfterStop:
+		return "processed after stop"
+	case MailboxProcessedConcurrently:
+		return "processed concurrently"
+	default:
+		return "unknown"
+	}
+}
+
+// MailboxAnomaly is published on the event stream by a debug mailbox, see Config.DebugMailboxHistory
+type MailboxAnomaly struct {
+	PID     *PID
+	Kind    MailboxAnomalyKind
+	Message interface{}
+	// History holds the last messages processed by the actor, oldest first, up to the message
+	History []MailboxRecord
+}
+
+// MailboxRecord is a message processed by an actor with a debug mailbox
+type MailboxRecord struct {
+	Time    time.Time
+	Message interface{}
+	System  bool
+}
+
+func debugMailboxHistoryFromEnv() int {
+	history, err := strconv.Atoi(os.Getenv(DebugMailboxEnv))
+	if err != nil || history < 0 {
+		return 0
+	}
+
+	return history
+}
+
+// debugMailbox decorates the mailbox of an actor, it records the last messages the actor processed and checks the
+// invariants of the mailbox. It is meant for development: it takes a lock and keeps the messages queued by
+// identity, as long as they are queued, to tell the ones processed twice
+type debugMailbox struct {
+	Mailbox
+	actorSystem *ActorSystem
+	ctx         *actorContext
+	processing  int32
+
+	mu      sync.Mutex
+	posted  map[interface{}]int
+	history []MailboxRecord
+	next    int
+	full    bool
+}
+
+func newDebugMailbox(actorSystem *ActorSystem, mailbox Mailbox, history int) *debugMailbox {
+	return &debugMailbox{
+		Mailbox:     mailbox,
+		actorSystem: actorSystem,
+		posted:      make(map[interface{}]int),
+		history:     make([]MailboxRecord, history),
+	}
+}
+
+func (m *debugMailbox) PostUserMessage(message interface{}) {
+	m.mu.Lock()
+	m.countPosted(message)
+	m.mu.Unlock()
+
+	m.Mailbox.PostUserMessage(message)
+}
+
+// countPosted counts the message and, like defaultMailbox, the messages of a batch it carries
+func (m *debugMailbox) countPosted(message interface{}) {
+	if batch, ok := UnwrapEnvelopeMessage(message).(MessageBatch); ok {
+		for _, msg := range batch.GetMessages() {
+			m.countPosted(msg)
+		}
+	}
+	if key, ok := debugMessageKey(message); ok {
+		m.posted[key]++
+	}
+}
+
+// debugMessageKey returns the identity of the messages sent by reference, the messages sent by value can not be told
+// apart from their copies
+func debugMessageKey(message interface{}) (interface{}, bool) {
+	if message == nil || reflect.TypeOf(message).Kind() != reflect.Ptr {
+		return nil, false
+	}
+
+	return message, true
+}
+
+func (m *debugMailbox) RegisterHandlers(invoker MessageInvoker, dispatcher Dispatcher) {
+	ctx, ok := invoker.(*actorContext)
+	if !ok {
+		m.Mailbox.RegisterHandlers(invoker, dispatcher)
+
+		return
+	}
+
+	m.ctx = ctx
+	ctx.debugMailbox = m
+	m.Mailbox.RegisterHandlers(&debugInvoker{Context: ctx, mailbox: m}, dispatcher)
+}
+
+// debugInvoker is the invoker of the decorated mailbox, it is the Context of the actor for the mailboxes which
+// resolve the actor of their invoker
+type debugInvoker struct {
+	Context
+	mailbox *debugMailbox
+}
+
+func (i *debugInvoker) InvokeSystemMessage(message interface{}) {
+	m := i.mailbox
+	defer m.begin(message, true)()
+
+	m.ctx.InvokeSystemMessage(message)
+}
+
+func (i *debugInvoker) InvokeUserMessage(message interface{}) {
+	m := i.mailbox
+	defer m.begin(message, false)()
+
+	if key, ok := debugMessageKey(message); ok {
+		m.mu.Lock()
+		n := m.uncountPosted(key)
+		m.mu.Unlock()
+
+		if n == 0 {
+			m.anomaly(MailboxProcessedTwice, message)
+		}
+	}
+
+	m.ctx.InvokeUserMessage(message)
+}
+
+// userMessageDropped uncounts the messages the mailbox drops, they are not processed
+func (i *debugInvoker) userMessageDropped(message interface{}) {
+	m := i.mailbox
+	if key, ok := debugMessageKey(message); ok {
+		m.mu.Lock()
+		m.uncountPosted(key)
+		m.mu.Unlock()
+	}
+}
+
+func (i *debugInvoker) EscalateFailure(reason interface{}, message interface{}) {
+	i.mailbox.ctx.EscalateFailure(reason, message)
+}
+
+// uncountPosted uncounts a message leaving the mailbox, it returns how many times it was counted before
+func (m *debugMailbox) uncountPosted(key interface{}) int {
+	n := m.posted[key]
+	switch n {
+	case 0:
+	case 1:
+		delete(m.posted, key)
+	default:
+		m.posted[key] = n - 1
+	}
+
+	return n
+}
+
+// receiving checks that the actor did not stop before receiving the message
+func (m *debugMailbox) receiving(message interface{}) {
+	if atomic.LoadInt32(&m.ctx.state) == stateStopped {
+		m.anomaly(MailboxProcessedAfterStop, message)
+	}
+}
+
+// begin checks that the actor is not processing another message and records the message, it returns the function
+// ending the processing
+func (m *debugMailbox) begin(message interface{}, system bool) func() {
+	concurrent := !atomic.CompareAndSwapInt32(&m.processing, 0, 1)
+
+	m.mu.Lock()
+	m.record(message, system)
+	m.mu.Unlock()
+
+	if concurrent {
+		m.anomaly(MailboxProcessedConcurrently, message)
+
+		return func() {}
+	}
+
+	return func() {
+		atomic.StoreInt32(&m.processing, 0)
+	}
+}
+
+func (m *debugMailbox) record(message interface{}, system bool) {
+	if len(m.history) == 0 {
+		return
+	}
+
+	m.history[m.next] = MailboxRecord{Time: time.Now(), Message: message, System: system}
+	m.next++
+	if m.next == len(m.history) {
+		m.next = 0
+		m.full = true
+	}
+}
+
+// anomaly logs the anomaly and publishes it with the recorded messages
+func (m *debugMailbox) anomaly(kind MailboxAnomalyKind, message interface{}) {
+	var history []MailboxRecord
+	m.mu.Lock()
+	if m.full {
+		history = append(history, m.history[m.next:]...)
+	}
+	history = append(history, m.history[:m.next]...)
+	m.mu.Unlock()
+
+	m.actorSystem.Logger().Warn("[MAILBOX] anomaly", log.Stringer("pid", m.ctx.self), log.Stringer("kind", kind), log.TypeOf("msg", message))
+	m.actorSystem.EventStream.Publish(&MailboxAnomaly{
+		PID:     m.ctx.self,
+		Kind:    kind,
+		Message: message,
+		History: history,
+	})
+}
@@ -0,0 +1,159 @@
+package actor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// duplicatingMailbox is a faulty mailbox delivering every user message twice
+type duplicatingMailbox struct {
+	Mailbox
+}
+
+func (m *duplicatingMailbox) PostUserMessage(message interface{}) {
+	m.Mailbox.PostUserMessage(message)
+	m.Mailbox.PostUserMessage(message)
+}
+
+type debugPing struct{ n int }
+
+func subscribeAnomalies(system *ActorSystem) chan *MailboxAnomaly {
+	anomalies := make(chan *MailboxAnomaly, 10)
+	system.EventStream.Subscribe(func(evt interface{}) {
+		if a, ok := evt.(*MailboxAnomaly); ok {
+			anomalies <- a
+		}
+	})
+
+	return anomalies
+}
+
+func TestDebugMailbox_DetectsMessagesProcessedTwice(t *testing.T) {
+	system := NewActorSystem(WithDebugMailboxes(2))
+	anomalies := subscribeAnomalies(system)
+
+	props := PropsFromFunc(func(ctx Context) {}, WithMailbox(func() Mailbox {
+		return &duplicatingMailbox{Mailbox: Unbounded()()}
+	}))
+	pid := system.Root.Spawn(props)
+	first, second := &debugPing{1}, &debugPing{2}
+	system.Root.Send(pid, first)
+	system.Root.Send(pid, second)
+
+	for _, expected := range []*debugPing{first, second} {
+		select {
+		case a := <-anomalies:
+			assert.Equal(t, MailboxProcessedTwice, a.Kind)
+			assert.Same(t, expected, a.Message)
+			assert.True(t, pid.Equal(a.PID))
+			require.Len(t, a.History, 2)
+			assert.Same(t, expected, a.History[0].Message)
+			assert.Same(t, expected, a.History[1].Message)
+		case <-time.After(time.Second):
+			t.Fatal("anomaly not published")
+		}
+	}
+}
+
+func TestDebugMailbox_DetectsMessagesAfterStop(t *testing.T) {
+	system := NewActorSystem(WithDebugMailboxes(5))
+	anomalies := subscribeAnomalies(system)
+	deadLetters := make(chan interface{}, 10)
+	system.EventStream.Subscribe(func(evt interface{}) {
+		if dl, ok := evt.(*DeadLetterEvent); ok {
+			deadLetters <- dl.Message
+		}
+	})
+
+	pid := system.Root.Spawn(PropsFromFunc(func(ctx Context) {}))
+	process, _ := system.ProcessRegistry.Get(pid)
+	require.NoError(t, system.Root.StopFuture(pid).Wait())
+
+	// a process resolved before the actor stopped still reaches its mailbox, the message is dead lettered
+	late := &debugPing{1}
+	process.SendUserMessage(pid, late)
+	select {
+	case msg := <-deadLetters:
+		assert.Same(t, late, msg)
+	case <-time.After(time.Second):
+		t.Fatal("late message not dead lettered")
+	}
+	assert.Empty(t, anomalies)
+
+	// a stopped actor receiving a message is an anomaly
+	ctx := process.(*ActorProcess).mailbox.(*debugMailbox).ctx
+	ctx.processMessage(late)
+
+	select {
+	case a := <-anomalies:
+		assert.Equal(t, MailboxProcessedAfterStop, a.Kind)
+		assert.Same(t, late, a.Message)
+		assert.NotEmpty(t, a.History)
+	case <-time.After(time.Second):
+		t.Fatal("anomaly not published")
+	}
+}
+
+func TestDebugMailbox_ForgetsDroppedMessages(t *testing.T) {
+	system := NewActorSystem(WithDebugMailboxes(5))
+	anomalies := subscribeAnomalies(system)
+
+	processed := make(chan interface{}, 10)
+	for _, producer := range []MailboxProducer{BoundedWithPolicy(1, BoundedDropNewest), BoundedDropping(2)} {
+		release := make(chan struct{})
+		pid := system.Root.Spawn(PropsFromFunc(func(ctx Context) {
+			if _, ok := ctx.Message().(*debugPing); ok {
+				<-release
+				processed <- ctx.Message()
+			}
+		}, WithMailbox(producer)))
+		process, _ := system.ProcessRegistry.Get(pid)
+		mailbox := process.(*ActorProcess).mailbox.(*debugMailbox)
+
+		first := &debugPing{1}
+		system.Root.Send(pid, first)
+		for mailbox.UserMessageCount() > 0 {
+			time.Sleep(time.Millisecond)
+		}
+		// the mailbox holds one message, the others are dropped
+		for i := 2; i <= 4; i++ {
+			system.Root.Send(pid, &debugPing{i})
+		}
+		close(release)
+		for i := 0; i < 2; i++ {
+			select {
+			case <-processed:
+			case <-time.After(time.Second):
+				t.Fatal("message not processed")
+			}
+		}
+
+		mailbox.mu.Lock()
+		assert.Empty(t, mailbox.posted)
+		mailbox.mu.Unlock()
+	}
+	assert.Empty(t, anomalies)
+}
+
+func TestDebugMailbox_QuietWhenMailboxBehaves(t *testing.T) {
+	system := NewActorSystem(WithDebugMailboxes(5))
+	anomalies := subscribeAnomalies(system)
+
+	pid := system.Root.Spawn(PropsFromFunc(func(ctx Context) {
+		if _, ok := ctx.Message().(*debugPing); ok {
+			ctx.Respond(ctx.Message())
+		}
+	}, WithMailbox(Bounded(10))))
+	// the same message may be sent several times
+	shared := &debugPing{1}
+	for i := 0; i < 3; i++ {
+		system.Root.Send(pid, shared)
+	}
+	_, err := system.Root.RequestFuture(pid, shared, time.Second).Result()
+	assert.NoError(t, err)
+
+	assert.Empty(t, anomalies)
+}
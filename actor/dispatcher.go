@@ -1,5 +1,7 @@
 package actor
 
+import "sync"
+
 type Dispatcher interface {
 	Schedule(fn func())
 	Throughput() int
@@ -36,3 +38,84 @@ func (d synchronizedDispatcher) Throughput() int {
 func NewSynchronizedDispatcher(throughput int) Dispatcher {
 	return synchronizedDispatcher(throughput)
 }
+
+// BoundedDispatcher processes at most maxConcurrent mailboxes at once, for CPU bound actors which would otherwise
+// oversubscribe the CPUs. Scheduling never blocks, mailboxes beyond the limit wait in a queue for a worker, so actors
+// can message each other on the same dispatcher. An actor blocking on a response from another actor of the dispatcher
+// still deadlocks once all the workers are blocked.
+//
+// A mailbox yields its worker after throughput messages by being scheduled again, at the end of the queue, so the
+// mailboxes waiting in the queue are picked up even while a busy actor keeps receiving messages
+type BoundedDispatcher struct {
+	throughput    int
+	maxConcurrent int
+
+	mu       sync.Mutex
+	pending  []func()
+	inFlight int
+}
+
+var (
+	_ Dispatcher           = &BoundedDispatcher{}
+	_ requeueingDispatcher = &BoundedDispatcher{}
+)
+
+func NewBoundedDispatcher(maxConcurrent, throughput int) *BoundedDispatcher {
+	if maxConcurrent < 1 {
+		maxConcurrent = 1
+	}
+
+	return &BoundedDispatcher{
+		throughput:    throughput,
+		maxConcurrent: maxConcurrent,
+	}
+}
+
+func (d *BoundedDispatcher) Schedule(fn func()) {
+	d.mu.Lock()
+	if d.inFlight >= d.maxConcurrent {
+		d.pending = append(d.pending, fn)
+		d.mu.Unlock()
+
+		return
+	}
+	d.inFlight++
+	d.mu.Unlock()
+
+	go d.work(fn)
+}
+
+func (d *BoundedDispatcher) Throughput() int {
+	return d.throughput
+}
+
+func (d *BoundedDispatcher) requeues() {}
+
+// InFlight returns the number of mailboxes being processed
+func (d *BoundedDispatcher) InFlight() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.inFlight
+}
+
+// work runs fn and then the pending mailboxes, until there are none left
+func (d *BoundedDispatcher) work(fn func()) {
+	for {
+		fn()
+
+		d.mu.Lock()
+		if len(d.pending) == 0 {
+			d.inFlight--
+			// release the memory of a burst
+			d.pending = nil
+			d.mu.Unlock()
+
+			return
+		}
+		fn = d.pending[0]
+		d.pending[0] = nil
+		d.pending = d.pending[1:]
+		d.mu.Unlock()
+	}
+}
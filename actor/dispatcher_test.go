@@ -0,0 +1,136 @@
+package actor
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBoundedDispatcher_LimitsConcurrency(t *testing.T) {
+	dispatcher := NewBoundedDispatcher(2, 10)
+
+	var running, maxRunning int32
+	var wg sync.WaitGroup
+	props := PropsFromFunc(func(ctx Context) {
+		if _, ok := ctx.Message().(string); ok {
+			n := atomic.AddInt32(&running, 1)
+			for {
+				max := atomic.LoadInt32(&maxRunning)
+				if n <= max || atomic.CompareAndSwapInt32(&maxRunning, max, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			atomic.AddInt32(&running, -1)
+			wg.Done()
+		}
+	}, WithDispatcher(dispatcher))
+
+	for i := 0; i < 10; i++ {
+		pid := rootContext.Spawn(props)
+		for j := 0; j < 3; j++ {
+			wg.Add(1)
+			rootContext.Send(pid, "work")
+		}
+	}
+	wg.Wait()
+
+	assert.LessOrEqual(t, atomic.LoadInt32(&maxRunning), int32(2))
+	assert.Eventually(t, func() bool { return dispatcher.InFlight() == 0 }, time.Second, time.Millisecond)
+}
+
+func TestBoundedDispatcher_ActorsMessageEachOther(t *testing.T) {
+	dispatcher := NewBoundedDispatcher(1, 10)
+
+	pong := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		if ctx.Message() == "ping" {
+			ctx.Respond("pong")
+		}
+	}, WithDispatcher(dispatcher)))
+
+	var caller *PID
+	ping := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		switch ctx.Message() {
+		case "start":
+			caller = ctx.Sender()
+			ctx.Request(pong, "ping")
+		case "pong":
+			ctx.Send(caller, "done")
+		}
+	}, WithDispatcher(dispatcher)))
+
+	res, err := rootContext.RequestFuture(ping, "start", time.Second).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, "done", res)
+}
+
+func TestBoundedDispatcher_MailboxesTakeTurns(t *testing.T) {
+	dispatcher := NewBoundedDispatcher(1, 10)
+
+	const messages = 1000
+	var processed int32
+	busy := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		if _, ok := ctx.Message().(int); ok {
+			atomic.AddInt32(&processed, 1)
+			time.Sleep(10 * time.Microsecond)
+		}
+	}, WithDispatcher(dispatcher)))
+	other := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		if _, ok := ctx.Message().(string); ok {
+			ctx.Respond(atomic.LoadInt32(&processed))
+		}
+	}, WithDispatcher(dispatcher)))
+
+	for i := 0; i < messages; i++ {
+		rootContext.Send(busy, i)
+	}
+	res, err := rootContext.RequestFuture(other, "progress", 5*time.Second).Result()
+	assert.NoError(t, err)
+	// the other actor was not starved until the busy one processed all its messages
+	assert.Less(t, res.(int32), int32(messages))
+
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&processed) == messages }, 5*time.Second, time.Millisecond)
+	assert.Eventually(t, func() bool { return dispatcher.InFlight() == 0 }, time.Second, time.Millisecond)
+}
+
+func TestAffinityDispatcher_AssignsActorsByPID(t *testing.T) {
+	dispatcher := NewAffinityDispatcher(4, 10)
+	defer dispatcher.Stop()
+
+	pid := NewPID("localhost", "actor")
+	assert.Same(t, dispatcher.forPID(pid), dispatcher.forPID(NewPID("localhost", "actor")))
+	assert.Len(t, dispatcher.QueueDepths(), 4)
+}
+
+func TestAffinityDispatcher_MailboxesTakeTurns(t *testing.T) {
+	dispatcher := NewAffinityDispatcher(1, 10)
+	defer dispatcher.Stop()
+
+	const messages = 1000
+	var processed int32
+	busy := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		if _, ok := ctx.Message().(int); ok {
+			atomic.AddInt32(&processed, 1)
+			time.Sleep(10 * time.Microsecond)
+		}
+	}, WithDispatcher(dispatcher)))
+	other := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		if _, ok := ctx.Message().(string); ok {
+			ctx.Respond(atomic.LoadInt32(&processed))
+		}
+	}, WithDispatcher(dispatcher)))
+
+	for i := 0; i < messages; i++ {
+		rootContext.Send(busy, i)
+	}
+	res, err := rootContext.RequestFuture(other, "progress", 5*time.Second).Result()
+	assert.NoError(t, err)
+	// the other actor of the worker did not wait for the busy one to process all its messages
+	assert.Less(t, res.(int32), int32(messages))
+
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&processed) == messages }, 5*time.Second, time.Millisecond)
+	assert.Eventually(t, func() bool { return dispatcher.QueueDepths()[0] == 0 }, time.Second, time.Millisecond)
+}
@@ -21,12 +21,39 @@ var ErrDeadLetter = errors.New("future: dead letter")
 
 // NewFuture creates and returns a new actor.Future with a timeout of duration d.
 func NewFuture(actorSystem *ActorSystem, d time.Duration) *Future {
+	return &newFutureProcess(actorSystem, d).Future
+}
+
+// NewFutureCtx creates and returns a new actor.Future which resolves with the error of ctx
+// if ctx is cancelled or its deadline passes before a result is received.
+func NewFutureCtx(actorSystem *ActorSystem, ctx context.Context) *Future {
+	ref := newFutureProcess(actorSystem, -1)
+
+	if done := ctx.Done(); done != nil {
+		completed := make(chan struct{})
+		ref.continueWith(func(_ interface{}, _ error) {
+			close(completed)
+		})
+
+		go func() {
+			select {
+			case <-done:
+				ref.fail(ctx.Err())
+			case <-completed:
+			}
+		}()
+	}
+
+	return &ref.Future
+}
+
+func newFutureProcess(actorSystem *ActorSystem, d time.Duration) *futureProcess {
 	ref := &futureProcess{Future{actorSystem: actorSystem, cond: sync.NewCond(&sync.Mutex{})}}
 	id := actorSystem.ProcessRegistry.NextId()
 
 	pid, ok := actorSystem.ProcessRegistry.Add(ref, "future"+id)
 	if !ok {
-		plog.Error("failed to register future process", log.Stringer("pid", pid))
+		actorSystem.Logger().Error("failed to register future process", log.Stringer("pid", pid))
 	}
 
 	sysMetrics, ok := actorSystem.Extensions.Get(extensionId).(*Metrics)
@@ -45,20 +72,12 @@ func NewFuture(actorSystem *ActorSystem, d time.Duration) *Future {
 
 	if d >= 0 {
 		tp := time.AfterFunc(d, func() {
-			ref.cond.L.Lock()
-			if ref.done {
-				ref.cond.L.Unlock()
-
-				return
-			}
-			ref.err = ErrTimeout
-			ref.cond.L.Unlock()
-			ref.Stop(pid)
+			ref.fail(ErrTimeout)
 		})
 		atomic.StorePointer((*unsafe.Pointer)(unsafe.Pointer(&ref.t)), unsafe.Pointer(tp))
 	}
 
-	return &ref.Future
+	return ref
 }
 
 type Future struct {
@@ -70,7 +89,7 @@ type Future struct {
 	result      interface{}
 	err         error
 	t           *time.Timer
-	pipes       []*PID
+	pipes       []pipe
 	completions []func(res interface{}, err error)
 }
 
@@ -79,10 +98,24 @@ func (f *Future) PID() *PID {
 	return f.pid
 }
 
+// pipe is a target the result of the future is forwarded to, on behalf of sender when set
+type pipe struct {
+	target *PID
+	sender *PID
+}
+
 // PipeTo forwards the result or error of the future to the specified pids.
 func (f *Future) PipeTo(pids ...*PID) {
+	f.PipeToWithSender(nil, pids...)
+}
+
+// PipeToWithSender forwards the result or error of the future to the specified pids on behalf of sender,
+// so that they can respond to it. It does not block, the future process is removed once the result is forwarded
+func (f *Future) PipeToWithSender(sender *PID, pids ...*PID) {
 	f.cond.L.Lock()
-	f.pipes = append(f.pipes, pids...)
+	for _, pid := range pids {
+		f.pipes = append(f.pipes, pipe{target: pid, sender: sender})
+	}
 	// for an already completed future, force push the result to targets.
 	if f.done {
 		f.sendToPipes()
@@ -102,8 +135,12 @@ func (f *Future) sendToPipes() {
 		m = f.result
 	}
 
-	for _, pid := range f.pipes {
-		pid.sendUserMessage(f.actorSystem, m)
+	for _, p := range f.pipes {
+		if p.sender == nil {
+			p.target.sendUserMessage(f.actorSystem, m)
+		} else {
+			p.target.sendUserMessage(f.actorSystem, &MessageEnvelope{Message: m, Sender: p.sender})
+		}
 	}
 
 	f.pipes = nil
@@ -117,7 +154,8 @@ func (f *Future) wait() {
 	f.cond.L.Unlock()
 }
 
-// Result waits for the future to resolve.
+// Result waits for the future to resolve. It can be called any number of times, from several goroutines,
+// and always returns the same result. The future is unregistered once it resolves, its waiters keep the result
 func (f *Future) Result() (interface{}, error) {
 	f.wait()
 
@@ -149,27 +187,23 @@ type futureProcess struct {
 var _ Process = &futureProcess{}
 
 func (ref *futureProcess) SendUserMessage(pid *PID, message interface{}) {
-	defer ref.instrument()
-
 	_, msg, _ := UnwrapEnvelope(message)
 
 	if _, ok := msg.(*DeadLetterResponse); ok {
-		ref.result = nil
-		ref.err = ErrDeadLetter
+		ref.complete(nil, ErrDeadLetter)
+		ref.instrument(ErrDeadLetter)
 	} else {
-		ref.result = msg
+		ref.complete(msg, nil)
+		ref.instrument(nil)
 	}
-
-	ref.Stop(pid)
 }
 
 func (ref *futureProcess) SendSystemMessage(pid *PID, message interface{}) {
-	defer ref.instrument()
-	ref.result = message
-	ref.Stop(pid)
+	ref.complete(message, nil)
+	ref.instrument(nil)
 }
 
-func (ref *futureProcess) instrument() {
+func (ref *futureProcess) instrument(err error) {
 	sysMetrics, ok := ref.actorSystem.Extensions.Get(extensionId).(*Metrics)
 	if ok && sysMetrics.enabled {
 		ctx := context.Background()
@@ -179,7 +213,7 @@ func (ref *futureProcess) instrument() {
 
 		instruments := sysMetrics.metrics.Get(metrics.InternalActorMetrics)
 		if instruments != nil {
-			if ref.err == nil {
+			if err == nil {
 				instruments.FuturesCompletedCount.Add(ctx, 1, labels...)
 			} else {
 				instruments.FuturesTimedOutCount.Add(ctx, 1, labels...)
@@ -188,7 +222,17 @@ func (ref *futureProcess) instrument() {
 	}
 }
 
+// fail resolves the future with err, unless it has already completed
+func (ref *futureProcess) fail(err error) {
+	ref.complete(nil, err)
+}
+
 func (ref *futureProcess) Stop(pid *PID) {
+	ref.complete(nil, nil)
+}
+
+// complete resolves the future with result and err unless it has already completed, and wakes all its waiters
+func (ref *futureProcess) complete(result interface{}, err error) {
 	ref.cond.L.Lock()
 	if ref.done {
 		ref.cond.L.Unlock()
@@ -196,6 +240,8 @@ func (ref *futureProcess) Stop(pid *PID) {
 		return
 	}
 
+	ref.result = result
+	ref.err = err
 	ref.done = true
 	tp := (*time.Timer)(atomic.LoadPointer((*unsafe.Pointer)(unsafe.Pointer(&ref.t))))
 
@@ -203,12 +249,12 @@ func (ref *futureProcess) Stop(pid *PID) {
 		tp.Stop()
 	}
 
-	ref.actorSystem.ProcessRegistry.Remove(pid)
+	ref.actorSystem.ProcessRegistry.Remove(ref.pid)
 
 	ref.sendToPipes()
 	ref.runCompletions()
 	ref.cond.L.Unlock()
-	ref.cond.Signal()
+	ref.cond.Broadcast()
 }
 
 // TODO: we could replace "pipes" with this
@@ -1,11 +1,14 @@
 package actor
 
 import (
+	"context"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/asynkron/protoactor-go/log"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestFuture_PipeTo_Message(t *testing.T) {
@@ -72,6 +75,41 @@ func TestFuture_PipeTo_TimeoutSendsError(t *testing.T) {
 	assert.Empty(t, fp.pipes, "pipes were not cleared")
 }
 
+func TestFuture_PipeToWithSender(t *testing.T) {
+	type piped struct {
+		message interface{}
+		sender  *PID
+	}
+	received := make(chan piped, 2)
+	target := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		switch ctx.Message().(type) {
+		case string, error:
+			received <- piped{message: ctx.Message(), sender: ctx.Sender()}
+		}
+	}))
+	defer rootContext.Stop(target)
+	sender := NewPID(system.Address(), "sender")
+
+	f := NewFuture(system, time.Second)
+	f.PipeToWithSender(sender, target)
+	rootContext.Send(f.PID(), "hello")
+
+	p := <-received
+	assert.Equal(t, "hello", p.message)
+	assert.Equal(t, sender, p.sender)
+	_, ok := system.ProcessRegistry.GetLocal(f.PID().Id)
+	assert.False(t, ok, "future process was not removed")
+
+	f = NewFuture(system, 10*time.Millisecond)
+	f.PipeToWithSender(sender, target)
+
+	p = <-received
+	assert.Equal(t, ErrTimeout, p.message)
+	assert.Equal(t, sender, p.sender)
+	_, ok = system.ProcessRegistry.GetLocal(f.PID().Id)
+	assert.False(t, ok, "future process was not removed")
+}
+
 func TestNewFuture_TimeoutNoRace(t *testing.T) {
 	plog.SetLevel(log.OffLevel)
 	future := NewFuture(system, 1*time.Microsecond)
@@ -124,3 +162,79 @@ func TestFuture_Result_Success(t *testing.T) {
 	resp := assertFutureSuccess(future, t)
 	a.Equal(EchoResponse{}, resp)
 }
+
+func TestFuture_Result_MultipleWaiters(t *testing.T) {
+	future := NewFuture(system, 5*time.Second)
+
+	var wg sync.WaitGroup
+	results := make(chan interface{}, 10)
+	for i := 0; i < cap(results); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res, err := future.Result()
+			assert.NoError(t, err)
+			results <- res
+		}()
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	rootContext.Send(future.PID(), EchoResponse{})
+	wg.Wait()
+	close(results)
+
+	for res := range results {
+		assert.Equal(t, EchoResponse{}, res)
+	}
+	_, registered := system.ProcessRegistry.GetLocal(future.PID().Id)
+	assert.False(t, registered)
+
+	// a resolved future keeps its result
+	res, err := future.Result()
+	assert.NoError(t, err)
+	assert.Equal(t, EchoResponse{}, res)
+}
+
+func TestFuture_Ctx_Cancelled(t *testing.T) {
+	pid, p := spawnMockProcess("ctx_cancelled")
+	defer removeMockProcess(pid)
+	p.On("SendUserMessage", pid, mock.Anything)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	future := rootContext.RequestFutureCtx(pid, "hello", ctx)
+	cancel()
+
+	_, err := future.Result()
+	assert.ErrorIs(t, err, context.Canceled)
+
+	_, ok := system.ProcessRegistry.GetLocal(future.PID().Id)
+	assert.False(t, ok, "future process should be unregistered")
+}
+
+func TestFuture_Ctx_Deadline(t *testing.T) {
+	pid, p := spawnMockProcess("ctx_deadline")
+	defer removeMockProcess(pid)
+	p.On("SendUserMessage", pid, mock.Anything)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := rootContext.RequestFutureCtx(pid, "hello", ctx).Result()
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestFuture_Ctx_Result(t *testing.T) {
+	pid := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		if msg, ok := ctx.Message().(string); ok {
+			ctx.Respond(msg)
+		}
+	}))
+	defer rootContext.Stop(pid)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	res, err := rootContext.RequestFutureCtx(pid, "hello", ctx).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", res)
+}
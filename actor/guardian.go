@@ -38,7 +38,7 @@ func (gs *guardiansValue) newGuardian(s SupervisorStrategy) *guardianProcess {
 
 	pid, ok := gs.actorSystem.ProcessRegistry.Add(ref, "guardian"+id)
 	if !ok {
-		plog.Error("failed to register guardian process", log.Stringer("pid", pid))
+		gs.actorSystem.Logger().Error("failed to register guardian process", log.Stringer("pid", pid))
 	}
 
 	ref.pid = pid
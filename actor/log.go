@@ -12,3 +12,12 @@ var plog = log.New(log.DebugLevel, "[ACTOR]")
 func SetLogLevel(level log.Level) {
 	plog.SetLevel(level)
 }
+
+// Logger returns the logger set with WithLogger, or the package logger
+func (as *ActorSystem) Logger() log.Interface {
+	if as.Config.Logger != nil {
+		return as.Config.Logger
+	}
+
+	return plog
+}
@@ -50,6 +50,8 @@ type defaultMailbox struct {
 	invoker         MessageInvoker
 	dispatcher      Dispatcher
 	middlewares     []MailboxMiddleware
+	// requeue yields the dispatcher after throughput messages by scheduling the mailbox again
+	requeue bool
 }
 
 func (m *defaultMailbox) PostUserMessage(message interface{}) {
@@ -101,9 +103,39 @@ func (m *defaultMailbox) PostSystemMessage(message interface{}) {
 	m.schedule()
 }
 
+// dropObserver is implemented by the invokers which follow the user messages dropped by the mailbox
+type dropObserver interface {
+	userMessageDropped(message interface{})
+}
+
+// dropUserMessage sends a user message dropped by the queue to dead letters
+func (m *defaultMailbox) dropUserMessage(message interface{}) {
+	atomic.AddInt32(&m.userMessages, -1)
+
+	if o, ok := m.invoker.(dropObserver); ok {
+		o.userMessageDropped(message)
+	}
+	if ctx, ok := m.invoker.(Context); ok {
+		ctx.ActorSystem().DeadLetter.SendUserMessage(ctx.Self(), message)
+	}
+}
+
 func (m *defaultMailbox) RegisterHandlers(invoker MessageInvoker, dispatcher Dispatcher) {
 	m.invoker = invoker
 	m.dispatcher = dispatcher
+	_, m.requeue = dispatcher.(requeueingDispatcher)
+	if q, ok := m.userMailbox.(*priorityFuncQueue); ok {
+		q.logger = m.logger()
+	}
+}
+
+// logger returns the logger of the actor system of the invoker, or the package logger
+func (m *defaultMailbox) logger() log.Interface {
+	if ctx, ok := m.invoker.(Context); ok {
+		return ctx.ActorSystem().Logger()
+	}
+
+	return plog
 }
 
 func (m *defaultMailbox) schedule() {
@@ -114,7 +146,12 @@ func (m *defaultMailbox) schedule() {
 
 func (m *defaultMailbox) processMessages() {
 process:
-	m.run()
+	if m.run() {
+		// the mailbox stays running, the dispatcher runs the mailboxes scheduled before it continues
+		m.dispatcher.Schedule(m.processMessages)
+
+		return
+	}
 
 	// set mailbox to idle
 	atomic.StoreInt32(&m.schedulerStatus, idle)
@@ -134,12 +171,14 @@ process:
 	}
 }
 
-func (m *defaultMailbox) run() {
+// run processes the messages of the mailbox until it is empty or suspended.
+// It returns true when it yielded to a requeueing dispatcher, with messages left
+func (m *defaultMailbox) run() (yielded bool) {
 	var msg interface{}
 
 	defer func() {
 		if r := recover(); r != nil {
-			plog.Info("[ACTOR] Recovering", log.Object("actor", m.invoker), log.Object("reason", r), log.Stack())
+			m.logger().Info("[ACTOR] Recovering", log.Object("actor", m.invoker), log.Object("reason", r), log.Stack())
 			m.invoker.EscalateFailure(r, msg)
 		}
 	}()
@@ -147,6 +186,9 @@ func (m *defaultMailbox) run() {
 	i, t := 0, m.dispatcher.Throughput()
 	for {
 		if i > t {
+			if m.requeue {
+				return true
+			}
 			i = 0
 			runtime.Gosched()
 		}
@@ -172,7 +214,7 @@ func (m *defaultMailbox) run() {
 
 		// didn't process a system message, so break until we are resumed
 		if atomic.LoadInt32(&m.suspended) == 1 {
-			return
+			return false
 		}
 
 		if msg = m.userMailbox.Pop(); msg != nil {
@@ -182,7 +224,7 @@ func (m *defaultMailbox) run() {
 				ms.MessageReceived(msg)
 			}
 		} else {
-			return
+			return false
 		}
 	}
 }
@@ -152,3 +152,63 @@ func TestMailboxUserMessageCount(t *testing.T) {
 	wg.Wait()
 	time.Sleep(100 * time.Millisecond)
 }
+
+func TestBoundedWithPolicy(t *testing.T) {
+	cases := map[string]struct {
+		policy   BoundedPolicy
+		expected []interface{}
+		dropped  []interface{}
+	}{
+		"drop newest": {BoundedDropNewest, []interface{}{1, 2}, []interface{}{3}},
+		"drop oldest": {BoundedDropOldest, []interface{}{2, 3}, []interface{}{1}},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			m := BoundedWithPolicy(2, tc.policy)().(*defaultMailbox)
+			var dropped []interface{}
+			m.userMailbox.(*boundedPolicyQueue).dropped = func(message interface{}) {
+				dropped = append(dropped, message)
+			}
+
+			for i := 1; i <= 3; i++ {
+				m.userMailbox.Push(i)
+			}
+
+			assert.Equal(t, tc.dropped, dropped)
+			assert.Equal(t, tc.expected, []interface{}{m.userMailbox.Pop(), m.userMailbox.Pop()})
+			assert.Nil(t, m.userMailbox.Pop())
+		})
+	}
+}
+
+func TestBoundedWithPolicy_DeadLetter(t *testing.T) {
+	system := NewActorSystem()
+	deadLetters := make(chan interface{}, 10)
+	sub := system.EventStream.Subscribe(func(evt interface{}) {
+		if dl, ok := evt.(*DeadLetterEvent); ok {
+			deadLetters <- dl.Message
+		}
+	})
+	defer system.EventStream.Unsubscribe(sub)
+
+	release := make(chan struct{})
+	pid := system.Root.Spawn(PropsFromFunc(func(ctx Context) {
+		if ctx.Message() == "block" {
+			<-release
+		}
+	}, WithMailbox(BoundedWithPolicy(1, BoundedDropNewest))))
+	defer system.Root.Stop(pid)
+
+	system.Root.Send(pid, "block")
+	proc, _ := system.ProcessRegistry.Get(pid)
+	assert.Eventually(t, func() bool {
+		return proc.(*ActorProcess).UserMessageCount() == 0
+	}, time.Second, time.Millisecond)
+
+	system.Root.Send(pid, "queued")
+	system.Root.Send(pid, "dropped")
+	assert.Equal(t, 1, proc.(*ActorProcess).UserMessageCount())
+	assert.Equal(t, "dropped", <-deadLetters)
+	close(release)
+}
@@ -30,17 +30,36 @@ func (header messageHeader) ToMap() map[string]string {
 	return mp
 }
 
+func (header messageHeader) Clone() MessageHeader {
+	return messageHeader(header.ToMap())
+}
+
 type ReadonlyMessageHeader interface {
 	Get(key string) string
 	Keys() []string
 	Length() int
 	ToMap() map[string]string
+	// Clone returns a copy of the header which can be changed, e.g. to propagate it with SendWithHeaders
+	Clone() MessageHeader
+}
+
+// MessageHeader is a message header which can be changed
+type MessageHeader interface {
+	ReadonlyMessageHeader
+	Set(key string, value string)
+}
+
+// NewMessageHeader creates an empty message header
+func NewMessageHeader() MessageHeader {
+	return make(messageHeader)
 }
 
 type MessageEnvelope struct {
 	Header  messageHeader
 	Message interface{}
 	Sender  *PID
+	// Scope holds the values of the RequestScope of the actor which sent the message
+	Scope requestScope
 }
 
 func (envelope *MessageEnvelope) GetHeader(key string) string {
@@ -59,11 +78,29 @@ func (envelope *MessageEnvelope) SetHeader(key string, value string) {
 
 var EmptyMessageHeader = make(messageHeader)
 
+// envelopeWithHeaders wraps message in an envelope carrying a copy of headers, on top of the headers it already has
+func envelopeWithHeaders(message interface{}, headers ReadonlyMessageHeader) *MessageEnvelope {
+	env := &MessageEnvelope{Message: message}
+	if e, ok := message.(*MessageEnvelope); ok {
+		*env = *e
+		env.Header = env.Header.Clone().(messageHeader)
+	}
+	if headers == nil {
+		return env
+	}
+
+	for _, key := range headers.Keys() {
+		env.SetHeader(key, headers.Get(key))
+	}
+
+	return env
+}
+
 func WrapEnvelope(message interface{}) *MessageEnvelope {
 	if e, ok := message.(*MessageEnvelope); ok {
 		return e
 	}
-	return &MessageEnvelope{nil, message, nil}
+	return &MessageEnvelope{Message: message}
 }
 
 func UnwrapEnvelope(message interface{}) (ReadonlyMessageHeader, interface{}, *PID) {
@@ -2,6 +2,7 @@ package actor
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -26,3 +27,40 @@ func TestNormalMessageGivesEmptyMessageHeaders(t *testing.T) {
 	res, _ := assertFutureSuccess(f, t).(int)
 	assert.Equal(t, 0, res)
 }
+
+func TestSendWithHeaders_PropagatesHeaders(t *testing.T) {
+	t.Parallel()
+
+	received := make(chan ReadonlyMessageHeader, 1)
+	target := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		if _, ok := ctx.Message().(string); ok {
+			received <- ctx.MessageHeader()
+		}
+	}))
+	forwarder := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		if msg, ok := ctx.Message().(string); ok {
+			headers := ctx.MessageHeader().Clone()
+			headers.Set("hop", "forwarder")
+			ctx.SendWithHeaders(target, msg, headers)
+		}
+	}))
+
+	defer func() {
+		_ = rootContext.StopFuture(forwarder).Wait()
+		_ = rootContext.StopFuture(target).Wait()
+	}()
+
+	headers := NewMessageHeader()
+	headers.Set("trace-id", "42")
+	rootContext.SendWithHeaders(forwarder, "hello", headers)
+	headers.Set("trace-id", "changed")
+
+	select {
+	case header := <-received:
+		assert.Equal(t, "42", header.Get("trace-id"))
+		assert.Equal(t, "forwarder", header.Get("hop"))
+		assert.Equal(t, 2, header.Length())
+	case <-time.After(testTimeout):
+		t.Fatal("message with headers not received")
+	}
+}
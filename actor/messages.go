@@ -68,6 +68,14 @@ type Failure struct {
 	Message      interface{}
 }
 
+// kill is sent by a parent stopping a child, which then terminates with TerminatedReason_Killed
+type kill struct{}
+
+// redeliver carries the message an actor failed on to the restarted actor, see OnRestartRedeliverOnce
+type redeliver struct {
+	message interface{}
+}
+
 type continuation struct {
 	message interface{}
 	f       func()
@@ -92,6 +100,8 @@ func (*Terminated) SystemMessage()   {}
 func (*Failure) SystemMessage()      {}
 func (*Restart) SystemMessage()      {}
 func (*continuation) SystemMessage() {}
+func (*kill) SystemMessage()         {}
+func (*redeliver) SystemMessage()    {}
 
 var (
 	restartingMessage     AutoReceiveMessage = &Restarting{}
@@ -102,6 +112,7 @@ var (
 	restartMessage        SystemMessage      = &Restart{}
 	startedMessage        SystemMessage      = &Started{}
 	stopMessage           SystemMessage      = &Stop{}
+	killMessage           SystemMessage      = &kill{}
 	resumeMailboxMessage  MailboxMessage     = &ResumeMailbox{}
 	suspendMailboxMessage MailboxMessage     = &SuspendMailbox{}
 	_                     AutoRespond        = &Touch{}
@@ -3,6 +3,7 @@
 package actor
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -22,10 +23,36 @@ var extensionId = extensions.NextExtensionID()
 type Metrics struct {
 	metrics *metrics.ProtoMetrics
 	enabled bool
+
+	registrations []metric.Registration
+	log           log.Interface
 }
 
+// MetricsLabel is an optional label of the mailbox length and message duration metrics of the actors
+type MetricsLabel int
+
+const (
+	// ActorTypeLabel labels the metrics with the type of the actor, so the actors spawned from the same props share
+	// their series
+	ActorTypeLabel MetricsLabel = iota
+
+	// ActorPIDLabel labels the metrics with the PID of the actor, a series per actor
+	ActorPIDLabel
+
+	// MessageTypeLabel labels the message duration with the type of the message
+	MessageTypeLabel
+)
+
 var _ extensions.Extension = &Metrics{}
 
+func (m *Metrics) logger() log.Interface {
+	if m.log != nil {
+		return m.log
+	}
+
+	return plog
+}
+
 func (m *Metrics) Enabled() bool {
 	return m.enabled
 }
@@ -45,6 +72,22 @@ func NewMetrics(provider metric.MeterProvider) *Metrics {
 	}
 }
 
+// Instruments returns the internal actor system instruments, or nil if metrics are disabled
+func (m *Metrics) Instruments() *metrics.ActorMetrics {
+	if !m.enabled {
+		return nil
+	}
+
+	return m.metrics.Get(metrics.InternalActorMetrics)
+}
+
+// GetMetrics returns the metrics extension of the actor system
+func GetMetrics(actorSystem *ActorSystem) *Metrics {
+	m, _ := actorSystem.Extensions.Get(extensionId).(*Metrics)
+
+	return m
+}
+
 func (m *Metrics) PrepareMailboxLengthGauge() {
 	meter := global.Meter(metrics.LibName)
 	gauge, err := meter.Int64ObservableGauge("protoactor_actor_mailbox_length",
@@ -52,7 +95,7 @@ func (m *Metrics) PrepareMailboxLengthGauge() {
 		instrument.WithUnit(unit.Dimensionless))
 	if err != nil {
 		err = fmt.Errorf("failed to create ActorMailBoxLength instrument, %w", err)
-		plog.Error(err.Error(), log.Error(err))
+		m.logger().Error(err.Error(), log.Error(err))
 	}
 	m.metrics.Instruments().SetActorMailboxLengthGauge(gauge)
 }
@@ -60,8 +103,123 @@ func (m *Metrics) PrepareMailboxLengthGauge() {
 func (m *Metrics) CommonLabels(ctx Context) []attribute.KeyValue {
 	labels := []attribute.KeyValue{
 		attribute.String("address", ctx.ActorSystem().Address()),
-		attribute.String("actortype", strings.Replace(fmt.Sprintf("%T", ctx.Actor()), "*", "", 1)),
+		attribute.String("actortype", actorTypeName(ctx.Actor())),
+	}
+
+	return labels
+}
+
+func actorTypeName(actor Actor) string {
+	return strings.Replace(fmt.Sprintf("%T", actor), "*", "", 1)
+}
+
+// actorLabels returns the labels configured for the mailbox length and message duration metrics of an actor,
+// but the address which may change once the actor is spawned
+func (m *Metrics) actorLabels(ctx *actorContext) []attribute.KeyValue {
+	var labels []attribute.KeyValue
+	for _, label := range ctx.actorSystem.Config.MetricsLabels {
+		switch label {
+		case ActorTypeLabel:
+			labels = append(labels, attribute.String("actortype", actorTypeName(ctx.actor)))
+		case ActorPIDLabel:
+			labels = append(labels, attribute.String("pid", ctx.self.Id))
+		}
+	}
+
+	return labels
+}
+
+// messageLabels returns the labels of the message duration metric of an actor
+func (m *Metrics) messageLabels(ctx *actorContext, message interface{}) []attribute.KeyValue {
+	if ctx.metricLabels == nil {
+		ctx.metricLabels = m.actorLabels(ctx)
+	}
+
+	labels := make([]attribute.KeyValue, 0, len(ctx.metricLabels)+2)
+	labels = append(labels, attribute.String("address", ctx.actorSystem.Address()))
+	labels = append(labels, ctx.metricLabels...)
+	for _, label := range ctx.actorSystem.Config.MetricsLabels {
+		if label == MessageTypeLabel {
+			labels = append(labels, attribute.String("messagetype", fmt.Sprintf("%T", UnwrapEnvelopeMessage(message))))
+		}
 	}
 
 	return labels
 }
+
+// observeMailboxLength reports the number of user messages queued in the mailboxes of the actors on each collection,
+// summed by the labels of the actors. Reading the lengths at collection time keeps the cost off the message path
+func (m *Metrics) observeMailboxLength(system *ActorSystem) {
+	m.PrepareMailboxLengthGauge()
+	gauge := m.Instruments().ActorMailboxLength
+
+	registration, err := global.Meter(metrics.LibName).RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		address := attribute.String("address", system.Address())
+
+		type mailboxLength struct {
+			labels []attribute.KeyValue
+			length int64
+		}
+		lengths := map[attribute.Distinct]*mailboxLength{}
+		system.ProcessRegistry.forEachActorProcess(func(_ *PID, process *ActorProcess) {
+			labels, ok := process.metricLabels.Load().(attribute.Set)
+			if !ok {
+				return
+			}
+
+			key := labels.Equivalent()
+			l, ok := lengths[key]
+			if !ok {
+				l = &mailboxLength{labels: append(labels.ToSlice(), address)}
+				lengths[key] = l
+			}
+			l.length += int64(process.UserMessageCount())
+		})
+
+		for _, l := range lengths {
+			o.ObserveInt64(gauge, l.length, l.labels...)
+		}
+
+		return nil
+	}, gauge)
+	if err != nil {
+		err = fmt.Errorf("failed to instrument Actor Mailbox, %w", err)
+		m.logger().Error(err.Error(), log.Error(err))
+
+		return
+	}
+	m.registrations = append(m.registrations, registration)
+}
+
+// observeProcessCache reports the hits and misses of the process cache of the registry, if it has one
+func (m *Metrics) observeProcessCache(system *ActorSystem) {
+	if system.ProcessRegistry.cache == nil {
+		return
+	}
+
+	instruments := m.Instruments()
+	registration, err := global.Meter(metrics.LibName).RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		address := attribute.String("address", system.Address())
+		hits, misses := system.ProcessRegistry.CacheStats()
+		o.ObserveInt64(instruments.ProcessCacheHitCount, int64(hits), address)
+		o.ObserveInt64(instruments.ProcessCacheMissCount, int64(misses), address)
+
+		return nil
+	}, instruments.ProcessCacheHitCount, instruments.ProcessCacheMissCount)
+	if err != nil {
+		err = fmt.Errorf("failed to instrument process cache, %w", err)
+		m.logger().Error(err.Error(), log.Error(err))
+
+		return
+	}
+	m.registrations = append(m.registrations, registration)
+}
+
+func (m *Metrics) stop() {
+	for _, registration := range m.registrations {
+		if err := registration.Unregister(); err != nil {
+			m.logger().Error("failed to unregister instrument callback", log.Error(err))
+		}
+	}
+	m.registrations = nil
+}
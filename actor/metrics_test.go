@@ -0,0 +1,87 @@
+package actor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/global"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func TestMetrics_MailboxLengthAndSampledDuration(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	global.SetMeterProvider(provider)
+
+	system := NewActorSystem(
+		WithMetricProviders(provider),
+		WithMetricsLabels(ActorTypeLabel, MessageTypeLabel),
+		WithMetricsSampleRate(2),
+	)
+	defer system.Shutdown()
+
+	release := make(chan struct{})
+	pid := system.Root.Spawn(PropsFromFunc(func(ctx Context) {
+		switch ctx.Message() {
+		case "block":
+			<-release
+		case "ping":
+			ctx.Respond("pong")
+		}
+	}))
+
+	system.Root.Send(pid, "block")
+	for i := 0; i < 4; i++ {
+		system.Root.Send(pid, "queued")
+	}
+
+	collect := func() map[string]metricdata.Aggregation {
+		data, err := reader.Collect(context.Background())
+		require.NoError(t, err)
+
+		collected := map[string]metricdata.Aggregation{}
+		for _, scope := range data.ScopeMetrics {
+			for _, m := range scope.Metrics {
+				collected[m.Name] = m.Data
+			}
+		}
+
+		return collected
+	}
+
+	// the blocked actor has the queued messages in its mailbox
+	assert.Eventually(t, func() bool {
+		gauge, ok := collect()["protoactor_actor_mailbox_length"].(metricdata.Gauge[int64])
+		if !ok {
+			return false
+		}
+		for _, point := range gauge.DataPoints {
+			actorType, _ := point.Attributes.Value("actortype")
+			if actorType.AsString() == "actor.ReceiveFunc" && point.Value == 4 {
+				return true
+			}
+		}
+
+		return false
+	}, time.Second, 10*time.Millisecond)
+
+	close(release)
+	_, err := system.Root.RequestFuture(pid, "ping", time.Second).Result()
+	require.NoError(t, err)
+
+	// one in two of Started, block and the 4 queued messages is measured, ping is not so it has been recorded
+	histogram, ok := collect()["protoactor_actor_message_receive_duration_seconds"].(metricdata.Histogram)
+	require.True(t, ok)
+	var count uint64
+	for _, point := range histogram.DataPoints {
+		count += point.Count
+		_, hasMessageType := point.Attributes.Value(attribute.Key("messagetype"))
+		assert.True(t, hasMessageType)
+	}
+	assert.Equal(t, uint64(3), count)
+}
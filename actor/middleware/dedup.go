@@ -0,0 +1,128 @@
+package middleware
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+)
+
+// DedupKeyFunc returns the key identifying message, false if message is not deduplicated
+type DedupKeyFunc func(message interface{}) (string, bool)
+
+// DedupOption configures the Dedup middleware
+type DedupOption func(config *dedupConfig)
+
+// WithDedupCapacity bounds the number of keys remembered by each actor, the oldest keys are forgotten first.
+// It defaults to 10000
+func WithDedupCapacity(capacity int) DedupOption {
+	return func(config *dedupConfig) {
+		config.capacity = capacity
+	}
+}
+
+type dedupConfig struct {
+	capacity int
+}
+
+// Dedup is receiver middleware which drops a message whose key was already received by the actor within window,
+// e.g. commands delivered at least once. The window starts when a key is first received, messages without a key
+// are always received. Keys are remembered per actor, and forgotten when it stops
+func Dedup(window time.Duration, keyFn DedupKeyFunc, opts ...DedupOption) actor.ReceiverMiddleware {
+	config := dedupConfig{capacity: 10000}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	var mu sync.Mutex
+	windows := make(map[string]*dedupWindow)
+
+	return func(next actor.ReceiverFunc) actor.ReceiverFunc {
+		return func(c actor.ReceiverContext, env *actor.MessageEnvelope) {
+			id := c.Self().Id
+			if _, ok := env.Message.(*actor.Stopped); ok {
+				mu.Lock()
+				delete(windows, id)
+				mu.Unlock()
+				next(c, env)
+
+				return
+			}
+
+			key, ok := keyFn(env.Message)
+			if !ok {
+				next(c, env)
+
+				return
+			}
+
+			mu.Lock()
+			w, ok := windows[id]
+			if !ok {
+				w = newDedupWindow(window, config.capacity)
+				windows[id] = w
+			}
+			mu.Unlock()
+
+			// the window of an actor is only used while it processes a message
+			if w.seen(key, time.Now()) {
+				return
+			}
+			next(c, env)
+		}
+	}
+}
+
+// dedupWindow remembers the keys received within window, at most capacity of them
+type dedupWindow struct {
+	window   time.Duration
+	capacity int
+	// keys are ordered from the least to the most recently seen
+	keys    *list.List
+	entries map[string]*list.Element
+}
+
+type dedupEntry struct {
+	key    string
+	seenAt time.Time
+}
+
+func newDedupWindow(window time.Duration, capacity int) *dedupWindow {
+	return &dedupWindow{
+		window:   window,
+		capacity: capacity,
+		keys:     list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+// seen tells whether key was seen within the window, and remembers it otherwise
+func (w *dedupWindow) seen(key string, now time.Time) bool {
+	w.expire(now)
+
+	if _, ok := w.entries[key]; ok {
+		return true
+	}
+
+	w.entries[key] = w.keys.PushBack(&dedupEntry{key: key, seenAt: now})
+	for w.capacity > 0 && w.keys.Len() > w.capacity {
+		w.remove(w.keys.Front())
+	}
+
+	return false
+}
+
+func (w *dedupWindow) expire(now time.Time) {
+	for e := w.keys.Front(); e != nil; e = w.keys.Front() {
+		if now.Sub(e.Value.(*dedupEntry).seenAt) < w.window {
+			return
+		}
+		w.remove(e)
+	}
+}
+
+func (w *dedupWindow) remove(e *list.Element) {
+	w.keys.Remove(e)
+	delete(w.entries, e.Value.(*dedupEntry).key)
+}
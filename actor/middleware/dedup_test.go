@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"testing"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/stretchr/testify/assert"
+)
+
+type command struct {
+	id string
+}
+
+func commandKey(message interface{}) (string, bool) {
+	if c, ok := message.(*command); ok && c.id != "" {
+		return c.id, true
+	}
+
+	return "", false
+}
+
+func TestDedup(t *testing.T) {
+	system := actor.NewActorSystem()
+	received := make(chan string, 10)
+	props := actor.PropsFromFunc(func(ctx actor.Context) {
+		switch msg := ctx.Message().(type) {
+		case *command:
+			received <- msg.id
+		case string:
+			ctx.Respond(msg)
+		}
+	}, actor.WithReceiverMiddleware(Dedup(100*time.Millisecond, commandKey)))
+	pid := system.Root.Spawn(props)
+
+	flush := func() []string {
+		_, err := system.Root.RequestFuture(pid, "flush", time.Second).Result()
+		assert.NoError(t, err)
+
+		var ids []string
+		for len(received) > 0 {
+			ids = append(ids, <-received)
+		}
+
+		return ids
+	}
+
+	system.Root.Send(pid, &command{id: "a"})
+	system.Root.Send(pid, &command{id: "a"})
+	system.Root.Send(pid, &command{id: "b"})
+	system.Root.Send(pid, &command{})
+	system.Root.Send(pid, &command{})
+	assert.Equal(t, []string{"a", "b", "", ""}, flush())
+
+	// the window elapsed
+	time.Sleep(150 * time.Millisecond)
+	system.Root.Send(pid, &command{id: "a"})
+	assert.Equal(t, []string{"a"}, flush())
+}
+
+func TestDedupWindow_Capacity(t *testing.T) {
+	w := newDedupWindow(time.Minute, 2)
+	now := time.Now()
+
+	assert.False(t, w.seen("a", now))
+	assert.False(t, w.seen("b", now))
+	assert.False(t, w.seen("c", now))
+	assert.True(t, w.seen("c", now))
+	// a was forgotten to remember c
+	assert.False(t, w.seen("a", now))
+}
@@ -0,0 +1,131 @@
+// Package tracing traces actor message handling with OpenTelemetry spans.
+// The trace context is propagated in the message headers, which the remote layer carries as MessageHeader.HeaderData
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/asynkron/protoactor-go/actor/middleware/tracing"
+
+type config struct {
+	provider   trace.TracerProvider
+	propagator propagation.TextMapPropagator
+}
+
+// Option configures the tracing middleware
+type Option func(config *config)
+
+// WithTracerProvider sets the provider of the tracer, the global provider is used by default
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(config *config) {
+		config.provider = provider
+	}
+}
+
+// WithPropagator sets how the trace context is written to message headers, W3C trace context is used by default
+func WithPropagator(propagator propagation.TextMapPropagator) Option {
+	return func(config *config) {
+		config.propagator = propagator
+	}
+}
+
+type tracer struct {
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+	// active holds the context of the span of the message each actor is receiving
+	active sync.Map
+}
+
+// Middleware returns the receiver and sender middleware tracing an actor.
+// The receiver middleware starts a span for each message carrying a trace context, named after the actor and message type.
+// The sender middleware propagates the context of that span to messages sent while the message is received
+func Middleware(options ...Option) (actor.ReceiverMiddleware, actor.SenderMiddleware) {
+	c := &config{
+		propagator: propagation.TraceContext{},
+	}
+	for _, option := range options {
+		option(c)
+	}
+	if c.provider == nil {
+		c.provider = otel.GetTracerProvider()
+	}
+
+	t := &tracer{
+		tracer:     c.provider.Tracer(instrumentationName),
+		propagator: c.propagator,
+	}
+
+	return t.receiverMiddleware, t.senderMiddleware
+}
+
+func (t *tracer) receiverMiddleware(next actor.ReceiverFunc) actor.ReceiverFunc {
+	return func(c actor.ReceiverContext, envelope *actor.MessageEnvelope) {
+		ctx := t.propagator.Extract(context.Background(), headerCarrier{envelope: envelope})
+		if !trace.SpanContextFromContext(ctx).IsValid() {
+			next(c, envelope)
+			return
+		}
+
+		ctx, span := t.tracer.Start(ctx, fmt.Sprintf("%T/%T", c.Actor(), envelope.Message),
+			trace.WithSpanKind(trace.SpanKindConsumer),
+			trace.WithAttributes(
+				attribute.String("actor.pid", c.Self().String()),
+				attribute.String("actor.type", fmt.Sprintf("%T", c.Actor())),
+				attribute.String("message.type", fmt.Sprintf("%T", envelope.Message)),
+			))
+
+		t.active.Store(c.Self(), ctx)
+		defer func() {
+			t.active.Delete(c.Self())
+			span.End()
+		}()
+
+		next(c, envelope)
+	}
+}
+
+func (t *tracer) senderMiddleware(next actor.SenderFunc) actor.SenderFunc {
+	return func(c actor.SenderContext, target *actor.PID, envelope *actor.MessageEnvelope) {
+		if ctx, ok := t.active.Load(c.Self()); ok {
+			t.propagator.Inject(ctx.(context.Context), headerCarrier{envelope: envelope})
+		}
+
+		next(c, target, envelope)
+	}
+}
+
+// headerCarrier reads and writes the trace context in the envelope headers
+type headerCarrier struct {
+	envelope *actor.MessageEnvelope
+}
+
+var _ propagation.TextMapCarrier = headerCarrier{}
+
+func (h headerCarrier) Get(key string) string {
+	if h.envelope.Header == nil {
+		return ""
+	}
+
+	return h.envelope.Header.Get(key)
+}
+
+func (h headerCarrier) Set(key string, value string) {
+	h.envelope.SetHeader(key, value)
+}
+
+func (h headerCarrier) Keys() []string {
+	if h.envelope.Header == nil {
+		return nil
+	}
+
+	return h.envelope.Header.Keys()
+}
@@ -0,0 +1,83 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestMiddleware_PropagatesSpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	receiver, sender := Middleware(WithTracerProvider(provider))
+
+	system := actor.NewActorSystem()
+	done := make(chan struct{})
+	last := system.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+		if _, ok := ctx.Message().(string); ok {
+			close(done)
+		}
+	}, actor.WithReceiverMiddleware(receiver)))
+	first := system.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+		if msg, ok := ctx.Message().(string); ok {
+			ctx.Send(last, msg)
+		}
+	}, actor.WithReceiverMiddleware(receiver), actor.WithSenderMiddleware(sender)))
+
+	ctx, root := provider.Tracer("test").Start(context.Background(), "root")
+	headers := propagation.MapCarrier{}
+	propagation.TraceContext{}.Inject(ctx, headers)
+	envelope := &actor.MessageEnvelope{Message: "hello"}
+	for key, value := range headers {
+		envelope.SetHeader(key, value)
+	}
+	system.Root.Send(first, envelope)
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("message was not forwarded")
+	}
+	root.End()
+
+	assert.Eventually(t, func() bool {
+		return len(recorder.Ended()) == 3
+	}, time.Second, 10*time.Millisecond)
+
+	parents := map[trace.SpanID]trace.SpanID{}
+	for _, span := range recorder.Ended() {
+		assert.Equal(t, root.SpanContext().TraceID(), span.SpanContext().TraceID())
+		parents[span.SpanContext().SpanID()] = span.Parent().SpanID()
+	}
+
+	// root -> first -> last
+	depth := 0
+	for id := range parents {
+		n := 0
+		for ; id != root.SpanContext().SpanID() && n < len(parents); id = parents[id] {
+			n++
+		}
+		if n > depth {
+			depth = n
+		}
+	}
+	assert.Equal(t, 2, depth)
+}
+
+func TestMiddleware_SkipsMessagesWithoutTrace(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	receiver, _ := Middleware(WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))))
+
+	system := actor.NewActorSystem()
+	pid := system.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {}, actor.WithReceiverMiddleware(receiver)))
+	_, _ = system.Root.RequestFuture(pid, "hello", time.Second).Result()
+
+	assert.Empty(t, recorder.Ended())
+}
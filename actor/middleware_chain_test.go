@@ -1,7 +1,9 @@
 package actor
 
 import (
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -44,3 +46,74 @@ func TestMakeReceiverMiddleware_CallsInCorrectOrder(t *testing.T) {
 func TestMakeInboundMiddleware_ReturnsNil(t *testing.T) {
 	assert.Nil(t, makeReceiverMiddlewareChain([]ReceiverMiddleware{}, func(_ ReceiverContext, _ *MessageEnvelope) {}))
 }
+
+func TestReceiverMiddleware_ExecutionOrder(t *testing.T) {
+	var mu sync.Mutex
+	var calls []string
+	record := func(name string) ReceiverMiddleware {
+		return func(next ReceiverFunc) ReceiverFunc {
+			return func(ctx ReceiverContext, env *MessageEnvelope) {
+				if _, ok := env.Message.(string); ok {
+					mu.Lock()
+					calls = append(calls, name)
+					mu.Unlock()
+				}
+				next(ctx, env)
+			}
+		}
+	}
+
+	// a library adds metrics and logging, tracing is prepended afterwards so it wraps both
+	props := PropsFromFunc(func(ctx Context) {
+		if _, ok := ctx.Message().(string); ok {
+			mu.Lock()
+			calls = append(calls, "actor")
+			mu.Unlock()
+			ctx.Respond(ctx.Message())
+		}
+	},
+		WithReceiverMiddleware(record("metrics"), record("logging")),
+		WithReceiverMiddlewarePrepend(record("tracing")),
+		WithReceiverMiddleware(record("validation")),
+	)
+
+	run := func(props *Props) []string {
+		mu.Lock()
+		calls = nil
+		mu.Unlock()
+
+		_, err := rootContext.RequestFuture(rootContext.Spawn(props), "hello", time.Second).Result()
+		assert.NoError(t, err)
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		return calls
+	}
+
+	expected := []string{"tracing", "metrics", "logging", "validation", "actor"}
+	assert.Equal(t, expected, run(props))
+	// a clone keeps the order
+	assert.Equal(t, expected, run(props.Clone()))
+}
+
+func TestSenderMiddleware_Prepend(t *testing.T) {
+	var calls []string
+	record := func(name string) SenderMiddleware {
+		return func(next SenderFunc) SenderFunc {
+			return func(ctx SenderContext, target *PID, env *MessageEnvelope) {
+				calls = append(calls, name)
+				next(ctx, target, env)
+			}
+		}
+	}
+
+	props := PropsFromFunc(func(ctx Context) {},
+		WithSenderMiddleware(record("metrics")),
+		WithSenderMiddlewarePrepend(record("tracing"), record("logging")),
+	)
+	chain := makeSenderMiddlewareChain(props.senderMiddleware, func(_ SenderContext, _ *PID, _ *MessageEnvelope) {})
+	chain(&mockContext{}, nil, &MessageEnvelope{})
+
+	assert.Equal(t, []string{"tracing", "logging", "metrics"}, calls)
+}
@@ -54,13 +54,24 @@ func (pid *PID) sendSystemMessage(actorSystem *ActorSystem, message interface{})
 	pid.ref(actorSystem).SendSystemMessage(pid, message)
 }
 
+// Equal tells whether the PIDs identify the same process. The RequestId is ignored, like in the lookups of the
+// process registry and of the remote endpoints, see Key
+//
 //goland:noinspection GoReceiverNames.
 func (pid *PID) Equal(other *PID) bool {
-	if pid != nil && other == nil {
-		return false
+	if pid == nil || other == nil {
+		return pid == other
 	}
 
-	return pid.Id == other.Id && pid.Address == other.Address && pid.RequestId == other.RequestId
+	return pid.Id == other.Id && pid.Address == other.Address
+}
+
+// Key returns the canonical string identifying the process of the PID, "address/id". Two PIDs have the same key
+// when they are Equal, it is the key to use in maps of PIDs
+//
+//goland:noinspection GoReceiverNames.
+func (pid *PID) Key() string {
+	return pid.Address + "/" + pid.Id
 }
 
 // NewPID returns a new instance of the PID struct.
@@ -38,3 +38,24 @@ func TestStopFuture(t *testing.T) {
 		assert.False(t, found)
 	}
 }
+
+func TestPID_EqualIgnoresRequestId(t *testing.T) {
+	pid := NewPID(localAddress, "p1")
+	request := &PID{Address: localAddress, Id: "p1", RequestId: 42}
+
+	assert.True(t, pid.Equal(request))
+	assert.Equal(t, pid.Key(), request.Key())
+	assert.False(t, pid.Equal(NewPID(localAddress, "p2")))
+	assert.NotEqual(t, pid.Key(), NewPID("other", "p1").Key())
+
+	var none *PID
+	assert.False(t, pid.Equal(none))
+	assert.False(t, none.Equal(pid))
+	assert.True(t, none.Equal(nil))
+
+	// a set finds and removes the PID whatever the request id
+	s := NewPIDSet(pid)
+	assert.True(t, s.Contains(request))
+	assert.True(t, s.Remove(request))
+	assert.True(t, s.Empty())
+}
@@ -1,14 +1,12 @@
 package actor
 
-import "fmt"
-
 type PIDSet struct {
 	pids   []*PID
 	lookup map[string]*PID
 }
 
 func (p *PIDSet) key(pid *PID) string {
-	return fmt.Sprintf("%v:%v", pid.Address, pid.Id)
+	return pid.Key()
 }
 
 // NewPIDSet returns a new PIDSet with the given pids.
@@ -0,0 +1,54 @@
+package actor
+
+import "time"
+
+// drainPill stops the actor once the continuations of ReenterAfter it is awaiting have run, see PoisonDrain
+type drainPill struct{}
+
+func (*drainPill) AutoReceiveMessage() {}
+
+var drainPillMessage AutoReceiveMessage = &drainPill{}
+
+// poisonDrain sends pid a drainPill, and stops pid once timeout elapsed if it is still draining.
+// The future completes when pid is stopped
+func poisonDrain(actorSystem *ActorSystem, pid *PID, timeout time.Duration) *Future {
+	future := NewFuture(actorSystem, timeout+10*time.Second)
+
+	pid.sendSystemMessage(actorSystem, &Watch{Watcher: future.pid})
+	pid.sendUserMessage(actorSystem, drainPillMessage)
+
+	hardStop := time.AfterFunc(timeout, func() {
+		pid.ref(actorSystem).Stop(pid)
+	})
+	future.continueWith(func(interface{}, error) {
+		hardStop.Stop()
+	})
+
+	return future
+}
+
+// handleDrainPill stops the actor, or waits for its pending continuations to run
+func (ctx *actorContext) handleDrainPill() {
+	if ctx.extras == nil || ctx.extras.pendingReentrancies == 0 {
+		ctx.Stop(ctx.self)
+
+		return
+	}
+
+	ctx.extras.draining = true
+}
+
+// beginReentrancy counts a continuation the actor awaits
+func (ctx *actorContext) beginReentrancy() {
+	ctx.ensureExtras().pendingReentrancies++
+}
+
+// endReentrancy is called once an awaited continuation ran, a draining actor stops after the last one
+func (ctx *actorContext) endReentrancy() {
+	extras := ctx.ensureExtras()
+	extras.pendingReentrancies--
+
+	if extras.draining && extras.pendingReentrancies == 0 {
+		ctx.Stop(ctx.self)
+	}
+}
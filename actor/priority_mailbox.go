@@ -0,0 +1,83 @@
+package actor
+
+import (
+	"github.com/asynkron/protoactor-go/internal/queue/mpsc"
+	"github.com/asynkron/protoactor-go/log"
+)
+
+// Priority levels for a PriorityMailbox with three levels
+const (
+	PriorityLow = iota
+	PriorityNormal
+	PriorityHigh
+)
+
+// PriorityFunc returns the priority level of a user message, higher levels are processed first
+type PriorityFunc func(message interface{}) int
+
+type priorityFuncQueue struct {
+	queues   []queue
+	priority PriorityFunc
+	logger   log.Interface
+}
+
+func (q *priorityFuncQueue) Push(item interface{}) {
+	q.queues[q.levelOf(item)].Push(item)
+}
+
+func (q *priorityFuncQueue) Pop() interface{} {
+	for p := len(q.queues) - 1; p >= 0; p-- {
+		if item := q.queues[p].Pop(); item != nil {
+			return item
+		}
+	}
+
+	return nil
+}
+
+// levelOf returns the level of the message, levels out of range are clamped.
+// If the priority function panics, the message gets the normal level
+func (q *priorityFuncQueue) levelOf(item interface{}) (level int) {
+	defer func() {
+		if r := recover(); r != nil {
+			q.logger.Error("[MAILBOX] priority function failed", log.TypeOf("msg", item), log.Object("reason", r))
+			level = len(q.queues) / 2
+		}
+	}()
+
+	level = q.priority(UnwrapEnvelopeMessage(item))
+	if level < 0 {
+		level = 0
+	}
+	if level > len(q.queues)-1 {
+		level = len(q.queues) - 1
+	}
+
+	return level
+}
+
+// PriorityMailbox returns a producer which creates an unbounded mailbox with the given number of priority levels.
+// User messages are processed in order of the level returned by priority, FIFO within a level.
+// System messages are always processed before user messages
+func PriorityMailbox(levels int, priority PriorityFunc, mailboxStats ...MailboxMiddleware) MailboxProducer {
+	if levels < 1 {
+		levels = 1
+	}
+
+	return func() Mailbox {
+		q := &priorityFuncQueue{
+			queues:   make([]queue, levels),
+			priority: priority,
+			logger:   plog,
+		}
+		for p := range q.queues {
+			q.queues[p] = mpsc.New()
+		}
+
+		return &defaultMailbox{
+			systemMailbox: mpsc.New(),
+			userMailbox:   q,
+			middlewares:   mailboxStats,
+		}
+	}
+}
@@ -191,3 +191,28 @@ func TestPushPopMpscPriority(t *testing.T) {
 		assert.Equal(t, "0 hello", res.(Message).GetMessage())
 	}
 }
+
+func TestPriorityFuncQueue(t *testing.T) {
+	q := PriorityMailbox(3, func(message interface{}) int {
+		switch message.(string) {
+		case "stop":
+			return PriorityHigh
+		case "panic":
+			panic("no priority")
+		case "data":
+			return PriorityLow
+		}
+		return 10
+	})().(*defaultMailbox).userMailbox
+
+	q.Push("data")
+	q.Push("panic")
+	q.Push(&MessageEnvelope{Message: "stop"})
+	q.Push("clamped")
+
+	assert.Equal(t, "stop", UnwrapEnvelopeMessage(q.Pop()))
+	assert.Equal(t, "clamped", q.Pop())
+	assert.Equal(t, "panic", q.Pop())
+	assert.Equal(t, "data", q.Pop())
+	assert.Nil(t, q.Pop())
+}
@@ -0,0 +1,110 @@
+package actor
+
+import (
+	"container/list"
+	"sync"
+	"sync/atomic"
+)
+
+// processCache is a bounded LRU cache of the processes resolved for PIDs. It spares the address resolvers and the
+// registry buckets on hot paths, entries are invalidated when the actor stops or its address terminates
+type processCache struct {
+	mu       sync.Mutex
+	capacity int
+	entries  map[processCacheKey]*list.Element
+	order    *list.List
+
+	hits   uint64
+	misses uint64
+}
+
+// processCacheKey identifies a PID, with an empty address for local PIDs
+type processCacheKey struct {
+	address string
+	id      string
+}
+
+type processCacheEntry struct {
+	key     processCacheKey
+	process Process
+}
+
+func newProcessCache(capacity int) *processCache {
+	return &processCache{
+		capacity: capacity,
+		entries:  make(map[processCacheKey]*list.Element, capacity),
+		order:    list.New(),
+	}
+}
+
+func (c *processCache) get(key processCacheKey) (Process, bool) {
+	c.mu.Lock()
+	element, ok := c.entries[key]
+	if !ok {
+		c.mu.Unlock()
+		atomic.AddUint64(&c.misses, 1)
+
+		return nil, false
+	}
+
+	process := element.Value.(*processCacheEntry).process
+	// an actor stopping while its process was being cached must not swallow messages
+	if l, ok := process.(*ActorProcess); ok && atomic.LoadInt32(&l.dead) == 1 {
+		c.removeElement(element)
+		c.mu.Unlock()
+		atomic.AddUint64(&c.misses, 1)
+
+		return nil, false
+	}
+	c.order.MoveToFront(element)
+	c.mu.Unlock()
+	atomic.AddUint64(&c.hits, 1)
+
+	return process, true
+}
+
+func (c *processCache) add(key processCacheKey, process Process) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[key]; ok {
+		element.Value.(*processCacheEntry).process = process
+		c.order.MoveToFront(element)
+
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&processCacheEntry{key: key, process: process})
+	if c.order.Len() > c.capacity {
+		c.removeElement(c.order.Back())
+	}
+}
+
+func (c *processCache) remove(key processCacheKey) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[key]; ok {
+		c.removeElement(element)
+	}
+}
+
+func (c *processCache) removeAddress(address string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, element := range c.entries {
+		if key.address == address {
+			c.removeElement(element)
+		}
+	}
+}
+
+func (c *processCache) removeElement(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.entries, element.Value.(*processCacheEntry).key)
+}
+
+func (c *processCache) stats() (hits, misses uint64) {
+	return atomic.LoadUint64(&c.hits), atomic.LoadUint64(&c.misses)
+}
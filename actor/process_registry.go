@@ -1,6 +1,7 @@
 package actor
 
 import (
+	"strings"
 	"sync/atomic"
 
 	murmur32 "github.com/twmb/murmur3"
@@ -14,6 +15,8 @@ type ProcessRegistryValue struct {
 	Address        string
 	LocalPIDs      *SliceMap
 	RemoteHandlers []AddressResolver
+
+	cache *processCache
 }
 
 type SliceMap struct {
@@ -43,11 +46,16 @@ const (
 )
 
 func NewProcessRegistry(actorSystem *ActorSystem) *ProcessRegistryValue {
-	return &ProcessRegistryValue{
+	pr := &ProcessRegistryValue{
 		ActorSystem: actorSystem,
 		Address:     localAddress,
 		LocalPIDs:   newSliceMap(),
 	}
+	if actorSystem.Config != nil && actorSystem.Config.ProcessCacheSize > 0 {
+		pr.cache = newProcessCache(actorSystem.Config.ProcessCacheSize)
+	}
+
+	return pr
 }
 
 // An AddressResolver is used to resolve remote actors
@@ -101,6 +109,26 @@ func (pr *ProcessRegistryValue) Remove(pid *PID) {
 	if l, ok := ref.(*ActorProcess); ok {
 		atomic.StoreInt32(&l.dead, 1)
 	}
+
+	if pr.cache != nil {
+		pr.cache.remove(processCacheKey{id: pid.Id})
+	}
+}
+
+// InvalidateAddress drops the processes cached for the PIDs at address, once the address terminated
+func (pr *ProcessRegistryValue) InvalidateAddress(address string) {
+	if pr.cache != nil {
+		pr.cache.removeAddress(address)
+	}
+}
+
+// CacheStats returns the number of lookups which hit and missed the process cache
+func (pr *ProcessRegistryValue) CacheStats() (hits, misses uint64) {
+	if pr.cache == nil {
+		return 0, 0
+	}
+
+	return pr.cache.stats()
 }
 
 func (pr *ProcessRegistryValue) Get(pid *PID) (Process, bool) {
@@ -108,6 +136,34 @@ func (pr *ProcessRegistryValue) Get(pid *PID) (Process, bool) {
 		return pr.ActorSystem.DeadLetter, false
 	}
 
+	if pr.cache == nil {
+		return pr.resolve(pid)
+	}
+
+	key := pr.cacheKey(pid)
+	if ref, ok := pr.cache.get(key); ok {
+		return ref, true
+	}
+
+	ref, ok := pr.resolve(pid)
+	// only actor processes tell when they are dead, a future completing while being cached would stay cached
+	if _, local := ref.(*ActorProcess); ok && (local || key.address != "") {
+		pr.cache.add(key, ref)
+	}
+
+	return ref, ok
+}
+
+// cacheKey returns the key of the cached process of pid, local PIDs whatever their address
+func (pr *ProcessRegistryValue) cacheKey(pid *PID) processCacheKey {
+	if pid.Address == localAddress || pid.Address == pr.Address {
+		return processCacheKey{id: pid.Id}
+	}
+
+	return processCacheKey{address: pid.Address, id: pid.Id}
+}
+
+func (pr *ProcessRegistryValue) resolve(pid *PID) (Process, bool) {
 	if pid.Address != localAddress && pid.Address != pr.Address {
 		for _, handler := range pr.RemoteHandlers {
 			ref, ok := handler(pid)
@@ -139,3 +195,51 @@ func (pr *ProcessRegistryValue) GetLocal(id string) (Process, bool) {
 
 	return ref.(Process), true
 }
+
+// ProcessInfo describes a local actor in a snapshot of the process registry
+type ProcessInfo struct {
+	PID *PID
+	// ActorType is the type name of the actor, empty if it is not known
+	ActorType string
+	// MailboxDepth is the number of user messages queued in the mailbox of the actor
+	MailboxDepth int
+}
+
+// Snapshot returns the local actors, in no particular order. See SnapshotPrefix
+func (pr *ProcessRegistryValue) Snapshot() []ProcessInfo {
+	return pr.SnapshotPrefix("")
+}
+
+// SnapshotPrefix returns the local actors whose id starts with prefix, in no particular order.
+// The registry is read one bucket at a time, so spawning and stopping actors is only held up for as long as a
+// bucket is read. Actors spawned or stopped meanwhile may or may not be part of the snapshot
+func (pr *ProcessRegistryValue) SnapshotPrefix(prefix string) []ProcessInfo {
+	var infos []ProcessInfo
+	for _, bucket := range pr.LocalPIDs.LocalPIDs {
+		bucket.IterCb(func(id string, v interface{}) {
+			process, ok := v.(*ActorProcess)
+			if !ok || !strings.HasPrefix(id, prefix) {
+				return
+			}
+
+			infos = append(infos, ProcessInfo{
+				PID:          NewPID(pr.Address, id),
+				ActorType:    process.ActorType(),
+				MailboxDepth: process.UserMessageCount(),
+			})
+		})
+	}
+
+	return infos
+}
+
+// forEachActorProcess invokes f for every local actor process
+func (pr *ProcessRegistryValue) forEachActorProcess(f func(pid *PID, process *ActorProcess)) {
+	for _, bucket := range pr.LocalPIDs.LocalPIDs {
+		bucket.IterCb(func(id string, v interface{}) {
+			if process, ok := v.(*ActorProcess); ok {
+				f(NewPID(pr.Address, id), process)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package actor
 import (
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -44,3 +45,98 @@ func BenchmarkUint64ToString2(b *testing.B) {
 	}
 	ss = s
 }
+
+func TestProcessRegistry_Cache(t *testing.T) {
+	system := NewActorSystem(WithProcessCache(2))
+	defer system.Shutdown()
+	registry := system.ProcessRegistry
+
+	received := make(chan interface{}, 1)
+	pid := system.Root.Spawn(PropsFromFunc(func(ctx Context) {
+		if msg, ok := ctx.Message().(string); ok {
+			received <- msg
+		}
+	}))
+
+	// lookups through new PID values hit the cache after the first one
+	for i := 0; i < 3; i++ {
+		_, ok := registry.Get(NewPID(pid.Address, pid.Id))
+		assert.True(t, ok)
+	}
+	hits, misses := registry.CacheStats()
+	assert.Equal(t, uint64(2), hits)
+	assert.Equal(t, uint64(1), misses)
+
+	// a stopped actor is not served from the cache
+	err := system.Root.StopFuture(pid).Wait()
+	assert.NoError(t, err)
+	ref, ok := registry.Get(NewPID(pid.Address, pid.Id))
+	assert.False(t, ok)
+	assert.Equal(t, system.DeadLetter, ref)
+}
+
+func TestProcessRegistry_CacheInvalidateAddress(t *testing.T) {
+	system := NewActorSystem(WithProcessCache(2))
+	defer system.Shutdown()
+	registry := system.ProcessRegistry
+
+	resolved := 0
+	registry.RegisterAddressResolver(func(pid *PID) (Process, bool) {
+		resolved++
+
+		return system.DeadLetter, true
+	})
+
+	remote := NewPID("remote:1", "actor")
+	registry.Get(remote)
+	registry.Get(remote)
+	assert.Equal(t, 1, resolved)
+
+	registry.InvalidateAddress("remote:1")
+	registry.Get(remote)
+	assert.Equal(t, 2, resolved)
+
+	// the least recently used PIDs are evicted
+	registry.Get(NewPID("remote:1", "other"))
+	registry.Get(NewPID("remote:2", "actor"))
+	registry.Get(remote)
+	assert.Equal(t, 5, resolved)
+}
+
+type snapshotActor struct {
+	block chan struct{}
+}
+
+func (a *snapshotActor) Receive(ctx Context) {
+	if _, ok := ctx.Message().(string); ok {
+		<-a.block
+	}
+}
+
+func TestProcessRegistry_Snapshot(t *testing.T) {
+	system := NewActorSystem()
+	block := make(chan struct{})
+	defer close(block)
+
+	props := PropsFromProducer(func() Actor { return &snapshotActor{block: block} })
+	busy, _ := system.Root.SpawnNamed(props, "admin/busy")
+	_, _ = system.Root.SpawnNamed(props, "admin/idle")
+	_, _ = system.Root.SpawnNamed(props, "other")
+	// the first message blocks the actor, the other two stay queued
+	for i := 0; i < 3; i++ {
+		system.Root.Send(busy, "work")
+	}
+
+	assert.Len(t, system.ProcessRegistry.Snapshot(), 3)
+
+	infos := system.ProcessRegistry.SnapshotPrefix("admin/")
+	assert.Len(t, infos, 2)
+	for _, info := range infos {
+		assert.Equal(t, "actor.snapshotActor", info.ActorType)
+		if info.PID.Equal(busy) {
+			assert.Eventually(t, func() bool {
+				return system.ProcessRegistry.SnapshotPrefix("admin/busy")[0].MailboxDepth == 2
+			}, time.Second, 10*time.Millisecond)
+		}
+	}
+}
@@ -1,14 +1,10 @@
 package actor
 
 import (
-	"context"
 	"errors"
-	"fmt"
+	"time"
 
-	"github.com/asynkron/protoactor-go/log"
-	"github.com/asynkron/protoactor-go/metrics"
-	"go.opentelemetry.io/otel/metric"
-	"go.opentelemetry.io/otel/metric/global"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type (
@@ -24,35 +20,30 @@ var (
 	defaultDispatcher      = NewDefaultDispatcher(300)
 	defaultMailboxProducer = Unbounded()
 	defaultSpawner         = func(actorSystem *ActorSystem, id string, props *Props, parentContext SpawnerContext) (*PID, error) {
-		ctx := newActorContext(actorSystem, props, parentContext.Self())
 		mb := props.produceMailbox()
-
-		// prepare the mailbox number counter
-		if ctx.actorSystem.Config.MetricsProvider != nil {
-			sysMetrics, ok := ctx.actorSystem.Extensions.Get(extensionId).(*Metrics)
-			if ok && sysMetrics.enabled {
-				if instruments := sysMetrics.metrics.Get(metrics.InternalActorMetrics); instruments != nil {
-					sysMetrics.PrepareMailboxLengthGauge()
-					meter := global.Meter(metrics.LibName)
-
-					if _, err := meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
-						o.ObserveInt64(instruments.ActorMailboxLength,int64(mb.UserMessageCount()), sysMetrics.CommonLabels(ctx)... )
-						return nil
-					}); err != nil {
-						err = fmt.Errorf("failed to instrument Actor Mailbox, %w", err)
-						plog.Error(err.Error(), log.Error(err))
-					}
-				}
-			}
+		if history := actorSystem.Config.DebugMailboxHistory; history > 0 {
+			mb = newDebugMailbox(actorSystem, mb, history)
 		}
-
-		dp := props.getDispatcher()
 		proc := NewActorProcess(mb)
+
+		// claim the name before anything else, so a collision has no side effects
 		pid, absent := actorSystem.ProcessRegistry.Add(proc, id)
 		if !absent {
 			return pid, ErrNameExists
 		}
+
+		ctx := newActorContext(actorSystem, props, parentContext.Self())
 		ctx.self = pid
+		proc.actorType.Store(actorTypeName(ctx.actor))
+		dp := props.getDispatcher()
+		if d, ok := dp.(pidDispatcher); ok {
+			dp = d.forPID(pid)
+		}
+
+		if sysMetrics, ok := actorSystem.Extensions.Get(extensionId).(*Metrics); ok && sysMetrics.enabled {
+			ctx.metricLabels = sysMetrics.actorLabels(ctx)
+			proc.metricLabels.Store(attribute.NewSet(ctx.metricLabels...))
+		}
 
 		initialize(props, ctx)
 
@@ -81,6 +72,7 @@ func initialize(props *Props, ctx *actorContext) {
 var DefaultSpawner SpawnFunc = defaultSpawner
 
 // ErrNameExists is the error used when an existing name is used for spawning an actor.
+// It is returned along with the PID of the process already registered under the name, nothing is spawned.
 var ErrNameExists = errors.New("spawn: name exists")
 
 // Props represents configuration to define how an actor should be created.
@@ -100,6 +92,25 @@ type Props struct {
 	contextDecorator        []ContextDecorator
 	contextDecoratorChain   ContextDecoratorFunc
 	onInit                  []func(ctx Context)
+	stashSize               int
+	stashOverflow           StashOverflowPolicy
+	maxBehaviorDepth        int
+	onRestartMessagePolicy  OnRestartMessagePolicy
+	readinessTimeout        time.Duration
+}
+
+func (props *Props) makeReceiverMiddlewareChain() {
+	// Construct the receiver middleware chain with the final receiver at the end
+	props.receiverMiddlewareChain = makeReceiverMiddlewareChain(props.receiverMiddleware, func(ctx ReceiverContext, envelope *MessageEnvelope) {
+		ctx.Receive(envelope)
+	})
+}
+
+func (props *Props) makeSenderMiddlewareChain() {
+	// Construct the sender middleware chain with the final sender at the end
+	props.senderMiddlewareChain = makeSenderMiddlewareChain(props.senderMiddleware, func(sender SenderContext, target *PID, envelope *MessageEnvelope) {
+		target.sendUserMessage(sender.ActorSystem(), envelope)
+	})
 }
 
 func (props *Props) getSpawner() SpawnFunc {
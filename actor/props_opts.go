@@ -1,5 +1,7 @@
 package actor
 
+import "time"
+
 type PropsOption func(props *Props)
 
 func WithOnInit(init ...func(ctx Context)) PropsOption {
@@ -48,25 +50,40 @@ func WithSupervisor(supervisor SupervisorStrategy) PropsOption {
 	}
 }
 
+// WithReceiverMiddleware appends middleware to the receiver middleware. Middleware run in the order they were added:
+// the first one wraps all the others and sees each message first, the last one calls the actor.
+// Use WithReceiverMiddlewarePrepend for middleware which must wrap middleware added before it
 func WithReceiverMiddleware(middleware ...ReceiverMiddleware) PropsOption {
 	return func(props *Props) {
 		props.receiverMiddleware = append(props.receiverMiddleware, middleware...)
+		props.makeReceiverMiddlewareChain()
+	}
+}
 
-		// Construct the receiver middleware chain with the final receiver at the end
-		props.receiverMiddlewareChain = makeReceiverMiddlewareChain(props.receiverMiddleware, func(ctx ReceiverContext, envelope *MessageEnvelope) {
-			ctx.Receive(envelope)
-		})
+// WithReceiverMiddlewarePrepend inserts middleware before the receiver middleware already added, so that they wrap it.
+// The middleware given keep their relative order
+func WithReceiverMiddlewarePrepend(middleware ...ReceiverMiddleware) PropsOption {
+	return func(props *Props) {
+		props.receiverMiddleware = append(append([]ReceiverMiddleware{}, middleware...), props.receiverMiddleware...)
+		props.makeReceiverMiddlewareChain()
 	}
 }
 
+// WithSenderMiddleware appends middleware to the sender middleware, which run in the order they were added like the
+// receiver middleware
 func WithSenderMiddleware(middleware ...SenderMiddleware) PropsOption {
 	return func(props *Props) {
 		props.senderMiddleware = append(props.senderMiddleware, middleware...)
+		props.makeSenderMiddlewareChain()
+	}
+}
 
-		// Construct the sender middleware chain with the final sender at the end
-		props.senderMiddlewareChain = makeSenderMiddlewareChain(props.senderMiddleware, func(sender SenderContext, target *PID, envelope *MessageEnvelope) {
-			target.sendUserMessage(sender.ActorSystem(), envelope)
-		})
+// WithSenderMiddlewarePrepend inserts middleware before the sender middleware already added, so that they wrap it.
+// The middleware given keep their relative order
+func WithSenderMiddlewarePrepend(middleware ...SenderMiddleware) PropsOption {
+	return func(props *Props) {
+		props.senderMiddleware = append(append([]SenderMiddleware{}, middleware...), props.senderMiddleware...)
+		props.makeSenderMiddlewareChain()
 	}
 }
 
@@ -97,6 +114,65 @@ func WithSpawnMiddleware(middleware ...SpawnMiddleware) PropsOption {
 	}
 }
 
+// StashOverflowPolicy decides what happens to a message stashed when the stash is full
+type StashOverflowPolicy int
+
+const (
+	// StashDropOldest drops the oldest stashed message to make room
+	StashDropOldest StashOverflowPolicy = iota
+	// StashDropNewest drops the message being stashed
+	StashDropNewest
+	// StashDeadLetter publishes the message being stashed as a DeadLetterEvent
+	StashDeadLetter
+)
+
+// WithStashSize bounds the number of stashed messages, policy applies once size messages are stashed.
+// A size of zero leaves the stash unbounded
+func WithStashSize(size int, policy StashOverflowPolicy) PropsOption {
+	return func(props *Props) {
+		props.stashSize = size
+		props.stashOverflow = policy
+	}
+}
+
+// OnRestartMessagePolicy decides what happens to the user message an actor failed on, once the actor restarted
+type OnRestartMessagePolicy int
+
+const (
+	// OnRestartDiscard drops the message the actor failed on
+	OnRestartDiscard OnRestartMessagePolicy = iota
+	// OnRestartRedeliverOnce delivers the message the actor failed on to the restarted actor, ahead of its mailbox.
+	// A message failing again is dropped
+	OnRestartRedeliverOnce
+	// OnRestartDeadLetter publishes the message the actor failed on as a DeadLetterEvent
+	OnRestartDeadLetter
+)
+
+// WithOnRestartMessagePolicy sets what happens to the message an actor failed on when it restarts, it is dropped by default
+func WithOnRestartMessagePolicy(policy OnRestartMessagePolicy) PropsOption {
+	return func(props *Props) {
+		props.onRestartMessagePolicy = policy
+	}
+}
+
+// WithReadinessTimeout holds the user messages an actor receives from its start until it calls Context.SetReady,
+// such as once a connection it opens with ReenterAfter is established. The held messages are then processed in order.
+// An actor not ready once timeout elapsed escalates a failure with ErrReadinessTimeout, and is held again when restarted
+func WithReadinessTimeout(timeout time.Duration) PropsOption {
+	return func(props *Props) {
+		props.readinessTimeout = timeout
+	}
+}
+
+// WithMaxBehaviorDepth fails the actor, once it processed a message, when its Behavior stack is deeper than depth.
+// It catches BecomeStacked calls without a matching UnbecomeStacked, which otherwise leak memory.
+// The depth is the Context.BehaviorStackSize of the actor
+func WithMaxBehaviorDepth(depth int) PropsOption {
+	return func(props *Props) {
+		props.maxBehaviorDepth = depth
+	}
+}
+
 // PropsFromProducer creates a props with the given actor producer assigned.
 func PropsFromProducer(producer Producer, opts ...PropsOption) *Props {
 	p := &Props{
@@ -127,6 +203,10 @@ func (props *Props) Clone(opts ...PropsOption) *Props {
 		WithSpawnFunc(props.spawner),
 		WithSpawnMiddleware(props.spawnMiddleware...),
 		WithOnInit(props.onInit...),
+		WithStashSize(props.stashSize, props.stashOverflow),
+		WithMaxBehaviorDepth(props.maxBehaviorDepth),
+		WithOnRestartMessagePolicy(props.onRestartMessagePolicy),
+		WithReadinessTimeout(props.readinessTimeout),
 	)
 
 	cp.Configure(opts...)
@@ -0,0 +1,112 @@
+package actor
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrReadinessTimeout is the reason of the failure escalated by an actor which did not call SetReady in time,
+// see WithReadinessTimeout
+var ErrReadinessTimeout = errors.New("actor: not ready in time")
+
+// readinessTimeout is sent by the readiness timer of an actor, gen identifies the start it was set for
+type readinessTimeout struct {
+	gen uint64
+}
+
+// ready replays the messages an actor received before it called SetReady
+type ready struct{}
+
+func (*readinessTimeout) SystemMessage() {}
+func (*ready) SystemMessage()            {}
+
+var readyMessage SystemMessage = &ready{}
+
+// awaitReadiness holds the user messages of the actor until it calls SetReady, or fails once timeout elapsed
+func (ctx *actorContext) awaitReadiness() {
+	timeout := ctx.props.readinessTimeout
+	if timeout <= 0 {
+		return
+	}
+
+	extra := ctx.ensureExtras()
+	extra.notReady = true
+	extra.readinessGen++
+	gen := extra.readinessGen
+	if extra.readinessTimer != nil {
+		extra.readinessTimer.Stop()
+	}
+	extra.readinessTimer = time.AfterFunc(timeout, func() {
+		ctx.self.sendSystemMessage(ctx.actorSystem, &readinessTimeout{gen: gen})
+	})
+}
+
+// SetReady replays the messages held since the actor started, ahead of its mailbox, and stops holding them.
+// It does nothing when the actor is ready already
+func (ctx *actorContext) SetReady() {
+	if ctx.extras == nil || !ctx.extras.notReady {
+		return
+	}
+
+	ctx.extras.notReady = false
+	if ctx.extras.readinessTimer != nil {
+		ctx.extras.readinessTimer.Stop()
+		ctx.extras.readinessTimer = nil
+	}
+
+	// replayed once the current message was processed, system messages are processed ahead of the user messages
+	ctx.self.sendSystemMessage(ctx.actorSystem, readyMessage)
+}
+
+// holdUntilReady keeps the user message for the actor to process once it is ready, it returns false when the
+// actor is ready
+func (ctx *actorContext) holdUntilReady(md interface{}) bool {
+	if ctx.extras == nil || !ctx.extras.notReady || !isUserMessage(md) {
+		return false
+	}
+
+	ctx.extras.notReadyMessages = append(ctx.extras.notReadyMessages, md)
+
+	return true
+}
+
+func (ctx *actorContext) replayNotReadyMessages() {
+	if ctx.extras == nil {
+		return
+	}
+
+	// messages replayed before the actor became not ready again, such as after a restart, are held again
+	for len(ctx.extras.notReadyMessages) > 0 && !ctx.extras.notReady {
+		msg := ctx.extras.notReadyMessages[0]
+		ctx.extras.notReadyMessages[0] = nil
+		ctx.extras.notReadyMessages = ctx.extras.notReadyMessages[1:]
+		ctx.InvokeUserMessage(msg)
+	}
+}
+
+func (ctx *actorContext) handleReadinessTimeout(msg *readinessTimeout) {
+	if ctx.extras == nil || !ctx.extras.notReady || msg.gen != ctx.extras.readinessGen {
+		return
+	}
+
+	ctx.extras.readinessTimer = nil
+	ctx.EscalateFailure(fmt.Errorf("%w after %v", ErrReadinessTimeout, ctx.props.readinessTimeout), nil)
+}
+
+// deadLetterNotReadyMessages hands the messages held for a stopped actor to the dead letters,
+// so that requests among them do not time out
+func (ctx *actorContext) deadLetterNotReadyMessages() {
+	if ctx.extras == nil {
+		return
+	}
+
+	if ctx.extras.readinessTimer != nil {
+		ctx.extras.readinessTimer.Stop()
+		ctx.extras.readinessTimer = nil
+	}
+	for _, msg := range ctx.extras.notReadyMessages {
+		ctx.actorSystem.DeadLetter.SendUserMessage(ctx.self, msg)
+	}
+	ctx.extras.notReadyMessages = nil
+}
@@ -0,0 +1,99 @@
+package actor
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	defaultRetryAttempts = 3
+	defaultRetryTimeout  = 5 * time.Second
+)
+
+// RetryOptions configures RequestWithRetry. The zero value makes 3 attempts of 5 seconds each, retrying timeouts and
+// dead letters immediately
+type RetryOptions struct {
+	// MaxAttempts is the number of requests made before giving up
+	MaxAttempts int
+
+	// Timeout is the time each attempt waits for a response
+	Timeout time.Duration
+
+	// Backoff returns the delay before the given retry, starting at 1. A nil Backoff retries immediately
+	Backoff func(retry int) time.Duration
+
+	// Retryable tells whether a failed attempt is retried. A nil Retryable retries ErrTimeout and ErrDeadLetter
+	Retryable func(err error) bool
+}
+
+// ExponentialRetryBackoff doubles the delay between retries from initial up to max
+func ExponentialRetryBackoff(initial, max time.Duration) func(retry int) time.Duration {
+	return func(retry int) time.Duration {
+		delay := initial
+		for i := 1; i < retry && delay < max; i++ {
+			delay *= 2
+		}
+		if delay > max {
+			delay = max
+		}
+
+		return delay
+	}
+}
+
+func (opts RetryOptions) withDefaults() RetryOptions {
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = defaultRetryAttempts
+	}
+	// each attempt must time out, otherwise its future would never leave the process registry
+	if opts.Timeout <= 0 {
+		opts.Timeout = defaultRetryTimeout
+	}
+	if opts.Backoff == nil {
+		opts.Backoff = func(int) time.Duration { return 0 }
+	}
+	if opts.Retryable == nil {
+		opts.Retryable = isTransientRequestError
+	}
+
+	return opts
+}
+
+func isTransientRequestError(err error) bool {
+	return errors.Is(err, ErrTimeout) || errors.Is(err, ErrDeadLetter)
+}
+
+func (ctx *actorContext) RequestWithRetry(pid *PID, message interface{}, opts RetryOptions, cont func(res interface{}, err error)) {
+	ctx.requestAttempt(pid, message, opts.withDefaults(), 1, cont)
+}
+
+func (ctx *actorContext) requestAttempt(pid *PID, message interface{}, opts RetryOptions, attempt int, cont func(res interface{}, err error)) {
+	f := ctx.RequestFuture(pid, message, opts.Timeout)
+	ctx.ReenterAfter(f, func(res interface{}, err error) {
+		if err == nil || attempt >= opts.MaxAttempts || !opts.Retryable(err) {
+			cont(res, err)
+
+			return
+		}
+
+		delay := opts.Backoff(attempt)
+		if delay <= 0 {
+			ctx.requestAttempt(pid, message, opts, attempt+1, cont)
+
+			return
+		}
+
+		// wait outside of the actor, like ReenterAfter, so it keeps processing messages meanwhile
+		current := ctx.messageOrEnvelope
+		ctx.beginReentrancy()
+		time.AfterFunc(delay, func() {
+			ctx.self.sendSystemMessage(ctx.actorSystem, &continuation{
+				f: func() {
+					defer ctx.endReentrancy()
+					ctx.requestAttempt(pid, message, opts, attempt+1, cont)
+				},
+				message: current,
+			})
+		})
+	})
+}
@@ -0,0 +1,131 @@
+package actor
+
+// RequestScope holds the values of the logical request the message being processed belongs to, such as a tenant or
+// a correlation id. The messages the actor sends while processing the message carry a copy of the values, the
+// receiving actors find them in their own RequestScope, and they are copied into the headers of the messages sent to
+// remote actors.
+//
+// The scope lives as long as the message being processed: the values set apply to the messages sent afterwards while
+// processing it, and are gone once Receive returns. Outside of Receive the scope is empty and Set does nothing
+type RequestScope interface {
+	Get(key string) string
+	Set(key string, value string)
+	Keys() []string
+}
+
+type requestScope map[string]string
+
+func (scope requestScope) Get(key string) string {
+	return scope[key]
+}
+
+func (scope requestScope) Set(key string, value string) {
+	scope[key] = value
+}
+
+func (scope requestScope) Keys() []string {
+	keys := make([]string, 0, len(scope))
+	for k := range scope {
+		keys = append(keys, k)
+	}
+
+	return keys
+}
+
+func (scope requestScope) clone() requestScope {
+	c := make(requestScope, len(scope))
+	for k, v := range scope {
+		c[k] = v
+	}
+
+	return c
+}
+
+// contextScope is the RequestScope of the message an actor is processing
+type contextScope struct {
+	ctx *actorContext
+}
+
+func (ctx *actorContext) RequestScope() RequestScope {
+	return contextScope{ctx: ctx}
+}
+
+func (s contextScope) Get(key string) string {
+	if env, ok := s.ctx.messageOrEnvelope.(*MessageEnvelope); ok {
+		return env.Scope[key]
+	}
+
+	return ""
+}
+
+func (s contextScope) Keys() []string {
+	if env, ok := s.ctx.messageOrEnvelope.(*MessageEnvelope); ok {
+		return env.Scope.Keys()
+	}
+
+	return []string{}
+}
+
+func (s contextScope) Set(key string, value string) {
+	ctx := s.ctx
+	if ctx.messageOrEnvelope == nil {
+		return
+	}
+
+	// the envelope received may be shared with other actors, the scope is set on a copy owned by the actor
+	if env, ok := ctx.messageOrEnvelope.(*MessageEnvelope); !ok || env != ctx.scopeEnvelope {
+		owned := WrapEnvelope(ctx.messageOrEnvelope)
+		if ok {
+			copied := *env
+			owned = &copied
+		}
+		owned.Scope = owned.Scope.clone()
+		ctx.messageOrEnvelope = owned
+		ctx.scopeEnvelope = owned
+	}
+
+	ctx.scopeEnvelope.Scope.Set(key, value)
+}
+
+// withRequestScope returns the message carrying a copy of the scope of the message being processed, on top of the
+// scope it already carries
+func (ctx *actorContext) withRequestScope(message interface{}) interface{} {
+	current, ok := ctx.messageOrEnvelope.(*MessageEnvelope)
+	if !ok || len(current.Scope) == 0 {
+		return message
+	}
+
+	env := &MessageEnvelope{Message: message}
+	if e, ok := message.(*MessageEnvelope); ok {
+		*env = *e
+	}
+	scope := current.Scope.clone()
+	for k, v := range env.Scope {
+		scope[k] = v
+	}
+	env.Scope = scope
+
+	return env
+}
+
+// UnwrapEnvelopeHeaderWithScope returns the header of the envelope with the values of its request scope copied into
+// it, for the processes sending messages out of the actor system. The header values take precedence
+func UnwrapEnvelopeHeaderWithScope(message interface{}) ReadonlyMessageHeader {
+	env, ok := message.(*MessageEnvelope)
+	if !ok {
+		return nil
+	}
+	if len(env.Scope) == 0 {
+		return env.Header
+	}
+
+	header := make(messageHeader, len(env.Scope)+len(env.Header))
+	for k, v := range env.Scope {
+		header[k] = v
+	}
+	for k, v := range env.Header {
+		header[k] = v
+	}
+
+	return header
+}
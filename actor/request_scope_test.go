@@ -0,0 +1,78 @@
+package actor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestScope_RidesWithTheRequest(t *testing.T) {
+	type seen struct {
+		actor, tenant, hop string
+	}
+	results := make(chan seen, 10)
+	record := func(name string, ctx Context) {
+		results <- seen{name, ctx.RequestScope().Get("tenant"), ctx.RequestScope().Get("hop")}
+	}
+
+	last := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		if _, ok := ctx.Message().(string); ok {
+			record("last", ctx)
+			ctx.Respond("done")
+		}
+	}))
+	defer rootContext.Stop(last)
+	middle := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		if _, ok := ctx.Message().(string); ok {
+			record("middle", ctx)
+			ctx.RequestScope().Set("hop", "middle")
+			ctx.Forward(last)
+		}
+	}))
+	defer rootContext.Stop(middle)
+	first := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		switch ctx.Message().(type) {
+		case string:
+			ctx.RequestScope().Set("tenant", "acme")
+			res, err := ctx.RequestFuture(middle, "work", time.Second).Result()
+			assert.NoError(t, err)
+			assert.Equal(t, "done", res)
+			// the values set by the actors down the line do not come back
+			record("first", ctx)
+		case int:
+			record("next", ctx)
+		}
+	}))
+	defer rootContext.Stop(first)
+
+	rootContext.Send(first, "start")
+	rootContext.Send(first, 1)
+
+	expected := []seen{
+		{"middle", "acme", ""},
+		{"last", "acme", "middle"},
+		{"first", "acme", ""},
+		// the scope is gone with the message it was set for
+		{"next", "", ""},
+	}
+	for _, e := range expected {
+		select {
+		case s := <-results:
+			assert.Equal(t, e, s)
+		case <-time.After(time.Second):
+			t.Fatalf("%v not reached", e.actor)
+		}
+	}
+}
+
+func TestUnwrapEnvelopeHeaderWithScope(t *testing.T) {
+	env := &MessageEnvelope{
+		Header: messageHeader{"correlation": "header"},
+		Scope:  requestScope{"correlation": "scope", "tenant": "acme"},
+	}
+
+	header := UnwrapEnvelopeHeaderWithScope(env)
+	assert.Equal(t, map[string]string{"correlation": "header", "tenant": "acme"}, header.ToMap())
+	assert.Nil(t, UnwrapEnvelopeHeaderWithScope("message"))
+}
@@ -1,6 +1,7 @@
 package actor
 
 import (
+	"context"
 	"time"
 )
 
@@ -10,6 +11,7 @@ type RootContext struct {
 	spawnMiddleware  SpawnFunc
 	headers          messageHeader
 	guardianStrategy SupervisorStrategy
+	system           bool
 }
 
 var (
@@ -68,6 +70,14 @@ func (rc *RootContext) WithGuardian(guardian SupervisorStrategy) *RootContext {
 	return rc
 }
 
+// WithSystemSpawns lets the context spawn while the actor system shuts down gracefully.
+// It is meant for internal actors a graceful drain still relies on, such as request collectors
+func (rc *RootContext) WithSystemSpawns() *RootContext {
+	rc.system = true
+
+	return rc
+}
+
 //
 // Interface: info
 //
@@ -108,30 +118,27 @@ func (rc *RootContext) Send(pid *PID, message interface{}) {
 	rc.sendUserMessage(pid, message)
 }
 
+func (rc *RootContext) SendWithHeaders(pid *PID, message interface{}, headers ReadonlyMessageHeader) {
+	rc.sendUserMessage(pid, envelopeWithHeaders(message, headers))
+}
+
 func (rc *RootContext) Request(pid *PID, message interface{}) {
 	rc.sendUserMessage(pid, message)
 }
 
 func (rc *RootContext) RequestWithCustomSender(pid *PID, message interface{}, sender *PID) {
-	env := &MessageEnvelope{
-		Header:  nil,
-		Message: message,
-		Sender:  sender,
-	}
-	rc.sendUserMessage(pid, env)
+	request(rc.actorSystem, rc.headers, pid, message, sender, rc.sendUserMessage)
 }
 
 // RequestFuture sends a message to a given PID and returns a Future.
+// The request is bounded by the deadline of the headers of the context, see DeadlineHeader
 func (rc *RootContext) RequestFuture(pid *PID, message interface{}, timeout time.Duration) *Future {
-	future := NewFuture(rc.actorSystem, timeout)
-	env := &MessageEnvelope{
-		Header:  nil,
-		Message: message,
-		Sender:  future.PID(),
-	}
-	rc.sendUserMessage(pid, env)
+	return requestFuture(rc.actorSystem, rc.headers, pid, message, timeout, rc.sendUserMessage)
+}
 
-	return future
+// RequestFutureCtx sends a message to a given PID and returns a Future, bounded by ctx.
+func (rc *RootContext) RequestFutureCtx(pid *PID, message interface{}, ctx context.Context) *Future {
+	return requestFutureCtx(rc.actorSystem, rc.headers, pid, message, ctx, rc.sendUserMessage)
 }
 
 func (rc *RootContext) sendUserMessage(pid *PID, message interface{}) {
@@ -149,8 +156,14 @@ func (rc *RootContext) sendUserMessage(pid *PID, message interface{}) {
 //
 
 // Spawn starts a new actor based on props and named with a unique id.
+// Once the actor system is shutting down gracefully, the actor is not started and a PID whose messages are dead
+// lettered is returned
 func (rc *RootContext) Spawn(props *Props) *PID {
-	pid, err := rc.SpawnNamed(props, rc.actorSystem.ProcessRegistry.NextId())
+	id := rc.actorSystem.ProcessRegistry.NextId()
+	pid, err := rc.SpawnNamed(props, id)
+	if err == ErrActorSystemShuttingDown {
+		return rc.actorSystem.NewLocalPID(id)
+	}
 	if err != nil {
 		panic(err)
 	}
@@ -159,8 +172,13 @@ func (rc *RootContext) Spawn(props *Props) *PID {
 }
 
 // SpawnPrefix starts a new actor based on props and named using a prefix followed by a unique id.
+// Like Spawn, it returns a dead lettered PID once the actor system is shutting down gracefully
 func (rc *RootContext) SpawnPrefix(props *Props, prefix string) *PID {
-	pid, err := rc.SpawnNamed(props, prefix+rc.actorSystem.ProcessRegistry.NextId())
+	id := prefix + rc.actorSystem.ProcessRegistry.NextId()
+	pid, err := rc.SpawnNamed(props, id)
+	if err == ErrActorSystemShuttingDown {
+		return rc.actorSystem.NewLocalPID(id)
+	}
 	if err != nil {
 		panic(err)
 	}
@@ -170,10 +188,17 @@ func (rc *RootContext) SpawnPrefix(props *Props, prefix string) *PID {
 
 // SpawnNamed starts a new actor based on props and named using the specified name
 //
-// # ErrNameExists will be returned if id already exists
+// # ErrNameExists will be returned along with the PID of the existing process if id already exists,
+// concurrent spawns of the same id spawn a single actor and all the others get ErrNameExists
 //
 // Please do not use name sharing same pattern with system actors, for example "YourPrefix$1", "Remote$1", "future$1".
+//
+// ErrActorSystemShuttingDown will be returned once the actor system is shutting down gracefully, unless WithSystemSpawns was used
 func (rc *RootContext) SpawnNamed(props *Props, name string) (*PID, error) {
+	if !rc.system && rc.actorSystem.isShuttingDown() {
+		return nil, ErrActorSystemShuttingDown
+	}
+
 	rootContext := rc
 	if props.guardianStrategy != nil {
 		rootContext = rc.Copy().WithGuardian(props.guardianStrategy)
@@ -219,3 +244,9 @@ func (rc *RootContext) PoisonFuture(pid *PID) *Future {
 
 	return future
 }
+
+// PoisonDrain will tell actor to stop after processing current user messages in mailbox and the continuations
+// it awaits with ReenterAfter, and return its future. The actor is stopped once timeout elapsed
+func (rc *RootContext) PoisonDrain(pid *PID, timeout time.Duration) *Future {
+	return poisonDrain(rc.actorSystem, pid, timeout)
+}
@@ -1,6 +1,9 @@
 package actor
 
 import (
+	"errors"
+	"sync"
+	"sync/atomic"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -57,3 +60,63 @@ func TestLookupById(t *testing.T) {
 		assert.Equal(t, 2, value.(int))
 	}
 }
+
+func TestSpawnNamed_ConcurrentCollision(t *testing.T) {
+	const spawners = 20
+
+	var started int32
+	props := PropsFromFunc(func(ctx Context) {
+		if _, ok := ctx.Message().(*Started); ok {
+			atomic.AddInt32(&started, 1)
+		}
+	})
+
+	var wg sync.WaitGroup
+	pids := make([]*PID, spawners)
+	errs := make([]error, spawners)
+	for i := 0; i < spawners; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			pids[i], errs[i] = rootContext.SpawnNamed(props, "collision")
+		}(i)
+	}
+	wg.Wait()
+
+	spawned := 0
+	for i, err := range errs {
+		if err == nil {
+			spawned++
+		} else {
+			assert.ErrorIs(t, err, ErrNameExists)
+		}
+		// every spawner gets the PID of the single actor
+		assert.True(t, pids[i].Equal(pids[0]))
+	}
+	assert.Equal(t, 1, spawned)
+
+	_, err := rootContext.PoisonFuture(pids[0]).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&started))
+}
+
+func TestSpawnNamed_ChildCollision(t *testing.T) {
+	errs := make(chan error, 1)
+	parent := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		if _, ok := ctx.Message().(*Started); ok {
+			child := PropsFromFunc(func(ctx Context) {})
+			first, _ := ctx.SpawnNamed(child, "child")
+			existing, err := ctx.SpawnNamed(child, "child")
+			if !existing.Equal(first) {
+				err = errors.New("the existing child was not returned")
+			}
+			if len(ctx.Children()) != 1 {
+				err = errors.New("the collision added a child")
+			}
+			errs <- err
+		}
+	}))
+	defer rootContext.Stop(parent)
+
+	assert.ErrorIs(t, <-errs, ErrNameExists)
+}
@@ -28,22 +28,24 @@ func (strategy *allForOneStrategy) HandleFailure(actorSystem *ActorSystem, super
 	switch directive {
 	case ResumeDirective:
 		// resume the failing child
-		logFailure(actorSystem, child, reason, directive)
+		logFailure(actorSystem, child, reason, directive, rs.FailureCount())
 		supervisor.ResumeChildren(child)
 	case RestartDirective:
 		children := supervisor.Children()
 		// try restart the all the children
+		failures := rs.FailureCount()
 		if strategy.shouldStop(rs) {
-			logFailure(actorSystem, child, reason, StopDirective)
+			// the statistics are reset once the child is stopped, count the failure which stopped it
+			logFailure(actorSystem, child, reason, StopDirective, failures+1)
 			supervisor.StopChildren(children...)
 		} else {
-			logFailure(actorSystem, child, reason, RestartDirective)
+			logFailure(actorSystem, child, reason, RestartDirective, rs.FailureCount())
 			supervisor.RestartChildren(children...)
 		}
 	case StopDirective:
 		children := supervisor.Children()
 		// stop all the children, no need to involve the crs
-		logFailure(actorSystem, child, reason, directive)
+		logFailure(actorSystem, child, reason, directive, rs.FailureCount())
 		supervisor.StopChildren(children...)
 	case EscalateDirective:
 		// send failure to parent
@@ -0,0 +1,76 @@
+package actor
+
+import (
+	"math/rand"
+	"time"
+)
+
+// NewExponentialBackoffDecorator wraps a SupervisorStrategy, delaying the restarts it decides on.
+// The delay starts at initialBackoff and doubles with every restart, up to maxBackoff, with a random jitter
+// of up to half the delay. Once a child stays alive for stablePeriod after a restart, the delay starts over.
+//
+// The decorated strategy keeps deciding the directive, so a custom decider composes with the backoff.
+// The failing child's mailbox stays suspended during the delay, its messages are processed after the restart
+func NewExponentialBackoffDecorator(strategy SupervisorStrategy, initialBackoff, maxBackoff, stablePeriod time.Duration) SupervisorStrategy {
+	return &exponentialBackoffDecorator{
+		strategy:       strategy,
+		initialBackoff: initialBackoff,
+		maxBackoff:     maxBackoff,
+		stablePeriod:   stablePeriod,
+	}
+}
+
+type exponentialBackoffDecorator struct {
+	strategy       SupervisorStrategy
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	stablePeriod   time.Duration
+}
+
+var _ SupervisorStrategy = &exponentialBackoffDecorator{}
+
+func (strategy *exponentialBackoffDecorator) HandleFailure(actorSystem *ActorSystem, supervisor Supervisor, child *PID, rs *RestartStatistics, reason interface{}, message interface{}) {
+	backoff := &backoffSupervisor{
+		Supervisor: supervisor,
+		delay: func() time.Duration {
+			return strategy.nextBackoff(rs)
+		},
+	}
+	strategy.strategy.HandleFailure(actorSystem, backoff, child, rs, reason, message)
+}
+
+// nextBackoff returns the delay before the next restart of the child, and records the restart
+func (strategy *exponentialBackoffDecorator) nextBackoff(rs *RestartStatistics) time.Duration {
+	now := time.Now()
+	if !rs.backoffRestartAt.IsZero() && now.Sub(rs.backoffRestartAt) >= strategy.stablePeriod {
+		rs.backoffAttempts = 0
+	}
+
+	delay := strategy.initialBackoff
+	for i := 0; i < rs.backoffAttempts && delay < strategy.maxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > strategy.maxBackoff {
+		delay = strategy.maxBackoff
+	}
+	if half := int64(delay / 2); half > 0 {
+		delay = delay - time.Duration(half) + time.Duration(rand.Int63n(half+1))
+	}
+
+	rs.backoffAttempts++
+	rs.backoffRestartAt = now.Add(delay)
+
+	return delay
+}
+
+// backoffSupervisor delays restarts, the other directives are applied immediately
+type backoffSupervisor struct {
+	Supervisor
+	delay func() time.Duration
+}
+
+func (s *backoffSupervisor) RestartChildren(pids ...*PID) {
+	time.AfterFunc(s.delay(), func() {
+		s.Supervisor.RestartChildren(pids...)
+	})
+}
@@ -29,8 +29,9 @@ func (strategy *exponentialBackoffStrategy) HandleFailure(actorSystem *ActorSyst
 	backoff := rs.FailureCount() * int(strategy.initialBackoff.Nanoseconds())
 	noise := rand.Intn(500)
 	dur := time.Duration(backoff + noise)
+	failures := rs.FailureCount()
 	time.AfterFunc(dur, func() {
-		logFailure(actorSystem, child, reason, RestartDirective)
+		logFailure(actorSystem, child, reason, RestartDirective, failures)
 		supervisor.RestartChildren(child)
 	})
 }
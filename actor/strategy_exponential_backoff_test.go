@@ -21,7 +21,7 @@ func TestExponentialBackoffStrategy_setFailureCount(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.n, func(t *testing.T) {
 			s := &exponentialBackoffStrategy{backoffWindow: 10 * time.Second}
-			rs := &RestartStatistics{[]time.Time{}}
+			rs := &RestartStatistics{failureTimes: []time.Time{}}
 			for i := 0; i < tc.fc; i++ {
 				rs.failureTimes = append(rs.failureTimes, time.Now().Add(-tc.ft))
 			}
@@ -54,3 +54,52 @@ func TestExponentialBackoffStrategy_ResetsFailureCount(t *testing.T) {
 
 	assert.Equal(t, 1, rs.FailureCount())
 }
+
+func TestExponentialBackoffDecorator_nextBackoff(t *testing.T) {
+	s := &exponentialBackoffDecorator{initialBackoff: 100 * time.Millisecond, maxBackoff: 1 * time.Second, stablePeriod: 10 * time.Second}
+	rs := NewRestartStatistics()
+
+	for _, limit := range []time.Duration{100, 200, 400, 800, 1000, 1000} {
+		delay := s.nextBackoff(rs)
+		assert.LessOrEqual(t, delay, limit*time.Millisecond)
+		assert.GreaterOrEqual(t, delay, limit*time.Millisecond/2)
+	}
+
+	// the child stayed alive for the stable period
+	rs.backoffRestartAt = time.Now().Add(-11 * time.Second)
+	assert.LessOrEqual(t, s.nextBackoff(rs), 100*time.Millisecond)
+}
+
+func TestExponentialBackoffDecorator_KeepsDecider(t *testing.T) {
+	system := NewActorSystem()
+	events := make(chan *SupervisorEvent, 10)
+	sub := system.EventStream.Subscribe(func(evt interface{}) {
+		if e, ok := evt.(*SupervisorEvent); ok {
+			events <- e
+		}
+	})
+	defer system.EventStream.Unsubscribe(sub)
+
+	decider := func(reason interface{}) Directive {
+		return ResumeDirective
+	}
+	strategy := NewExponentialBackoffDecorator(NewOneForOneStrategy(10, 0, decider), time.Hour, time.Hour, time.Hour)
+	props := PropsFromFunc(func(ctx Context) {
+		if _, ok := ctx.Message().(string); ok {
+			panic("failing")
+		}
+	})
+	parent := system.Root.Spawn(PropsFromFunc(func(ctx Context) {
+		if _, ok := ctx.Message().(*Started); ok {
+			ctx.Send(ctx.Spawn(props), "fail")
+		}
+	}, WithSupervisor(strategy)))
+	defer system.Root.Stop(parent)
+
+	select {
+	case e := <-events:
+		assert.Equal(t, ResumeDirective, e.Directive)
+	case <-time.After(time.Second):
+		t.Fatal("expected the child to be resumed without delay")
+	}
+}
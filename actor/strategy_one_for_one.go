@@ -6,17 +6,35 @@ import "time"
 // to the failing child process.
 //
 // This strategy is applicable if it is safe to handle a single child in isolation from its peers or dependents
-func NewOneForOneStrategy(maxNrOfRetries int, withinDuration time.Duration, decider DeciderFunc) SupervisorStrategy {
-	return &oneForOneStrategy{
+func NewOneForOneStrategy(maxNrOfRetries int, withinDuration time.Duration, decider DeciderFunc, opts ...OneForOneOption) SupervisorStrategy {
+	strategy := &oneForOneStrategy{
 		maxNrOfRetries: maxNrOfRetries,
 		withinDuration: withinDuration,
 		decider:        decider,
 	}
+	for _, opt := range opts {
+		opt(strategy)
+	}
+
+	return strategy
+}
+
+// OneForOneOption configures a strategy created with NewOneForOneStrategy
+type OneForOneOption func(strategy *oneForOneStrategy)
+
+// WithRestartStablePeriod resets the restart count of a child which stayed alive for stablePeriod since it was last
+// restarted. The failures of a long-lived child failing once in a while never add up to maxNrOfRetries, only
+// the ones of a crash loop do
+func WithRestartStablePeriod(stablePeriod time.Duration) OneForOneOption {
+	return func(strategy *oneForOneStrategy) {
+		strategy.stablePeriod = stablePeriod
+	}
 }
 
 type oneForOneStrategy struct {
 	maxNrOfRetries int
 	withinDuration time.Duration
+	stablePeriod   time.Duration
 	decider        DeciderFunc
 }
 
@@ -28,20 +46,22 @@ func (strategy *oneForOneStrategy) HandleFailure(actorSystem *ActorSystem, super
 	switch directive {
 	case ResumeDirective:
 		// resume the failing child
-		logFailure(actorSystem, child, reason, directive)
+		logFailure(actorSystem, child, reason, directive, rs.FailureCount())
 		supervisor.ResumeChildren(child)
 	case RestartDirective:
 		// try restart the failing child
+		failures := rs.FailureCount()
 		if strategy.shouldStop(rs) {
-			logFailure(actorSystem, child, reason, StopDirective)
+			// the statistics are reset once the child is stopped, count the failure which stopped it
+			logFailure(actorSystem, child, reason, StopDirective, failures+1)
 			supervisor.StopChildren(child)
 		} else {
-			logFailure(actorSystem, child, reason, RestartDirective)
+			logFailure(actorSystem, child, reason, RestartDirective, rs.FailureCount())
 			supervisor.RestartChildren(child)
 		}
 	case StopDirective:
 		// stop the failing child, no need to involve the crs
-		logFailure(actorSystem, child, reason, directive)
+		logFailure(actorSystem, child, reason, directive, rs.FailureCount())
 		supervisor.StopChildren(child)
 	case EscalateDirective:
 		// send failure to parent
@@ -57,6 +77,13 @@ func (strategy *oneForOneStrategy) shouldStop(rs *RestartStatistics) bool {
 		return true
 	}
 
+	if strategy.stablePeriod > 0 {
+		if restarted := rs.lastRestart(); !restarted.IsZero() && time.Since(restarted) >= strategy.stablePeriod {
+			// the child recovered from its previous failures
+			rs.Reset()
+		}
+	}
+
 	rs.Fail()
 
 	if rs.NumberOfFailures(strategy.withinDuration) > strategy.maxNrOfRetries {
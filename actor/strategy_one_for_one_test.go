@@ -69,6 +69,36 @@ func TestOneForOneStrategy_requestRestartPermission(t *testing.T) {
 			expectedResult: false,
 			expectedCount:  1,
 		},
+		{
+			n: "restart and FailureCount reset when the child stayed alive for the stable period",
+
+			s:  oneForOneStrategy{maxNrOfRetries: 2, stablePeriod: time.Hour},
+			rs: RestartStatistics{failureTimes: []time.Time{time.Now().Add(-3 * time.Hour), time.Now().Add(-2 * time.Hour)}},
+
+			expectedResult: false,
+			expectedCount:  1,
+		},
+		{
+			n: "no restart when the child fails within the stable period and exceeds max retries",
+
+			s:  oneForOneStrategy{maxNrOfRetries: 1, stablePeriod: time.Hour},
+			rs: RestartStatistics{failureTimes: []time.Time{time.Now().Add(-2 * time.Hour), time.Now().Add(-time.Minute)}},
+
+			expectedResult: true,
+			expectedCount:  0,
+		},
+		{
+			n: "the stable period starts when a delayed restart happens",
+
+			s: oneForOneStrategy{maxNrOfRetries: 1, stablePeriod: time.Hour},
+			rs: RestartStatistics{
+				failureTimes:     []time.Time{time.Now().Add(-2 * time.Hour)},
+				backoffRestartAt: time.Now().Add(-time.Minute),
+			},
+
+			expectedResult: true,
+			expectedCount:  0,
+		},
 	}
 
 	for _, tc := range cases {
@@ -81,3 +111,51 @@ func TestOneForOneStrategy_requestRestartPermission(t *testing.T) {
 		})
 	}
 }
+
+func TestOneForOneStrategy_StablePeriod(t *testing.T) {
+	run := func(t *testing.T, pause time.Duration) []Directive {
+		events := make(chan *SupervisorEvent, 10)
+		system := NewActorSystem()
+		system.EventStream.Subscribe(func(evt interface{}) {
+			if e, ok := evt.(*SupervisorEvent); ok {
+				events <- e
+			}
+		})
+
+		children := make(chan *PID, 1)
+		strategy := NewOneForOneStrategy(2, 0, DefaultDecider, WithRestartStablePeriod(100*time.Millisecond))
+		system.Root.Spawn(PropsFromFunc(func(ctx Context) {
+			if _, ok := ctx.Message().(*Started); ok {
+				children <- ctx.Spawn(PropsFromProducer(func() Actor { return &panicActor{} }))
+			}
+		}, WithSupervisor(strategy)))
+
+		child := <-children
+		var directives []Directive
+		for i := 0; i < 4; i++ {
+			system.Root.Send(child, "Fail!")
+			select {
+			case e := <-events:
+				directives = append(directives, e.Directive)
+			case <-time.After(time.Second):
+				t.Fatalf("no supervisor event %v", i+1)
+			}
+			if directives[len(directives)-1] == StopDirective {
+				break
+			}
+			time.Sleep(pause)
+		}
+
+		return directives
+	}
+
+	t.Run("slow drip failures keep restarting", func(t *testing.T) {
+		directives := run(t, 200*time.Millisecond)
+		assert.Equal(t, []Directive{RestartDirective, RestartDirective, RestartDirective, RestartDirective}, directives)
+	})
+
+	t.Run("tight loop stops the child", func(t *testing.T) {
+		directives := run(t, 0)
+		assert.Equal(t, []Directive{RestartDirective, RestartDirective, StopDirective}, directives)
+	})
+}
@@ -10,6 +10,6 @@ var _ SupervisorStrategy = &restartingStrategy{}
 
 func (strategy *restartingStrategy) HandleFailure(actorSystem *ActorSystem, supervisor Supervisor, child *PID, _ *RestartStatistics, reason interface{}, _ interface{}) {
 	// always restart
-	logFailure(actorSystem, child, reason, RestartDirective)
+	logFailure(actorSystem, child, reason, RestartDirective, 0)
 	supervisor.RestartChildren(child)
 }
@@ -21,11 +21,16 @@ type Supervisor interface {
 	ResumeChildren(pids ...*PID)
 }
 
-func logFailure(actorSystem *ActorSystem, child *PID, reason interface{}, directive Directive) {
+func logFailure(actorSystem *ActorSystem, child *PID, reason interface{}, directive Directive, restartCount int) {
+	if !actorSystem.Config.SupervisionEvents {
+		return
+	}
+
 	actorSystem.EventStream.Publish(&SupervisorEvent{
-		Child:     child,
-		Reason:    reason,
-		Directive: directive,
+		Child:        child,
+		Reason:       reason,
+		Directive:    directive,
+		RestartCount: restartCount,
 	})
 }
 
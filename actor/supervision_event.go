@@ -4,17 +4,21 @@ import (
 	"github.com/asynkron/protoactor-go/log"
 )
 
-// SupervisorEvent is sent on the EventStream when a supervisor have applied a directive to a failing child actor
+// SupervisorEvent is sent on the EventStream when a supervisor have applied a directive to a failing child actor,
+// unless disabled with WithSupervisionEvents
 type SupervisorEvent struct {
 	Child     *PID
 	Reason    interface{}
 	Directive Directive
+	// RestartCount is the number of failures of the child counted by the strategy, including this one.
+	// It is zero for strategies which do not count failures
+	RestartCount int
 }
 
 func SubscribeSupervision(actorSystem *ActorSystem) {
 	_ = actorSystem.EventStream.Subscribe(func(evt interface{}) {
 		if supervisorEvent, ok := evt.(*SupervisorEvent); ok {
-			plog.Debug("[SUPERVISION]", log.Stringer("actor", supervisorEvent.Child), log.Stringer("directive", supervisorEvent.Directive), log.Object("reason", supervisorEvent.Reason))
+			actorSystem.Logger().Debug("[SUPERVISION]", log.Stringer("actor", supervisorEvent.Child), log.Stringer("directive", supervisorEvent.Directive), log.Object("reason", supervisorEvent.Reason), log.Int("restarts", supervisorEvent.RestartCount))
 		}
 	})
 }
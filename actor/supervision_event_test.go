@@ -4,6 +4,8 @@ import (
 	"sync"
 	"testing"
 	"time"
+
+	"github.com/stretchr/testify/assert"
 )
 
 type panicActor struct{}
@@ -58,3 +60,73 @@ func TestSupervisorEventHandleFromEventstream(t *testing.T) {
 		})
 	}
 }
+
+func TestSupervisorEvent_RestartCount(t *testing.T) {
+	events := make(chan *SupervisorEvent, 10)
+	sid := system.EventStream.Subscribe(func(evt interface{}) {
+		if e, ok := evt.(*SupervisorEvent); ok {
+			events <- e
+		}
+	})
+	defer system.EventStream.Unsubscribe(sid)
+
+	children := make(chan *PID, 1)
+	parent := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		if _, ok := ctx.Message().(*Started); ok {
+			children <- ctx.Spawn(PropsFromProducer(func() Actor { return &panicActor{} }))
+		}
+	}, WithSupervisor(NewOneForOneStrategy(2, time.Minute, DefaultDecider))))
+	defer rootContext.Stop(parent)
+
+	child := <-children
+	for i := 0; i < 3; i++ {
+		rootContext.Send(child, "Fail!")
+	}
+
+	for i, directive := range []Directive{RestartDirective, RestartDirective, StopDirective} {
+		select {
+		case e := <-events:
+			assert.Equal(t, child, e.Child)
+			assert.Equal(t, directive, e.Directive)
+			assert.Equal(t, i+1, e.RestartCount)
+		case <-time.After(time.Second):
+			t.Fatalf("no supervisor event %v", i+1)
+		}
+	}
+}
+
+func TestSupervisorEvent_Disabled(t *testing.T) {
+	system := NewActorSystem(WithSupervisionEvents(false))
+	events := make(chan *SupervisorEvent, 1)
+	system.EventStream.Subscribe(func(evt interface{}) {
+		if e, ok := evt.(*SupervisorEvent); ok {
+			events <- e
+		}
+	})
+
+	stopped := make(chan struct{})
+	child := PropsFromFunc(func(ctx Context) {
+		switch ctx.Message().(type) {
+		case string:
+			panic("Boom!")
+		case *Stopped:
+			close(stopped)
+		}
+	})
+	system.Root.Spawn(PropsFromFunc(func(ctx Context) {
+		if _, ok := ctx.Message().(*Started); ok {
+			ctx.Send(ctx.Spawn(child), "Fail!")
+		}
+	}, WithSupervisor(NewOneForOneStrategy(0, 0, DefaultDecider))))
+
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("child not stopped")
+	}
+	select {
+	case e := <-events:
+		t.Fatalf("unexpected supervisor event %v", e)
+	default:
+	}
+}
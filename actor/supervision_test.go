@@ -100,3 +100,47 @@ func TestActorStopsAfterXRestarts(t *testing.T) {
 	// the 11th time should cause a termination
 	e.ExpectMsg(stoppingMessage, t)
 }
+
+func TestActorRedeliversFailedMessageOnceAfterRestart(t *testing.T) {
+	m, e := NewObserver()
+	props := PropsFromProducer(func() Actor { return &failingChildActor{} },
+		WithReceiverMiddleware(m), WithOnRestartMessagePolicy(OnRestartRedeliverOnce))
+	child := rootContext.Spawn(props)
+	fail := "fail!"
+
+	e.ExpectMsg(startedMessage, t)
+	rootContext.Send(child, fail)
+	e.ExpectMsg(fail, t)
+	e.ExpectMsg(restartingMessage, t)
+	e.ExpectMsg(startedMessage, t)
+
+	// the redelivered message fails again, and is not redelivered a second time
+	e.ExpectMsg(fail, t)
+	e.ExpectMsg(restartingMessage, t)
+	e.ExpectMsg(startedMessage, t)
+	e.ExpectNoMsg(t)
+}
+
+func TestActorDeadLettersFailedMessageAfterRestart(t *testing.T) {
+	deadLetters := make(chan *DeadLetterEvent, 1)
+	sub := system.EventStream.Subscribe(func(msg interface{}) {
+		if deadLetter, ok := msg.(*DeadLetterEvent); ok && deadLetter.Message == "fail!" {
+			deadLetters <- deadLetter
+		}
+	})
+	defer system.EventStream.Unsubscribe(sub)
+
+	props := PropsFromProducer(func() Actor { return &failingChildActor{} },
+		WithOnRestartMessagePolicy(OnRestartDeadLetter))
+	child := rootContext.Spawn(props)
+	rootContext.Send(child, "fail!")
+
+	select {
+	case deadLetter := <-deadLetters:
+		if !deadLetter.PID.Equal(child) {
+			t.Errorf("Expected dead letter from %v, got %v", child, deadLetter.PID)
+		}
+	case <-time.After(time.Second):
+		t.Error("Expected the failed message to be dead lettered")
+	}
+}
@@ -0,0 +1,117 @@
+package actor
+
+import (
+	"sync"
+	"time"
+)
+
+// actorTimer is a timer set with Context.SetTimer or Context.SetRecurringTimer
+type actorTimer struct {
+	message   interface{}
+	recurring bool
+
+	mu    sync.Mutex
+	timer *time.Timer
+	// cancelled stops a recurring timer from being armed again once it fired
+	cancelled bool
+}
+
+func (t *actorTimer) cancel() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.cancelled = true
+	t.timer.Stop()
+}
+
+// timerFired is sent by a timer to its actor, which delivers the message unless the timer was cancelled or replaced.
+// It carries the timer itself, so a timer fired before being cancelled never delivers into a later timer of the same key
+type timerFired struct {
+	key   string
+	timer *actorTimer
+}
+
+func (*timerFired) SystemMessage() {}
+
+func (ctx *actorContext) SetTimer(key string, d time.Duration, message interface{}) {
+	ctx.setTimer(key, d, message, false)
+}
+
+func (ctx *actorContext) SetRecurringTimer(key string, interval time.Duration, message interface{}) {
+	if interval <= 0 {
+		panic("Interval must be greater than zero")
+	}
+
+	ctx.setTimer(key, interval, message, true)
+}
+
+func (ctx *actorContext) CancelTimer(key string) {
+	if ctx.extras == nil {
+		return
+	}
+
+	if t, ok := ctx.extras.timers[key]; ok {
+		t.cancel()
+		delete(ctx.extras.timers, key)
+	}
+}
+
+func (ctx *actorContext) setTimer(key string, d time.Duration, message interface{}, recurring bool) {
+	extras := ctx.ensureExtras()
+	if extras.timers == nil {
+		extras.timers = make(map[string]*actorTimer)
+	}
+
+	if previous, ok := extras.timers[key]; ok {
+		previous.cancel()
+	}
+
+	t := &actorTimer{
+		message:   message,
+		recurring: recurring,
+	}
+	fired := &timerFired{key: key, timer: t}
+	system, self := ctx.actorSystem, ctx.self
+	t.mu.Lock()
+	t.timer = time.AfterFunc(d, func() {
+		self.sendSystemMessage(system, fired)
+		if recurring {
+			t.mu.Lock()
+			if !t.cancelled {
+				t.timer.Reset(d)
+			}
+			t.mu.Unlock()
+		}
+	})
+	t.mu.Unlock()
+	extras.timers[key] = t
+}
+
+func (ctx *actorContext) handleTimerFired(msg *timerFired) {
+	if ctx.extras == nil {
+		return
+	}
+
+	t, ok := ctx.extras.timers[msg.key]
+	if !ok || t != msg.timer {
+		// cancelled or replaced since it fired
+		return
+	}
+	if !t.recurring {
+		delete(ctx.extras.timers, msg.key)
+	}
+
+	ctx.InvokeUserMessage(t.message)
+}
+
+// cancelTimers cancels all the timers of the actor, when it stops or restarts
+func (ctx *actorContext) cancelTimers() {
+	if ctx.extras == nil {
+		return
+	}
+
+	for _, t := range ctx.extras.timers {
+		t.cancel()
+	}
+	ctx.extras.timers = nil
+}
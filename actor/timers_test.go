@@ -0,0 +1,113 @@
+package actor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestActorContext_SetTimer(t *testing.T) {
+	received := make(chan string, 10)
+	pid := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		switch msg := ctx.Message().(type) {
+		case *Started:
+			ctx.SetTimer("replaced", 10*time.Millisecond, "first")
+			ctx.SetTimer("replaced", 20*time.Millisecond, "second")
+			ctx.SetTimer("cancelled", 10*time.Millisecond, "cancelled")
+			ctx.CancelTimer("cancelled")
+		case string:
+			received <- msg
+		}
+	}))
+	defer rootContext.Stop(pid)
+
+	assert.Equal(t, "second", <-received)
+	select {
+	case msg := <-received:
+		t.Fatalf("unexpected timer message %s", msg)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestActorContext_SetRecurringTimer(t *testing.T) {
+	ticks := make(chan struct{}, 100)
+	pid := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		switch ctx.Message().(type) {
+		case *Started:
+			ctx.SetRecurringTimer("tick", 5*time.Millisecond, "tick")
+		case string:
+			ticks <- struct{}{}
+		}
+	}))
+
+	for i := 0; i < 3; i++ {
+		select {
+		case <-ticks:
+		case <-time.After(time.Second):
+			t.Fatal("recurring timer did not fire")
+		}
+	}
+
+	// stopping the actor cancels its timers
+	_ = rootContext.StopFuture(pid).Wait()
+	time.Sleep(20 * time.Millisecond)
+	for len(ticks) > 0 {
+		<-ticks
+	}
+	time.Sleep(20 * time.Millisecond)
+	assert.Empty(t, ticks)
+}
+
+func TestActorContext_TimersCancelledOnRestart(t *testing.T) {
+	received := make(chan string, 10)
+	started := 0
+	pid := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		switch msg := ctx.Message().(type) {
+		case *Started:
+			started++
+			if started == 1 {
+				ctx.SetTimer("before restart", 30*time.Millisecond, "before restart")
+			}
+		case string:
+			if msg == "fail" {
+				panic("restart")
+			}
+			received <- msg
+		}
+	}))
+	defer rootContext.Stop(pid)
+
+	rootContext.Send(pid, "fail")
+	rootContext.Send(pid, "after restart")
+	assert.Equal(t, "after restart", <-received)
+
+	select {
+	case msg := <-received:
+		t.Fatalf("unexpected timer message %s", msg)
+	case <-time.After(60 * time.Millisecond):
+	}
+}
+
+func TestActorContext_StaleTimerAfterCancel(t *testing.T) {
+	received := make(chan string, 10)
+	pid := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		switch msg := ctx.Message().(type) {
+		case *Started:
+			// the first timer fires while the actor is busy, before it is cancelled
+			ctx.SetTimer("key", time.Millisecond, "cancelled")
+			time.Sleep(20 * time.Millisecond)
+			ctx.CancelTimer("key")
+			ctx.SetTimer("key", time.Hour, "later")
+		case string:
+			received <- msg
+		}
+	}))
+	defer rootContext.Stop(pid)
+
+	select {
+	case msg := <-received:
+		t.Fatalf("unexpected timer message %s", msg)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
@@ -0,0 +1,79 @@
+package actor
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// TypedPID is a PID of an actor which accepts messages of type T.
+// It is a compile time guarantee only, the wire format is the one of the underlying PID
+type TypedPID[T any] struct {
+	PID *PID
+}
+
+// NewTypedPID wraps a PID of an actor which accepts messages of type T
+func NewTypedPID[T any](pid *PID) *TypedPID[T] {
+	return &TypedPID[T]{PID: pid}
+}
+
+// String returns the string representation of the underlying PID
+func (pid *TypedPID[T]) String() string {
+	return pid.PID.String()
+}
+
+// Send sends a message of the type accepted by the actor
+func Send[T any](ctx SenderContext, pid *TypedPID[T], message T) {
+	ctx.Send(pid.PID, message)
+}
+
+// Request sends a message of the type accepted by the actor and returns a future for a response of type TResp
+func Request[TReq any, TResp any](ctx SenderContext, pid *TypedPID[TReq], message TReq, timeout time.Duration) *TypedFuture[TResp] {
+	return &TypedFuture[TResp]{future: ctx.RequestFuture(pid.PID, message, timeout)}
+}
+
+// UnexpectedResponseError is the error of a TypedFuture which received a response of another type than expected
+type UnexpectedResponseError struct {
+	Expected reflect.Type
+	Response interface{}
+}
+
+func (e *UnexpectedResponseError) Error() string {
+	return fmt.Sprintf("future: expected response of type %v, got %T", e.Expected, e.Response)
+}
+
+// TypedFuture is a Future resolving with a response of type T
+type TypedFuture[T any] struct {
+	future *Future
+}
+
+// Future returns the underlying untyped Future
+func (f *TypedFuture[T]) Future() *Future {
+	return f.future
+}
+
+// PID to the backing actor for the Future result.
+func (f *TypedFuture[T]) PID() *PID {
+	return f.future.PID()
+}
+
+// Result waits for the future to resolve.
+// A response of another type than T resolves with an *UnexpectedResponseError
+func (f *TypedFuture[T]) Result() (T, error) {
+	var zero T
+
+	res, err := f.future.Result()
+	if err != nil {
+		return zero, err
+	}
+
+	typed, ok := res.(T)
+	if !ok {
+		return zero, &UnexpectedResponseError{
+			Expected: reflect.TypeOf((*T)(nil)).Elem(),
+			Response: res,
+		}
+	}
+
+	return typed, nil
+}
@@ -0,0 +1,41 @@
+package actor
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type typedPing struct{ Value int }
+
+type typedPong struct{ Value int }
+
+func TestTypedRequest(t *testing.T) {
+	pid := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		if msg, ok := ctx.Message().(*typedPing); ok {
+			ctx.Respond(&typedPong{Value: msg.Value})
+		}
+	}))
+	defer rootContext.Stop(pid)
+
+	res, err := Request[*typedPing, *typedPong](rootContext, NewTypedPID[*typedPing](pid), &typedPing{Value: 42}, testTimeout).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, 42, res.Value)
+}
+
+func TestTypedRequest_UnexpectedResponse(t *testing.T) {
+	pid := rootContext.Spawn(PropsFromFunc(func(ctx Context) {
+		if _, ok := ctx.Message().(*typedPing); ok {
+			ctx.Respond("not a pong")
+		}
+	}))
+	defer rootContext.Stop(pid)
+
+	res, err := Request[*typedPing, *typedPong](rootContext, NewTypedPID[*typedPing](pid), &typedPing{}, testTimeout).Result()
+	assert.Nil(t, res)
+
+	var unexpected *UnexpectedResponseError
+	assert.True(t, errors.As(err, &unexpected))
+	assert.Equal(t, "not a pong", unexpected.Response)
+}
@@ -1,9 +1,11 @@
 package cluster
 
 import (
+	"fmt"
 	"time"
 
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 
 	"github.com/asynkron/gofun/set"
 
@@ -66,6 +68,13 @@ func (c *Cluster) subscribeToTopologyEvents() {
 	})
 }
 
+// OnTopologyChanged registers fn to be called with the members which joined and left the cluster, and the current
+// members, once the member list has settled for Config.TopologyChangeDebounce. Members joining and leaving again
+// within that period are not reported. The callbacks are called one at a time, outside of the actor system
+func (c *Cluster) OnTopologyChanged(fn func(topology *ClusterTopology)) {
+	c.MemberList.topologyHooks.add(fn)
+}
+
 func (c *Cluster) ExtensionID() extensions.ExtensionID {
 	return extensionID
 }
@@ -91,7 +100,7 @@ func (c *Cluster) StartMember() {
 	c.Remote.Start()
 
 	address := c.ActorSystem.Address()
-	plog.Info("Starting Proto.Actor cluster member", log.String("id", c.ActorSystem.ID), log.String("address", address))
+	c.Logger().Info("Starting Proto.Actor cluster member", log.String("id", c.ActorSystem.ID), log.String("address", address))
 
 	c.IdentityLookup = cfg.IdentityLookup
 	c.IdentityLookup.Setup(c, c.GetClusterKinds(), false)
@@ -101,6 +110,9 @@ func (c *Cluster) StartMember() {
 	if err := c.Gossip.StartGossiping(); err != nil {
 		panic(err)
 	}
+	if cfg.MemberWeight > 1 {
+		_ = c.Gossip.SetState(WeightKey, wrapperspb.Int32(int32(cfg.MemberWeight)))
+	}
 	c.PubSub.Start()
 	c.MemberList.InitializeTopologyConsensus()
 
@@ -127,7 +139,7 @@ func (c *Cluster) StartClient() {
 	c.Remote.Start()
 
 	address := c.ActorSystem.Address()
-	plog.Info("Starting Proto.Actor cluster-client", log.String("address", address))
+	c.Logger().Info("Starting Proto.Actor cluster-client", log.String("address", address))
 
 	c.IdentityLookup = cfg.IdentityLookup
 	c.IdentityLookup.Setup(c, c.GetClusterKinds(), true)
@@ -139,7 +151,7 @@ func (c *Cluster) StartClient() {
 }
 
 func (c *Cluster) Shutdown(graceful bool) {
-	c.Gossip.SetState(GracefullyLeftKey, &emptypb.Empty{})
+	_ = c.Gossip.SetState(GracefullyLeftKey, &emptypb.Empty{})
 	c.ActorSystem.Shutdown()
 	if graceful {
 		_ = c.Config.ClusterProvider.Shutdown(graceful)
@@ -154,7 +166,7 @@ func (c *Cluster) Shutdown(graceful bool) {
 	c.Remote.Shutdown(graceful)
 
 	address := c.ActorSystem.Address()
-	plog.Info("Stopped Proto.Actor cluster", log.String("address", address))
+	c.Logger().Info("Stopped Proto.Actor cluster", log.String("address", address))
 }
 
 func (c *Cluster) Get(identity string, kind string) *actor.PID {
@@ -168,7 +180,7 @@ func (c *Cluster) Request(identity string, kind string, message interface{}) (in
 func (c *Cluster) GetClusterKind(kind string) *ActivatedKind {
 	k, ok := c.kinds[kind]
 	if !ok {
-		plog.Error("Invalid kind", log.String("kind", kind))
+		c.Logger().Error("Invalid kind", log.String("kind", kind))
 
 		return nil
 	}
@@ -208,11 +220,32 @@ func (c *Cluster) ensureTopicKindRegistered() {
 	}
 }
 
+// CallRetriesExhaustedError is returned by Call when the grain could not be reached within the retries and the
+// total timeout of the call, as opposed to an error returned by the grain itself
+type CallRetriesExhaustedError struct {
+	Identity string
+	Kind     string
+	Attempts int
+	// LastErr is the error of the last attempt, remote.ErrUnknownError if the grain had no placement
+	LastErr error
+}
+
+func (e *CallRetriesExhaustedError) Error() string {
+	return fmt.Sprintf("call to %s/%s failed after %d attempts: %v", e.Kind, e.Identity, e.Attempts, e.LastErr)
+}
+
+func (e *CallRetriesExhaustedError) Unwrap() error {
+	return e.LastErr
+}
+
 // Call is a wrap of context.RequestFuture with retries.
+// A grain without placement, gone or not responding, e.g. while it is relocated, is looked up again and retried,
+// other errors are returned as is. A *CallRetriesExhaustedError is returned once the retries are exhausted
 func (c *Cluster) Call(name string, kind string, msg interface{}, opts ...GrainCallOption) (interface{}, error) {
-	callConfig := DefaultGrainCallConfig(c)
+	// options apply to this call only
+	callConfig := *DefaultGrainCallConfig(c)
 	for _, o := range opts {
-		o(callConfig)
+		o(&callConfig)
 	}
 
 	_context := callConfig.Context
@@ -220,34 +253,52 @@ func (c *Cluster) Call(name string, kind string, msg interface{}, opts ...GrainC
 		_context = c.ActorSystem.Root
 	}
 
-	var lastError error
+	var deadline time.Time
+	if callConfig.TotalTimeout > 0 {
+		deadline = time.Now().Add(callConfig.TotalTimeout)
+	}
 
-	for i := 0; i < callConfig.RetryCount; i++ {
-		pid := c.Get(name, kind)
+	id := &ClusterIdentity{Kind: kind, Identity: name}
+	var lastError error = remote.ErrUnknownError
+	attempts := 0
 
+	for attempts < callConfig.RetryCount {
+		timeout := callConfig.Timeout
+		if !deadline.IsZero() {
+			remaining := time.Until(deadline)
+			if remaining <= 0 {
+				break
+			}
+			if remaining < timeout {
+				timeout = remaining
+			}
+		}
+
+		attempts++
+		pid := c.Get(name, kind)
 		if pid == nil {
-			return nil, remote.ErrUnknownError
+			lastError = remote.ErrUnknownError
+			callConfig.RetryAction(attempts - 1)
+
+			continue
 		}
 
-		timeout := callConfig.Timeout
 		_resp, err := _context.RequestFuture(pid, msg, timeout).Result()
 		if err != nil {
-			plog.Error("cluster.RequestFuture failed", log.Error(err), log.PID("pid", pid))
+			c.Logger().Error("cluster.RequestFuture failed", log.Error(err), log.PID("pid", pid))
 			lastError = err
 
 			switch err {
 			case actor.ErrTimeout, remote.ErrTimeout:
-				callConfig.RetryAction(i)
-
-				id := ClusterIdentity{Kind: kind, Identity: name}
-				c.PidCache.Remove(id.Identity, id.Kind)
+				c.PidCache.RemoveByValue(id.Identity, id.Kind, pid)
+				callConfig.RetryAction(attempts - 1)
 
 				continue
 			case actor.ErrDeadLetter, remote.ErrDeadLetter:
-				callConfig.RetryAction(i)
-
-				id := ClusterIdentity{Kind: kind, Identity: name}
-				c.PidCache.Remove(id.Identity, id.Kind)
+				// the activation is gone, the lookup has to place the grain again
+				c.PidCache.RemoveByValue(id.Identity, id.Kind, pid)
+				c.IdentityLookup.RemovePid(id, pid)
+				callConfig.RetryAction(attempts - 1)
 
 				continue
 			default:
@@ -258,5 +309,5 @@ func (c *Cluster) Call(name string, kind string, msg interface{}, opts ...GrainC
 		return _resp, nil
 	}
 
-	return nil, lastError
+	return nil, &CallRetriesExhaustedError{Identity: name, Kind: kind, Attempts: attempts, LastErr: lastError}
 }
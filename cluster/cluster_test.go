@@ -1,6 +1,7 @@
 package cluster
 
 import (
+	"errors"
 	"fmt"
 	"sync"
 	"testing"
@@ -92,7 +93,7 @@ func (l *fakeIdentityLookup) Get(identity *ClusterIdentity) *actor.PID {
 }
 
 func (l *fakeIdentityLookup) RemovePid(identity *ClusterIdentity, pid *actor.PID) {
-	if existPid := l.Get(identity); existPid.Equal(pid) {
+	if existPid := l.Get(identity); existPid != nil && existPid.Equal(pid) {
 		l.m.Delete(identity.Identity)
 	}
 }
@@ -165,6 +166,109 @@ func TestCluster_Call(t *testing.T) {
 	// t.Fatalf("need more testcases for cluster.Call")
 }
 
+// relocatingLookup places a grain on its first pid, and on the next one once the pid is reported gone
+type relocatingLookup struct {
+	mu   sync.Mutex
+	pids []*actor.PID
+}
+
+func (l *relocatingLookup) Get(*ClusterIdentity) *actor.PID {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.pids) == 0 {
+		return nil
+	}
+
+	return l.pids[0]
+}
+
+func (l *relocatingLookup) RemovePid(_ *ClusterIdentity, pid *actor.PID) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.pids) > 0 && l.pids[0].Equal(pid) {
+		l.pids = l.pids[1:]
+	}
+}
+
+func (l *relocatingLookup) Setup(*Cluster, []string, bool) {}
+
+func (l *relocatingLookup) Shutdown() {}
+
+func TestCluster_Call_RetriesAcrossRelocation(t *testing.T) {
+	c := newClusterForTest("mycluster", nil)
+	root := c.ActorSystem.Root
+	noWait := WithRetryAction(func(int) {})
+
+	grain := root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+		switch msg := ctx.Message().(type) {
+		case string:
+			if msg == "fail" {
+				ctx.Respond(&GrainErrorResponse{Err: "failed"})
+			} else {
+				ctx.Respond(msg)
+			}
+		}
+	}))
+	relocated := root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {}))
+	_ = root.StopFuture(relocated).Wait()
+
+	t.Run("relocated grain", func(t *testing.T) {
+		c.IdentityLookup = &relocatingLookup{pids: []*actor.PID{relocated, grain}}
+		resp, err := c.Call("name", "kind", "hello", WithRetry(3), noWait)
+		assert.NoError(t, err)
+		assert.Equal(t, "hello", resp)
+	})
+
+	t.Run("grain error", func(t *testing.T) {
+		c.IdentityLookup = &relocatingLookup{pids: []*actor.PID{grain}}
+		resp, err := c.Call("name", "kind", "fail", WithRetry(3), noWait)
+		assert.NoError(t, err)
+		assert.Equal(t, &GrainErrorResponse{Err: "failed"}, resp)
+	})
+
+	t.Run("retries exhausted", func(t *testing.T) {
+		c.IdentityLookup = &relocatingLookup{}
+		_, err := c.Call("name", "kind", "hello", WithRetry(3), noWait)
+		var exhausted *CallRetriesExhaustedError
+		assert.True(t, errors.As(err, &exhausted))
+		assert.Equal(t, 3, exhausted.Attempts)
+		assert.Equal(t, remote.ErrUnknownError, exhausted.LastErr)
+	})
+
+	t.Run("total timeout", func(t *testing.T) {
+		c.IdentityLookup = &relocatingLookup{}
+		start := time.Now()
+		_, err := c.Call("name", "kind", "hello", WithRetry(1000), WithTotalTimeout(100*time.Millisecond),
+			WithRetryAction(func(int) { time.Sleep(10 * time.Millisecond) }))
+		var exhausted *CallRetriesExhaustedError
+		assert.True(t, errors.As(err, &exhausted))
+		assert.Less(t, exhausted.Attempts, 1000)
+		assert.Less(t, time.Since(start), time.Second)
+	})
+}
+
+func TestCluster_CallGrain(t *testing.T) {
+	c := newClusterForTest("mycluster", nil)
+	grain := c.ActorSystem.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+		if msg, ok := ctx.Message().(*GrainRequest); ok {
+			if msg.MethodIndex == 0 {
+				ctx.Respond(&GrainResponse{MessageData: msg.MessageData})
+			} else {
+				ctx.Respond(&GrainErrorResponse{Err: "unknown method"})
+			}
+		}
+	}))
+	c.IdentityLookup = &relocatingLookup{pids: []*actor.PID{grain}}
+
+	var caller GrainCaller = c
+	resp, err := caller.CallGrain("name", "kind", 0, []byte("request"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("request"), resp)
+
+	_, err = caller.CallGrain("name", "kind", 1, []byte("request"))
+	assert.EqualError(t, err, "unknown method")
+}
+
 func TestCluster_Get(t *testing.T) {
 	t.Skipf("Maintaining")
 	cp := newInmemoryProvider()
@@ -0,0 +1,64 @@
+package cluster_test_tool
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/asynkron/protoactor-go/cluster"
+	"github.com/stretchr/testify/suite"
+)
+
+const PassivatingKind = "passivating"
+
+type PassivationTestSuite struct {
+	suite.Suite
+	fixture     *BaseClusterFixture
+	activations int32
+	stops       int32
+}
+
+func (suite *PassivationTestSuite) SetupTest() {
+	atomic.StoreInt32(&suite.activations, 0)
+	atomic.StoreInt32(&suite.stops, 0)
+
+	props := actor.PropsFromFunc(func(context actor.Context) {
+		switch context.Message().(type) {
+		case *actor.Started:
+			atomic.AddInt32(&suite.activations, 1)
+		case *actor.Stopped:
+			atomic.AddInt32(&suite.stops, 1)
+		case *DataPublished:
+			context.Respond(&cluster.Acknowledge{})
+		}
+	})
+
+	suite.fixture = NewBaseClusterFixture(1, WithGetClusterKinds(func() []*cluster.Kind {
+		return []*cluster.Kind{cluster.NewKind(PassivatingKind, props).WithPassivation(200 * time.Millisecond)}
+	}))
+	suite.fixture.Initialize()
+}
+
+func (suite *PassivationTestSuite) TearDownTest() {
+	suite.fixture.ShutDown()
+}
+
+func (suite *PassivationTestSuite) TestIdleGrainIsDeactivatedAndReactivated() {
+	member := suite.fixture.GetMembers()[0]
+
+	_, err := member.Call("grain-1", PassivatingKind, &DataPublished{Data: 1})
+	suite.Assert().NoError(err)
+
+	WaitUntil(suite.T(), func() bool {
+		return atomic.LoadInt32(&suite.stops) == 1
+	}, "idle grain should be deactivated", DefaultWaitTimeout)
+
+	_, err = member.Call("grain-1", PassivatingKind, &DataPublished{Data: 2})
+	suite.Assert().NoError(err)
+	suite.Assert().Equal(int32(2), atomic.LoadInt32(&suite.activations))
+}
+
+func TestPassivationTestSuite(t *testing.T) {
+	suite.Run(t, new(PassivationTestSuite))
+}
@@ -194,6 +194,34 @@ func (suite *PubSubTestSuite) TestStoppedActorThatDidNotUnsubscribeDoesNotBlockP
 	}, "pid2 should be removed from subscriber store", DefaultWaitTimeout*1000)
 }
 
+func (suite *PubSubTestSuite) TestPublishResponseReportsFailedDeliveries() {
+	const topic = "failed-delivery-report"
+
+	props := actor.PropsFromFunc(func(context actor.Context) {})
+	member := suite.fixture.GetMembers()[0]
+	pid1 := member.ActorSystem.Root.Spawn(props)
+	pid2 := member.ActorSystem.Root.Spawn(props)
+
+	_, err := member.SubscribeByPid(topic, pid1)
+	suite.Assert().NoError(err, "SubscribeByPid1 should not has error")
+	_, err = member.SubscribeByPid(topic, pid2)
+	suite.Assert().NoError(err, "SubscribeByPid2 should not has error")
+
+	res, err := member.Publish(topic, &DataPublished{Data: 1})
+	suite.Assert().NoError(err, "Publish should not has error")
+	suite.Assert().Equal(cluster.PublishStatus_Ok, res.Status)
+	suite.Assert().Empty(res.FailedDeliveries)
+
+	_ = member.ActorSystem.Root.StopFuture(pid2).Wait()
+
+	res, err = member.Publish(topic, &DataPublished{Data: 2})
+	suite.Assert().NoError(err, "Publish should not has error")
+	suite.Assert().Equal(cluster.PublishStatus_Failed, res.Status)
+	suite.Assert().Len(res.FailedDeliveries, 1)
+	suite.Assert().Equal(pid2.Id, res.FailedDeliveries[0].Subscriber.GetPid().Id)
+	suite.Assert().Equal(cluster.DeliveryStatus_SubscriberNoLongerReachable, res.FailedDeliveries[0].Status)
+}
+
 func (suite *PubSubTestSuite) TestSlowPidSubscriberThatTimesOutDoesNotPreventSubsequentPublishes() {
 	const topic = "slow-pid-subscriber"
 	var deliveryCount int32 = 0
@@ -0,0 +1,126 @@
+package cluster_test_tool
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/asynkron/protoactor-go/cluster"
+	"github.com/stretchr/testify/suite"
+)
+
+type SingletonTestSuite struct {
+	suite.Suite
+	fixture *BaseClusterFixture
+
+	mu    sync.Mutex
+	hosts map[string]int
+}
+
+func (suite *SingletonTestSuite) SetupTest() {
+	suite.hosts = map[string]int{}
+	suite.fixture = NewBaseInMemoryClusterFixture(3)
+	suite.fixture.Initialize()
+}
+
+func (suite *SingletonTestSuite) TearDownTest() {
+	suite.fixture.ShutDown()
+}
+
+func (suite *SingletonTestSuite) props() *actor.Props {
+	return actor.PropsFromFunc(func(context actor.Context) {
+		switch context.Message().(type) {
+		case *actor.Started:
+			suite.mu.Lock()
+			suite.hosts[context.ActorSystem().Address()]++
+			suite.mu.Unlock()
+		case *actor.Stopped:
+			suite.mu.Lock()
+			suite.hosts[context.ActorSystem().Address()]--
+			suite.mu.Unlock()
+		case *DataPublished:
+			context.Respond(&cluster.Acknowledge{})
+		}
+	})
+}
+
+// activeHosts returns the addresses currently hosting the singleton
+func (suite *SingletonTestSuite) activeHosts() []string {
+	suite.mu.Lock()
+	defer suite.mu.Unlock()
+
+	var hosts []string
+	for address, count := range suite.hosts {
+		if count > 0 {
+			hosts = append(hosts, address)
+		}
+	}
+
+	return hosts
+}
+
+func (suite *SingletonTestSuite) register(member *cluster.Cluster) *actor.PID {
+	pid, err := member.Singleton("coordinator", suite.props())
+	suite.Require().NoError(err)
+
+	return pid
+}
+
+func (suite *SingletonTestSuite) assertReachable(member *cluster.Cluster, pid *actor.PID) {
+	res, err := member.ActorSystem.Root.RequestFuture(pid, &DataPublished{Data: 1}, 5*time.Second).Result()
+	suite.Require().NoError(err)
+	suite.Assert().IsType(&cluster.Acknowledge{}, res)
+}
+
+func (suite *SingletonTestSuite) TestSingleActivationReachableFromEveryMember() {
+	members := suite.fixture.GetMembers()
+	pids := make([]*actor.PID, len(members))
+	for i, member := range members {
+		pids[i] = suite.register(member)
+	}
+
+	for i, member := range members {
+		suite.assertReachable(member, pids[i])
+	}
+	suite.Assert().Len(suite.activeHosts(), 1)
+}
+
+func (suite *SingletonTestSuite) TestSingletonMovesWhenHostLeaves() {
+	members := suite.fixture.GetMembers()
+	pids := make(map[*cluster.Cluster]*actor.PID, len(members))
+	for _, member := range members {
+		pids[member] = suite.register(member)
+	}
+	suite.assertReachable(members[0], pids[members[0]])
+
+	hosts := suite.activeHosts()
+	suite.Require().Len(hosts, 1)
+	previous := hosts[0]
+	for _, member := range members {
+		if member.ActorSystem.Address() == previous {
+			suite.fixture.RemoveNode(member, true)
+			delete(pids, member)
+		}
+	}
+
+	// actors of the removed member are not stopped on shutdown, so only the remaining members are counted
+	WaitUntil(suite.T(), func() bool {
+		remaining := 0
+		for _, host := range suite.activeHosts() {
+			if host != previous {
+				remaining++
+			}
+		}
+
+		return remaining == 1
+	}, "singleton should be hosted by a remaining member", DefaultWaitTimeout)
+
+	for member, pid := range pids {
+		suite.assertReachable(member, pid)
+	}
+}
+
+func TestSingletonTestSuite(t *testing.T) {
+	suite.Run(t, new(SingletonTestSuite))
+}
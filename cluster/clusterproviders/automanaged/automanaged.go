@@ -45,6 +45,7 @@ type AutoManagedProvider struct {
 	clusterTTLError       error
 	clusterMonitorError   error
 	cluster               *cluster.Cluster
+	logger                log.Interface
 }
 
 // New creates a AutoManagedProvider that connects locally
@@ -82,6 +83,7 @@ func NewWithConfig(refreshTTL time.Duration, autoManPort int, hosts ...string) *
 		autoManagePort:        autoManPort,
 		activeProviderRunning: false,
 		monitoringStatus:      false,
+		logger:                plog,
 	}
 
 	return p
@@ -108,6 +110,7 @@ func (p *AutoManagedProvider) init(cluster *cluster.Cluster) error {
 	p.deregistered = false
 	p.shutdown = false
 	p.cluster = cluster
+	p.logger = cluster.Logger()
 	return nil
 }
 
@@ -179,7 +182,7 @@ func (p *AutoManagedProvider) UpdateTTL() {
 		activeProviderRunningMutex.Unlock()
 
 		appURI := fmt.Sprintf("0.0.0.0:%d", p.autoManagePort)
-		plog.Error("Automanaged server stopping..!", log.Error(p.activeProvider.Start(appURI)))
+		p.logger.Error("Automanaged server stopping..!", log.Error(p.activeProvider.Start(appURI)))
 
 		activeProviderRunningMutex.Lock()
 		p.activeProviderRunning = false
@@ -233,7 +236,7 @@ func (p *AutoManagedProvider) monitorStatuses() {
 
 	autoManagedNodes, err := p.checkNodes()
 	if err != nil && len(autoManagedNodes) == 0 {
-		plog.Error("Failure reaching nodes", log.Error(err))
+		p.logger.Error("Failure reaching nodes", log.Error(err))
 		p.clusterMonitorError = err
 		time.Sleep(p.refreshTTL)
 		return
@@ -275,13 +278,13 @@ func (p *AutoManagedProvider) checkNodes() ([]*NodeModel, error) {
 			url := fmt.Sprintf("http://%s/_health", el)
 			req, err := http.NewRequest("GET", url, nil)
 			if err != nil {
-				plog.Error("Couldn't request node health status", log.Error(err), log.String("autoManMemberUrl", url))
+				p.logger.Error("Couldn't request node health status", log.Error(err), log.String("autoManMemberUrl", url))
 				return err
 			}
 
 			resp, err := p.httpClient.Do(req)
 			if err != nil {
-				plog.Error("Bad connection to the node health status", log.Error(err), log.String("autoManMemberUrl", url))
+				p.logger.Error("Bad connection to the node health status", log.Error(err), log.String("autoManMemberUrl", url))
 				return err
 			}
 
@@ -289,7 +292,7 @@ func (p *AutoManagedProvider) checkNodes() ([]*NodeModel, error) {
 
 			if resp.StatusCode != http.StatusOK {
 				err = fmt.Errorf("non 200 status returned: %d - from node: %s", resp.StatusCode, el)
-				plog.Error("Bad response from the node health status", log.Error(err), log.String("autoManMemberUrl", url))
+				p.logger.Error("Bad response from the node health status", log.Error(err), log.String("autoManMemberUrl", url))
 				return err
 			}
 
@@ -297,7 +300,7 @@ func (p *AutoManagedProvider) checkNodes() ([]*NodeModel, error) {
 			err = json.NewDecoder(resp.Body).Decode(&node)
 			if err != nil {
 				err = fmt.Errorf("could not deserialize response: %v - from node: %s", resp, el)
-				plog.Error("Bad data from the node health status", log.Error(err), log.String("autoManMemberUrl", url))
+				p.logger.Error("Bad data from the node health status", log.Error(err), log.String("autoManMemberUrl", url))
 				return err
 			}
 
@@ -348,7 +351,7 @@ func (p *AutoManagedProvider) startActiveProvider() {
 			p.activeProviderRunning = true
 			activeProviderRunningMutex.Unlock()
 
-			plog.Error("Automanaged server stopping..!", log.Error(p.activeProvider.Start(appURI)))
+			p.logger.Error("Automanaged server stopping..!", log.Error(p.activeProvider.Start(appURI)))
 
 			activeProviderRunningMutex.Lock()
 			p.activeProviderRunning = false
@@ -33,6 +33,7 @@ type Provider struct {
 	clusterError       error
 	pid                *actor.PID
 	consulConfig       *api.Config
+	logger             log.Interface
 }
 
 func New(opts ...Option) (*Provider, error) {
@@ -51,6 +52,7 @@ func NewWithConfig(consulConfig *api.Config, opts ...Option) (*Provider, error)
 		deregisterCritical: 60 * time.Second,
 		blockingWaitTime:   20 * time.Second,
 		consulConfig:       consulConfig,
+		logger:             plog,
 	}
 	for _, opt := range opts {
 		opt(p)
@@ -69,6 +71,7 @@ func (p *Provider) init(c *cluster.Cluster) error {
 	}
 
 	p.cluster = c
+	p.logger = c.Logger()
 	p.id = memberId
 	p.clusterName = clusterName
 	p.address = host
@@ -87,7 +90,7 @@ func (p *Provider) StartMember(c *cluster.Cluster) error {
 		return newProviderActor(p)
 	}), "consul-provider")
 	if err != nil {
-		plog.Error("Failed to start consul-provider actor", log.Error(err))
+		p.logger.Error("Failed to start consul-provider actor", log.Error(err))
 		return err
 	}
 
@@ -120,7 +123,7 @@ func (p *Provider) Shutdown(graceful bool) error {
 	p.shutdown = true
 	if p.pid != nil {
 		if err := p.cluster.ActorSystem.Root.StopFuture(p.pid).Wait(); err != nil {
-			plog.Error("Failed to stop consul-provider actor", log.Error(err))
+			p.logger.Error("Failed to stop consul-provider actor", log.Error(err))
 		}
 		p.pid = nil
 	}
@@ -165,10 +168,10 @@ func (p *Provider) notifyStatuses() {
 		WaitIndex: p.index,
 		WaitTime:  p.blockingWaitTime,
 	})
-	plog.Info("Consul health check")
+	p.logger.Info("Consul health check")
 
 	if err != nil {
-		plog.Error("notifyStatues", log.Error(err))
+		p.logger.Error("notifyStatues", log.Error(err))
 		return
 	}
 	p.index = meta.LastIndex
@@ -179,7 +182,7 @@ func (p *Provider) notifyStatuses() {
 			memberId := v.Service.Meta["id"]
 			if memberId == "" {
 				memberId = fmt.Sprintf("%v@%v:%v", p.clusterName, v.Service.Address, v.Service.Port)
-				plog.Info("meta['id'] was empty, fixeds", log.String("id", memberId))
+				p.logger.Info("meta['id'] was empty, fixeds", log.String("id", memberId))
 			}
 			members = append(members, &cluster.Member{
 				Id:    memberId,
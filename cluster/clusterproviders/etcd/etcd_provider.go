@@ -30,6 +30,7 @@ type Provider struct {
 	keepAliveTTL  time.Duration
 	retryInterval time.Duration
 	revision      uint64
+	logger        log.Interface
 	// deregisterCritical time.Duration
 }
 
@@ -52,12 +53,14 @@ func NewWithConfig(baseKey string, cfg clientv3.Config) (*Provider, error) {
 		baseKey:       baseKey,
 		members:       map[string]*Node{},
 		cancelWatchCh: make(chan bool),
+		logger:        plog,
 	}
 	return p, nil
 }
 
 func (p *Provider) init(c *cluster.Cluster) error {
 	p.cluster = c
+	p.logger = c.Logger()
 	addr := p.cluster.ActorSystem.Address()
 	host, port, err := splitHostPort(addr)
 	if err != nil {
@@ -118,7 +121,7 @@ func (p *Provider) Shutdown(graceful bool) error {
 	if !p.deregistered {
 		err := p.deregisterService()
 		if err != nil {
-			plog.Error("deregisterMember", log.Error(err))
+			p.logger.Error("deregisterMember", log.Error(err))
 			return err
 		}
 		p.deregistered = true
@@ -181,12 +184,12 @@ func (p *Provider) startKeepAlive(ctx context.Context) {
 	go func() {
 		for !p.shutdown {
 			if err := ctx.Err(); err != nil {
-				plog.Info("Keepalive was stopped.", log.Error(err))
+				p.logger.Info("Keepalive was stopped.", log.Error(err))
 				return
 			}
 
 			if err := p.keepAliveForever(ctx); err != nil {
-				plog.Info("Failure refreshing service TTL. ReTrying...", log.Duration("after", p.retryInterval))
+				p.logger.Info("Failure refreshing service TTL. ReTrying...", log.Duration("after", p.retryInterval))
 				time.Sleep(p.retryInterval)
 			}
 		}
@@ -237,7 +240,7 @@ func (p *Provider) handleWatchResponse(resp clientv3.WatchResponse) map[string]*
 		key := string(ev.Kv.Key)
 		nodeId, err := getNodeID(key, "/")
 		if err != nil {
-			plog.Error("Invalid member.", log.String("key", key))
+			p.logger.Error("Invalid member.", log.String("key", key))
 			continue
 		}
 
@@ -245,17 +248,17 @@ func (p *Provider) handleWatchResponse(resp clientv3.WatchResponse) map[string]*
 		case clientv3.EventTypePut:
 			node, err := NewNodeFromBytes(ev.Kv.Value)
 			if err != nil {
-				plog.Error("Invalid member.", log.String("key", key))
+				p.logger.Error("Invalid member.", log.String("key", key))
 				continue
 			}
 			if p.self.Equal(node) {
-				plog.Debug("Skip self.", log.String("key", key))
+				p.logger.Debug("Skip self.", log.String("key", key))
 				continue
 			}
 			if _, ok := p.members[nodeId]; ok {
-				plog.Debug("Update member.", log.String("key", key))
+				p.logger.Debug("Update member.", log.String("key", key))
 			} else {
-				plog.Debug("New member.", log.String("key", key))
+				p.logger.Debug("New member.", log.String("key", key))
 			}
 			changes[nodeId] = node
 		case clientv3.EventTypeDelete:
@@ -263,12 +266,12 @@ func (p *Provider) handleWatchResponse(resp clientv3.WatchResponse) map[string]*
 			if !ok {
 				continue
 			}
-			plog.Debug("Delete member.", log.String("key", key))
+			p.logger.Debug("Delete member.", log.String("key", key))
 			cloned := *node
 			cloned.SetAlive(false)
 			changes[nodeId] = &cloned
 		default:
-			plog.Error("Invalid etcd event.type.", log.String("key", key),
+			p.logger.Error("Invalid etcd event.type.", log.String("key", key),
 				log.String("type", ev.Type.String()))
 		}
 	}
@@ -285,11 +288,11 @@ func (p *Provider) keepWatching(ctx context.Context) error {
 func (p *Provider) _keepWatching(stream clientv3.WatchChan) error {
 	for resp := range stream {
 		if err := resp.Err(); err != nil {
-			plog.Error("Failure watching service.")
+			p.logger.Error("Failure watching service.")
 			return err
 		}
 		if len(resp.Events) <= 0 {
-			plog.Error("Empty etcd.events.", log.Int("events", len(resp.Events)))
+			p.logger.Error("Empty etcd.events.", log.Int("events", len(resp.Events)))
 			continue
 		}
 		nodesChanges := p.handleWatchResponse(resp)
@@ -306,7 +309,7 @@ func (p *Provider) startWatching() {
 	go func() {
 		for !p.shutdown {
 			if err := p.keepWatching(ctx); err != nil {
-				plog.Error("Failed to keepWatching.", log.Error(err))
+				p.logger.Error("Failed to keepWatching.", log.Error(err))
 				p.clusterError = err
 			}
 		}
@@ -379,7 +382,7 @@ func (p *Provider) createClusterTopologyEvent() []*cluster.Member {
 
 func (p *Provider) publishClusterTopologyEvent() {
 	res := p.createClusterTopologyEvent()
-	plog.Info("Update cluster.", log.Int("members", len(res)))
+	p.logger.Info("Update cluster.", log.Int("members", len(res)))
 	// for _, m := range res {
 	// 	plog.Info("\t", log.Object("member", m))
 	// }
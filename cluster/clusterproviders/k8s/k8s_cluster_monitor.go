@@ -24,28 +24,28 @@ func (kcm *k8sClusterMonitorActor) init(ctx actor.Context) {
 		timeout := getTimeout(ctx, kcm)
 
 		if err := kcm.registerMember(timeout); err != nil {
-			plog.Error("Failed to register service to k8s, will retry", log.Error(err))
+			kcm.logger.Error("Failed to register service to k8s, will retry", log.Error(err))
 			ctx.Send(ctx.Self(), r)
 			return
 		}
-		plog.Info("Registered service to k8s")
+		kcm.logger.Info("Registered service to k8s")
 	case *DeregisterMember:
-		plog.Debug("Deregistering service from k8s")
+		kcm.logger.Debug("Deregistering service from k8s")
 		timeout := getTimeout(ctx, kcm)
 
 		if err := kcm.deregisterMember(timeout); err != nil {
-			plog.Error("Failed to deregister service from k8s, proceeding with shutdown", log.Error(err))
+			kcm.logger.Error("Failed to deregister service from k8s, proceeding with shutdown", log.Error(err))
 		} else {
-			plog.Info("Deregistered service from k8s")
+			kcm.logger.Info("Deregistered service from k8s")
 		}
 		ctx.Respond(&DeregisterMemberResponse{})
 	case *StartWatchingCluster:
 		if err := kcm.startWatchingCluster(); err != nil {
-			plog.Error("Failed to start watching k8s cluster, will retry", log.Error(err))
+			kcm.logger.Error("Failed to start watching k8s cluster, will retry", log.Error(err))
 			ctx.Send(ctx.Self(), r)
 			return
 		}
-		plog.Info("k8s cluster started to being watched")
+		kcm.logger.Info("k8s cluster started to being watched")
 	case *StopWatchingCluster:
 		if kcm.cancelWatch != nil {
 			kcm.cancelWatch()
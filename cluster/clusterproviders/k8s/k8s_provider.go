@@ -45,6 +45,7 @@ type Provider struct {
 	deregistered   bool
 	shutdown       bool
 	cancelWatch    context.CancelFunc
+	logger         log.Interface
 }
 
 // make sure our Provider complies with the ClusterProvider interface
@@ -71,6 +72,7 @@ func NewWithConfig(config *rest.Config, opts ...Option) (*Provider, error) {
 
 	p := Provider{
 		client: clientset,
+		logger: plog,
 	}
 
 	// process given options
@@ -88,6 +90,7 @@ func (p *Provider) init(c *cluster.Cluster) error {
 	}
 
 	p.cluster = c
+	p.logger = c.Logger()
 	p.id = strings.Replace(uuid.New().String(), "-", "", -1)
 	p.knownKinds = c.GetClusterKinds()
 	p.clusterName = c.Config.Name
@@ -136,14 +139,14 @@ func (p *Provider) Shutdown(graceful bool) error {
 
 	p.shutdown = true
 
-	plog.Info("Shutting down k8s cluster provider")
+	p.logger.Info("Shutting down k8s cluster provider")
 	if p.clusterMonitor != nil {
 		if err := p.cluster.ActorSystem.Root.RequestFuture(p.clusterMonitor, &DeregisterMember{}, 5*time.Second).Wait(); err != nil {
-			plog.Error("Failed to deregister member - cluster monitor did not respond, proceeding with shutdown", log.Error(err))
+			p.logger.Error("Failed to deregister member - cluster monitor did not respond, proceeding with shutdown", log.Error(err))
 		}
 
 		if err := p.cluster.ActorSystem.Root.RequestFuture(p.clusterMonitor, &StopWatchingCluster{}, 5*time.Second).Wait(); err != nil {
-			plog.Error("Failed to deregister member - cluster monitor did not respond, proceeding with shutdown", log.Error(err))
+			p.logger.Error("Failed to deregister member - cluster monitor did not respond, proceeding with shutdown", log.Error(err))
 		}
 
 		_ = p.cluster.ActorSystem.Root.StopFuture(p.clusterMonitor).Wait()
@@ -161,7 +164,7 @@ func (p *Provider) startClusterMonitor(c *cluster.Cluster) error {
 	}), "k8s-cluster-monitor")
 
 	if err != nil {
-		plog.Error("Failed to start k8s-cluster-monitor actor", log.Error(err))
+		p.logger.Error("Failed to start k8s-cluster-monitor actor", log.Error(err))
 		return err
 	}
 
@@ -177,7 +180,7 @@ func (p *Provider) registerMemberAsync(c *cluster.Cluster) {
 
 // registers itself as a member in k8s cluster
 func (p *Provider) registerMember(timeout time.Duration) error {
-	plog.Info(fmt.Sprintf("Registering service %s on %s", p.podName, p.address))
+	p.logger.Info(fmt.Sprintf("Registering service %s on %s", p.podName, p.address))
 
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
@@ -187,7 +190,7 @@ func (p *Provider) registerMember(timeout time.Duration) error {
 		return fmt.Errorf("unable to get own pod information for %s: %w", p.podName, err)
 	}
 
-	plog.Info(fmt.Sprintf("Using Kubernetes namespace: %s\nUsing Kubernetes port: %d", pod.Namespace, p.port))
+	p.logger.Info(fmt.Sprintf("Using Kubernetes namespace: %s\nUsing Kubernetes port: %d", pod.Namespace, p.port))
 
 	labels := Labels{
 		LabelCluster:  p.clusterName,
@@ -218,7 +221,7 @@ func (p *Provider) startWatchingClusterAsync(c *cluster.Cluster) {
 func (p *Provider) startWatchingCluster() error {
 	selector := fmt.Sprintf("%s=%s", LabelCluster, p.clusterName)
 
-	plog.Debug(fmt.Sprintf("Starting to watch pods with %s", selector), log.String("selector", selector))
+	p.logger.Debug(fmt.Sprintf("Starting to watch pods with %s", selector), log.String("selector", selector))
 
 	ctx, cancel := context.WithCancel(context.Background())
 	p.cancelWatch = cancel
@@ -228,11 +231,11 @@ func (p *Provider) startWatchingCluster() error {
 		for {
 			select {
 			case <-ctx.Done():
-				plog.Debug("Stopping watch on pods")
+				p.logger.Debug("Stopping watch on pods")
 				return
 			default:
 				if err := p.watchPods(ctx, selector); err != nil {
-					plog.Error("Error watching pods, will retry", log.Error(err))
+					p.logger.Error("Error watching pods, will retry", log.Error(err))
 					time.Sleep(5 * time.Second)
 				}
 			}
@@ -246,11 +249,11 @@ func (p *Provider) watchPods(ctx context.Context, selector string) error {
 	watcher, err := p.client.CoreV1().Pods(p.retrieveNamespace()).Watch(context.Background(), metav1.ListOptions{LabelSelector: selector, Watch: true})
 	if err != nil {
 		err = fmt.Errorf("unable to watch pods: %w", err)
-		plog.Error(err.Error(), log.Error(err))
+		p.logger.Error(err.Error(), log.Error(err))
 		return err
 	}
 
-	plog.Info("Pod watcher started")
+	p.logger.Info("Pod watcher started")
 
 	for {
 		select {
@@ -264,7 +267,7 @@ func (p *Provider) watchPods(ctx context.Context, selector string) error {
 			pod, ok := event.Object.(*v1.Pod)
 			if !ok {
 				err := fmt.Errorf("could not cast %#v[%T] into v1.Pod", event.Object, event.Object)
-				plog.Error(err.Error(), log.Error(err))
+				p.logger.Error(err.Error(), log.Error(err))
 				continue
 			}
 
@@ -274,14 +277,14 @@ func (p *Provider) watchPods(ctx context.Context, selector string) error {
 }
 
 func (p *Provider) processPodEvent(event watch.Event, pod *v1.Pod) {
-	plog.Debug("Watcher reported event for pod", log.Object("eventType", event.Type), log.String("podName", pod.ObjectMeta.Name))
+	p.logger.Debug("Watcher reported event for pod", log.Object("eventType", event.Type), log.String("podName", pod.ObjectMeta.Name))
 
 	podClusterName, hasClusterName := pod.ObjectMeta.Labels[LabelCluster]
 	if !hasClusterName {
-		plog.Info("The pod is not a cluster member", log.Object("podName", pod.ObjectMeta.Name))
+		p.logger.Info("The pod is not a cluster member", log.Object("podName", pod.ObjectMeta.Name))
 		delete(p.clusterPods, pod.UID) // pod could have been in the cluster, but then it was deregistered
 	} else if podClusterName != p.clusterName {
-		plog.Info("The pod is a member of another cluster", log.Object("podName", pod.ObjectMeta.Name), log.String("otherCluster", podClusterName))
+		p.logger.Info("The pod is a member of another cluster", log.Object("podName", pod.ObjectMeta.Name), log.String("otherCluster", podClusterName))
 		return
 	} else {
 		switch event.Type {
@@ -289,31 +292,31 @@ func (p *Provider) processPodEvent(event watch.Event, pod *v1.Pod) {
 			delete(p.clusterPods, pod.UID)
 		case watch.Error:
 			err := apierrors.FromObject(event.Object)
-			plog.Error(err.Error(), log.Error(err))
+			p.logger.Error(err.Error(), log.Error(err))
 		default:
 			p.clusterPods[pod.UID] = pod
 		}
 	}
 
-	if plog.Level() == log.DebugLevel {
-		logCurrentPods(p.clusterPods)
+	if logger, ok := p.logger.(*log.Logger); !ok || logger.Level() == log.DebugLevel {
+		p.logCurrentPods(p.clusterPods)
 	}
 
-	members := mapPodsToMembers(p.clusterPods)
+	members := p.mapPodsToMembers(p.clusterPods)
 
-	plog.Info("Topology received from Kubernetes", log.Object("members", members))
+	p.logger.Info("Topology received from Kubernetes", log.Object("members", members))
 	p.cluster.MemberList.UpdateClusterTopology(members)
 }
 
-func logCurrentPods(clusterPods map[types.UID]*v1.Pod) {
+func (p *Provider) logCurrentPods(clusterPods map[types.UID]*v1.Pod) {
 	podNames := make([]string, 0, len(clusterPods))
 	for _, pod := range clusterPods {
 		podNames = append(podNames, pod.ObjectMeta.Name)
 	}
-	plog.Debug("Detected cluster pods are now", log.Int("numberOfPods", len(clusterPods)), log.Object("podNames", podNames))
+	p.logger.Debug("Detected cluster pods are now", log.Int("numberOfPods", len(clusterPods)), log.Object("podNames", podNames))
 }
 
-func mapPodsToMembers(clusterPods map[types.UID]*v1.Pod) []*cluster.Member {
+func (p *Provider) mapPodsToMembers(clusterPods map[types.UID]*v1.Pod) []*cluster.Member {
 	members := make([]*cluster.Member, 0, len(clusterPods))
 	for _, clusterPod := range clusterPods {
 		if clusterPod.Status.Phase == "Running" && len(clusterPod.Status.PodIPs) > 0 {
@@ -329,7 +332,7 @@ func mapPodsToMembers(clusterPods map[types.UID]*v1.Pod) []*cluster.Member {
 			port, err := strconv.Atoi(clusterPod.ObjectMeta.Labels[LabelPort])
 			if err != nil {
 				err = fmt.Errorf("can not convert pod meta %s into integer: %w", LabelPort, err)
-				plog.Error(err.Error(), log.Error(err))
+				p.logger.Error(err.Error(), log.Error(err))
 				continue
 			}
 
@@ -337,7 +340,7 @@ func mapPodsToMembers(clusterPods map[types.UID]*v1.Pod) []*cluster.Member {
 			alive := true
 			for _, status := range clusterPod.Status.ContainerStatuses {
 				if !status.Ready {
-					plog.Debug("Pod container is not ready", log.String("podName", clusterPod.ObjectMeta.Name), log.String("containerName", status.Name))
+					p.logger.Debug("Pod container is not ready", log.String("podName", clusterPod.ObjectMeta.Name), log.String("containerName", status.Name))
 					alive = false
 					break
 				}
@@ -347,7 +350,7 @@ func mapPodsToMembers(clusterPods map[types.UID]*v1.Pod) []*cluster.Member {
 				continue
 			}
 
-			plog.Debug("Pod is running and all containers are ready", log.String("podName", clusterPod.ObjectMeta.Name), log.Object("podIPs", clusterPod.Status.PodIPs), log.String("podPhase", string(clusterPod.Status.Phase)))
+			p.logger.Debug("Pod is running and all containers are ready", log.String("podName", clusterPod.ObjectMeta.Name), log.Object("podIPs", clusterPod.Status.PodIPs), log.String("podPhase", string(clusterPod.Status.Phase)))
 
 			members = append(members, &cluster.Member{
 				Id:    mid,
@@ -356,7 +359,7 @@ func mapPodsToMembers(clusterPods map[types.UID]*v1.Pod) []*cluster.Member {
 				Kinds: kinds,
 			})
 		} else {
-			plog.Debug("Pod is not in Running state", log.String("podName", clusterPod.ObjectMeta.Name), log.Object("podIPs", clusterPod.Status.PodIPs), log.String("podPhase", string(clusterPod.Status.Phase)))
+			p.logger.Debug("Pod is not in Running state", log.String("podName", clusterPod.ObjectMeta.Name), log.Object("podIPs", clusterPod.Status.PodIPs), log.String("podPhase", string(clusterPod.Status.Phase)))
 		}
 	}
 
@@ -365,7 +368,7 @@ func mapPodsToMembers(clusterPods map[types.UID]*v1.Pod) []*cluster.Member {
 
 // deregister itself as a member from a k8s cluster
 func (p *Provider) deregisterMember(timeout time.Duration) error {
-	plog.Info(fmt.Sprintf("Deregistering service %s from %s", p.podName, p.address))
+	p.logger.Info(fmt.Sprintf("Deregistering service %s from %s", p.podName, p.address))
 
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
@@ -390,7 +393,7 @@ func (p *Provider) deregisterMember(timeout time.Duration) error {
 
 // prepares a patching payload and sends it to kubernetes to replace labels
 func (p *Provider) replacePodLabels(ctx context.Context, pod *v1.Pod) error {
-	plog.Debug("Setting pod labels to ", log.Object("labels", pod.GetLabels()))
+	p.logger.Debug("Setting pod labels to ", log.Object("labels", pod.GetLabels()))
 
 	payload := []struct {
 		Op    string `json:"op"`
@@ -419,7 +422,7 @@ func (p *Provider) retrieveNamespace() string {
 		filename := filepath.Join(string(filepath.Separator), "var", "run", "secrets", "kubernetes.io", "serviceaccount", "namespace")
 		content, err := os.ReadFile(filename)
 		if err != nil {
-			plog.Warn(fmt.Sprintf("Could not read %s contents defaulting to empty namespace: %s", filename, err.Error()))
+			p.logger.Warn(fmt.Sprintf("Could not read %s contents defaulting to empty namespace: %s", filename, err.Error()))
 			return p.namespace
 		}
 		p.namespace = string(content)
@@ -48,6 +48,7 @@ type Provider struct {
 	agent           *InMemAgent
 	id              string
 	ttlReportTicker *time.Ticker
+	logger          log.Interface
 }
 
 func NewTestProvider(agent *InMemAgent, options ...ProviderOption) *Provider {
@@ -62,11 +63,13 @@ func NewTestProvider(agent *InMemAgent, options ...ProviderOption) *Provider {
 	return &Provider{
 		config: config,
 		agent:  agent,
+		logger: plog,
 	}
 }
 
 func (t *Provider) StartMember(c *cluster.Cluster) error {
-	plog.Debug("start cluster member")
+	t.logger = c.Logger()
+	t.logger.Debug("start cluster member")
 	t.memberList = c.MemberList
 	host, port, err := c.ActorSystem.GetHostPort()
 	if err != nil {
@@ -81,6 +84,7 @@ func (t *Provider) StartMember(c *cluster.Cluster) error {
 }
 
 func (t *Provider) StartClient(cluster *cluster.Cluster) error {
+	t.logger = cluster.Logger()
 	t.memberList = cluster.MemberList
 	t.id = cluster.ActorSystem.ID
 	t.agent.SubscribeStatusUpdate(t.notifyStatuses)
@@ -89,7 +93,7 @@ func (t *Provider) StartClient(cluster *cluster.Cluster) error {
 }
 
 func (t *Provider) Shutdown(_ bool) error {
-	plog.Debug("Unregistering service", log.String("service", t.id))
+	t.logger.Debug("Unregistering service", log.String("service", t.id))
 	if t.ttlReportTicker != nil {
 		t.ttlReportTicker.Stop()
 	}
@@ -101,7 +105,7 @@ func (t *Provider) Shutdown(_ bool) error {
 func (t *Provider) notifyStatuses() {
 	statuses := t.agent.GetStatusHealth()
 
-	plog.Debug("TestAgent response", log.Object("statuses", statuses))
+	t.logger.Debug("TestAgent response", log.Object("statuses", statuses))
 	members := make([]*cluster.Member, 0, len(statuses))
 	for _, status := range statuses {
 		copiedKinds := make([]string, 0, len(status.Kinds))
@@ -48,7 +48,7 @@ func (suite *MiscTestSuite) TestMapString() {
 }
 
 func (suite *MiscTestSuite) TestSafeRun() {
-	suite.NotPanics(func() { safeRun(func() { panic("don't worry, should panic here") }) })
+	suite.NotPanics(func() { safeRun(plog, func() { panic("don't worry, should panic here") }) })
 }
 
 func (suite *MiscTestSuite) TestNode() {
@@ -55,10 +55,10 @@ func mapString(list []string, fn func(string) string) []string {
 	return l
 }
 
-func safeRun(fn func()) {
+func safeRun(logger log.Interface, fn func()) {
 	defer func() {
 		if r := recover(); r != nil {
-			plog.Warn("OnRoleChanged.", log.Error(fmt.Errorf("%v\n%s", r, string(getRunTimeStack()))))
+			logger.Warn("OnRoleChanged.", log.Error(fmt.Errorf("%v\n%s", r, string(getRunTimeStack()))))
 		}
 	}()
 	fn()
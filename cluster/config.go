@@ -26,8 +26,11 @@ type Config struct {
 	GossipRequestTimeout                         time.Duration
 	GossipFanOut                                 int
 	GossipMaxSend                                int
+	GossipMaxStateSize                           int // Maximum size in bytes of the value of a single gossip state key
 	HeartbeatExpiration                          time.Duration // Gossip heartbeat timeout. If the member does not update its heartbeat within this period, it will be added to the BlockList
 	PubSubConfig                                 *PubSubConfig
+	MemberWeight                                 int // Capacity hint of this member, members with a higher weight get a larger share of the identities
+	TopologyChangeDebounce                       time.Duration // Quiet period after a topology change before the OnTopologyChanged callbacks are called
 }
 
 func Configure(clusterName string, clusterProvider ClusterProvider, identityLookup IdentityLookup, remoteConfig *remote.Config, options ...ConfigOption) *Config {
@@ -42,13 +45,16 @@ func Configure(clusterName string, clusterProvider ClusterProvider, identityLook
 		Kinds:                     make(map[string]*Kind),
 		ClusterContextProducer:    newDefaultClusterContext,
 		MaxNumberOfEventsInRequestLogThrottledPeriod: defaultMaxNumberOfEvetsInRequestLogThrottledPeriod,
-		TimeoutTime:          time.Second * 5,
-		GossipInterval:       time.Millisecond * 300,
-		GossipRequestTimeout: time.Millisecond * 500,
-		GossipFanOut:         3,
-		GossipMaxSend:        50,
-		HeartbeatExpiration:  time.Second * 20,
-		PubSubConfig:         newPubSubConfig(),
+		TimeoutTime:            time.Second * 5,
+		GossipInterval:         time.Millisecond * 300,
+		GossipRequestTimeout:   time.Millisecond * 500,
+		GossipFanOut:           3,
+		GossipMaxSend:          50,
+		GossipMaxStateSize:     64 * 1024,
+		HeartbeatExpiration:    time.Second * 20,
+		PubSubConfig:           newPubSubConfig(),
+		MemberWeight:           1,
+		TopologyChangeDebounce: time.Millisecond * 500,
 	}
 
 	for _, option := range options {
@@ -1,6 +1,10 @@
 package cluster
 
-import "time"
+import (
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+)
 
 type ConfigOption func(config *Config)
 
@@ -32,6 +36,11 @@ func WithMaxNumberOfEventsInRequestLogThrottlePeriod(maxNumber int) ConfigOption
 	}
 }
 
+// WithKind registers the kind of grains spawned from props, see NewKind
+func WithKind(kind string, props *actor.Props) ConfigOption {
+	return WithKinds(NewKind(kind, props))
+}
+
 func WithKinds(kinds ...*Kind) ConfigOption {
 	return func(c *Config) {
 		for _, kind := range kinds {
@@ -48,6 +57,44 @@ func WithPubSubSubscriberTimeout(timeout time.Duration) ConfigOption {
 	}
 }
 
+// WithPubSubBackpressure bounds the batches a topic delivers at once to the subscribers on each member to maxInFlight,
+// and queues up to queueSize more, so that a lagging member does not hold back the publishers of the topic.
+// The policy decides what happens to the batches for a member whose queue is full
+func WithPubSubBackpressure(maxInFlight, queueSize int, policy LaggingSubscriberPolicy) ConfigOption {
+	return func(c *Config) {
+		c.PubSubConfig.MaxInFlightBatchesPerMember = maxInFlight
+		c.PubSubConfig.MemberQueueSize = queueSize
+		c.PubSubConfig.LaggingSubscriberPolicy = policy
+	}
+}
+
+// WithMemberWeight sets the capacity hint this member advertises to the cluster.
+// Identities are placed on members proportionally to their weight. Default is 1.
+func WithMemberWeight(weight int) ConfigOption {
+	return func(c *Config) {
+		if weight < 1 {
+			weight = 1
+		}
+		c.MemberWeight = weight
+	}
+}
+
+// WithGossipMaxStateSize sets the maximum size in bytes of the value of a single gossip state key.
+// Setting a larger value fails with ErrGossipStateTooLarge. Default is 64KiB.
+func WithGossipMaxStateSize(size int) ConfigOption {
+	return func(c *Config) {
+		c.GossipMaxStateSize = size
+	}
+}
+
+// WithTopologyChangeDebounce sets how long the topology must stay unchanged before the OnTopologyChanged callbacks
+// are called. Default is 500ms.
+func WithTopologyChangeDebounce(t time.Duration) ConfigOption {
+	return func(c *Config) {
+		c.TopologyChangeDebounce = t
+	}
+}
+
 // WithHeartbeatExpiration sets the gossip heartbeat expiration.
 func WithHeartbeatExpiration(t time.Duration) ConfigOption {
 	return func(c *Config) {
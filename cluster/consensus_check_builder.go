@@ -29,6 +29,7 @@ type consensusMemberValue struct {
 type ConsensusCheckBuilder struct {
 	getConsensusValues []*consensusValue
 	check              ConsensusChecker
+	logger             log.Interface
 }
 
 func NewConsensusCheckBuilder(key string, getValue func(*anypb.Any) interface{}) *ConsensusCheckBuilder {
@@ -39,6 +40,7 @@ func NewConsensusCheckBuilder(key string, getValue func(*anypb.Any) interface{})
 				Value: getValue,
 			},
 		},
+		logger: plog,
 	}
 	builder.check = builder.build()
 	return &builder
@@ -119,7 +121,8 @@ func (ccb *ConsensusCheckBuilder) MapToValue(valueTuple *consensusValue) func(st
 }
 
 func (ccb *ConsensusCheckBuilder) build() func(*GossipState, map[string]empty) (bool, interface{}) {
-	getValidMemberStates := func(state *GossipState, ids map[string]empty, result []map[string]*GossipMemberState) {
+	getValidMemberStates := func(state *GossipState, ids map[string]empty) []map[string]*GossipMemberState {
+		var result []map[string]*GossipMemberState
 		for member, memberState := range state.Members {
 			if _, ok := ids[member]; ok {
 				result = append(result, map[string]*GossipMemberState{
@@ -127,10 +130,13 @@ func (ccb *ConsensusCheckBuilder) build() func(*GossipState, map[string]empty) (
 				})
 			}
 		}
+
+		return result
 	}
 
 	showLog := func(hasConsensus bool, topologyHash uint64, valueTuples []*consensusMemberValue) {
-		if plog.Level() == log.DebugLevel {
+		// the values are only grouped when the logger may log at debug level
+		if logger, ok := ccb.logger.(*log.Logger); !ok || logger.Level() == log.DebugLevel {
 			groups := map[string]int{}
 			for _, memberValue := range valueTuples {
 				key := fmt.Sprintf("%s:%d", memberValue.key, memberValue.value)
@@ -146,7 +152,7 @@ func (ccb *ConsensusCheckBuilder) build() func(*GossipState, map[string]empty) (
 				if value > 1 {
 					suffix = fmt.Sprintf("%s, %d nodes", k, value)
 				}
-				plog.Debug("consensus", log.Bool("consensus", hasConsensus), log.String("values", suffix))
+				ccb.logger.Debug("consensus", log.Bool("consensus", hasConsensus), log.String("values", suffix))
 			}
 		}
 	}
@@ -155,8 +161,7 @@ func (ccb *ConsensusCheckBuilder) build() func(*GossipState, map[string]empty) (
 		mapToValue := ccb.MapToValue(ccb.getConsensusValues[0])
 
 		return func(state *GossipState, ids map[string]empty) (bool, interface{}) {
-			var memberStates []map[string]*GossipMemberState
-			getValidMemberStates(state, ids, memberStates)
+			memberStates := getValidMemberStates(state, ids)
 
 			if len(memberStates) < len(ids) { // Not all members have state...
 				return false, nil
@@ -178,8 +183,7 @@ func (ccb *ConsensusCheckBuilder) build() func(*GossipState, map[string]empty) (
 	}
 
 	return func(state *GossipState, ids map[string]empty) (bool, interface{}) {
-		var memberStates []map[string]*GossipMemberState
-		getValidMemberStates(state, ids, memberStates)
+		memberStates := getValidMemberStates(state, ids)
 
 		if len(memberStates) < len(ids) { // Not all members have state...
 			return false, nil
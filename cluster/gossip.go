@@ -3,6 +3,7 @@
 package cluster
 
 import (
+	"github.com/asynkron/protoactor-go/remote"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -39,3 +40,16 @@ type Gossip interface {
 	GossipConsensusChecker
 	GossipCore
 }
+
+// gossip keeps the members in agreement about the cluster, it is sent to the remote members
+// ahead of the user messages queued for them so a busy endpoint does not make members flap
+var (
+	_ remote.ControlMessage = (*GossipRequest)(nil)
+	_ remote.ControlMessage = (*GossipResponse)(nil)
+)
+
+// RemoteControlMessage makes GossipRequest a remote.ControlMessage
+func (*GossipRequest) RemoteControlMessage() {}
+
+// RemoteControlMessage makes GossipResponse a remote.ControlMessage
+func (*GossipResponse) RemoteControlMessage() {}
@@ -21,14 +21,16 @@ type GossipActor struct {
 
 	/// Message throttler
 	throttler actor.ShouldThrottle
+	logger    log.Interface
 }
 
 // Creates a new GossipActor and returns a pointer to its location in the heap
-func NewGossipActor(requestTimeout time.Duration, myID string, getBlockedMembers func() set.Set[string], fanOut int, maxSend int) *GossipActor {
-	informer := newInformer(myID, getBlockedMembers, fanOut, maxSend)
+func NewGossipActor(requestTimeout time.Duration, myID string, getBlockedMembers func() set.Set[string], fanOut int, maxSend int, logger log.Interface) *GossipActor {
+	informer := newInformer(myID, getBlockedMembers, fanOut, maxSend, logger)
 	gossipActor := GossipActor{
 		gossipRequestTimeout: requestTimeout,
 		gossip:               informer,
+		logger:               logger,
 	}
 	gossipActor.throttler = actor.NewThrottle(3, 60*time.Second, gossipActor.throttledLog)
 
@@ -55,9 +57,9 @@ func (ga *GossipActor) Receive(ctx actor.Context) {
 	case *ClusterTopology:
 		ga.onClusterTopology(r)
 	case *GossipResponse:
-		plog.Error("GossipResponse should not be received by GossipActor") // it should be a response to a request
+		ga.logger.Error("GossipResponse should not be received by GossipActor") // it should be a response to a request
 	default:
-		plog.Warn("Gossip received unknown message request", log.Message(r), log.TypeOf("msg_type", r))
+		ga.logger.Warn("Gossip received unknown message request", log.Message(r), log.TypeOf("msg_type", r))
 	}
 }
 
@@ -81,12 +83,12 @@ func (ga *GossipActor) onGetGossipStateKey(r *GetGossipStateRequest, ctx actor.C
 
 func (ga *GossipActor) onGossipRequest(r *GossipRequest, ctx actor.Context) {
 	if ga.throttler() == actor.Open {
-		plog.Debug("OnGossipRequest", log.PID("sender", ctx.Sender()))
+		ga.logger.Debug("OnGossipRequest", log.PID("sender", ctx.Sender()))
 	}
 	ga.ReceiveState(r.State, ctx)
 
 	if !GetCluster(ctx.ActorSystem()).MemberList.ContainsMemberID(r.MemberId) {
-		plog.Warn("Got gossip request from unknown member", log.String("MemberId", r.MemberId))
+		ga.logger.Warn("Got gossip request from unknown member", log.String("MemberId", r.MemberId))
 
 		// nothing to send, do not provide sender or state payload
 		// ctx.Respond(&GossipResponse{State: &GossipState{Members: make(map[string]*GossipState_GossipMemberState)}})
@@ -97,7 +99,7 @@ func (ga *GossipActor) onGossipRequest(r *GossipRequest, ctx actor.Context) {
 
 	memberState := ga.gossip.GetMemberStateDelta(r.MemberId)
 	if !memberState.HasState {
-		plog.Warn("Got gossip request from member, but no state was found", log.String("MemberId", r.MemberId))
+		ga.logger.Warn("Got gossip request from member, but no state was found", log.String("MemberId", r.MemberId))
 
 		// nothing to send, do not provide sender or state payload
 		ctx.Respond(&GossipResponse{})
@@ -164,7 +166,7 @@ func (ga *GossipActor) ReceiveState(remoteState *GossipState, ctx actor.Context)
 func (ga *GossipActor) sendGossipForMember(member *Member, memberStateDelta *MemberStateDelta, ctx actor.Context) {
 	pid := actor.NewPID(member.Address(), DefaultGossipActorName)
 	if ga.throttler() == actor.Open {
-		plog.Debug("Sending GossipRequest", log.String("MemberId", member.Id))
+		ga.logger.Debug("Sending GossipRequest", log.String("MemberId", member.Id))
 	}
 
 	// a short timeout is massively important, we cannot afford hanging around waiting
@@ -178,13 +180,13 @@ func (ga *GossipActor) sendGossipForMember(member *Member, memberStateDelta *Mem
 
 	ctx.ReenterAfter(future, func(res interface{}, err error) {
 		if err != nil {
-			plog.Warn("sendGossipForMember failed", log.String("MemberId", member.Id), log.Error(err))
+			ga.logger.Warn("sendGossipForMember failed", log.String("MemberId", member.Id), log.Error(err))
 			return
 		}
 
 		resp, ok := res.(*GossipResponse)
 		if !ok {
-			plog.Error("sendGossipForMember received unknown response message", log.TypeOf("messageType", res), log.Message(resp))
+			ga.logger.Error("sendGossipForMember received unknown response message", log.TypeOf("messageType", res), log.Message(resp))
 
 			return
 		}
@@ -198,5 +200,5 @@ func (ga *GossipActor) sendGossipForMember(member *Member, memberStateDelta *Mem
 }
 
 func (ga *GossipActor) throttledLog(counter int32) {
-	plog.Debug("[Gossip] Sending GossipRequest", log.Int("throttled", int(counter)))
+	ga.logger.Debug("[Gossip] Sending GossipRequest", log.Int("throttled", int(counter)))
 }
@@ -14,12 +14,16 @@ import (
 	"google.golang.org/protobuf/proto"
 
 	"github.com/asynkron/protoactor-go/actor"
+	"github.com/asynkron/protoactor-go/eventstream"
 	"github.com/asynkron/protoactor-go/log"
 	"google.golang.org/protobuf/types/known/anypb"
 )
 
 const DefaultGossipActorName string = "gossip"
 
+// ErrGossipStateTooLarge is returned when setting a gossip state value larger than Config.GossipMaxStateSize
+var ErrGossipStateTooLarge = errors.New("gossip state too large")
+
 // GossipUpdate Used to update gossip data when a ClusterTopology event occurs
 type GossipUpdate struct {
 	MemberID, Key string
@@ -69,7 +73,7 @@ func newGossiper(cl *Cluster, opts ...Option) (*Gossiper, error) {
 }
 
 func (g *Gossiper) GetState(key string) (map[string]*GossipKeyValue, error) {
-	plog.Debug(fmt.Sprintf("Gossiper getting state from %s", g.pid))
+	g.cluster.Logger().Debug(fmt.Sprintf("Gossiper getting state from %s", g.pid))
 
 	msg := NewGetGossipStateRequest(key)
 	timeout := g.cluster.Config.TimeoutTime
@@ -77,13 +81,13 @@ func (g *Gossiper) GetState(key string) (map[string]*GossipKeyValue, error) {
 	if err != nil {
 		switch err {
 		case actor.ErrTimeout:
-			plog.Error("Could not get a response from GossipActor: request timeout", log.Error(err), log.String("remote", g.pid.String()))
+			g.cluster.Logger().Error("Could not get a response from GossipActor: request timeout", log.Error(err), log.String("remote", g.pid.String()))
 			return nil, err
 		case actor.ErrDeadLetter:
-			plog.Error("remote no longer exists", log.Error(err), log.String("remote", g.pid.String()))
+			g.cluster.Logger().Error("remote no longer exists", log.Error(err), log.String("remote", g.pid.String()))
 			return nil, err
 		default:
-			plog.Error("Could not get a response from GossipActor", log.Error(err), log.String("remote", g.pid.String()))
+			g.cluster.Logger().Error("Could not get a response from GossipActor", log.Error(err), log.String("remote", g.pid.String()))
 			return nil, err
 		}
 	}
@@ -92,31 +96,41 @@ func (g *Gossiper) GetState(key string) (map[string]*GossipKeyValue, error) {
 	response, ok := r.(*GetGossipStateResponse)
 	if !ok {
 		err := fmt.Errorf("could not promote %T interface to GetGossipStateResponse", r)
-		plog.Error("Could not get a response from GossipActor", log.Error(err), log.String("remote", g.pid.String()))
+		g.cluster.Logger().Error("Could not get a response from GossipActor", log.Error(err), log.String("remote", g.pid.String()))
 		return nil, err
 	}
 
 	return response.State, nil
 }
 
-// SetState Sends fire and forget message to update member state
-func (g *Gossiper) SetState(key string, value proto.Message) {
+// SetState Sends fire and forget message to update member state.
+// The state is propagated to the other members by the following gossip rounds
+func (g *Gossiper) SetState(key string, value proto.Message) error {
+	if err := g.checkStateSize(key, value); err != nil {
+		return err
+	}
+
 	if g.throttler() == actor.Open {
-		plog.Debug(fmt.Sprintf("Gossiper setting state %s to %s", key, g.pid))
+		g.cluster.Logger().Debug(fmt.Sprintf("Gossiper setting state %s to %s", key, g.pid))
 	}
 
 	if g.pid == nil {
-		return
+		return nil
 	}
 
 	msg := NewGossipStateKey(key, value)
 	g.cluster.ActorSystem.Root.Send(g.pid, &msg)
+	return nil
 }
 
 // SetStateRequest Sends a Request (that blocks) to update member state
 func (g *Gossiper) SetStateRequest(key string, value proto.Message) error {
+	if err := g.checkStateSize(key, value); err != nil {
+		return err
+	}
+
 	if g.throttler() == actor.Open {
-		plog.Debug(fmt.Sprintf("Gossiper setting state %s to %s", key, g.pid))
+		g.cluster.Logger().Debug(fmt.Sprintf("Gossiper setting state %s to %s", key, g.pid))
 	}
 
 	if g.pid == nil {
@@ -127,10 +141,10 @@ func (g *Gossiper) SetStateRequest(key string, value proto.Message) error {
 	r, err := g.cluster.ActorSystem.Root.RequestFuture(g.pid, &msg, g.cluster.Config.TimeoutTime).Result()
 	if err != nil {
 		if err == actor.ErrTimeout {
-			plog.Error("Could not get a response from Gossiper Actor: request timeout", log.String("remote", g.pid.String()))
+			g.cluster.Logger().Error("Could not get a response from Gossiper Actor: request timeout", log.String("remote", g.pid.String()))
 			return err
 		}
-		plog.Error("Could not get a response from Gossiper Actor", log.Error(err), log.String("remote", g.pid.String()))
+		g.cluster.Logger().Error("Could not get a response from Gossiper Actor", log.Error(err), log.String("remote", g.pid.String()))
 		return err
 	}
 
@@ -138,12 +152,44 @@ func (g *Gossiper) SetStateRequest(key string, value proto.Message) error {
 	_, ok := r.(*SetGossipStateResponse)
 	if !ok {
 		err := fmt.Errorf("could not promote %T interface to SetGossipStateResponse", r)
-		plog.Error("Could not get a response from Gossip Actor", log.Error(err), log.String("remote", g.pid.String()))
+		g.cluster.Logger().Error("Could not get a response from Gossip Actor", log.Error(err), log.String("remote", g.pid.String()))
 		return err
 	}
 	return nil
 }
 
+// checkStateSize keeps the gossip messages small, as every state is sent to other members in each gossip round
+// until they converge
+func (g *Gossiper) checkStateSize(key string, value proto.Message) error {
+	maxSize := g.cluster.Config.GossipMaxStateSize
+	if maxSize <= 0 {
+		return nil
+	}
+
+	if size := proto.Size(value); size > maxSize {
+		return fmt.Errorf("%w: key %q is %d bytes, the limit is %d bytes", ErrGossipStateTooLarge, key, size, maxSize)
+	}
+	return nil
+}
+
+// OnStateChanged calls handler whenever another member changes its state of the key. The subscription is removed by
+// unsubscribing it from the event stream of the actor system
+func (g *Gossiper) OnStateChanged(key string, handler func(memberID string, value proto.Message)) *eventstream.Subscription {
+	return g.cluster.ActorSystem.EventStream.Subscribe(func(evt interface{}) {
+		update, ok := evt.(*GossipUpdate)
+		if !ok || update.Key != key {
+			return
+		}
+
+		value, err := update.Value.UnmarshalNew()
+		if err != nil {
+			g.cluster.Logger().Warn("Gossip could not unpack state", log.String("key", key), log.String("member", update.MemberID), log.Error(err))
+			return
+		}
+		handler(update.MemberID, value)
+	})
+}
+
 func (g *Gossiper) SendState() {
 	if g.pid == nil {
 		return
@@ -151,12 +197,12 @@ func (g *Gossiper) SendState() {
 
 	r, err := g.cluster.ActorSystem.Root.RequestFuture(g.pid, &SendGossipStateRequest{}, 5*time.Second).Result()
 	if err != nil {
-		plog.Warn("Gossip could not send gossip request", log.PID("PID", g.pid), log.Error(err))
+		g.cluster.Logger().Warn("Gossip could not send gossip request", log.PID("PID", g.pid), log.Error(err))
 		return
 	}
 
 	if _, ok := r.(*SendGossipStateResponse); !ok {
-		plog.Error("Gossip SendState received unknown response", log.Message(r))
+		g.cluster.Logger().Error("Gossip SendState received unknown response", log.Message(r))
 	}
 }
 
@@ -164,6 +210,7 @@ func (g *Gossiper) SendState() {
 // Gossip actor and returns the handler back to the caller
 func (g *Gossiper) RegisterConsensusCheck(key string, getValue func(*anypb.Any) interface{}) ConsensusHandler {
 	definition := NewConsensusCheckBuilder(key, getValue)
+	definition.logger = g.cluster.Logger()
 	consensusHandle, check := definition.Build()
 	request := NewAddConsensusCheck(consensusHandle.GetID(), check)
 	g.cluster.ActorSystem.Root.Send(g.pid, &request)
@@ -181,11 +228,12 @@ func (g *Gossiper) StartGossiping() error {
 			},
 			g.cluster.Config.GossipFanOut,
 			g.cluster.Config.GossipMaxSend,
+			g.cluster.Logger(),
 		)
 	}), g.GossipActorName)
 
 	if err != nil {
-		plog.Error("Failed to start gossip actor", log.Error(err))
+		g.cluster.Logger().Error("Failed to start gossip actor", log.Error(err))
 		return err
 	}
 
@@ -194,7 +242,7 @@ func (g *Gossiper) StartGossiping() error {
 			g.cluster.ActorSystem.Root.Send(g.pid, topology)
 		}
 	})
-	plog.Info("Started Cluster Gossip")
+	g.cluster.Logger().Info("Started Cluster Gossip")
 	g.throttler = actor.NewThrottle(3, 60*time.Second, g.throttledLog)
 	go g.gossipLoop()
 
@@ -206,20 +254,20 @@ func (g *Gossiper) Shutdown() {
 		return
 	}
 
-	plog.Info("Shutting down gossip")
+	g.cluster.Logger().Info("Shutting down gossip")
 
 	close(g.close)
 
 	err := g.cluster.ActorSystem.Root.StopFuture(g.pid).Wait()
 	if err != nil {
-		plog.Error("failed to stop gossip actor", log.Error(err))
+		g.cluster.Logger().Error("failed to stop gossip actor", log.Error(err))
 	}
 
-	plog.Info("Shut down gossip")
+	g.cluster.Logger().Info("Shut down gossip")
 }
 
 func (g *Gossiper) gossipLoop() {
-	plog.Info("Starting gossip loop")
+	g.cluster.Logger().Info("Starting gossip loop")
 
 	// create a ticker that will tick each GossipInterval milliseconds
 	// we do not use sleep as sleep puts the goroutine out of the scheduler
@@ -229,14 +277,14 @@ breakLoop:
 	for !g.cluster.ActorSystem.IsStopped() {
 		select {
 		case <-g.close:
-			plog.Info("Stopping Gossip Loop")
+			g.cluster.Logger().Info("Stopping Gossip Loop")
 			break breakLoop
 		case <-ticker.C:
 
 			g.blockExpiredHeartbeats()
 			g.blockGracefullyLeft()
 
-			g.SetState(HearthbeatKey, &MemberHeartbeat{
+			_ = g.SetState(HearthbeatKey, &MemberHeartbeat{
 				// todo collect the actor statistics
 				ActorStatistics: &ActorStatistics{},
 			})
@@ -252,7 +300,7 @@ func (g *Gossiper) blockExpiredHeartbeats() {
 	}
 	t, err := g.GetState(HearthbeatKey)
 	if err != nil {
-		plog.Error("Could not get heartbeat state", log.Error(err))
+		g.cluster.Logger().Error("Could not get heartbeat state", log.Error(err))
 		return
 	}
 
@@ -269,7 +317,7 @@ func (g *Gossiper) blockExpiredHeartbeats() {
 	}
 
 	if len(blocked) > 0 {
-		plog.Info("Blocking members due to expired heartbeat", log.String("members", strings.Join(blocked, ",")))
+		g.cluster.Logger().Info("Blocking members due to expired heartbeat", log.String("members", strings.Join(blocked, ",")))
 		blockList.Block(blocked...)
 	}
 }
@@ -278,7 +326,7 @@ func (g *Gossiper) blockExpiredHeartbeats() {
 func (g *Gossiper) blockGracefullyLeft() {
 	t, err := g.GetState(GracefullyLeftKey)
 	if err != nil {
-		plog.Error("Could not get gracefully left members", log.Error(err))
+		g.cluster.Logger().Error("Could not get gracefully left members", log.Error(err))
 		return
 	}
 
@@ -291,11 +339,11 @@ func (g *Gossiper) blockGracefullyLeft() {
 		}
 	}
 	if len(gracefullyLeft) > 0 {
-		plog.Info("Blocking members due to gracefully leaving", log.String("members", strings.Join(gracefullyLeft, ",")))
+		g.cluster.Logger().Info("Blocking members due to gracefully leaving", log.String("members", strings.Join(gracefullyLeft, ",")))
 		blockList.Block(gracefullyLeft...)
 	}
 }
 
 func (g *Gossiper) throttledLog(counter int32) {
-	plog.Debug(fmt.Sprintf("[Gossiper] Gossiper Setting State to %s", g.pid), log.Int("throttled", int(counter)))
+	g.cluster.Logger().Debug(fmt.Sprintf("[Gossiper] Gossiper Setting State to %s", g.pid), log.Int("throttled", int(counter)))
 }
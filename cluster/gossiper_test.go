@@ -0,0 +1,42 @@
+package cluster
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestGossiper_SetStateRejectsLargeValues(t *testing.T) {
+	c := newClusterForTest("test-gossip-size", nil, WithGossipMaxStateSize(100))
+
+	err := c.Gossip.SetState("large", wrapperspb.String(strings.Repeat("x", 200)))
+	assert.True(t, errors.Is(err, ErrGossipStateTooLarge))
+	assert.Contains(t, err.Error(), `"large"`)
+
+	err = c.Gossip.SetStateRequest("large", wrapperspb.String(strings.Repeat("x", 200)))
+	assert.True(t, errors.Is(err, ErrGossipStateTooLarge))
+}
+
+func TestGossiper_OnStateChanged(t *testing.T) {
+	c := newClusterForTest("test-gossip-changed", nil)
+
+	var members []string
+	var values []proto.Message
+	sub := c.Gossip.OnStateChanged("app", func(memberID string, value proto.Message) {
+		members = append(members, memberID)
+		values = append(values, value)
+	})
+	defer c.ActorSystem.EventStream.Unsubscribe(sub)
+
+	value, _ := anypb.New(wrapperspb.String("state"))
+	c.ActorSystem.EventStream.Publish(&GossipUpdate{MemberID: "member1", Key: "other", Value: value})
+	c.ActorSystem.EventStream.Publish(&GossipUpdate{MemberID: "member1", Key: "app", Value: value})
+
+	assert.Equal(t, []string{"member1"}, members)
+	assert.Equal(t, "state", values[0].(*wrapperspb.StringValue).Value)
+}
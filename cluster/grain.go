@@ -11,6 +11,8 @@ type GrainCallConfig struct {
 	Timeout     time.Duration
 	RetryAction func(n int)
 	Context     actor.SenderContext
+	// TotalTimeout bounds the time spent on all attempts of a call, zero bounds the call by RetryCount only
+	TotalTimeout time.Duration
 }
 
 type GrainCallOption func(config *GrainCallConfig)
@@ -47,6 +49,13 @@ func WithRetry(count int) GrainCallOption {
 	}
 }
 
+// WithTotalTimeout bounds the time spent on all attempts of a call, Timeout still bounds each attempt
+func WithTotalTimeout(timeout time.Duration) GrainCallOption {
+	return func(config *GrainCallConfig) {
+		config.TotalTimeout = timeout
+	}
+}
+
 func WithRetryAction(act func(i int)) GrainCallOption {
 	return func(config *GrainCallConfig) {
 		config.RetryAction = act
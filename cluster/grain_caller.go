@@ -0,0 +1,30 @@
+package cluster
+
+import "errors"
+
+// GrainCaller calls a method of a grain with its serialized request, and returns the serialized response.
+// Generated grain clients call grains through a GrainCaller, Cluster calls them through the built-in remote
+// and other transports can be plugged in by implementing it
+type GrainCaller interface {
+	CallGrain(identity string, kind string, methodIndex int32, request []byte, opts ...GrainCallOption) ([]byte, error)
+}
+
+var _ GrainCaller = (*Cluster)(nil)
+
+// CallGrain calls the method of the grain identity of kind with Call. An error returned by the grain method
+// is returned with its message
+func (c *Cluster) CallGrain(identity string, kind string, methodIndex int32, request []byte, opts ...GrainCallOption) ([]byte, error) {
+	resp, err := c.Call(identity, kind, &GrainRequest{MethodIndex: methodIndex, MessageData: request}, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	switch msg := resp.(type) {
+	case *GrainResponse:
+		return msg.MessageData, nil
+	case *GrainErrorResponse:
+		return nil, errors.New(msg.Err)
+	default:
+		return nil, errors.New("unknown response")
+	}
+}
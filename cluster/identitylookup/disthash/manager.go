@@ -28,17 +28,20 @@ func newPartitionManager(c *clustering.Cluster) *Manager {
 }
 
 func (pm *Manager) Start() {
-	plog.Info("Started partition manager")
+	clusterLogger(pm.cluster).Info("Started partition manager")
 	system := pm.cluster.ActorSystem
 
 	activatorProps := actor.PropsFromProducer(func() actor.Actor { return newPlacementActor(pm.cluster, pm) })
 	pm.placementActor, _ = system.Root.SpawnNamed(activatorProps, PartitionActivatorActorName)
-	plog.Info("Started partition placement actor")
+	clusterLogger(pm.cluster).Info("Started partition placement actor")
 
 	pm.topologySub = system.EventStream.
 		Subscribe(func(ev interface{}) {
-			if topology, ok := ev.(*clustering.ClusterTopology); ok {
-				pm.onClusterTopology(topology)
+			switch msg := ev.(type) {
+			case *clustering.ClusterTopology:
+				pm.onClusterTopology(msg)
+			case *clustering.MemberWeightsChanged:
+				pm.rdv.UpdateWeights(msg.Weights)
 			}
 		})
 }
@@ -49,10 +52,10 @@ func (pm *Manager) Stop() {
 
 	err := system.Root.PoisonFuture(pm.placementActor).Wait()
 	if err != nil {
-		plog.Error("Failed to shutdown partition placement actor", log.Error(err))
+		clusterLogger(pm.cluster).Error("Failed to shutdown partition placement actor", log.Error(err))
 	}
 
-	plog.Info("Stopped PartitionManager")
+	clusterLogger(pm.cluster).Info("Stopped PartitionManager")
 }
 
 func (pm *Manager) PidOfActivatorActor(addr string) *actor.PID {
@@ -60,17 +63,19 @@ func (pm *Manager) PidOfActivatorActor(addr string) *actor.PID {
 }
 
 func (pm *Manager) onClusterTopology(tplg *clustering.ClusterTopology) {
-	plog.Info("onClusterTopology", log.Uint64("topology-hash", tplg.TopologyHash))
+	clusterLogger(pm.cluster).Info("onClusterTopology", log.Uint64("topology-hash", tplg.TopologyHash))
 
 	for _, m := range tplg.Members {
-		plog.Info("Got member ", log.String("MemberId", m.Id))
+		clusterLogger(pm.cluster).Info("Got member ", log.String("MemberId", m.Id))
 		for _, k := range m.Kinds {
-			plog.Info("" + m.Id + " - " + k)
+			clusterLogger(pm.cluster).Info("" + m.Id + " - " + k)
 		}
 	}
 
-	pm.rdv = clustering.NewRendezvous()
-	pm.rdv.UpdateMembers(tplg.Members)
+	rdv := clustering.NewRendezvous()
+	rdv.UpdateWeights(pm.cluster.MemberList.MemberWeights())
+	rdv.UpdateMembers(tplg.Members)
+	pm.rdv = rdv
 	pm.cluster.ActorSystem.Root.Send(pm.placementActor, tplg)
 }
 
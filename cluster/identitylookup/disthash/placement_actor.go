@@ -28,12 +28,12 @@ func newPlacementActor(c *clustering.Cluster, pm *Manager) *placementActor {
 func (p *placementActor) Receive(ctx actor.Context) {
 	switch msg := ctx.Message().(type) {
 	case *actor.Started:
-		plog.Info("Placement actor started")
+		clusterLogger(p.cluster).Info("Placement actor started")
 	case *actor.Stopping:
-		plog.Info("Placement actor stopping")
+		clusterLogger(p.cluster).Info("Placement actor stopping")
 		p.onStopping(ctx)
 	case *actor.Stopped:
-		plog.Info("Placement actor stopped")
+		clusterLogger(p.cluster).Info("Placement actor stopped")
 	case *actor.Terminated:
 		p.onTerminated(msg, ctx)
 	case *clustering.ActivationRequest:
@@ -41,7 +41,7 @@ func (p *placementActor) Receive(ctx actor.Context) {
 	case *clustering.ClusterTopology:
 		p.onClusterTopology(msg, ctx)
 	default:
-		plog.Error("Invalid message", log.TypeOf("type", msg), log.PID("sender", ctx.Sender()))
+		clusterLogger(p.cluster).Error("Invalid message", log.TypeOf("type", msg), log.PID("sender", ctx.Sender()))
 	}
 }
 
@@ -69,7 +69,7 @@ func (p *placementActor) onStopping(ctx actor.Context) {
 	for key, future := range futures {
 		err := future.Wait()
 		if err != nil {
-			plog.Error("Failed to poison actor", log.String("identity", key), log.Error(err))
+			clusterLogger(p.cluster).Error("Failed to poison actor", log.String("identity", key), log.Error(err))
 		}
 	}
 }
@@ -87,7 +87,7 @@ func (p *placementActor) onActivationRequest(msg *clustering.ActivationRequest,
 
 	clusterKind := p.cluster.GetClusterKind(msg.ClusterIdentity.Kind)
 	if clusterKind == nil {
-		plog.Error("Unknown cluster kind", log.String("kind", msg.ClusterIdentity.Kind))
+		clusterLogger(p.cluster).Error("Unknown cluster kind", log.String("kind", msg.ClusterIdentity.Kind))
 
 		// TODO: what to do here?
 		ctx.Respond(nil)
@@ -127,11 +127,11 @@ func (p *placementActor) onClusterTopology(msg *clustering.ClusterTopology, ctx
 		ownerAddress := rdv.GetByIdentity(identity)
 		if ownerAddress == myAddress {
 
-			plog.Debug("Actor stays", log.String("identity", identity), log.String("owner", ownerAddress), log.String("me", myAddress))
+			clusterLogger(p.cluster).Debug("Actor stays", log.String("identity", identity), log.String("owner", ownerAddress), log.String("me", myAddress))
 			continue
 		}
 
-		plog.Debug("Actor moved", log.String("identity", identity), log.String("owner", ownerAddress), log.String("me", myAddress))
+		clusterLogger(p.cluster).Debug("Actor moved", log.String("identity", identity), log.String("owner", ownerAddress), log.String("me", myAddress))
 
 		ctx.Poison(meta.PID)
 	}
@@ -47,12 +47,12 @@ func (p *identityActor) Receive(ctx actor.Context) {
 	case *clustering.ClusterTopology:
 		p.onClusterTopology(msg, ctx)
 	default:
-		plog.Error("Invalid message", log.TypeOf("type", msg), log.PID("sender", ctx.Sender()))
+		clusterLogger(p.cluster).Error("Invalid message", log.TypeOf("type", msg), log.PID("sender", ctx.Sender()))
 	}
 }
 
 func (p *identityActor) onStart(ctx actor.Context) {
-	plog.Debug("Started PartitionIdentity")
+	clusterLogger(p.cluster).Debug("Started PartitionIdentity")
 	self := ctx.Self()
 	ctx.ActorSystem().EventStream.Subscribe(func(evt interface{}) {
 		if at, ok := evt.(*clustering.ActivationTerminated); ok {
@@ -62,7 +62,7 @@ func (p *identityActor) onStart(ctx actor.Context) {
 }
 
 func (p *identityActor) onStopped() {
-	plog.Info("Stopped PartitionIdentity")
+	clusterLogger(p.cluster).Info("Stopped PartitionIdentity")
 }
 
 func (p *identityActor) onActivationRequest(msg *clustering.ActivationRequest, ctx actor.Context) {
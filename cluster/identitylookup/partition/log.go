@@ -1,6 +1,7 @@
 package partition
 
 import (
+	clustering "github.com/asynkron/protoactor-go/cluster"
 	"github.com/asynkron/protoactor-go/log"
 )
 
@@ -12,3 +13,12 @@ var plog = log.New(log.DefaultLevel, "[PARTITION]")
 func SetLogLevel(level log.Level) {
 	plog.SetLevel(level)
 }
+
+// clusterLogger returns the logger of the actor system of c when one was set with actor.WithLogger, or the package logger
+func clusterLogger(c *clustering.Cluster) log.Interface {
+	if logger := c.ActorSystem.Config.Logger; logger != nil {
+		return logger
+	}
+
+	return plog
+}
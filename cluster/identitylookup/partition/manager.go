@@ -29,16 +29,16 @@ func newPartitionManager(c *clustering.Cluster) *Manager {
 }
 
 func (pm *Manager) Start() {
-	plog.Info("Started partition manager")
+	clusterLogger(pm.cluster).Info("Started partition manager")
 	system := pm.cluster.ActorSystem
 
 	identityProps := actor.PropsFromProducer(func() actor.Actor { return newIdentityActor(pm.cluster, pm) })
 	pm.identityActor, _ = system.Root.SpawnNamed(identityProps, ActorNameIdentity)
-	plog.Info("Started partition identity actor")
+	clusterLogger(pm.cluster).Info("Started partition identity actor")
 
 	activatorProps := actor.PropsFromProducer(func() actor.Actor { return newPlacementActor(pm.cluster, pm) })
 	pm.placementActor, _ = system.Root.SpawnNamed(activatorProps, ActorNamePlacement)
-	plog.Info("Started partition placement actor")
+	clusterLogger(pm.cluster).Info("Started partition placement actor")
 
 	pm.topologySub = system.EventStream.
 		Subscribe(func(ev interface{}) {
@@ -55,10 +55,10 @@ func (pm *Manager) Stop() {
 
 	err := system.Root.PoisonFuture(pm.placementActor).Wait()
 	if err != nil {
-		plog.Error("Failed to shutdown partition placement actor", log.Error(err))
+		clusterLogger(pm.cluster).Error("Failed to shutdown partition placement actor", log.Error(err))
 	}
 
-	plog.Info("Stopped PartitionManager")
+	clusterLogger(pm.cluster).Info("Stopped PartitionManager")
 }
 
 func (pm *Manager) PidOfIdentityActor(addr string) *actor.PID {
@@ -70,13 +70,13 @@ func (pm *Manager) PidOfActivatorActor(addr string) *actor.PID {
 }
 
 func (pm *Manager) onClusterTopology(tplg *clustering.ClusterTopology) {
-	plog.Info("onClusterTopology", log.Uint64("eventId", tplg.TopologyHash))
+	clusterLogger(pm.cluster).Info("onClusterTopology", log.Uint64("eventId", tplg.TopologyHash))
 
 	for _, m := range tplg.Members {
-		plog.Info("Got member " + m.Id)
+		clusterLogger(pm.cluster).Info("Got member " + m.Id)
 
 		for _, k := range m.Kinds {
-			plog.Info("" + m.Id + " - " + k)
+			clusterLogger(pm.cluster).Info("" + m.Id + " - " + k)
 		}
 	}
 
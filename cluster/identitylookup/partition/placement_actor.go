@@ -28,10 +28,10 @@ func newPlacementActor(c *clustering.Cluster, pm *Manager) *placementActor {
 func (p *placementActor) Receive(ctx actor.Context) {
 	switch msg := ctx.Message().(type) {
 	case *actor.Stopping:
-		plog.Info("Placement actor stopping")
+		clusterLogger(p.cluster).Info("Placement actor stopping")
 		p.onStopping(ctx)
 	case *actor.Stopped:
-		plog.Info("Placement actor stopped")
+		clusterLogger(p.cluster).Info("Placement actor stopped")
 	case *actor.Terminated:
 		p.onTerminated(msg, ctx)
 	case *clustering.IdentityHandoverRequest:
@@ -39,7 +39,7 @@ func (p *placementActor) Receive(ctx actor.Context) {
 	case *clustering.ActivationRequest:
 		p.onActivationRequest(msg, ctx)
 	default:
-		plog.Error("Invalid message", log.TypeOf("type", msg), log.PID("sender", ctx.Sender()))
+		clusterLogger(p.cluster).Error("Invalid message", log.TypeOf("type", msg), log.PID("sender", ctx.Sender()))
 	}
 }
 
@@ -67,7 +67,7 @@ func (p *placementActor) onStopping(ctx actor.Context) {
 	for key, future := range futures {
 		err := future.Wait()
 		if err != nil {
-			plog.Error("Failed to poison actor", log.String("identity", key), log.Error(err))
+			clusterLogger(p.cluster).Error("Failed to poison actor", log.String("identity", key), log.Error(err))
 		}
 	}
 }
@@ -101,7 +101,7 @@ func (p *placementActor) onIdentityHandoverRequest(msg *clustering.IdentityHando
 		count++
 	}
 
-	plog.Debug("Transferred ownership to other members", log.Int("count", count))
+	clusterLogger(p.cluster).Debug("Transferred ownership to other members", log.Int("count", count))
 	ctx.Respond(response)
 }
 
@@ -0,0 +1,21 @@
+package redis
+
+import (
+	"context"
+	"time"
+)
+
+// Client is the subset of Redis commands used to store activations.
+// It is implemented by a thin adapter over the Redis client of the application, such as go-redis
+type Client interface {
+	// Get returns the value of key, found is false if the key does not exist
+	Get(ctx context.Context, key string) (value string, found bool, err error)
+	// SetNX sets key to value with the expiration if the key does not exist, and returns whether it was set
+	SetNX(ctx context.Context, key string, value string, expiration time.Duration) (bool, error)
+	// Del removes the keys
+	Del(ctx context.Context, keys ...string) error
+	// SMembers returns the members of the set stored at key
+	SMembers(ctx context.Context, key string) ([]string, error)
+	// Eval runs the Lua script with the keys and arguments, and returns its result
+	Eval(ctx context.Context, script string, keys []string, args ...interface{}) (interface{}, error)
+}
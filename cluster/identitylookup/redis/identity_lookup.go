@@ -0,0 +1,161 @@
+package redis
+
+import (
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/asynkron/protoactor-go/cluster"
+	"github.com/asynkron/protoactor-go/eventstream"
+	"github.com/asynkron/protoactor-go/log"
+)
+
+// IdentityLookup places each cluster identity on a single member, using Redis as the shared directory of activations.
+// A member looks the activation up in Redis, and if there is none, takes the spawn lock of the identity and asks
+// the activator member to spawn it. Activations of members leaving the cluster are removed
+type IdentityLookup struct {
+	storage        *Storage
+	cluster        *cluster.Cluster
+	placementActor *actor.PID
+	topologySub    *eventstream.Subscription
+}
+
+var _ cluster.IdentityLookup = &IdentityLookup{}
+
+// New creates an identity lookup storing activations through client, all keys start with prefix
+func New(client Client, prefix string) *IdentityLookup {
+	return &IdentityLookup{storage: NewStorage(client, prefix)}
+}
+
+// Storage returns the storage of the activations, to tune its timeouts before the cluster starts
+func (l *IdentityLookup) Storage() *Storage {
+	return l.storage
+}
+
+func (l *IdentityLookup) Setup(c *cluster.Cluster, kinds []string, isClient bool) {
+	l.cluster = c
+	l.storage.logger = clusterLogger(c)
+	system := c.ActorSystem
+
+	if !isClient {
+		props := actor.PropsFromProducer(func() actor.Actor { return newPlacementActor(l) })
+		l.placementActor, _ = system.Root.SpawnNamed(props, PlacementActorName)
+	}
+
+	l.topologySub = system.EventStream.Subscribe(func(ev interface{}) {
+		if topology, ok := ev.(*cluster.ClusterTopology); ok {
+			for _, m := range topology.Left {
+				l.storage.RemoveMemberId(m.Id)
+			}
+		}
+	})
+}
+
+func (l *IdentityLookup) Shutdown() {
+	system := l.cluster.ActorSystem
+	system.EventStream.Unsubscribe(l.topologySub)
+
+	if l.placementActor != nil {
+		if err := system.Root.PoisonFuture(l.placementActor).Wait(); err != nil {
+			clusterLogger(l.cluster).Error("Failed to shutdown placement actor", log.Error(err))
+		}
+	}
+}
+
+func (l *IdentityLookup) Get(clusterIdentity *cluster.ClusterIdentity) *actor.PID {
+	if pid := l.existingActivation(l.storage.TryGetExistingActivation(clusterIdentity)); pid != nil {
+		return pid
+	}
+
+	lock := l.storage.TryAcquireLock(clusterIdentity)
+	if lock == nil {
+		// another member is activating the identity
+		return l.existingActivation(l.storage.WaitForActivation(clusterIdentity))
+	}
+
+	// the activation may have been stored between the lookup and taking the lock
+	if pid := l.existingActivation(l.storage.TryGetExistingActivation(clusterIdentity)); pid != nil {
+		l.storage.RemoveLock(*lock)
+		return pid
+	}
+
+	return l.activate(lock)
+}
+
+// existingActivation returns the pid of the activation, if its member is still in the cluster.
+// Activations of members that left are removed
+func (l *IdentityLookup) existingActivation(activation *cluster.StoredActivation) *actor.PID {
+	if activation == nil {
+		return nil
+	}
+
+	pid := pidFromString(activation.Pid)
+	if pid == nil {
+		return nil
+	}
+	if !l.cluster.MemberList.ContainsMemberID(activation.MemberID) {
+		l.storage.RemoveMemberId(activation.MemberID)
+		return nil
+	}
+
+	return pid
+}
+
+func (l *IdentityLookup) activate(lock *cluster.SpawnLock) *actor.PID {
+	clusterIdentity := lock.ClusterIdentity
+	system := l.cluster.ActorSystem
+
+	member := l.activatorMember(clusterIdentity.Kind)
+	if member == nil {
+		clusterLogger(l.cluster).Error("No member available to activate", log.String("identity", clusterIdentity.AsKey()))
+		l.storage.RemoveLock(*lock)
+		return nil
+	}
+
+	request := &cluster.ActivationRequest{
+		ClusterIdentity: clusterIdentity,
+		RequestId:       lock.LockID,
+	}
+	res, err := system.Root.RequestFuture(actor.NewPID(member.Address(), PlacementActorName), request, l.cluster.Config.RequestTimeoutTime).Result()
+	if err != nil {
+		clusterLogger(l.cluster).Error("Failed to activate", log.String("identity", clusterIdentity.AsKey()), log.Error(err))
+		l.storage.RemoveLock(*lock)
+		return nil
+	}
+
+	response, ok := res.(*cluster.ActivationResponse)
+	if !ok || response.Failed || response.Pid == nil {
+		l.storage.RemoveLock(*lock)
+		return nil
+	}
+
+	if !l.storage.storeActivation(member.Id, lock, response.Pid) {
+		// the lock expired and another member may have activated the identity meanwhile
+		clusterLogger(l.cluster).Warn("Spawn lock expired before storing activation", log.String("identity", clusterIdentity.AsKey()))
+		system.Root.Send(response.Pid, &actor.PoisonPill{})
+		return l.existingActivation(l.storage.TryGetExistingActivation(clusterIdentity))
+	}
+
+	return response.Pid
+}
+
+func (l *IdentityLookup) activatorMember(kind string) *cluster.Member {
+	address := l.cluster.MemberList.GetActivatorMember(kind, l.cluster.ActorSystem.Address())
+	if address == "" {
+		return nil
+	}
+
+	for _, m := range l.cluster.MemberList.Members().Members() {
+		if m.Address() == address {
+			return m
+		}
+	}
+
+	return nil
+}
+
+func (l *IdentityLookup) RemovePid(clusterIdentity *cluster.ClusterIdentity, pid *actor.PID) {
+	activation := l.storage.TryGetExistingActivation(clusterIdentity)
+	if activation == nil || activation.Pid != pidToString(pid) {
+		return
+	}
+
+	l.storage.removePid(clusterIdentity, activation.MemberID, pid)
+}
@@ -0,0 +1,24 @@
+package redis
+
+import (
+	"github.com/asynkron/protoactor-go/cluster"
+	"github.com/asynkron/protoactor-go/log"
+)
+
+var plog = log.New(log.DefaultLevel, "[REDIS]")
+
+// SetLogLevel sets the log level for the logger.
+//
+// SetLogLevel is safe to call concurrently
+func SetLogLevel(level log.Level) {
+	plog.SetLevel(level)
+}
+
+// clusterLogger returns the logger of the actor system of c when one was set with actor.WithLogger, or the package logger
+func clusterLogger(c *cluster.Cluster) log.Interface {
+	if logger := c.ActorSystem.Config.Logger; logger != nil {
+		return logger
+	}
+
+	return plog
+}
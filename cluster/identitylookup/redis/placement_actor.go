@@ -0,0 +1,93 @@
+package redis
+
+import (
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/asynkron/protoactor-go/cluster"
+	"github.com/asynkron/protoactor-go/log"
+)
+
+const PlacementActorName = "redis-placement"
+
+type placementActor struct {
+	lookup *IdentityLookup
+	actors map[string]*actor.PID
+	ids    map[string]*cluster.ClusterIdentity
+}
+
+func newPlacementActor(lookup *IdentityLookup) *placementActor {
+	return &placementActor{
+		lookup: lookup,
+		actors: map[string]*actor.PID{},
+		ids:    map[string]*cluster.ClusterIdentity{},
+	}
+}
+
+func (p *placementActor) Receive(ctx actor.Context) {
+	switch msg := ctx.Message().(type) {
+	case *actor.Started:
+		clusterLogger(p.lookup.cluster).Info("Placement actor started")
+	case *actor.Stopping:
+		p.onStopping(ctx)
+	case *actor.Stopped:
+		clusterLogger(p.lookup.cluster).Info("Placement actor stopped")
+	case *actor.Terminated:
+		p.onTerminated(msg)
+	case *cluster.ActivationRequest:
+		p.onActivationRequest(msg, ctx)
+	default:
+		clusterLogger(p.lookup.cluster).Error("Invalid message", log.TypeOf("type", msg), log.PID("sender", ctx.Sender()))
+	}
+}
+
+func (p *placementActor) onActivationRequest(msg *cluster.ActivationRequest, ctx actor.Context) {
+	key := msg.ClusterIdentity.AsKey()
+	if pid, found := p.actors[key]; found {
+		ctx.Respond(&cluster.ActivationResponse{Pid: pid})
+		return
+	}
+
+	clusterKind, ok := p.lookup.cluster.TryGetClusterKind(msg.ClusterIdentity.Kind)
+	if !ok {
+		clusterLogger(p.lookup.cluster).Error("Unknown cluster kind", log.String("kind", msg.ClusterIdentity.Kind))
+		ctx.Respond(&cluster.ActivationResponse{Failed: true})
+		return
+	}
+
+	props := cluster.WithClusterIdentity(clusterKind.Props, msg.ClusterIdentity)
+	pid := ctx.SpawnPrefix(props, msg.ClusterIdentity.Identity)
+	p.actors[key] = pid
+	p.ids[pid.Id] = msg.ClusterIdentity
+
+	ctx.Respond(&cluster.ActivationResponse{Pid: pid})
+}
+
+// onTerminated removes the activation from the storage, so the next request activates the identity again
+func (p *placementActor) onTerminated(msg *actor.Terminated) {
+	clusterIdentity, found := p.ids[msg.Who.Id]
+	if !found {
+		return
+	}
+
+	delete(p.ids, msg.Who.Id)
+	delete(p.actors, clusterIdentity.AsKey())
+	p.lookup.storage.removePid(clusterIdentity, p.lookup.cluster.ActorSystem.ID, msg.Who)
+	p.lookup.cluster.MemberList.BroadcastEvent(&cluster.ActivationTerminated{
+		Pid:             msg.Who,
+		ClusterIdentity: clusterIdentity,
+	}, true)
+}
+
+func (p *placementActor) onStopping(ctx actor.Context) {
+	futures := make(map[string]*actor.Future, len(p.actors))
+	for key, pid := range p.actors {
+		futures[key] = ctx.PoisonFuture(pid)
+	}
+
+	for key, future := range futures {
+		if err := future.Wait(); err != nil {
+			clusterLogger(p.lookup.cluster).Error("Failed to poison actor", log.String("identity", key), log.Error(err))
+		}
+	}
+
+	p.lookup.storage.RemoveMemberId(p.lookup.cluster.ActorSystem.ID)
+}
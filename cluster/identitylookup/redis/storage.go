@@ -0,0 +1,243 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/asynkron/protoactor-go/cluster"
+	"github.com/asynkron/protoactor-go/log"
+	"github.com/lithammer/shortuuid/v4"
+)
+
+// storeActivationScript stores the activation and releases the lock, only if the lock is still held
+const storeActivationScript = `
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	redis.call('SET', KEYS[2], ARGV[2])
+	redis.call('SADD', KEYS[3], KEYS[2])
+	redis.call('DEL', KEYS[1])
+	return 1
+end
+return 0`
+
+// removeLockScript releases the lock, only if it is still held by the same owner
+const removeLockScript = `
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1])
+end
+return 0`
+
+// removeActivationScript removes the activation, only if it is the expected one
+const removeActivationScript = `
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	redis.call('SREM', KEYS[2], KEYS[1])
+	return redis.call('DEL', KEYS[1])
+end
+return 0`
+
+// Storage stores activations and spawn locks in Redis, implementing cluster.StorageLookup.
+// Spawn locks expire after LockTTL, so a member failing while activating does not block the identity
+type Storage struct {
+	client Client
+	prefix string
+
+	// LockTTL is how long a spawn lock is held at most
+	LockTTL time.Duration
+	// WaitTimeout is how long WaitForActivation waits for another member to store the activation
+	WaitTimeout time.Duration
+	// Timeout bounds every Redis command
+	Timeout time.Duration
+
+	logger log.Interface
+}
+
+var _ cluster.StorageLookup = &Storage{}
+
+// NewStorage creates a storage using client, all keys start with prefix
+func NewStorage(client Client, prefix string) *Storage {
+	return &Storage{
+		client:      client,
+		prefix:      prefix,
+		LockTTL:     10 * time.Second,
+		WaitTimeout: 5 * time.Second,
+		Timeout:     2 * time.Second,
+		logger:      plog,
+	}
+}
+
+func (s *Storage) activationKey(clusterIdentity *cluster.ClusterIdentity) string {
+	return s.prefix + ":activation:" + clusterIdentity.AsKey()
+}
+
+func (s *Storage) lockKey(clusterIdentity *cluster.ClusterIdentity) string {
+	return s.prefix + ":lock:" + clusterIdentity.AsKey()
+}
+
+func (s *Storage) memberKey(memberID string) string {
+	return s.prefix + ":member:" + memberID
+}
+
+func (s *Storage) context() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), s.Timeout)
+}
+
+// TryGetExistingActivation returns the stored activation of the identity, nil if there is none
+func (s *Storage) TryGetExistingActivation(clusterIdentity *cluster.ClusterIdentity) *cluster.StoredActivation {
+	ctx, cancel := s.context()
+	defer cancel()
+
+	value, found, err := s.client.Get(ctx, s.activationKey(clusterIdentity))
+	if err != nil {
+		s.logger.Error("Failed to get activation", log.String("identity", clusterIdentity.AsKey()), log.Error(err))
+		return nil
+	}
+	if !found {
+		return nil
+	}
+
+	activation := &cluster.StoredActivation{}
+	if err := json.Unmarshal([]byte(value), activation); err != nil {
+		s.logger.Error("Invalid stored activation", log.String("identity", clusterIdentity.AsKey()), log.Error(err))
+		return nil
+	}
+
+	return activation
+}
+
+// TryAcquireLock takes the spawn lock of the identity, nil if another member holds it
+func (s *Storage) TryAcquireLock(clusterIdentity *cluster.ClusterIdentity) *cluster.SpawnLock {
+	ctx, cancel := s.context()
+	defer cancel()
+
+	lock := &cluster.SpawnLock{LockID: shortuuid.New(), ClusterIdentity: clusterIdentity}
+	acquired, err := s.client.SetNX(ctx, s.lockKey(clusterIdentity), lock.LockID, s.LockTTL)
+	if err != nil {
+		s.logger.Error("Failed to acquire spawn lock", log.String("identity", clusterIdentity.AsKey()), log.Error(err))
+		return nil
+	}
+	if !acquired {
+		return nil
+	}
+
+	return lock
+}
+
+// WaitForActivation waits for the member holding the spawn lock to store the activation. It returns nil
+// once the lock is released without an activation, or after WaitTimeout
+func (s *Storage) WaitForActivation(clusterIdentity *cluster.ClusterIdentity) *cluster.StoredActivation {
+	deadline := time.Now().Add(s.WaitTimeout)
+	for time.Now().Before(deadline) {
+		if activation := s.TryGetExistingActivation(clusterIdentity); activation != nil {
+			return activation
+		}
+
+		ctx, cancel := s.context()
+		_, locked, err := s.client.Get(ctx, s.lockKey(clusterIdentity))
+		cancel()
+		if err == nil && !locked {
+			return s.TryGetExistingActivation(clusterIdentity)
+		}
+
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	return nil
+}
+
+// RemoveLock releases the spawn lock, if it is still held
+func (s *Storage) RemoveLock(spawnLock cluster.SpawnLock) {
+	ctx, cancel := s.context()
+	defer cancel()
+
+	keys := []string{s.lockKey(spawnLock.ClusterIdentity)}
+	if _, err := s.client.Eval(ctx, removeLockScript, keys, spawnLock.LockID); err != nil {
+		s.logger.Error("Failed to remove spawn lock", log.String("identity", spawnLock.ClusterIdentity.AsKey()), log.Error(err))
+	}
+}
+
+// StoreActivation stores the activation of the identity on the member and releases the spawn lock.
+// Nothing is stored if the lock expired meanwhile
+func (s *Storage) StoreActivation(memberID string, spawnLock *cluster.SpawnLock, pid *actor.PID) {
+	s.storeActivation(memberID, spawnLock, pid)
+}
+
+func (s *Storage) storeActivation(memberID string, spawnLock *cluster.SpawnLock, pid *actor.PID) bool {
+	ctx, cancel := s.context()
+	defer cancel()
+
+	value, _ := json.Marshal(&cluster.StoredActivation{Pid: pidToString(pid), MemberID: memberID})
+	activationKey := s.activationKey(spawnLock.ClusterIdentity)
+	keys := []string{s.lockKey(spawnLock.ClusterIdentity), activationKey, s.memberKey(memberID)}
+	res, err := s.client.Eval(ctx, storeActivationScript, keys, spawnLock.LockID, string(value))
+	if err != nil {
+		s.logger.Error("Failed to store activation", log.String("identity", spawnLock.ClusterIdentity.AsKey()), log.Error(err))
+		return false
+	}
+
+	return isOne(res)
+}
+
+// RemoveActivation removes the activation of the identity of the lock
+func (s *Storage) RemoveActivation(spawnLock *cluster.SpawnLock) {
+	ctx, cancel := s.context()
+	defer cancel()
+
+	if err := s.client.Del(ctx, s.activationKey(spawnLock.ClusterIdentity)); err != nil {
+		s.logger.Error("Failed to remove activation", log.String("identity", spawnLock.ClusterIdentity.AsKey()), log.Error(err))
+	}
+}
+
+// removePid removes the activation of the identity, only if it is pid
+func (s *Storage) removePid(clusterIdentity *cluster.ClusterIdentity, memberID string, pid *actor.PID) {
+	ctx, cancel := s.context()
+	defer cancel()
+
+	value, _ := json.Marshal(&cluster.StoredActivation{Pid: pidToString(pid), MemberID: memberID})
+	keys := []string{s.activationKey(clusterIdentity), s.memberKey(memberID)}
+	if _, err := s.client.Eval(ctx, removeActivationScript, keys, string(value)); err != nil {
+		s.logger.Error("Failed to remove activation", log.String("identity", clusterIdentity.AsKey()), log.Error(err))
+	}
+}
+
+// RemoveMemberId removes all activations of the member
+func (s *Storage) RemoveMemberId(memberID string) {
+	ctx, cancel := s.context()
+	defer cancel()
+
+	keys, err := s.client.SMembers(ctx, s.memberKey(memberID))
+	if err != nil {
+		s.logger.Error("Failed to get member activations", log.String("member", memberID), log.Error(err))
+		return
+	}
+
+	if err := s.client.Del(ctx, append(keys, s.memberKey(memberID))...); err != nil {
+		s.logger.Error("Failed to remove member activations", log.String("member", memberID), log.Error(err))
+	}
+}
+
+func isOne(res interface{}) bool {
+	switch v := res.(type) {
+	case int64:
+		return v == 1
+	case int:
+		return v == 1
+	}
+
+	return false
+}
+
+func pidToString(pid *actor.PID) string {
+	return pid.Address + "/" + pid.Id
+}
+
+// pidFromString parses a pid stored as "address/id", addresses never contain a slash
+func pidFromString(s string) *actor.PID {
+	address, id, ok := strings.Cut(s, "/")
+	if !ok {
+		return nil
+	}
+
+	return actor.NewPID(address, id)
+}
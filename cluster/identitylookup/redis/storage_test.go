@@ -0,0 +1,164 @@
+package redis
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/asynkron/protoactor-go/cluster"
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryClient emulates the Redis commands and scripts used by the storage
+type memoryClient struct {
+	mu     sync.Mutex
+	values map[string]string
+	sets   map[string]map[string]struct{}
+}
+
+func newMemoryClient() *memoryClient {
+	return &memoryClient{values: map[string]string{}, sets: map[string]map[string]struct{}{}}
+}
+
+func (c *memoryClient) Get(_ context.Context, key string) (string, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	v, ok := c.values[key]
+	return v, ok, nil
+}
+
+func (c *memoryClient) SetNX(_ context.Context, key string, value string, _ time.Duration) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.values[key]; ok {
+		return false, nil
+	}
+	c.values[key] = value
+	return true, nil
+}
+
+func (c *memoryClient) Del(_ context.Context, keys ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range keys {
+		delete(c.values, key)
+		delete(c.sets, key)
+	}
+	return nil
+}
+
+func (c *memoryClient) SMembers(_ context.Context, key string) ([]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var res []string
+	for m := range c.sets[key] {
+		res = append(res, m)
+	}
+	return res, nil
+}
+
+func (c *memoryClient) Eval(_ context.Context, script string, keys []string, args ...interface{}) (interface{}, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.values[keys[0]] != args[0] {
+		return int64(0), nil
+	}
+
+	switch script {
+	case storeActivationScript:
+		c.values[keys[1]] = args[1].(string)
+		if c.sets[keys[2]] == nil {
+			c.sets[keys[2]] = map[string]struct{}{}
+		}
+		c.sets[keys[2]][keys[1]] = struct{}{}
+		delete(c.values, keys[0])
+	case removeLockScript:
+		delete(c.values, keys[0])
+	case removeActivationScript:
+		delete(c.sets[keys[1]], keys[0])
+		delete(c.values, keys[0])
+	}
+	return int64(1), nil
+}
+
+func TestStorage_SingleLockOwner(t *testing.T) {
+	storage := NewStorage(newMemoryClient(), "test")
+	identity := cluster.NewClusterIdentity("a", "kind")
+
+	var wg sync.WaitGroup
+	locks := make(chan *cluster.SpawnLock, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if lock := storage.TryAcquireLock(identity); lock != nil {
+				locks <- lock
+			}
+		}()
+	}
+	wg.Wait()
+	close(locks)
+
+	assert.Len(t, locks, 1)
+}
+
+func TestStorage_StoreActivation(t *testing.T) {
+	storage := NewStorage(newMemoryClient(), "test")
+	identity := cluster.NewClusterIdentity("a", "kind")
+	pid := actor.NewPID("localhost:8090", "partition-activator/a$1")
+
+	lock := storage.TryAcquireLock(identity)
+	assert.NotNil(t, lock)
+
+	waited := make(chan *cluster.StoredActivation)
+	go func() { waited <- storage.WaitForActivation(identity) }()
+
+	storage.StoreActivation("member1", lock, pid)
+
+	activation := <-waited
+	assert.NotNil(t, activation)
+	assert.Equal(t, "member1", activation.MemberID)
+	assert.True(t, pid.Equal(pidFromString(activation.Pid)))
+
+	// the lock was released
+	assert.NotNil(t, storage.TryAcquireLock(identity))
+}
+
+func TestStorage_ExpiredLockDoesNotStore(t *testing.T) {
+	client := newMemoryClient()
+	storage := NewStorage(client, "test")
+	identity := cluster.NewClusterIdentity("a", "kind")
+
+	lock := storage.TryAcquireLock(identity)
+	// the lock expires and another member takes it
+	_ = client.Del(context.Background(), storage.lockKey(identity))
+	other := storage.TryAcquireLock(identity)
+	assert.NotNil(t, other)
+
+	assert.False(t, storage.storeActivation("member1", lock, actor.NewPID("localhost:8090", "a")))
+	assert.Nil(t, storage.TryGetExistingActivation(identity))
+
+	storage.RemoveLock(*lock)
+	assert.Nil(t, storage.TryAcquireLock(identity), "the lock of the other member must be kept")
+}
+
+func TestStorage_RemoveMemberId(t *testing.T) {
+	storage := NewStorage(newMemoryClient(), "test")
+	a := cluster.NewClusterIdentity("a", "kind")
+	b := cluster.NewClusterIdentity("b", "kind")
+
+	storage.StoreActivation("member1", storage.TryAcquireLock(a), actor.NewPID("localhost:8090", "a"))
+	storage.StoreActivation("member2", storage.TryAcquireLock(b), actor.NewPID("localhost:8091", "b"))
+
+	storage.RemoveMemberId("member1")
+
+	assert.Nil(t, storage.TryGetExistingActivation(a))
+	assert.NotNil(t, storage.TryGetExistingActivation(b))
+}
@@ -18,6 +18,7 @@ const (
 	TopologyKey       string = "topology"
 	HearthbeatKey     string = "heathbeat"
 	GracefullyLeftKey string = "left"
+	WeightKey         string = "weight"
 )
 
 // create and seed a pseudo random numbers generator
@@ -36,6 +37,7 @@ type Informer struct {
 	gossipFanOut      int
 	gossipMaxSend     int
 	throttler         actor.ShouldThrottle
+	logger            log.Interface
 }
 
 // makes sure Informer complies with the Gossip interface
@@ -43,7 +45,7 @@ var _ Gossip = (*Informer)(nil)
 
 // Creates a new Informer value with the given properties and returns
 // back a pointer to its memory location in the heap
-func newInformer(myID string, getBlockedMembers func() set.Set[string], fanOut int, maxSend int) *Informer {
+func newInformer(myID string, getBlockedMembers func() set.Set[string], fanOut int, maxSend int, logger log.Interface) *Informer {
 	informer := Informer{
 		myID: myID,
 		state: &GossipState{
@@ -56,6 +58,7 @@ func newInformer(myID string, getBlockedMembers func() set.Set[string], fanOut i
 		getBlockedMembers: getBlockedMembers,
 		gossipFanOut:      fanOut,
 		gossipMaxSend:     maxSend,
+		logger:            logger,
 	}
 	informer.throttler = actor.NewThrottle(3, 60*time.Second, informer.throttledLog)
 	return &informer
@@ -75,6 +78,7 @@ func (inf *Informer) UpdateClusterTopology(topology *ClusterTopology) {
 	for _, member := range topology.Members {
 		active[member.Id] = empty{}
 	}
+	inf.activeMemberIDs = active
 
 	inf.SetState(TopologyKey, topology)
 }
@@ -96,7 +100,7 @@ func (inf *Informer) SetState(key string, message proto.Message) {
 	//}
 
 	if _, ok := inf.state.Members[inf.myID]; !ok {
-		plog.Error("State corrupt")
+		inf.logger.Error("State corrupt")
 	}
 
 	inf.checkConsensusKey(key)
@@ -288,5 +292,5 @@ func (inf *Informer) commitPendingOffsets(offsets map[string]int64) {
 }
 
 func (inf *Informer) throttledLog(counter int32) {
-	plog.Debug("[Gossip] Setting State", log.Int("throttled", int(counter)))
+	inf.logger.Debug("[Gossip] Setting State", log.Int("throttled", int(counter)))
 }
@@ -20,7 +20,7 @@ func TestInformer_SetState(t *testing.T) {
 		ActorStatistics: &ActorStatistics{},
 	}
 
-	i := newInformer("member1", a, 3, 3)
+	i := newInformer("member1", a, 3, 3, plog)
 	i.SetState("heartbeat", s)
 }
 
@@ -35,7 +35,7 @@ func TestInformer_GetState(t *testing.T) {
 		ActorStatistics: &ActorStatistics{},
 	}
 
-	i := newInformer("member1", a, 3, 3)
+	i := newInformer("member1", a, 3, 3, plog)
 	i.SetState("heartbeat", s)
 
 	m := i.GetState("heartbeat")
@@ -65,7 +65,7 @@ func TestInformer_ReceiveState(t *testing.T) {
 	}
 	dummyValue, _ := anypb.New(s)
 
-	i := newInformer("member1", a, 3, 3)
+	i := newInformer("member1", a, 3, 3, plog)
 	i.SetState("heartbeat", s)
 
 	remoteState := &GossipState{
@@ -134,7 +134,7 @@ func TestInformer_SendState(t *testing.T) {
 		ActorStatistics: &ActorStatistics{},
 	}
 
-	i := newInformer("member1", a, 3, 3)
+	i := newInformer("member1", a, 3, 3, plog)
 	i.SetState("heartbeat", s)
 	// the cluster sees two nodes. itself and member2
 	i.UpdateClusterTopology(&ClusterTopology{
@@ -167,7 +167,7 @@ func TestInformer_UpdateClusterTopology(t *testing.T) {
 	s := &MemberHeartbeat{
 		ActorStatistics: &ActorStatistics{},
 	}
-	i := newInformer("member1", a, 3, 3)
+	i := newInformer("member1", a, 3, 3, plog)
 	i.SetState("heartbeat", s)
 	// the cluster sees two nodes. itself and member2
 	i.UpdateClusterTopology(&ClusterTopology{
@@ -199,7 +199,7 @@ func TestInformer_GetMemberStateDelta(t *testing.T) {
 		ActorStatistics: &ActorStatistics{},
 	}
 
-	i := newInformer("member1", a, 3, 3)
+	i := newInformer("member1", a, 3, 3, plog)
 	i.SetState("heartbeat", s)
 
 	m := i.GetMemberStateDelta("member1")
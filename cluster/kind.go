@@ -2,6 +2,7 @@ package cluster
 
 import (
 	"sync/atomic"
+	"time"
 
 	"github.com/asynkron/protoactor-go/actor"
 )
@@ -11,9 +12,12 @@ type Kind struct {
 	Kind            string
 	Props           *actor.Props
 	StrategyBuilder func(*Cluster) MemberStrategy
+	// PassivationTimeout is how long a grain of the kind may be idle before it is deactivated, zero keeps it active
+	PassivationTimeout time.Duration
 }
 
-// NewKind creates a new instance of a kind
+// NewKind creates a new instance of a kind. The grains of the kind are spawned from props as is, with their mailbox,
+// dispatcher and middleware, the cluster and passivation middleware run after the middleware of props
 func NewKind(kind string, props *actor.Props) *Kind {
 	// add cluster middleware
 	p := props.Clone(withClusterReceiveMiddleware())
@@ -28,15 +32,27 @@ func (k *Kind) WithMemberStrategy(strategyBuilder func(*Cluster) MemberStrategy)
 	k.StrategyBuilder = strategyBuilder
 }
 
+// WithPassivation deactivates grains of the kind which did not receive a message within idle.
+// The grain is stopped and removed from the identity lookup, the next message activates it again
+func (k *Kind) WithPassivation(idle time.Duration) *Kind {
+	k.PassivationTimeout = idle
+	return k
+}
+
 func (k *Kind) Build(cluster *Cluster) *ActivatedKind {
 	var strategy MemberStrategy = nil
 	if k.StrategyBuilder != nil {
 		strategy = k.StrategyBuilder(cluster)
 	}
 
+	props := k.Props
+	if k.PassivationTimeout > 0 {
+		props = props.Clone(withPassivation(k.PassivationTimeout))
+	}
+
 	return &ActivatedKind{
 		Kind:     k.Kind,
-		Props:    k.Props,
+		Props:    props,
 		Strategy: strategy,
 	}
 }
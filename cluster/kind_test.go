@@ -0,0 +1,102 @@
+package cluster
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/asynkron/protoactor-go/persistence"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type countingDispatcher struct {
+	scheduled int32
+}
+
+func (d *countingDispatcher) Schedule(fn func()) {
+	atomic.AddInt32(&d.scheduled, 1)
+	go fn()
+}
+
+func (d *countingDispatcher) Throughput() int {
+	return 300
+}
+
+type inMemoryStore struct {
+	state persistence.ProviderState
+}
+
+func (s *inMemoryStore) GetState() persistence.ProviderState {
+	return s.state
+}
+
+type persistentGrain struct {
+	persistence.Mixin
+	values  []string
+	stopped chan struct{}
+}
+
+func (g *persistentGrain) Receive(ctx actor.Context) {
+	switch msg := ctx.Message().(type) {
+	case *wrapperspb.StringValue:
+		if !g.Recovering() {
+			g.PersistReceive(msg)
+		}
+		g.values = append(g.values, msg.Value)
+	case *wrapperspb.BoolValue:
+		ctx.Respond(append([]string(nil), g.values...))
+	case *actor.Stopped:
+		g.stopped <- struct{}{}
+	}
+}
+
+func TestKind_HonorsPropsWithPassivationAndPersistence(t *testing.T) {
+	var mailboxes int32
+	mailbox := actor.Unbounded()
+	dispatcher := &countingDispatcher{}
+	stopped := make(chan struct{}, 1)
+	provider := &inMemoryStore{state: persistence.NewInMemoryProvider(10)}
+
+	props := actor.PropsFromProducer(func() actor.Actor {
+		return &persistentGrain{stopped: stopped}
+	},
+		actor.WithMailbox(func() actor.Mailbox {
+			atomic.AddInt32(&mailboxes, 1)
+			return mailbox()
+		}),
+		actor.WithDispatcher(dispatcher),
+		actor.WithReceiverMiddleware(persistence.Using(provider, persistence.WithName(func(ctx actor.Context) string {
+			return GetClusterIdentity(ctx).AsKey()
+		}))),
+	)
+
+	c := newClusterForTest("mycluster", nil, WithKind("counter", props))
+	c.IdentityLookup = c.Config.IdentityLookup
+	kind := c.Config.Kinds["counter"].WithPassivation(50 * time.Millisecond).Build(c)
+	identity := NewClusterIdentity("a", "counter")
+	system := c.ActorSystem
+
+	pid := system.Root.Spawn(WithClusterIdentity(kind.Props, identity))
+	system.Root.Send(pid, wrapperspb.String("1"))
+	system.Root.Send(pid, wrapperspb.String("2"))
+	res, err := system.Root.RequestFuture(pid, wrapperspb.Bool(true), time.Second).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1", "2"}, res)
+
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("grain not passivated")
+	}
+
+	// the next activation recovers the events persisted for the identity by the previous one
+	pid = system.Root.Spawn(WithClusterIdentity(kind.Props, identity))
+	res, err = system.Root.RequestFuture(pid, wrapperspb.Bool(true), time.Second).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1", "2"}, res)
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(&mailboxes))
+	assert.Greater(t, atomic.LoadInt32(&dispatcher.scheduled), int32(0))
+}
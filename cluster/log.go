@@ -1,6 +1,7 @@
 package cluster
 
 import (
+	"github.com/asynkron/protoactor-go/actor"
 	"github.com/asynkron/protoactor-go/log"
 )
 
@@ -12,3 +13,17 @@ var plog = log.New(log.DefaultLevel, "[CLUSTER]")
 func SetLogLevel(level log.Level) {
 	plog.SetLevel(level)
 }
+
+// Logger returns the logger of the actor system when one was set with actor.WithLogger, or the package logger
+func (c *Cluster) Logger() log.Interface {
+	return systemLogger(c.ActorSystem)
+}
+
+// systemLogger is Cluster.Logger for the actors of the cluster, which only know their actor system
+func systemLogger(system *actor.ActorSystem) log.Interface {
+	if logger := system.Config.Logger; logger != nil {
+		return logger
+	}
+
+	return plog
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/asynkron/protoactor-go/log"
 	"github.com/asynkron/protoactor-go/remote"
 	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
 // MemberList is responsible to keep track of the current cluster topology
@@ -19,9 +20,13 @@ type MemberList struct {
 	mutex                sync.RWMutex
 	members              *MemberSet
 	memberStrategyByKind map[string]MemberStrategy
+	weightsMutex         sync.RWMutex
+	weights              map[string]int
 
 	eventSteam        *eventstream.EventStream
 	topologyConsensus ConsensusHandler
+
+	topologyHooks topologyHooks
 }
 
 func NewMemberList(cluster *Cluster) *MemberList {
@@ -29,11 +34,26 @@ func NewMemberList(cluster *Cluster) *MemberList {
 		cluster:              cluster,
 		members:              emptyMemberSet,
 		memberStrategyByKind: make(map[string]MemberStrategy),
+		weights:              make(map[string]int),
 		eventSteam:           cluster.ActorSystem.EventStream,
 	}
+	if cluster.Config != nil && cluster.Config.MemberWeight > 1 {
+		memberList.weights[cluster.ActorSystem.ID] = cluster.Config.MemberWeight
+	}
 	memberList.eventSteam.Subscribe(func(evt interface{}) {
 		switch t := evt.(type) {
 		case *GossipUpdate:
+			if t.Key == WeightKey {
+				var weight wrapperspb.Int32Value
+				if err := t.Value.UnmarshalTo(&weight); err != nil {
+					cluster.Logger().Warn("could not unpack member weight", log.String("member", t.MemberID), log.Error(err))
+
+					break
+				}
+				memberList.updateMemberWeight(t.MemberID, int(weight.Value))
+
+				break
+			}
 			if t.Key != "topology" {
 				break
 			}
@@ -42,7 +62,7 @@ func NewMemberList(cluster *Cluster) *MemberList {
 			// and merge that without own blocked set
 			var topology ClusterTopology
 			if err := t.Value.UnmarshalTo(&topology); err != nil {
-				plog.Warn("could not unpack into ClusterTopology proto.Message form Any", log.Error(err))
+				cluster.Logger().Warn("could not unpack into ClusterTopology proto.Message form Any", log.Error(err))
 
 				break
 			}
@@ -54,15 +74,66 @@ func NewMemberList(cluster *Cluster) *MemberList {
 	return memberList
 }
 
+// MemberWeights returns the weights advertised by the members, by member id. Members which did not advertise a weight
+// have a weight of 1 and are not included
+func (ml *MemberList) MemberWeights() map[string]int {
+	ml.weightsMutex.RLock()
+	defer ml.weightsMutex.RUnlock()
+
+	weights := make(map[string]int, len(ml.weights))
+	for id, weight := range ml.weights {
+		weights[id] = weight
+	}
+
+	return weights
+}
+
+// updateMemberWeight rebalances the member strategies when a member advertises a new weight
+func (ml *MemberList) updateMemberWeight(memberID string, weight int) {
+	if weight < 1 {
+		weight = 1
+	}
+
+	ml.weightsMutex.Lock()
+	current, ok := ml.weights[memberID]
+	if !ok {
+		current = 1
+	}
+	if current == weight {
+		ml.weightsMutex.Unlock()
+		return
+	}
+
+	if weight == 1 {
+		delete(ml.weights, memberID)
+	} else {
+		ml.weights[memberID] = weight
+	}
+	ml.weightsMutex.Unlock()
+
+	weights := ml.MemberWeights()
+	ml.mutex.Lock()
+	for _, strategy := range ml.memberStrategyByKind {
+		if weighted, ok := strategy.(WeightedMemberStrategy); ok {
+			weighted.UpdateWeights(weights)
+		}
+	}
+	ml.mutex.Unlock()
+
+	ml.cluster.Logger().Info("member weight changed", log.String("member", memberID), log.Int("weight", weight))
+	ml.cluster.ActorSystem.EventStream.Publish(&MemberWeightsChanged{Weights: weights})
+}
+
 func (ml *MemberList) stopMemberList() {
 	// ml.cluster.ActorSystem.EventStream.Unsubscribe(ml.membershipSub)
+	ml.topologyHooks.stop()
 }
 
 func (ml *MemberList) InitializeTopologyConsensus() {
 	ml.topologyConsensus = ml.cluster.Gossip.RegisterConsensusCheck("topology", func(any *anypb.Any) interface{} {
 		var topology ClusterTopology
 		if unpackErr := any.UnmarshalTo(&topology); unpackErr != nil {
-			plog.Error("could not unpack topology message", log.Error(unpackErr))
+			ml.cluster.Logger().Error("could not unpack topology message", log.Error(unpackErr))
 
 			return nil
 		}
@@ -158,8 +229,9 @@ func (ml *MemberList) UpdateClusterTopology(members Members) {
 	}
 
 	ml.cluster.ActorSystem.EventStream.Publish(topology)
+	ml.topologyHooks.changed(ml)
 
-	plog.Info("Updated ClusterTopology",
+	ml.cluster.Logger().Info("Updated ClusterTopology",
 		log.Uint64("topology-hash", topology.TopologyHash),
 		log.Int("members", len(topology.Members)),
 		log.Int("joined", len(topology.Joined)),
@@ -169,7 +241,7 @@ func (ml *MemberList) UpdateClusterTopology(members Members) {
 }
 
 func (ml *MemberList) memberJoin(joiningMember *Member) {
-	plog.Info("member joined", log.String("member", joiningMember.Id))
+	ml.cluster.Logger().Info("member joined", log.String("member", joiningMember.Id))
 
 	for _, kind := range joiningMember.Kinds {
 		if ml.memberStrategyByKind[kind] == nil {
@@ -181,6 +253,10 @@ func (ml *MemberList) memberJoin(joiningMember *Member) {
 }
 
 func (ml *MemberList) memberLeave(leavingMember *Member) {
+	ml.weightsMutex.Lock()
+	delete(ml.weights, leavingMember.Id)
+	ml.weightsMutex.Unlock()
+
 	for _, kind := range leavingMember.Kinds {
 		if ml.memberStrategyByKind[kind] == nil {
 			continue
@@ -240,7 +316,7 @@ func (ml *MemberList) ContainsMemberID(memberID string) bool {
 }
 
 func (ml *MemberList) getMemberStrategyByKind(kind string) MemberStrategy {
-	plog.Info("creating member strategy", log.String("kind", kind))
+	ml.cluster.Logger().Info("creating member strategy", log.String("kind", kind))
 
 	clusterKind, ok := ml.cluster.TryGetClusterKind(kind)
 
@@ -251,9 +327,13 @@ func (ml *MemberList) getMemberStrategyByKind(kind string) MemberStrategy {
 	}
 
 	strategy := ml.cluster.Config.MemberStrategyBuilder(ml.cluster, kind)
-	if strategy != nil {
-		return strategy
+	if strategy == nil {
+		strategy = newDefaultMemberStrategy(ml.cluster, kind)
+	}
+
+	if weighted, ok := strategy.(WeightedMemberStrategy); ok {
+		weighted.UpdateWeights(ml.MemberWeights())
 	}
 
-	return newDefaultMemberStrategy(ml.cluster, kind)
+	return strategy
 }
@@ -8,6 +8,8 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
 //func TestPublishRaceCondition(t *testing.T) {
@@ -284,3 +286,74 @@ func TestMemberList_newMemberStrategies(t *testing.T) {
 		a.Equal(v, len(obj.memberStrategyByKind["kind2"].GetAllMembers()))
 	}
 }
+
+func TestMemberList_MemberWeightFromGossip(t *testing.T) {
+	a := assert.New(t)
+
+	c := newClusterForTest("test-memberlist-weight", nil)
+	obj := NewMemberList(c)
+	members := newMembersForTest(3)
+	obj.UpdateClusterTopology(members)
+
+	changed := make(chan *MemberWeightsChanged, 1)
+	c.ActorSystem.EventStream.Subscribe(func(evt interface{}) {
+		if e, ok := evt.(*MemberWeightsChanged); ok {
+			select {
+			case changed <- e:
+			default:
+			}
+		}
+	})
+
+	value, _ := anypb.New(wrapperspb.Int32(3))
+	c.ActorSystem.EventStream.Publish(&GossipUpdate{MemberID: members[0].Id, Key: WeightKey, Value: value})
+
+	a.Equal(map[string]int{members[0].Id: 3}, obj.MemberWeights())
+	a.Equal(map[string]int{members[0].Id: 3}, (<-changed).Weights)
+
+	// the member leaving forgets its weight
+	obj.UpdateClusterTopology(members[1:])
+	a.Empty(obj.MemberWeights())
+}
+
+func TestCluster_OnTopologyChanged(t *testing.T) {
+	c := newClusterForTest("test-OnTopologyChanged", nil, WithTopologyChangeDebounce(50*time.Millisecond))
+	changes := make(chan *ClusterTopology, 10)
+	c.OnTopologyChanged(func(topology *ClusterTopology) {
+		changes <- topology
+	})
+
+	receive := func() *ClusterTopology {
+		select {
+		case topology := <-changes:
+			return topology
+		case <-time.After(time.Second):
+			t.Fatal("topology change not reported")
+			return nil
+		}
+	}
+
+	// a member joining and leaving again before the topology settled is not reported
+	members := newMembersForTest(4)
+	c.MemberList.UpdateClusterTopology(members[:3])
+	c.MemberList.UpdateClusterTopology(members)
+	c.MemberList.UpdateClusterTopology(members[:3])
+
+	topology := receive()
+	assert.Equal(t, NewMemberSet(members[:3]), NewMemberSet(topology.Members))
+	assert.Equal(t, NewMemberSet(members[:3]), NewMemberSet(topology.Joined))
+	assert.Empty(t, topology.Left)
+	assert.Equal(t, NewMemberSet(members[:3]).TopologyHash(), topology.TopologyHash)
+
+	c.MemberList.UpdateClusterTopology(members[1:3])
+	topology = receive()
+	assert.Equal(t, NewMemberSet(members[1:3]), NewMemberSet(topology.Members))
+	assert.Empty(t, topology.Joined)
+	assert.Equal(t, NewMemberSet(members[:1]), NewMemberSet(topology.Left))
+
+	select {
+	case topology := <-changes:
+		t.Fatalf("unexpected topology change %v", topology)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
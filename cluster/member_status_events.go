@@ -50,3 +50,9 @@ type MemberAvailableEvent struct {
 }
 
 func (*MemberAvailableEvent) MemberStatusEvent() {}
+
+// MemberWeightsChanged is published when a member advertises a new weight, it holds the weights of all members
+// by member id. Members not included have a weight of 1
+type MemberWeightsChanged struct {
+	Weights map[string]int
+}
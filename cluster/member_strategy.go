@@ -8,6 +8,13 @@ type MemberStrategy interface {
 	GetActivator(senderAddress string) string
 }
 
+// WeightedMemberStrategy is implemented by member strategies which take the weights of the members into account
+type WeightedMemberStrategy interface {
+	MemberStrategy
+	// UpdateWeights sets the weights of the members by member id
+	UpdateWeights(weights map[string]int)
+}
+
 type simpleMemberStrategy struct {
 	members Members
 	rr      *SimpleRoundRobin
@@ -21,6 +28,10 @@ func newDefaultMemberStrategy(cluster *Cluster, kind string) MemberStrategy {
 	return ms
 }
 
+func (m *simpleMemberStrategy) UpdateWeights(weights map[string]int) {
+	m.rdv.UpdateWeights(weights)
+}
+
 func (m *simpleMemberStrategy) AddMember(member *Member) {
 	m.members = append(m.members, member)
 	m.rdv.UpdateMembers(m.members)
@@ -0,0 +1,129 @@
+package cluster
+
+import (
+	"context"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/asynkron/protoactor-go/ctxext"
+	"github.com/asynkron/protoactor-go/log"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// deactivate is sent by an idle grain to its own mailbox, behind the messages which reached it before it was deactivated
+type deactivate struct {
+	process actor.Process
+}
+
+// passivationTick is sent by the idle timer of a grain, the grain is deactivated if it did not receive a message since
+type passivationTick struct{}
+
+func (*passivationTick) NotInfluenceReceiveTimeout() {}
+
+var passivationExtensionId = ctxext.NextContextExtensionID()
+
+// passivationState tracks when the grain last received a message. It is only accessed by the grain itself,
+// the timer merely sends passivationTick
+type passivationState struct {
+	timer        *time.Timer
+	lastActivity time.Time
+	passivated   bool
+}
+
+func (s *passivationState) ExtensionID() ctxext.ContextExtensionID {
+	return passivationExtensionId
+}
+
+func withPassivation(idle time.Duration) actor.PropsOption {
+	return actor.WithReceiverMiddleware(func(next actor.ReceiverFunc) actor.ReceiverFunc {
+		return func(c actor.ReceiverContext, envelope *actor.MessageEnvelope) {
+			switch msg := envelope.Message.(type) {
+			case *actor.Started:
+				next(c, envelope)
+				startPassivationTimer(c, idle)
+			case *passivationTick:
+				state, ok := c.Get(passivationExtensionId).(*passivationState)
+				if !ok || state.passivated {
+					return
+				}
+				if elapsed := time.Since(state.lastActivity); elapsed < idle {
+					state.timer.Reset(idle - elapsed)
+					return
+				}
+				state.passivated = true
+				passivate(c)
+			case *deactivate:
+				msg.process.Stop(c.Self())
+			case *actor.Stopped:
+				if state, ok := c.Get(passivationExtensionId).(*passivationState); ok {
+					state.timer.Stop()
+				}
+				next(c, envelope)
+			case *actor.ReceiveTimeout:
+				// the receive timeout of the grain itself is no activity
+				next(c, envelope)
+			default:
+				if state, ok := c.Get(passivationExtensionId).(*passivationState); ok {
+					state.lastActivity = time.Now()
+				}
+				next(c, envelope)
+			}
+		}
+	})
+}
+
+func startPassivationTimer(c actor.ReceiverContext, idle time.Duration) {
+	if state, ok := c.Get(passivationExtensionId).(*passivationState); ok {
+		// restarted, the grain keeps the timer it had
+		state.lastActivity = time.Now()
+		state.passivated = false
+		state.timer.Reset(idle)
+		return
+	}
+
+	system, self := c.ActorSystem(), c.Self()
+	c.Set(&passivationState{
+		lastActivity: time.Now(),
+		timer: time.AfterFunc(idle, func() {
+			system.Root.Send(self, &passivationTick{})
+		}),
+	})
+}
+
+// passivate deactivates the idle grain. It is removed from the process registry first, so messages sent from now on
+// are dead lettered and the cluster request activates the grain again. Messages which reached the mailbox before are
+// still processed, as the grain only stops once the deactivate message queued behind them is received
+func passivate(c actor.ReceiverContext) {
+	system := c.ActorSystem()
+	self := c.Self()
+
+	process, ok := system.ProcessRegistry.GetLocal(self.Id)
+	if !ok {
+		return
+	}
+	system.ProcessRegistry.Remove(self)
+
+	cl := GetCluster(system)
+	if identity, ok := c.Get(ciExtensionId).(*ClusterIdentity); ok {
+		cl.Logger().Debug("Passivating idle grain", log.String("identity", identity.AsKey()), log.PID("pid", self))
+		cl.PidCache.RemoveByValue(identity.Identity, identity.Kind, self)
+		cl.IdentityLookup.RemovePid(identity, self)
+		recordPassivation(system, identity.Kind)
+	}
+
+	process.SendUserMessage(self, &deactivate{process: process})
+}
+
+func recordPassivation(system *actor.ActorSystem, kind string) {
+	m := actor.GetMetrics(system)
+	if m == nil || !m.Enabled() {
+		return
+	}
+
+	if instruments := m.Instruments(); instruments != nil {
+		instruments.GrainPassivatedCount.Add(context.Background(), 1,
+			attribute.String("address", system.Address()),
+			attribute.String("clusterkind", kind),
+		)
+	}
+}
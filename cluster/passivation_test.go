@@ -0,0 +1,92 @@
+package cluster
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPassivation_ForwardsReceiveTimeoutOfTheGrain(t *testing.T) {
+	timeouts := make(chan struct{}, 10)
+	stopped := make(chan struct{}, 1)
+	props := actor.PropsFromFunc(func(ctx actor.Context) {
+		switch ctx.Message().(type) {
+		case *actor.Started:
+			ctx.SetReceiveTimeout(10 * time.Millisecond)
+		case *actor.ReceiveTimeout:
+			timeouts <- struct{}{}
+		case *actor.Stopped:
+			stopped <- struct{}{}
+		}
+	})
+
+	c := newClusterForTest("mycluster", nil, WithKind("timeouts", props))
+	c.IdentityLookup = c.Config.IdentityLookup
+	kind := c.Config.Kinds["timeouts"].WithPassivation(500 * time.Millisecond).Build(c)
+	c.ActorSystem.Root.Spawn(WithClusterIdentity(kind.Props, NewClusterIdentity("a", "timeouts")))
+
+	select {
+	case <-timeouts:
+	case <-stopped:
+		t.Fatal("grain passivated before it received its receive timeout")
+	case <-time.After(time.Second):
+		t.Fatal("the receive timeout of the grain was not delivered")
+	}
+
+	// receive timeouts are no activity, the grain still passivates
+	select {
+	case <-stopped:
+	case <-time.After(2 * time.Second):
+		t.Fatal("grain not passivated")
+	}
+}
+
+func TestPassivation_DeliversMessagesQueuedBeforeDeactivation(t *testing.T) {
+	release := make(chan struct{})
+	var mu sync.Mutex
+	var received []interface{}
+	record := func(msg interface{}) {
+		mu.Lock()
+		defer mu.Unlock()
+		received = append(received, msg)
+	}
+	props := actor.PropsFromFunc(func(ctx actor.Context) {
+		switch msg := ctx.Message().(type) {
+		case string:
+			record(msg)
+			if msg == "block" {
+				<-release
+			}
+		case *actor.Stopped:
+			record("stopped")
+		}
+	})
+
+	c := newClusterForTest("mycluster", nil, WithKind("blocking", props))
+	c.IdentityLookup = c.Config.IdentityLookup
+	kind := c.Config.Kinds["blocking"].WithPassivation(20 * time.Millisecond).Build(c)
+	system := c.ActorSystem
+	pid := system.Root.Spawn(WithClusterIdentity(kind.Props, NewClusterIdentity("a", "blocking")))
+
+	// the idle timer fires while the grain is busy, and the tick queues behind the block message
+	system.Root.Send(pid, "block")
+	time.Sleep(50 * time.Millisecond)
+	system.Root.Send(pid, "queued")
+	close(release)
+
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(received) > 0 && received[len(received)-1] == "stopped"
+	}, 2*time.Second, 10*time.Millisecond)
+
+	// messages which reached the mailbox before the grain was deactivated are processed, later ones are not
+	mu.Lock()
+	assert.Equal(t, []interface{}{"block", "queued", "stopped"}, received)
+	mu.Unlock()
+	_, ok := system.ProcessRegistry.GetLocal(pid.Id)
+	assert.False(t, ok)
+}
@@ -32,7 +32,7 @@ func (p *PubSub) Start() {
 	if err != nil {
 		panic(err) // let it crash
 	}
-	plog.Info("Started Cluster PubSub")
+	p.cluster.Logger().Info("Started Cluster PubSub")
 }
 
 func (p *PubSub) ExtensionID() extensions.ExtensionID {
@@ -45,6 +45,13 @@ type PubSubConfig struct {
 	// This value gets rounded to seconds for optimization of cancellation token creation. Note that internally,
 	// cluster request is used to deliver messages to ClusterIdentity subscribers.
 	SubscriberTimeout time.Duration
+
+	// MaxInFlightBatchesPerMember bounds the batches a topic delivers at once to the subscribers on one member, the
+	// next batches wait in a queue of MemberQueueSize batches until a delivery is reported. Once the queue is full,
+	// the LaggingSubscriberPolicy applies. Zero disables the windows and each publish waits for all members
+	MaxInFlightBatchesPerMember int
+	MemberQueueSize             int
+	LaggingSubscriberPolicy     LaggingSubscriberPolicy
 }
 
 func newPubSubConfig() *PubSubConfig {
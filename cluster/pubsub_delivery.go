@@ -8,21 +8,28 @@ import (
 	"github.com/asynkron/protoactor-go/remote"
 )
 
-var pubsubMemberDeliveryLogThrottle = actor.NewThrottle(10, time.Second, func(i int32) {
-	plog.Warn("[PubSubMemberDeliveryActor] Throttled logs", log.Int("count", int(i)))
-})
-
 type PubSubMemberDeliveryActor struct {
 	subscriberTimeout time.Duration
+	logger            log.Interface
+	logThrottle       actor.ShouldThrottle
 }
 
 func NewPubSubMemberDeliveryActor(subscriberTimeout time.Duration) *PubSubMemberDeliveryActor {
-	return &PubSubMemberDeliveryActor{
+	p := &PubSubMemberDeliveryActor{
 		subscriberTimeout: subscriberTimeout,
+		logger:            plog,
 	}
+	p.logThrottle = actor.NewThrottle(10, time.Second, func(i int32) {
+		p.logger.Warn("[PubSubMemberDeliveryActor] Throttled logs", log.Int("count", int(i)))
+	})
+
+	return p
 }
 
 func (p *PubSubMemberDeliveryActor) Receive(c actor.Context) {
+	if _, ok := c.Message().(*actor.Started); ok {
+		p.logger = systemLogger(c.ActorSystem())
+	}
 	if batch, ok := c.Message().(*DeliverBatchRequest); ok {
 		topicBatch := &PubSubAutoRespondBatch{Envelopes: batch.PubSubBatch.Envelopes}
 		siList := batch.Subscribers.Subscribers
@@ -44,11 +51,11 @@ func (p *PubSubMemberDeliveryActor) Receive(c actor.Context) {
 		for _, fWithIdentity := range futureList {
 			_, err := fWithIdentity.future.Result()
 			identityLog := func(err error) {
-				if pubsubMemberDeliveryLogThrottle() == actor.Open {
+				if p.logThrottle() == actor.Open {
 					if fWithIdentity.identity.GetPid() != nil {
-						plog.Info("Pub-sub message delivered to PID", log.String("pid", fWithIdentity.identity.GetPid().String()))
+						p.logger.Info("Pub-sub message delivered to PID", log.String("pid", fWithIdentity.identity.GetPid().String()))
 					} else if fWithIdentity.identity.GetClusterIdentity() != nil {
-						plog.Info("Pub-sub message delivered to cluster identity", log.String("cluster identity", fWithIdentity.identity.GetClusterIdentity().String()))
+						p.logger.Info("Pub-sub message delivered to cluster identity", log.String("cluster identity", fWithIdentity.identity.GetClusterIdentity().String()))
 					}
 				}
 			}
@@ -72,6 +79,12 @@ func (p *PubSubMemberDeliveryActor) Receive(c actor.Context) {
 			}
 		}
 
+		// the topic waits for the delivery report, to report failed deliveries to the publisher
+		if c.Sender() != nil {
+			c.Respond(&PublishResponse{FailedDeliveries: invalidDeliveries})
+			return
+		}
+
 		if len(invalidDeliveries) > 0 {
 			cluster := GetCluster(c.ActorSystem())
 			// we use cluster.Call to locate the topic actor in the cluster
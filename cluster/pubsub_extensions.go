@@ -1,6 +1,8 @@
 package cluster
 
 import (
+	"context"
+
 	"github.com/asynkron/protoactor-go/actor"
 )
 
@@ -14,6 +16,12 @@ func (c *Cluster) BatchingProducer(topic string, opts ...BatchingProducerConfigO
 	return NewBatchingProducer(c.Publisher(), topic, opts...)
 }
 
+// Publish publishes a single message to all subscribers of the topic. The response reports the subscribers
+// the message could not be delivered to
+func (c *Cluster) Publish(topic string, message interface{}, opts ...GrainCallOption) (*PublishResponse, error) {
+	return c.Publisher().Publish(context.Background(), topic, message, opts...)
+}
+
 // SubscribeByPid subscribes to a PubSub topic by subscriber PID
 func (c *Cluster) SubscribeByPid(topic string, pid *actor.PID, opts ...GrainCallOption) (*SubscribeResponse, error) {
 	res, err := c.Call(topic, TopicActorKind, &SubscribeRequest{
@@ -31,8 +31,8 @@ type BatchingProducerConfig struct {
 	// Default: Fail and stop the BatchingProducer
 	OnPublishingError PublishingErrorHandler
 
-	// A throttle for logging from this producer. By default, a throttle shared between all instances of
-	// BatchingProducer is used, that allows for 10 events in 1 second.
+	// A throttle for logging from this producer. By default, each BatchingProducer uses a throttle of its own,
+	// that allows for 10 events in 1 second.
 	LogThrottle actor.ShouldThrottle
 
 	// Optional idle timeout which will specify to the `IPublisher` how long it should wait before invoking clean
@@ -40,10 +40,6 @@ type BatchingProducerConfig struct {
 	PublisherIdleTimeout time.Duration
 }
 
-var defaultBatchingProducerLogThrottle = actor.NewThrottle(10, time.Second, func(i int32) {
-	plog.Info("[BatchingProducer] Throttled logs", log.Int("count", int(i)))
-})
-
 func newBatchingProducerConfig(opts ...BatchingProducerConfigOption) *BatchingProducerConfig {
 	config := &BatchingProducerConfig{
 		BatchSize:      2000,
@@ -51,7 +47,6 @@ func newBatchingProducerConfig(opts ...BatchingProducerConfigOption) *BatchingPr
 		OnPublishingError: func(retries int, e error, batch *PubSubBatch) *PublishingErrorDecision {
 			return FailBatchAndStop
 		},
-		LogThrottle: defaultBatchingProducerLogThrottle,
 	}
 
 	for _, opt := range opts {
@@ -69,6 +64,7 @@ type BatchingProducer struct {
 	loopCancel       context.CancelFunc
 	loopDone         chan struct{}
 	msgLeft          uint32
+	logger           log.Interface
 }
 
 func NewBatchingProducer(publisher Publisher, topic string, opts ...BatchingProducerConfigOption) *BatchingProducer {
@@ -79,6 +75,16 @@ func NewBatchingProducer(publisher Publisher, topic string, opts ...BatchingProd
 		publisher: publisher,
 		msgLeft:   0,
 		loopDone:  make(chan struct{}),
+		logger:    plog,
+	}
+	// the producers of a cluster log with the logger of its actor system
+	if dp, ok := publisher.(*defaultPublisher); ok {
+		p.logger = dp.cluster.Logger()
+	}
+	if config.LogThrottle == nil {
+		config.LogThrottle = actor.NewThrottle(10, time.Second, func(i int32) {
+			p.logger.Info("[BatchingProducer] Throttled logs", log.Int("count", int(i)))
+		})
 	}
 	if config.MaxQueueSize > 0 {
 		p.publisherChannel = newBoundedChannel[produceMessage](config.MaxQueueSize)
@@ -197,13 +203,13 @@ func (p *BatchingProducer) Produce(ctx context.Context, message interface{}) (*P
 func (p *BatchingProducer) publishLoop(ctx context.Context) {
 	defer close(p.loopDone)
 
-	plog.Debug("Producer is starting the publisher loop for topic", log.String("topic", p.topic))
+	p.logger.Debug("Producer is starting the publisher loop for topic", log.String("topic", p.topic))
 	batchWrapper := newPubSubBatchWithReceipts()
 
 	handleUnrecoverableError := func(err error) {
 		p.stopAcceptingNewMessages()
 		if p.config.LogThrottle() == actor.Open {
-			plog.Error("Error in the publisher loop of Producer for topic", log.String("topic", p.topic), log.Error(err))
+			p.logger.Error("Error in the publisher loop of Producer for topic", log.String("topic", p.topic), log.Error(err))
 		}
 		p.failBatch(batchWrapper, err)
 		p.failPendingMessages(err)
@@ -366,7 +372,7 @@ loop:
 				}
 
 				if p.config.LogThrottle() == actor.Open {
-					plog.Warn("Error while publishing batch", log.Error(err))
+					p.logger.Warn("Error while publishing batch", log.Error(err))
 				}
 
 				if decision == FailBatchAndContinue {
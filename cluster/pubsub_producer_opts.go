@@ -41,8 +41,8 @@ func WithBatchingProducerOnPublishingError(onPublishingError PublishingErrorHand
 	}
 }
 
-// WithBatchingProducerLogThrottle sets a throttle for logging from this producer. By default, a throttle shared between all instances of
-// BatchingProducer is used, that allows for 10 events in 10 seconds.
+// WithBatchingProducerLogThrottle sets a throttle for logging from this producer. By default, each BatchingProducer uses a
+// throttle of its own, that allows for 10 events in 1 second.
 func WithBatchingProducerLogThrottle(logThrottle actor.ShouldThrottle) BatchingProducerConfigOption {
 	return func(config *BatchingProducerConfig) {
 		config.LogThrottle = logThrottle
@@ -8,27 +8,35 @@ import (
 	"github.com/asynkron/protoactor-go/actor"
 	"github.com/asynkron/protoactor-go/eventstream"
 	"github.com/asynkron/protoactor-go/log"
+	"github.com/asynkron/protoactor-go/remote"
 	"golang.org/x/exp/maps"
 )
 
 const TopicActorKind = "prototopic"
 
-var topicLogThrottle = actor.NewThrottle(10, time.Second, func(count int32) {
-	plog.Info("[TopicActor] Throttled logs", log.Int("count", int(count)))
-})
-
 type TopicActor struct {
 	topic                string
 	subscribers          map[subscribeIdentityStruct]*SubscriberIdentity
 	subscriptionStore    KeyValueStore[*Subscribers]
 	topologySubscription *eventstream.Subscription
+	endpointSubscription *eventstream.Subscription
+	deliveries           map[string]*memberDelivery
+	logger               log.Interface
+	logThrottle          actor.ShouldThrottle
 }
 
 func NewTopicActor(store KeyValueStore[*Subscribers]) *TopicActor {
-	return &TopicActor{
+	t := &TopicActor{
 		subscriptionStore: store,
 		subscribers:       make(map[subscribeIdentityStruct]*SubscriberIdentity),
+		deliveries:        make(map[string]*memberDelivery),
+		logger:            plog,
 	}
+	t.logThrottle = actor.NewThrottle(10, time.Second, func(count int32) {
+		t.logger.Info("[TopicActor] Throttled logs", log.Int("count", int(count)))
+	})
+
+	return t
 }
 
 func (t *TopicActor) Receive(c actor.Context) {
@@ -51,16 +59,24 @@ func (t *TopicActor) Receive(c actor.Context) {
 		t.onNotifyAboutFailingSubscribers(c, msg)
 	case *ClusterTopology:
 		t.onClusterTopologyChanged(c, msg)
+	case *remote.EndpointTerminatedEvent:
+		t.onEndpointTerminated(c, msg)
 	}
 }
 
 func (t *TopicActor) onStarted(c actor.Context) {
+	t.logger = systemLogger(c.ActorSystem())
 	t.topic = GetClusterIdentity(c).Identity
 	t.topologySubscription = c.ActorSystem().EventStream.Subscribe(func(evt interface{}) {
 		if clusterTopology, ok := evt.(*ClusterTopology); ok {
 			c.Send(c.Self(), clusterTopology)
 		}
 	})
+	t.endpointSubscription = c.ActorSystem().EventStream.Subscribe(func(evt interface{}) {
+		if terminated, ok := evt.(*remote.EndpointTerminatedEvent); ok {
+			c.Send(c.Self(), terminated)
+		}
+	})
 
 	sub := t.loadSubscriptions(t.topic)
 	if sub.Subscribers != nil {
@@ -70,7 +86,7 @@ func (t *TopicActor) onStarted(c actor.Context) {
 	}
 	t.unsubscribeSubscribersOnMembersThatLeft(c)
 
-	plog.Debug("Topic started", log.String("topic", t.topic))
+	t.logger.Debug("Topic started", log.String("topic", t.topic))
 }
 
 func (t *TopicActor) onStopping(c actor.Context) {
@@ -78,6 +94,10 @@ func (t *TopicActor) onStopping(c actor.Context) {
 		c.ActorSystem().EventStream.Unsubscribe(t.topologySubscription)
 		t.topologySubscription = nil
 	}
+	if t.endpointSubscription != nil {
+		c.ActorSystem().EventStream.Unsubscribe(t.endpointSubscription)
+		t.endpointSubscription = nil
+	}
 }
 
 func (t *TopicActor) onReceiveTimeout(c actor.Context) {
@@ -110,7 +130,20 @@ func (t *TopicActor) onPubSubBatch(c actor.Context, batch *PubSubBatch) {
 		}
 	}
 
-	// send message to each member
+	if len(members) == 0 {
+		c.Respond(&PublishResponse{})
+		return
+	}
+
+	if config := GetCluster(c.ActorSystem()).Config.PubSubConfig; config.MaxInFlightBatchesPerMember > 0 {
+		t.onPubSubBatchWithBackpressure(c, batch, members, config)
+		return
+	}
+
+	// send message to each member, and respond once all members reported the delivery
+	timeout := GetCluster(c.ActorSystem()).Config.PubSubConfig.SubscriberTimeout + time.Second
+	pending := len(members)
+	var failed []*SubscriberDeliveryReport
 	for address, member := range members {
 		subscribersOnMember := t.getSubscribersForAddress(member)
 		deliveryMessage := &DeliverBatchRequest{
@@ -119,9 +152,45 @@ func (t *TopicActor) onPubSubBatch(c actor.Context, batch *PubSubBatch) {
 			Topic:       t.topic,
 		}
 		deliveryPid := actor.NewPID(address, PubSubDeliveryName)
-		c.Send(deliveryPid, deliveryMessage)
+		c.ReenterAfter(c.RequestFuture(deliveryPid, deliveryMessage, timeout), func(res interface{}, err error) {
+			failed = append(failed, t.memberDeliveryFailures(subscribersOnMember, res, err)...)
+			pending--
+			if pending > 0 {
+				return
+			}
+
+			if len(failed) == 0 {
+				c.Respond(&PublishResponse{})
+				return
+			}
+
+			t.unsubscribeUnreachablePidSubscribers(c, failed)
+			t.logDeliveryErrors(failed)
+			c.Respond(&PublishResponse{Status: PublishStatus_Failed, FailedDeliveries: failed})
+		})
 	}
-	c.Respond(&PublishResponse{})
+}
+
+// memberDeliveryFailures returns the failed deliveries reported by the delivery actor of a member.
+// If the member did not report, the delivery failed for all its subscribers
+func (t *TopicActor) memberDeliveryFailures(subscribers *Subscribers, res interface{}, err error) []*SubscriberDeliveryReport {
+	if response, ok := res.(*PublishResponse); ok && err == nil {
+		return response.FailedDeliveries
+	}
+
+	status := DeliveryStatus_OtherError
+	switch err {
+	case actor.ErrTimeout:
+		status = DeliveryStatus_Timeout
+	case actor.ErrDeadLetter:
+		status = DeliveryStatus_SubscriberNoLongerReachable
+	}
+
+	reports := make([]*SubscriberDeliveryReport, len(subscribers.Subscribers))
+	for i, subscriber := range subscribers.Subscribers {
+		reports[i] = &SubscriberDeliveryReport{Subscriber: subscriber, Status: status}
+	}
+	return reports
 }
 
 // getSubscribersForAddress returns the subscribers for the given member list
@@ -160,12 +229,12 @@ func (t *TopicActor) onNotifyAboutFailingSubscribers(c actor.Context, msg *Notif
 
 // logDeliveryErrors logs the delivery errors in one log line
 func (t *TopicActor) logDeliveryErrors(reports []*SubscriberDeliveryReport) {
-	if len(reports) > 0 || topicLogThrottle() == actor.Open {
+	if len(reports) > 0 || t.logThrottle() == actor.Open {
 		subscribers := make([]string, len(reports))
 		for i, report := range reports {
 			subscribers[i] = report.Subscriber.String()
 		}
-		plog.Error("Topic following subscribers could not process the batch", log.String("topic", t.topic), log.String("subscribers", strings.Join(subscribers, ",")))
+		t.logger.Error("Topic following subscribers could not process the batch", log.String("topic", t.topic), log.String("subscribers", strings.Join(subscribers, ",")))
 	}
 }
 
@@ -186,6 +255,7 @@ func (t *TopicActor) onClusterTopologyChanged(_ actor.Context, msg *ClusterTopol
 		addressMap := make(map[string]struct{})
 		for _, member := range msg.Left {
 			addressMap[member.Address()] = struct{}{}
+			delete(t.deliveries, member.Address())
 		}
 
 		subscribersThatLeft := make([]subscribeIdentityStruct, 0, len(msg.Left))
@@ -201,6 +271,18 @@ func (t *TopicActor) onClusterTopologyChanged(_ actor.Context, msg *ClusterTopol
 	}
 }
 
+// onEndpointTerminated removes the PID subscribers on the terminated endpoint, as they can no longer receive messages
+func (t *TopicActor) onEndpointTerminated(_ actor.Context, msg *remote.EndpointTerminatedEvent) {
+	delete(t.deliveries, msg.Address)
+	subscribersThatLeft := make([]subscribeIdentityStruct, 0)
+	for s := range t.subscribers {
+		if s.isPID && s.pid.address == msg.Address {
+			subscribersThatLeft = append(subscribersThatLeft, s)
+		}
+	}
+	t.removeSubscribers(subscribersThatLeft)
+}
+
 // unsubscribeSubscribersOnMembersThatLeft removes subscribers that are on members that left the clusterIdentity
 func (t *TopicActor) unsubscribeSubscribersOnMembersThatLeft(c actor.Context) {
 	members := GetCluster(c.ActorSystem()).MemberList.Members()
@@ -226,8 +308,8 @@ func (t *TopicActor) removeSubscribers(subscribersThatLeft []subscribeIdentitySt
 		for _, subscriber := range subscribersThatLeft {
 			delete(t.subscribers, subscriber)
 		}
-		if topicLogThrottle() == actor.Open {
-			plog.Warn("Topic removed subscribers, because they are dead or they are on members that left the clusterIdentity:", log.String("topic", t.topic), log.Object("subscribers", subscribersThatLeft))
+		if t.logThrottle() == actor.Open {
+			t.logger.Warn("Topic removed subscribers, because they are dead or they are on members that left the clusterIdentity:", log.String("topic", t.topic), log.Object("subscribers", subscribersThatLeft))
 		}
 		t.saveSubscriptionsInTopicActor()
 	}
@@ -238,15 +320,15 @@ func (t *TopicActor) loadSubscriptions(topic string) *Subscribers {
 	// TODO: cancellation logic config?
 	state, err := t.subscriptionStore.Get(context.Background(), topic)
 	if err != nil {
-		if topicLogThrottle() == actor.Open {
-			plog.Error("Error when loading subscriptions", log.String("topic", topic), log.Error(err))
+		if t.logThrottle() == actor.Open {
+			t.logger.Error("Error when loading subscriptions", log.String("topic", topic), log.Error(err))
 		}
 		return &Subscribers{}
 	}
 	if state == nil {
 		return &Subscribers{}
 	}
-	plog.Debug("Loaded subscriptions for topic", log.String("topic", topic), log.Object("subscriptions", state))
+	t.logger.Debug("Loaded subscriptions for topic", log.String("topic", topic), log.Object("subscriptions", state))
 	return state
 }
 
@@ -258,10 +340,10 @@ func (t *TopicActor) saveSubscriptionsInTopicActor() {
 // saveSubscriptions saves the subscribers for the topic to the subscription store
 func (t *TopicActor) saveSubscriptions(topic string, subscribers *Subscribers) {
 	// TODO: cancellation logic config?
-	plog.Debug("Saving subscriptions for topic", log.String("topic", topic), log.Object("subscriptions", subscribers))
+	t.logger.Debug("Saving subscriptions for topic", log.String("topic", topic), log.Object("subscriptions", subscribers))
 	err := t.subscriptionStore.Set(context.Background(), topic, subscribers)
-	if err != nil && topicLogThrottle() == actor.Open {
-		plog.Error("Error when saving subscriptions", log.String("topic", topic), log.Error(err))
+	if err != nil && t.logThrottle() == actor.Open {
+		t.logger.Error("Error when saving subscriptions", log.String("topic", topic), log.Error(err))
 	}
 }
 
@@ -273,7 +355,7 @@ func (t *TopicActor) onUnsubscribe(c actor.Context, msg *UnsubscribeRequest) {
 
 func (t *TopicActor) onSubscribe(c actor.Context, msg *SubscribeRequest) {
 	t.subscribers[newSubscribeIdentityStruct(msg.Subscriber)] = msg.Subscriber
-	plog.Debug("Topic subscribed", log.String("topic", t.topic), log.Object("subscriber", msg.Subscriber))
+	t.logger.Debug("Topic subscribed", log.String("topic", t.topic), log.Object("subscriber", msg.Subscriber))
 	t.saveSubscriptionsInTopicActor()
 	c.Respond(&SubscribeResponse{})
 }
@@ -0,0 +1,157 @@
+package cluster
+
+import (
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/asynkron/protoactor-go/log"
+)
+
+// LaggingSubscriberPolicy decides what a topic does with a batch for a member whose delivery queue is full
+type LaggingSubscriberPolicy int
+
+const (
+	// DropLaggingBatches skips the batch for the lagging member, its subscribers are reported as failed deliveries
+	DropLaggingBatches LaggingSubscriberPolicy = iota
+	// DisconnectLaggingSubscribers unsubscribes the subscribers on the lagging member from the topic and discards
+	// the batches queued for them
+	DisconnectLaggingSubscribers
+)
+
+// SubscribersLaggingEvent is published to the event stream when a topic drops a batch for the subscribers of a
+// lagging member, or disconnects them
+type SubscribersLaggingEvent struct {
+	Topic        string
+	Address      string
+	Subscribers  []*SubscriberIdentity
+	Disconnected bool
+}
+
+// memberDelivery is the delivery window of a topic to the subscribers on one member.
+// Batches beyond the in-flight limit wait in the queue, each delivery report takes the next one
+type memberDelivery struct {
+	inFlight int
+	queue    []*DeliverBatchRequest
+}
+
+// onPubSubBatchWithBackpressure delivers the batch within the delivery window of each member. The publisher gets
+// the delivery reports of the members the batch was sent to right away, the batches queued for lagging members are
+// not waited for and their failures are only handled by the topic
+func (t *TopicActor) onPubSubBatchWithBackpressure(c actor.Context, batch *PubSubBatch, members map[string][]pidAndSubscriber, config *PubSubConfig) {
+	timeout := config.SubscriberTimeout + time.Second
+	pending := 0
+	var failed []*SubscriberDeliveryReport
+	respond := func() {
+		if len(failed) == 0 {
+			c.Respond(&PublishResponse{})
+			return
+		}
+
+		t.unsubscribeUnreachablePidSubscribers(c, failed)
+		t.logDeliveryErrors(failed)
+		c.Respond(&PublishResponse{Status: PublishStatus_Failed, FailedDeliveries: failed})
+	}
+
+	for address, member := range members {
+		request := &DeliverBatchRequest{
+			Subscribers: t.getSubscribersForAddress(member),
+			PubSubBatch: batch,
+			Topic:       t.topic,
+		}
+
+		delivery, ok := t.deliveries[address]
+		if !ok {
+			delivery = &memberDelivery{}
+			t.deliveries[address] = delivery
+		}
+
+		switch {
+		case delivery.inFlight < config.MaxInFlightBatchesPerMember:
+			pending++
+			t.deliverToMember(c, address, delivery, request, timeout, func(reports []*SubscriberDeliveryReport) {
+				failed = append(failed, reports...)
+				pending--
+				if pending == 0 {
+					respond()
+				}
+			})
+		case len(delivery.queue) < config.MemberQueueSize:
+			delivery.queue = append(delivery.queue, request)
+		default:
+			failed = append(failed, t.onMemberLagging(c, address, request.Subscribers, config.LaggingSubscriberPolicy)...)
+		}
+	}
+
+	if pending == 0 {
+		respond()
+	}
+}
+
+// deliverToMember sends the request to the delivery actor of the member, its delivery report acknowledges the batch
+// and moves the window of the member forward
+func (t *TopicActor) deliverToMember(c actor.Context, address string, delivery *memberDelivery, request *DeliverBatchRequest, timeout time.Duration, done func([]*SubscriberDeliveryReport)) {
+	delivery.inFlight++
+	deliveryPid := actor.NewPID(address, PubSubDeliveryName)
+	c.ReenterAfter(c.RequestFuture(deliveryPid, request, timeout), func(res interface{}, err error) {
+		reports := t.memberDeliveryFailures(request.Subscribers, res, err)
+		t.acknowledgeMemberDelivery(c, address, delivery, timeout)
+		done(reports)
+	})
+}
+
+// acknowledgeMemberDelivery frees the in-flight slot of a delivered batch and sends the next queued batch, unless
+// the member was disconnected or left meanwhile
+func (t *TopicActor) acknowledgeMemberDelivery(c actor.Context, address string, delivery *memberDelivery, timeout time.Duration) {
+	delivery.inFlight--
+	if t.deliveries[address] != delivery {
+		return
+	}
+
+	if len(delivery.queue) == 0 {
+		if delivery.inFlight == 0 {
+			delete(t.deliveries, address)
+		}
+		return
+	}
+
+	next := delivery.queue[0]
+	delivery.queue[0] = nil
+	delivery.queue = delivery.queue[1:]
+	t.deliverToMember(c, address, delivery, next, timeout, func(reports []*SubscriberDeliveryReport) {
+		if len(reports) > 0 {
+			t.unsubscribeUnreachablePidSubscribers(c, reports)
+			t.logDeliveryErrors(reports)
+		}
+	})
+}
+
+// onMemberLagging applies the LaggingSubscriberPolicy to the subscribers of a member whose queue is full,
+// and returns their failed delivery reports for the batch
+func (t *TopicActor) onMemberLagging(c actor.Context, address string, subscribers *Subscribers, policy LaggingSubscriberPolicy) []*SubscriberDeliveryReport {
+	disconnect := policy == DisconnectLaggingSubscribers
+	if disconnect {
+		delete(t.deliveries, address)
+
+		identities := make([]subscribeIdentityStruct, len(subscribers.Subscribers))
+		for i, subscriber := range subscribers.Subscribers {
+			identities[i] = newSubscribeIdentityStruct(subscriber)
+		}
+		t.removeSubscribers(identities)
+	}
+
+	if t.logThrottle() == actor.Open {
+		t.logger.Warn("Topic subscribers are lagging", log.String("topic", t.topic), log.String("address", address), log.Bool("disconnected", disconnect))
+	}
+	c.ActorSystem().EventStream.Publish(&SubscribersLaggingEvent{
+		Topic:        t.topic,
+		Address:      address,
+		Subscribers:  subscribers.Subscribers,
+		Disconnected: disconnect,
+	})
+
+	reports := make([]*SubscriberDeliveryReport, len(subscribers.Subscribers))
+	for i, subscriber := range subscribers.Subscribers {
+		reports[i] = &SubscriberDeliveryReport{Subscriber: subscriber, Status: DeliveryStatus_OtherError}
+	}
+	return reports
+}
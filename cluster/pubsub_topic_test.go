@@ -0,0 +1,68 @@
+package cluster
+
+import (
+	"testing"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTopicActor_DisconnectsLaggingSubscribers(t *testing.T) {
+	c := newClusterForTest("mycluster", nil, WithPubSubBackpressure(1, 1, DisconnectLaggingSubscribers))
+	system := c.ActorSystem
+
+	// the delivery actor of the member holds the first batch until released
+	release := make(chan struct{})
+	_, err := system.Root.SpawnNamed(actor.PropsFromFunc(func(ctx actor.Context) {
+		if _, ok := ctx.Message().(*DeliverBatchRequest); ok {
+			<-release
+			ctx.Respond(&PublishResponse{})
+		}
+	}), PubSubDeliveryName)
+	assert.NoError(t, err)
+
+	lagging := make(chan *SubscribersLaggingEvent, 1)
+	system.EventStream.Subscribe(func(evt interface{}) {
+		if e, ok := evt.(*SubscribersLaggingEvent); ok {
+			lagging <- e
+		}
+	})
+
+	props := actor.PropsFromProducer(func() actor.Actor {
+		return NewTopicActor(&EmptyKeyValueStore[*Subscribers]{})
+	})
+	topic := system.Root.Spawn(WithClusterIdentity(props, NewClusterIdentity("topic", TopicActorKind)))
+	subscriber := &SubscriberIdentity{Identity: &SubscriberIdentity_Pid{Pid: actor.NewPID(system.Address(), "subscriber")}}
+	_, err = system.Root.RequestFuture(topic, &SubscribeRequest{Subscriber: subscriber}, time.Second).Result()
+	assert.NoError(t, err)
+
+	// the first batch is in flight, the second one is queued and the third one finds the queue full
+	inFlight := system.Root.RequestFuture(topic, &PubSubBatch{}, 5*time.Second)
+	res, err := system.Root.RequestFuture(topic, &PubSubBatch{}, time.Second).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, PublishStatus_Ok, res.(*PublishResponse).Status)
+
+	res, err = system.Root.RequestFuture(topic, &PubSubBatch{}, time.Second).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, PublishStatus_Failed, res.(*PublishResponse).Status)
+
+	select {
+	case e := <-lagging:
+		assert.Equal(t, "topic", e.Topic)
+		assert.True(t, e.Disconnected)
+		assert.Len(t, e.Subscribers, 1)
+	case <-time.After(time.Second):
+		t.Fatal("no lagging event")
+	}
+
+	close(release)
+	res, err = inFlight.Result()
+	assert.NoError(t, err)
+	assert.Equal(t, PublishStatus_Ok, res.(*PublishResponse).Status)
+
+	// the disconnected subscriber no longer gets batches
+	res, err = system.Root.RequestFuture(topic, &PubSubBatch{}, time.Second).Result()
+	assert.NoError(t, err)
+	assert.Empty(t, res.(*PublishResponse).FailedDeliveries)
+}
@@ -7,19 +7,22 @@ package cluster
 import (
 	"hash"
 	"hash/fnv"
+	"strconv"
 	"strings"
 	"sync"
 )
 
+// memberData holds the hash keys of the virtual nodes of a member, one per unit of weight
 type memberData struct {
 	member    *Member
-	hashBytes []byte
+	hashBytes [][]byte
 }
 type Rendezvous struct {
 	mutex      sync.RWMutex
 	hasher     hash.Hash32
 	hasherLock sync.Mutex
 	members    []*memberData
+	weights    map[string]int
 }
 
 func NewRendezvous() *Rendezvous {
@@ -53,10 +56,12 @@ func (r *Rendezvous) GetByClusterIdentity(ci *ClusterIdentity) string {
 	var score uint32
 
 	for _, node := range m {
-		score = r.hash(node.hashBytes, keyBytes)
-		if score > maxScore {
-			maxScore = score
-			maxMember = node
+		for _, hashBytes := range node.hashBytes {
+			score = r.hash(hashBytes, keyBytes)
+			if score > maxScore {
+				maxScore = score
+				maxMember = node
+			}
 		}
 	}
 
@@ -93,14 +98,47 @@ func (r *Rendezvous) UpdateMembers(members Members) {
 	r.members = make([]*memberData, 0)
 
 	for _, m := range tmp.Members() {
-		keyBytes := []byte(m.Address()) // TODO: should be utf8 to match .net
 		r.members = append(r.members, &memberData{
 			member:    m,
-			hashBytes: keyBytes,
+			hashBytes: r.virtualNodes(m),
 		})
 	}
 }
 
+// UpdateWeights sets the weights of the members by member id, members without a weight have a weight of 1.
+// A member gets a virtual node per unit of weight, so it wins a proportional share of the identities
+func (r *Rendezvous) UpdateWeights(weights map[string]int) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.weights = make(map[string]int, len(weights))
+	for id, weight := range weights {
+		r.weights[id] = weight
+	}
+
+	for _, md := range r.members {
+		md.hashBytes = r.virtualNodes(md.member)
+	}
+}
+
+// virtualNodes returns the hash keys of the member. The first one is the address alone,
+// so members with a weight of 1 are placed as before weights existed
+func (r *Rendezvous) virtualNodes(m *Member) [][]byte {
+	weight := r.weights[m.Id]
+	if weight < 1 {
+		weight = 1
+	}
+
+	address := m.Address()
+	nodes := make([][]byte, weight)
+	nodes[0] = []byte(address) // TODO: should be utf8 to match .net
+	for i := 1; i < weight; i++ {
+		nodes[i] = []byte(address + "#" + strconv.Itoa(i))
+	}
+
+	return nodes
+}
+
 func (r *Rendezvous) hash(node, key []byte) uint32 {
 	r.hasherLock.Lock()
 	defer r.hasherLock.Unlock()
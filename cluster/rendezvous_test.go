@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/asynkron/protoactor-go/log"
+	"github.com/stretchr/testify/assert"
 )
 
 func Benchmark_Rendezvous_Get(b *testing.B) {
@@ -30,3 +31,36 @@ func Benchmark_Rendezvous_Get(b *testing.B) {
 		})
 	}
 }
+
+func TestRendezvous_UnweightedPlacementUnchanged(t *testing.T) {
+	members := newMembersForTest(5)
+	unweighted := NewRendezvous()
+	unweighted.UpdateMembers(members)
+
+	weighted := NewRendezvous()
+	weighted.UpdateWeights(map[string]int{"memberId-0": 1})
+	weighted.UpdateMembers(members)
+
+	for i := 0; i < 1000; i++ {
+		identity := fmt.Sprintf("kind/%d", i)
+		assert.Equal(t, unweighted.GetByIdentity(identity), weighted.GetByIdentity(identity))
+	}
+}
+
+func TestRendezvous_PlacementFavoursHeavierMembers(t *testing.T) {
+	members := newMembersForTest(3)
+	obj := NewRendezvous()
+	obj.UpdateMembers(members)
+	obj.UpdateWeights(map[string]int{"memberId-0": 4})
+
+	counts := map[string]int{}
+	const identities = 12000
+	for i := 0; i < identities; i++ {
+		counts[obj.GetByIdentity(fmt.Sprintf("kind/%d", i))]++
+	}
+
+	// weights 4, 1, 1: the hash is not perfectly uniform, so only check the heavy member gets a much larger share
+	heavy := counts[members[0].Address()]
+	assert.Greater(t, heavy, 2*counts[members[1].Address()])
+	assert.Greater(t, heavy, 2*counts[members[2].Address()])
+}
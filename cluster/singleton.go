@@ -0,0 +1,231 @@
+package cluster
+
+import (
+	"context"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/asynkron/protoactor-go/eventstream"
+	"github.com/asynkron/protoactor-go/log"
+	"github.com/asynkron/protoactor-go/remote"
+)
+
+const (
+	singletonPrefix       = "singleton-"
+	singletonInstanceName = "instance"
+	singletonStashSize    = 1000
+)
+
+// checkSingletonConsensus is sent by the elected host to itself until the cluster agrees on the topology it was elected in
+type checkSingletonConsensus struct {
+	topologyHash uint64
+}
+
+type stashedMessage struct {
+	message interface{}
+	sender  *actor.PID
+}
+
+// Singleton registers a cluster wide singleton actor on this member and returns the PID to message it through.
+// Every member registers the same singleton, the one elected from the member list hosts it and all of them route the
+// messages they receive to wherever it currently lives. The host is elected again when it leaves the cluster.
+// During a partition only the side which sees a majority of the last topology it hosted the singleton in keeps hosting it
+func (c *Cluster) Singleton(name string, props *actor.Props) (*actor.PID, error) {
+	return c.ActorSystem.Root.SpawnNamed(actor.PropsFromProducer(func() actor.Actor {
+		return newSingletonManager(c, props)
+	}), singletonPrefix+name)
+}
+
+// singletonManager hosts the singleton as its child when this member is elected, and forwards to the elected host otherwise
+type singletonManager struct {
+	cluster  *Cluster
+	props    *actor.Props
+	instance *actor.PID
+
+	topologyHash uint64
+	host         string
+	hosting      bool
+	quorumSize   int
+
+	stash         []stashedMessage
+	subscriptions []*eventstream.Subscription
+}
+
+func newSingletonManager(c *Cluster, props *actor.Props) *singletonManager {
+	return &singletonManager{
+		cluster: c,
+		props:   props,
+	}
+}
+
+func (s *singletonManager) Receive(ctx actor.Context) {
+	switch msg := ctx.Message().(type) {
+	case *actor.Started:
+		s.subscribe(ctx)
+		if members := s.cluster.MemberList.Members(); members.Len() > 0 {
+			s.onTopology(ctx, members.TopologyHash(), members.Members())
+		}
+	case *actor.Stopping:
+		for _, sub := range s.subscriptions {
+			s.cluster.ActorSystem.EventStream.Unsubscribe(sub)
+		}
+	case *actor.Stopped, *actor.Restarting:
+	case *ClusterTopology:
+		s.onTopology(ctx, msg.TopologyHash, msg.Members)
+	case *remote.EndpointTerminatedEvent:
+		if msg.Address == s.host {
+			s.cluster.Logger().Info("Singleton host terminated", log.String("singleton", ctx.Self().Id), log.String("address", msg.Address))
+			s.host = ""
+		}
+	case *checkSingletonConsensus:
+		s.onCheckConsensus(ctx, msg.topologyHash)
+	case *actor.Terminated:
+		if s.instance != nil && msg.Who.Equal(s.instance) {
+			s.instance = nil
+			if s.hosting {
+				s.spawnInstance(ctx)
+			}
+		}
+	default:
+		s.route(ctx)
+	}
+}
+
+func (s *singletonManager) subscribe(ctx actor.Context) {
+	system := ctx.ActorSystem()
+	self := ctx.Self()
+
+	s.subscriptions = append(s.subscriptions,
+		system.EventStream.SubscribeWithPredicate(func(evt interface{}) {
+			system.Root.Send(self, evt)
+		}, func(evt interface{}) bool {
+			switch evt.(type) {
+			case *ClusterTopology, *remote.EndpointTerminatedEvent:
+				return true
+			}
+
+			return false
+		}),
+	)
+}
+
+func (s *singletonManager) onTopology(ctx actor.Context, topologyHash uint64, members Members) {
+	s.topologyHash = topologyHash
+
+	if !s.hasQuorum(len(members)) {
+		s.cluster.Logger().Warn("Singleton lost quorum", log.String("singleton", ctx.Self().Id),
+			log.Int("members", len(members)), log.Int("quorum", s.quorumSize/2+1))
+		s.host = ""
+		s.stopHosting(ctx)
+
+		return
+	}
+	s.quorumSize = len(members)
+
+	leader := electSingletonHost(members)
+	if leader.Id != s.cluster.ActorSystem.ID {
+		s.stopHosting(ctx)
+		s.host = leader.Address()
+		s.unstash(ctx)
+
+		return
+	}
+
+	// wait for the cluster to agree on the topology before activating, the previous host stops on the same topology
+	s.host = ""
+	s.onCheckConsensus(ctx, topologyHash)
+}
+
+// hasQuorum tells whether a topology with the given number of members holds a strict majority of the last topology
+// which had one, so that at most one side of a partition keeps hosting the singleton
+func (s *singletonManager) hasQuorum(members int) bool {
+	return members > 0 && members > s.quorumSize/2
+}
+
+func (s *singletonManager) onCheckConsensus(ctx actor.Context, topologyHash uint64) {
+	if topologyHash != s.topologyHash || s.hosting {
+		return
+	}
+
+	hash, ok := s.cluster.MemberList.TopologyConsensus(context.Background())
+	if !ok || hash != topologyHash {
+		system := ctx.ActorSystem()
+		self := ctx.Self()
+		time.AfterFunc(s.cluster.Config.GossipInterval, func() {
+			system.Root.Send(self, &checkSingletonConsensus{topologyHash: topologyHash})
+		})
+
+		return
+	}
+
+	s.cluster.Logger().Info("Hosting singleton", log.String("singleton", ctx.Self().Id))
+	s.hosting = true
+	s.host = s.cluster.ActorSystem.Address()
+	s.spawnInstance(ctx)
+	s.unstash(ctx)
+}
+
+func (s *singletonManager) spawnInstance(ctx actor.Context) {
+	pid, err := ctx.SpawnNamed(s.props, singletonInstanceName)
+	if err != nil {
+		s.cluster.Logger().Error("Failed to spawn singleton", log.String("singleton", ctx.Self().Id), log.Error(err))
+
+		return
+	}
+	s.instance = pid
+}
+
+func (s *singletonManager) stopHosting(ctx actor.Context) {
+	if !s.hosting {
+		return
+	}
+
+	s.cluster.Logger().Info("Handing over singleton", log.String("singleton", ctx.Self().Id))
+	s.hosting = false
+	if s.instance != nil {
+		ctx.Stop(s.instance)
+		s.instance = nil
+	}
+}
+
+// route forwards the message to the singleton, stashing it while no host is known.
+// The instance is addressed directly so a stale view of the host dead letters the message instead of bouncing it
+func (s *singletonManager) route(ctx actor.Context) {
+	if s.host == "" {
+		if len(s.stash) >= singletonStashSize {
+			ctx.ActorSystem().DeadLetter.SendUserMessage(ctx.Self(), &actor.MessageEnvelope{Message: ctx.Message(), Sender: ctx.Sender()})
+
+			return
+		}
+		s.stash = append(s.stash, stashedMessage{message: ctx.Message(), sender: ctx.Sender()})
+
+		return
+	}
+
+	ctx.Forward(s.instancePID(ctx))
+}
+
+func (s *singletonManager) unstash(ctx actor.Context) {
+	if len(s.stash) == 0 {
+		return
+	}
+
+	target := s.instancePID(ctx)
+	for _, m := range s.stash {
+		ctx.RequestWithCustomSender(target, m.message, m.sender)
+	}
+	s.stash = nil
+}
+
+func (s *singletonManager) instancePID(ctx actor.Context) *actor.PID {
+	if s.hosting && s.instance != nil {
+		return s.instance
+	}
+
+	return actor.NewPID(s.host, ctx.Self().Id+"/"+singletonInstanceName)
+}
+
+// electSingletonHost picks the host deterministically, so every member agreeing on the topology elects the same one
+func electSingletonHost(members Members) *Member {
+	return CopySortMembers(members)[0]
+}
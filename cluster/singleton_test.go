@@ -0,0 +1,33 @@
+package cluster
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSingletonManager_hasQuorum(t *testing.T) {
+	a := assert.New(t)
+	s := &singletonManager{}
+
+	a.False(s.hasQuorum(0))
+	a.True(s.hasQuorum(1))
+
+	s.quorumSize = 4
+	a.True(s.hasQuorum(3))
+	// an even split leaves both sides without quorum
+	a.False(s.hasQuorum(2))
+
+	s.quorumSize = 5
+	a.True(s.hasQuorum(3))
+	a.False(s.hasQuorum(2))
+}
+
+func TestElectSingletonHost(t *testing.T) {
+	members := newMembersForTest(3)
+	host := electSingletonHost(members)
+
+	// every member elects the same host whatever order it sees the members in
+	reversed := Members{members[2], members[1], members[0]}
+	assert.Equal(t, host.Id, electSingletonHost(reversed).Id)
+}
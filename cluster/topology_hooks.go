@@ -0,0 +1,92 @@
+package cluster
+
+import (
+	"sync"
+	"time"
+)
+
+// topologyHooks calls the OnTopologyChanged callbacks with the changes of the member list since they were last
+// called, once the member list was not updated for the debounce period
+type topologyHooks struct {
+	mutex    sync.Mutex
+	hooks    []func(topology *ClusterTopology)
+	timer    *time.Timer
+	stopped  bool
+	notified *MemberSet
+
+	// serializes the calls of the callbacks
+	notifyMutex sync.Mutex
+}
+
+func (h *topologyHooks) add(fn func(topology *ClusterTopology)) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.hooks = append(h.hooks, fn)
+}
+
+// changed restarts the debounce period, it is called with the member list locked
+func (h *topologyHooks) changed(ml *MemberList) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if h.stopped || len(h.hooks) == 0 {
+		return
+	}
+
+	if h.timer != nil {
+		h.timer.Stop()
+	}
+	debounce := ml.cluster.Config.TopologyChangeDebounce
+	h.timer = time.AfterFunc(debounce, func() {
+		h.notify(ml)
+	})
+}
+
+func (h *topologyHooks) notify(ml *MemberList) {
+	h.notifyMutex.Lock()
+	defer h.notifyMutex.Unlock()
+
+	ml.mutex.RLock()
+	members := ml.members
+	ml.mutex.RUnlock()
+
+	h.mutex.Lock()
+	if h.stopped {
+		h.mutex.Unlock()
+		return
+	}
+	notified := h.notified
+	if notified == nil {
+		notified = emptyMemberSet
+	}
+	hooks := h.hooks
+	h.notified = members
+	h.mutex.Unlock()
+
+	// a member which left and joined again, or joined and left, within the debounce period is not a change
+	joined := members.Except(notified)
+	left := notified.Except(members)
+	if joined.Len() == 0 && left.Len() == 0 {
+		return
+	}
+
+	for _, hook := range hooks {
+		hook(&ClusterTopology{
+			TopologyHash: members.TopologyHash(),
+			Members:      members.Members(),
+			Joined:       joined.Members(),
+			Left:         left.Members(),
+		})
+	}
+}
+
+func (h *topologyHooks) stop() {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.stopped = true
+	if h.timer != nil {
+		h.timer.Stop()
+	}
+}
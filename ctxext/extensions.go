@@ -27,7 +27,12 @@ func NextContextExtensionID() ContextExtensionID {
 	return ContextExtensionID(id)
 }
 
+// Get returns the extension set for id, nil if none was set
 func (ex *ContextExtensions) Get(id ContextExtensionID) ContextExtension {
+	if int(id) >= len(ex.extensions) {
+		return nil
+	}
+
 	return ex.extensions[id]
 }
 
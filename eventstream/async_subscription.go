@@ -0,0 +1,94 @@
+package eventstream
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// OverflowPolicy decides what happens to an event published to an asynchronous subscription whose buffer is full
+type OverflowPolicy int
+
+const (
+	// DropOnOverflow discards the event and counts it in Subscription.Dropped
+	DropOnOverflow OverflowPolicy = iota
+	// BlockOnOverflow makes the publisher wait until the subscriber frees a slot in the buffer
+	BlockOnOverflow
+)
+
+// SubscribeAsync subscribes the handler through a buffer of bufferSize events, drained by a goroutine dedicated to
+// the subscription, so a slow handler does not delay the publisher nor the other subscribers.
+// The policy decides what happens to the events published while the buffer is full.
+// Unsubscribe stops the goroutine, the events still buffered are not delivered
+func (es *EventStream) SubscribeAsync(bufferSize int, policy OverflowPolicy, handler Handler) *Subscription {
+	delivery := &asyncDelivery{
+		events: make(chan interface{}, bufferSize),
+		done:   make(chan struct{}),
+		policy: policy,
+	}
+	go delivery.run(handler)
+
+	return es.subscribe(&Subscription{
+		handler: delivery.enqueue,
+		async:   delivery,
+		active:  1,
+	})
+}
+
+type asyncDelivery struct {
+	events   chan interface{}
+	done     chan struct{}
+	stopOnce sync.Once
+	policy   OverflowPolicy
+	dropped  uint64
+}
+
+func (d *asyncDelivery) enqueue(evt interface{}) {
+	if d.policy == BlockOnOverflow {
+		select {
+		case d.events <- evt:
+		case <-d.done:
+		}
+
+		return
+	}
+
+	select {
+	case d.events <- evt:
+	case <-d.done:
+	default:
+		atomic.AddUint64(&d.dropped, 1)
+	}
+}
+
+func (d *asyncDelivery) run(handler Handler) {
+	for {
+		select {
+		case evt := <-d.events:
+			handler(evt)
+		case <-d.done:
+			return
+		}
+	}
+}
+
+func (d *asyncDelivery) stop() {
+	if d == nil {
+		return
+	}
+
+	d.stopOnce.Do(func() {
+		close(d.done)
+	})
+}
+
+// Dropped returns the number of events an asynchronous or pooled subscription discarded because its buffer was full
+func (s *Subscription) Dropped() uint64 {
+	switch {
+	case s.async != nil:
+		return atomic.LoadUint64(&s.async.dropped)
+	case s.pooled != nil:
+		return atomic.LoadUint64(&s.pooled.dropped)
+	default:
+		return 0
+	}
+}
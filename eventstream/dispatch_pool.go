@@ -0,0 +1,178 @@
+package eventstream
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Option configures an EventStream created by NewEventStream
+type Option func(es *EventStream)
+
+// WithDispatchPool backs the subscriptions made with SubscribePooled by workers goroutines, so that a publish only
+// queues the event and the pooled subscribers handle it concurrently. Each pooled subscription queues up to queueSize
+// events, the events published while the queue of a subscriber is full are dropped and counted in
+// Subscription.Dropped. Publishing never waits for the workers, so pooled handlers may publish to the stream.
+// The events of a subscription are handled one at a time and in the order they were published, whichever worker runs it.
+// Zero workers keeps the synchronous dispatch, where SubscribePooled behaves like SubscribeWithPredicate
+func WithDispatchPool(workers, queueSize int) Option {
+	return func(es *EventStream) {
+		if workers <= 0 {
+			return
+		}
+
+		if queueSize <= 0 {
+			queueSize = 1
+		}
+
+		es.pool = newDispatchPool(workers, queueSize)
+	}
+}
+
+// SubscribePooled subscribes the handler through the dispatch pool of the EventStream, see WithDispatchPool.
+// The predicate, when not nil, still runs on the publishing goroutine and only the matching events are queued.
+// Subscribers which rely on handling an event before Publish returns should keep using Subscribe
+func (es *EventStream) SubscribePooled(handler Handler, p Predicate) *Subscription {
+	if es.pool == nil {
+		return es.SubscribeWithPredicate(handler, p)
+	}
+
+	delivery := &pooledDelivery{
+		pool:    es.pool,
+		handler: handler,
+		events:  make(chan interface{}, es.pool.queueSize),
+		done:    make(chan struct{}),
+	}
+
+	return es.subscribe(&Subscription{
+		handler: delivery.enqueue,
+		p:       p,
+		pooled:  delivery,
+		active:  1,
+	})
+}
+
+// Close stops the workers of the dispatch pool, the events still queued are not delivered.
+// It does nothing when the EventStream dispatches synchronously
+func (es *EventStream) Close() {
+	es.pool.stop()
+}
+
+type dispatchPool struct {
+	queueSize int
+	ready     chan *pooledDelivery
+	done      chan struct{}
+	stopOnce  sync.Once
+}
+
+func newDispatchPool(workers, queueSize int) *dispatchPool {
+	pool := &dispatchPool{
+		queueSize: queueSize,
+		ready:     make(chan *pooledDelivery, workers),
+		done:      make(chan struct{}),
+	}
+
+	for i := 0; i < workers; i++ {
+		go pool.run()
+	}
+
+	return pool
+}
+
+func (p *dispatchPool) run() {
+	for {
+		select {
+		case d := <-p.ready:
+			d.drain()
+		case <-p.done:
+			return
+		}
+	}
+}
+
+func (p *dispatchPool) schedule(d *pooledDelivery) {
+	select {
+	case p.ready <- d:
+	case <-p.done:
+	default:
+		// all the workers are busy, possibly publishing themselves, hand the delivery over without waiting
+		go func() {
+			select {
+			case p.ready <- d:
+			case <-p.done:
+			}
+		}()
+	}
+}
+
+func (p *dispatchPool) stop() {
+	if p == nil {
+		return
+	}
+
+	p.stopOnce.Do(func() {
+		close(p.done)
+	})
+}
+
+// pooledDelivery queues the events of one subscription, it is handed to a single worker at a time while it has events
+type pooledDelivery struct {
+	pool      *dispatchPool
+	handler   Handler
+	events    chan interface{}
+	scheduled int32
+	done      chan struct{}
+	stopOnce  sync.Once
+	dropped   uint64
+}
+
+// enqueue never blocks, a publisher waiting for a full queue would deadlock the pool when it is a pooled handler
+func (d *pooledDelivery) enqueue(evt interface{}) {
+	select {
+	case <-d.done:
+		return
+	case <-d.pool.done:
+		return
+	default:
+	}
+
+	select {
+	case d.events <- evt:
+	default:
+		atomic.AddUint64(&d.dropped, 1)
+
+		return
+	}
+
+	if atomic.CompareAndSwapInt32(&d.scheduled, 0, 1) {
+		d.pool.schedule(d)
+	}
+}
+
+func (d *pooledDelivery) drain() {
+	for {
+		select {
+		case <-d.done:
+			return
+		case evt := <-d.events:
+			d.handler(evt)
+		default:
+			atomic.StoreInt32(&d.scheduled, 0)
+
+			// an event queued after the queue was seen empty but before the flag was cleared did not schedule the
+			// delivery, keep draining unless another publish scheduled it since
+			if len(d.events) == 0 || !atomic.CompareAndSwapInt32(&d.scheduled, 0, 1) {
+				return
+			}
+		}
+	}
+}
+
+func (d *pooledDelivery) stop() {
+	if d == nil {
+		return
+	}
+
+	d.stopOnce.Do(func() {
+		close(d.done)
+	})
+}
@@ -19,43 +19,58 @@ type EventStream struct {
 
 	// Atomically maintained elements counter
 	counter int32
+
+	// workers of the pooled subscriptions, nil when the stream dispatches synchronously
+	pool *dispatchPool
 }
 
 // Create a new EventStream value and returns it back.
-func NewEventStream() *EventStream {
+func NewEventStream(opts ...Option) *EventStream {
 	es := &EventStream{
 		subscriptions: []*Subscription{},
 	}
 
+	for _, opt := range opts {
+		opt(es)
+	}
+
 	return es
 }
 
 // Subscribe the given handler to the EventStream
 func (es *EventStream) Subscribe(handler Handler) *Subscription {
-	sub := &Subscription{
+	return es.subscribe(&Subscription{
 		handler: handler,
 		active:  1,
-	}
+	})
+}
 
+// SubscribeWithPredicate creates a new Subscription value and sets a predicate to filter messages passed to
+// the subscriber, it returns a pointer to the Subscription value.
+//
+// The predicate runs on the publishing goroutine for every published event, before the handler and while the
+// publisher waits, so it must be cheap and must not block: matching on the type or a few fields of the event keeps
+// the filtering cost of an event to one call per subscriber, and spares the handlers the events they would discard
+func (es *EventStream) SubscribeWithPredicate(handler Handler, p Predicate) *Subscription {
+	return es.subscribe(&Subscription{
+		handler: handler,
+		p:       p,
+		active:  1,
+	})
+}
+
+// subscribe registers the fully built subscription, it is visible to publishers as soon as it is added
+func (es *EventStream) subscribe(sub *Subscription) *Subscription {
 	es.Lock()
 	defer es.Unlock()
 
 	sub.id = es.counter
-	es.counter++
+	atomic.AddInt32(&es.counter, 1)
 	es.subscriptions = append(es.subscriptions, sub)
 
 	return sub
 }
 
-// SubscribeWithPredicate creates a new Subscription value and sets a predicate to filter messages passed to
-// the subscriber, it returns a pointer to the Subscription value
-func (es *EventStream) SubscribeWithPredicate(handler Handler, p Predicate) *Subscription {
-	sub := es.Subscribe(handler)
-	sub.p = p
-
-	return sub
-}
-
 // Unsubscribes the given subscription from the EventStream
 func (es *EventStream) Unsubscribe(sub *Subscription) {
 	if sub == nil {
@@ -67,6 +82,9 @@ func (es *EventStream) Unsubscribe(sub *Subscription) {
 		defer es.Unlock()
 
 		if sub.Deactivate() {
+			sub.async.stop()
+			sub.pooled.stop()
+
 			if es.counter == 0 {
 				es.subscriptions = nil
 
@@ -78,7 +96,7 @@ func (es *EventStream) Unsubscribe(sub *Subscription) {
 			es.subscriptions[sub.id].id = sub.id
 			es.subscriptions[l] = nil
 			es.subscriptions = es.subscriptions[:l]
-			es.counter--
+			atomic.AddInt32(&es.counter, -1)
 
 			if es.counter == 0 {
 				es.subscriptions = nil
@@ -121,6 +139,8 @@ type Subscription struct {
 	id      int32
 	handler Handler
 	p       Predicate
+	async   *asyncDelivery
+	pooled  *pooledDelivery
 	active  uint32
 }
 
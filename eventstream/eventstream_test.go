@@ -2,6 +2,7 @@ package eventstream_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/asynkron/protoactor-go/eventstream"
 	"github.com/stretchr/testify/assert"
@@ -77,6 +78,155 @@ type Event struct {
 	i int
 }
 
+func TestEventStream_Subscribe_WithPredicate_MatchesFieldValues(t *testing.T) {
+	var received []int
+	es := &eventstream.EventStream{}
+	es.SubscribeWithPredicate(
+		func(evt interface{}) { received = append(received, evt.(*Event).i) },
+		func(evt interface{}) bool {
+			e, ok := evt.(*Event)
+			return ok && e.i%2 == 0
+		},
+	)
+	for i := 0; i < 5; i++ {
+		es.Publish(&Event{i: i})
+	}
+	es.Publish("not an event")
+
+	assert.Equal(t, []int{0, 2, 4}, received)
+}
+
+func TestEventStream_Subscribe_WithPredicate_WhilePublishing(t *testing.T) {
+	es := eventstream.NewEventStream()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 1000; i++ {
+			es.Publish(&Event{i: i})
+		}
+	}()
+
+	for i := 0; i < 100; i++ {
+		es.SubscribeWithPredicate(func(interface{}) {}, func(interface{}) bool { return false })
+	}
+	<-done
+}
+
+func TestEventStream_SubscribeAsync_DoesNotBlockPublisher(t *testing.T) {
+	es := eventstream.NewEventStream()
+	release := make(chan struct{})
+	received := make(chan interface{}, 10)
+	sub := es.SubscribeAsync(2, eventstream.DropOnOverflow, func(evt interface{}) {
+		<-release
+		received <- evt
+	})
+
+	// the handler holds the first event, the buffer takes two more and the rest is dropped
+	es.Publish(1)
+	time.Sleep(10 * time.Millisecond)
+	for i := 2; i <= 5; i++ {
+		es.Publish(i)
+	}
+	assert.Equal(t, uint64(2), sub.Dropped())
+
+	close(release)
+	for _, expected := range []int{1, 2, 3} {
+		assert.Equal(t, expected, <-received)
+	}
+	es.Unsubscribe(sub)
+}
+
+func TestEventStream_SubscribeAsync_BlockOnOverflow(t *testing.T) {
+	es := eventstream.NewEventStream()
+	received := make(chan interface{})
+	sub := es.SubscribeAsync(1, eventstream.BlockOnOverflow, func(evt interface{}) {
+		received <- evt
+	})
+
+	published := make(chan struct{})
+	go func() {
+		defer close(published)
+		for i := 0; i < 3; i++ {
+			es.Publish(i)
+		}
+	}()
+
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, i, <-received)
+	}
+	<-published
+	assert.Zero(t, sub.Dropped())
+	es.Unsubscribe(sub)
+}
+
+func TestEventStream_SubscribePooled_ConcurrentAndOrdered(t *testing.T) {
+	es := eventstream.NewEventStream(eventstream.WithDispatchPool(2, 10))
+	defer es.Close()
+
+	// the slow subscriber blocks one worker, the fast one still gets its events in order on the other
+	release := make(chan struct{})
+	slow := make(chan interface{}, 10)
+	fast := make(chan interface{}, 10)
+	es.SubscribePooled(func(evt interface{}) {
+		<-release
+		slow <- evt
+	}, nil)
+	es.SubscribePooled(func(evt interface{}) { fast <- evt }, func(evt interface{}) bool { return evt.(int) > 0 })
+
+	for i := 0; i < 5; i++ {
+		es.Publish(i)
+	}
+
+	for i := 1; i < 5; i++ {
+		select {
+		case evt := <-fast:
+			assert.Equal(t, i, evt)
+		case <-time.After(time.Second):
+			t.Fatal("pooled subscriber was blocked by a slow one")
+		}
+	}
+
+	close(release)
+	for i := 0; i < 5; i++ {
+		assert.Equal(t, i, <-slow)
+	}
+}
+
+func TestEventStream_SubscribePooled_HandlerPublishes(t *testing.T) {
+	es := eventstream.NewEventStream(eventstream.WithDispatchPool(1, 1))
+	defer es.Close()
+
+	// the only worker publishes to its own full queue, the overflow is dropped instead of deadlocking the pool
+	done := make(chan struct{})
+	sub := es.SubscribePooled(func(evt interface{}) {
+		if evt == "start" {
+			for i := 0; i < 5; i++ {
+				es.Publish(i)
+			}
+			close(done)
+		}
+	}, nil)
+
+	es.Publish("start")
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("the pooled handler deadlocked publishing to its own queue")
+	}
+	// the queue took the first event
+	assert.Equal(t, uint64(4), sub.Dropped())
+}
+
+func TestEventStream_SubscribePooled_WithoutPoolIsSynchronous(t *testing.T) {
+	es := eventstream.NewEventStream()
+
+	var v int
+	es.SubscribePooled(func(evt interface{}) { v = evt.(int) }, nil)
+
+	es.Publish(1)
+	assert.Equal(t, 1, v)
+}
+
 func BenchmarkEventStream(b *testing.B) {
 	es := eventstream.NewEventStream()
 	subs := make([]*eventstream.Subscription, 10)
@@ -36,3 +36,12 @@ func (ex *Extensions) Register(extension Extension) {
 	id := extension.ExtensionID()
 	ex.extensions[id] = extension
 }
+
+// ForEach invokes f for every registered extension
+func (ex *Extensions) ForEach(f func(extension Extension)) {
+	for _, extension := range ex.extensions {
+		if extension != nil {
+			f(extension)
+		}
+	}
+}
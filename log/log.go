@@ -27,6 +27,17 @@ func (l Level) String() string {
 	return levelNames[int(l)]
 }
 
+// Interface is the logger used by the subsystems of an actor system, it is implemented by Logger.
+// Implement it to route the logs of an actor system elsewhere, the fields of a log line encode themselves with Field.Encode
+type Interface interface {
+	Debug(msg string, fields ...Field)
+	Info(msg string, fields ...Field)
+	Warn(msg string, fields ...Field)
+	Error(msg string, fields ...Field)
+}
+
+var _ Interface = (*Logger)(nil)
+
 type Logger struct {
 	level        Level
 	prefix       string
@@ -39,6 +39,20 @@ type ActorMetrics struct {
 
 	// Threadpool
 	ThreadPoolLatency instrument.Int64Histogram
+
+	// Remote endpoints
+	EndpointConnectedCount          instrument.Int64UpDownCounter
+	EndpointMessagesSentCount       instrument.Int64Counter
+	EndpointBytesSentCount          instrument.Int64Counter
+	EndpointSerializationErrorCount instrument.Int64Counter
+	EndpointReconnectCount          instrument.Int64Counter
+
+	// Cluster
+	GrainPassivatedCount instrument.Int64Counter
+
+	// Process cache
+	ProcessCacheHitCount  instrument.Int64ObservableCounter
+	ProcessCacheMissCount instrument.Int64ObservableCounter
 }
 
 // NewActorMetrics creates a new ActorMetrics value and returns a pointer to it
@@ -144,6 +158,78 @@ func newInstruments() *ActorMetrics {
 		plog.Error(err.Error(), log.Error(err))
 	}
 
+	if instruments.EndpointConnectedCount, err = meter.Int64UpDownCounter(
+		"protoactor_remote_endpoint_connected",
+		instrument.WithDescription("Number of connected remote endpoints"),
+		instrument.WithUnit(unit.Dimensionless),
+	); err != nil {
+		err = fmt.Errorf("failed to create EndpointConnectedCount instrument, %w", err)
+		plog.Error(err.Error(), log.Error(err))
+	}
+
+	if instruments.EndpointMessagesSentCount, err = meter.Int64Counter(
+		"protoactor_remote_endpoint_messages_sent_count",
+		instrument.WithDescription("Number of messages sent to remote endpoints"),
+		instrument.WithUnit(unit.Dimensionless),
+	); err != nil {
+		err = fmt.Errorf("failed to create EndpointMessagesSentCount instrument, %w", err)
+		plog.Error(err.Error(), log.Error(err))
+	}
+
+	if instruments.EndpointBytesSentCount, err = meter.Int64Counter(
+		"protoactor_remote_endpoint_bytes_sent_count",
+		instrument.WithDescription("Number of bytes sent to remote endpoints"),
+		instrument.WithUnit(unit.Bytes),
+	); err != nil {
+		err = fmt.Errorf("failed to create EndpointBytesSentCount instrument, %w", err)
+		plog.Error(err.Error(), log.Error(err))
+	}
+
+	if instruments.EndpointSerializationErrorCount, err = meter.Int64Counter(
+		"protoactor_remote_endpoint_serialization_error_count",
+		instrument.WithDescription("Number of messages which failed to serialize"),
+		instrument.WithUnit(unit.Dimensionless),
+	); err != nil {
+		err = fmt.Errorf("failed to create EndpointSerializationErrorCount instrument, %w", err)
+		plog.Error(err.Error(), log.Error(err))
+	}
+
+	if instruments.EndpointReconnectCount, err = meter.Int64Counter(
+		"protoactor_remote_endpoint_reconnect_count",
+		instrument.WithDescription("Number of remote endpoint connection retries"),
+		instrument.WithUnit(unit.Dimensionless),
+	); err != nil {
+		err = fmt.Errorf("failed to create EndpointReconnectCount instrument, %w", err)
+		plog.Error(err.Error(), log.Error(err))
+	}
+
+	if instruments.GrainPassivatedCount, err = meter.Int64Counter(
+		"protoactor_cluster_grain_passivated_count",
+		instrument.WithDescription("Number of grains deactivated after being idle"),
+		instrument.WithUnit(unit.Dimensionless),
+	); err != nil {
+		err = fmt.Errorf("failed to create GrainPassivatedCount instrument, %w", err)
+		plog.Error(err.Error(), log.Error(err))
+	}
+
+	if instruments.ProcessCacheHitCount, err = meter.Int64ObservableCounter(
+		"protoactor_process_cache_hit_count",
+		instrument.WithDescription("Number of PID lookups served by the process cache"),
+		instrument.WithUnit(unit.Dimensionless),
+	); err != nil {
+		err = fmt.Errorf("failed to create ProcessCacheHitCount instrument, %w", err)
+		plog.Error(err.Error(), log.Error(err))
+	}
+
+	if instruments.ProcessCacheMissCount, err = meter.Int64ObservableCounter(
+		"protoactor_process_cache_miss_count",
+		instrument.WithDescription("Number of PID lookups missing the process cache"),
+		instrument.WithUnit(unit.Dimensionless),
+	); err != nil {
+		err = fmt.Errorf("failed to create ProcessCacheMissCount instrument, %w", err)
+		plog.Error(err.Error(), log.Error(err))
+	}
+
 	return &instruments
 }
 
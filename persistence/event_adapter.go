@@ -0,0 +1,38 @@
+package persistence
+
+import (
+	"reflect"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// EventAdapter converts an event loaded from the journal into the event the actor handles today,
+// so the events of an older schema are replayed without rewriting the journal
+type EventAdapter interface {
+	FromJournal(event interface{}) (interface{}, error)
+}
+
+// JournalEventAdapter is an EventAdapter which also converts the events of its type before they are persisted
+type JournalEventAdapter interface {
+	EventAdapter
+	ToJournal(event proto.Message) proto.Message
+}
+
+// EventAdapterFunc is a function implementing EventAdapter
+type EventAdapterFunc func(event interface{}) (interface{}, error)
+
+func (f EventAdapterFunc) FromJournal(event interface{}) (interface{}, error) {
+	return f(event)
+}
+
+// WithEventAdapter registers the adapter for the events of the same type as event, e.g. (*OrderPlacedV1)(nil).
+// Loaded events of that type go through FromJournal during recovery, and persisted ones through ToJournal
+// when the adapter is a JournalEventAdapter
+func WithEventAdapter(event interface{}, adapter EventAdapter) Option {
+	return func(c *config) {
+		if c.eventAdapters == nil {
+			c.eventAdapters = make(map[reflect.Type]EventAdapter)
+		}
+		c.eventAdapters[reflect.TypeOf(event)] = adapter
+	}
+}
@@ -12,6 +12,7 @@ type entry struct {
 	events     []proto.Message
 }
 
+// InMemoryProvider keeps the events and snapshots in memory, it is safe for concurrent use
 type InMemoryProvider struct {
 	snapshotInterval int
 	mu               sync.RWMutex
@@ -33,11 +34,16 @@ func (provider *InMemoryProvider) loadOrInit(actorName string) (e *entry, loaded
 	e, ok := provider.store[actorName]
 	provider.mu.RUnlock()
 
-	if !ok {
-		provider.mu.Lock()
+	if ok {
+		return e, true
+	}
+
+	provider.mu.Lock()
+	defer provider.mu.Unlock()
+	// another caller may have initialized it meanwhile
+	if e, ok = provider.store[actorName]; !ok {
 		e = &entry{}
 		provider.store[actorName] = e
-		provider.mu.Unlock()
 	}
 
 	return e, ok
@@ -51,6 +57,9 @@ func (provider *InMemoryProvider) GetSnapshotInterval() int {
 
 func (provider *InMemoryProvider) GetSnapshot(actorName string) (snapshot interface{}, eventIndex int, ok bool) {
 	entry, loaded := provider.loadOrInit(actorName)
+
+	provider.mu.RLock()
+	defer provider.mu.RUnlock()
 	if !loaded || entry.snapshot == nil {
 		return nil, 0, false
 	}
@@ -59,27 +68,57 @@ func (provider *InMemoryProvider) GetSnapshot(actorName string) (snapshot interf
 
 func (provider *InMemoryProvider) PersistSnapshot(actorName string, eventIndex int, snapshot proto.Message) {
 	entry, _ := provider.loadOrInit(actorName)
+
+	provider.mu.Lock()
+	defer provider.mu.Unlock()
 	entry.eventIndex = eventIndex
 	entry.snapshot = snapshot
 }
 
+// DeleteSnapshots removes the snapshot if it was taken at or before inclusiveToIndex
 func (provider *InMemoryProvider) DeleteSnapshots(actorName string, inclusiveToIndex int) {
+	entry, _ := provider.loadOrInit(actorName)
+
+	provider.mu.Lock()
+	defer provider.mu.Unlock()
+	if entry.snapshot != nil && entry.eventIndex <= inclusiveToIndex {
+		entry.snapshot = nil
+		entry.eventIndex = 0
+	}
 }
 
 func (provider *InMemoryProvider) GetEvents(actorName string, eventIndexStart int, eventIndexEnd int, callback func(e interface{})) {
 	entry, _ := provider.loadOrInit(actorName)
+
+	provider.mu.RLock()
 	if eventIndexEnd == 0 {
 		eventIndexEnd = len(entry.events)
 	}
-	for _, e := range entry.events[eventIndexStart:eventIndexEnd] {
-		callback(e)
+	events := append([]proto.Message(nil), entry.events[eventIndexStart:eventIndexEnd]...)
+	provider.mu.RUnlock()
+
+	for _, e := range events {
+		// deleted events are kept as nil, so the others keep their index
+		if e != nil {
+			callback(e)
+		}
 	}
 }
 
 func (provider *InMemoryProvider) PersistEvent(actorName string, eventIndex int, event proto.Message) {
 	entry, _ := provider.loadOrInit(actorName)
+
+	provider.mu.Lock()
+	defer provider.mu.Unlock()
 	entry.events = append(entry.events, event)
 }
 
 func (provider *InMemoryProvider) DeleteEvents(actorName string, inclusiveToIndex int) {
+	entry, _ := provider.loadOrInit(actorName)
+
+	provider.mu.Lock()
+	defer provider.mu.Unlock()
+	for i := 0; i <= inclusiveToIndex && i < len(entry.events); i++ {
+		entry.events[i] = nil
+	}
 }
@@ -0,0 +1,24 @@
+package persistence
+
+import (
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/asynkron/protoactor-go/log"
+)
+
+var plog = log.New(log.DefaultLevel, "[PERSISTENCE]")
+
+// SetLogLevel sets the log level for the logger.
+//
+// SetLogLevel is safe to call concurrently
+func SetLogLevel(level log.Level) {
+	plog.SetLevel(level)
+}
+
+// systemLogger returns the logger of the actor system when one was set with actor.WithLogger, or the package logger
+func systemLogger(system *actor.ActorSystem) log.Interface {
+	if logger := system.Config.Logger; logger != nil {
+		return logger
+	}
+
+	return plog
+}
@@ -0,0 +1,51 @@
+package persistence
+
+import (
+	"reflect"
+
+	"github.com/asynkron/protoactor-go/actor"
+)
+
+// Option configures the persistence of the actors spawned with Using
+type Option func(*config)
+
+type config struct {
+	snapshotStrategies []SnapshotStrategy
+	eventAdapters      map[reflect.Type]EventAdapter
+	name               func(ctx actor.Context) string
+	snapshotOnly       bool
+}
+
+// WithName names the journal of an actor from its context instead of its PID id, which changes across the
+// activations of an actor such as a cluster grain, e.g. from its cluster identity
+func WithName(name func(ctx actor.Context) string) Option {
+	return func(c *config) {
+		c.name = name
+	}
+}
+
+// SnapshotOnly persists the actor through its snapshots alone, without an event journal: recovery restores the
+// latest snapshot and replays no events, and the EventStore of the provider is never used.
+// PersistReceive counts a change instead of journaling it, and the snapshot strategies decide after which changes
+// a snapshot is requested, the actor may also call PersistSnapshot at any time.
+// Changes made since the last snapshot are lost when the actor stops, snapshot on actor.Stopping to keep them
+func SnapshotOnly() Option {
+	return func(c *config) {
+		c.snapshotOnly = true
+	}
+}
+
+func newConfig(opts ...Option) *config {
+	c := &config{}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+func (c *config) eventAdapter(event interface{}) (EventAdapter, bool) {
+	adapter, ok := c.eventAdapters[reflect.TypeOf(event)]
+
+	return adapter, ok
+}
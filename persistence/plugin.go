@@ -1,14 +1,19 @@
 package persistence
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/asynkron/protoactor-go/actor"
+	"github.com/asynkron/protoactor-go/log"
 	"google.golang.org/protobuf/proto"
 )
 
 type persistent interface {
-	init(provider Provider, context actor.Context)
+	init(provider Provider, context actor.Context, config *config)
 	PersistReceive(message proto.Message)
 	PersistSnapshot(snapshot proto.Message)
+	afterReceive()
 	Recovering() bool
 	Name() string
 }
@@ -19,6 +24,17 @@ type Mixin struct {
 	name          string
 	receiver      receiver
 	recovering    bool
+
+	// snapshotPending asks a snapshot only actor for a snapshot once the change it is handling is applied
+	snapshotPending bool
+	// supersededBy is the event index of the last snapshot, whose superseded events and snapshots are deleted
+	// once the actor handled the message, so the provider is only ever called by the actor
+	supersededBy int
+
+	config             *config
+	snapshotStrategies []SnapshotStrategy
+	lastSnapshot       time.Time
+	logger             log.Interface
 }
 
 // enforces that Mixin implements persistent interface
@@ -34,18 +50,94 @@ func (mixin *Mixin) Name() string {
 }
 
 func (mixin *Mixin) PersistReceive(message proto.Message) {
-	mixin.providerState.PersistEvent(mixin.Name(), mixin.eventIndex, message)
-	if mixin.eventIndex%mixin.providerState.GetSnapshotInterval() == 0 {
+	if mixin.config.snapshotOnly {
+		mixin.eventIndex++
+		if mixin.shouldSnapshot() {
+			mixin.snapshotPending = true
+		}
+
+		return
+	}
+
+	mixin.providerState.PersistEvent(mixin.Name(), mixin.eventIndex, mixin.toJournal(message))
+	if mixin.shouldSnapshot() {
 		mixin.receiver.Receive(&actor.MessageEnvelope{Message: &RequestSnapshot{}})
 	}
 	mixin.eventIndex++
 }
 
+// afterReceive requests the snapshot a snapshot only actor asked for while handling the message,
+// then deletes what the snapshots taken meanwhile superseded
+func (mixin *Mixin) afterReceive() {
+	if mixin.snapshotPending {
+		mixin.snapshotPending = false
+		mixin.receiver.Receive(&actor.MessageEnvelope{Message: &RequestSnapshot{}})
+	}
+
+	if mixin.supersededBy > 0 {
+		mixin.deleteSuperseded(mixin.supersededBy)
+		mixin.supersededBy = 0
+	}
+}
+
+func (mixin *Mixin) shouldSnapshot() bool {
+	if len(mixin.snapshotStrategies) == 0 {
+		return mixin.eventIndex%mixin.providerState.GetSnapshotInterval() == 0
+	}
+
+	for _, strategy := range mixin.snapshotStrategies {
+		if strategy.ShouldSnapshot(mixin.eventIndex, mixin.lastSnapshot) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// PersistSnapshot stores the snapshot, the events and snapshots it supersedes are deleted once the actor handled
+// the current message.
+// The snapshot of a SnapshotOnly actor covers all the changes it counted so far.
+// A failed write is logged and leaves the journal untouched, so recovery replays the events the snapshot would have covered
 func (mixin *Mixin) PersistSnapshot(snapshot proto.Message) {
-	mixin.providerState.PersistSnapshot(mixin.Name(), mixin.eventIndex, snapshot)
+	name, eventIndex := mixin.Name(), mixin.eventIndex
+	if err := mixin.tryProvider(func() {
+		mixin.providerState.PersistSnapshot(name, eventIndex, snapshot)
+	}); err != nil {
+		mixin.logger.Error("Failed to persist snapshot", log.String("actor", name), log.Int("eventIndex", eventIndex), log.Error(err))
+
+		return
+	}
+	mixin.lastSnapshot = time.Now()
+	mixin.supersededBy = eventIndex
+}
+
+// deleteSuperseded deletes the events and snapshots before eventIndex, a failure is logged and retried
+// with the next snapshot
+func (mixin *Mixin) deleteSuperseded(eventIndex int) {
+	name := mixin.Name()
+	if err := mixin.tryProvider(func() {
+		if !mixin.config.snapshotOnly {
+			mixin.providerState.DeleteEvents(name, eventIndex-1)
+		}
+		mixin.providerState.DeleteSnapshots(name, eventIndex-1)
+	}); err != nil {
+		mixin.logger.Warn("Failed to delete superseded events and snapshots", log.String("actor", name), log.Int("eventIndex", eventIndex), log.Error(err))
+	}
+}
+
+// tryProvider calls the provider, turning a panic of the provider into an error
+func (mixin *Mixin) tryProvider(f func()) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("persistence provider failed: %v", r)
+		}
+	}()
+	f()
+
+	return nil
 }
 
-func (mixin *Mixin) init(provider Provider, context actor.Context) {
+func (mixin *Mixin) init(provider Provider, context actor.Context, config *config) {
 	if mixin.providerState == nil {
 		mixin.providerState = provider.GetState()
 	}
@@ -53,23 +145,61 @@ func (mixin *Mixin) init(provider Provider, context actor.Context) {
 	receiver := context.(receiver)
 
 	mixin.name = context.Self().Id
+	if config.name != nil {
+		mixin.name = config.name(context)
+	}
 	mixin.eventIndex = 0
 	mixin.receiver = receiver
+	mixin.logger = systemLogger(context.ActorSystem())
+	mixin.snapshotPending = false
+	mixin.supersededBy = 0
 	mixin.recovering = true
+	mixin.config = config
+	mixin.snapshotStrategies = config.snapshotStrategies
+	mixin.lastSnapshot = time.Now()
 
 	mixin.providerState.Restart()
 	if snapshot, eventIndex, ok := mixin.providerState.GetSnapshot(mixin.Name()); ok {
 		mixin.eventIndex = eventIndex
 		receiver.Receive(&actor.MessageEnvelope{Message: snapshot})
 	}
-	mixin.providerState.GetEvents(mixin.Name(), mixin.eventIndex, 0 /* 0 means max */, func(e interface{}) {
-		receiver.Receive(&actor.MessageEnvelope{Message: e})
-		mixin.eventIndex++
-	})
+	if !config.snapshotOnly {
+		mixin.providerState.GetEvents(mixin.Name(), mixin.eventIndex, 0 /* 0 means max */, func(e interface{}) {
+			receiver.Receive(&actor.MessageEnvelope{Message: mixin.fromJournal(e)})
+			mixin.eventIndex++
+		})
+	}
 	mixin.recovering = false
 	receiver.Receive(&actor.MessageEnvelope{Message: &ReplayComplete{}})
 }
 
+func (mixin *Mixin) toJournal(event proto.Message) proto.Message {
+	if adapter, ok := mixin.config.eventAdapter(event); ok {
+		if journalAdapter, ok := adapter.(JournalEventAdapter); ok {
+			return journalAdapter.ToJournal(event)
+		}
+	}
+
+	return event
+}
+
+// fromJournal adapts a replayed event. Skipping an event which can not be adapted would recover a wrong state,
+// so the failure panics and the supervisor of the actor decides
+func (mixin *Mixin) fromJournal(event interface{}) interface{} {
+	adapter, ok := mixin.config.eventAdapter(event)
+	if !ok {
+		return event
+	}
+
+	adapted, err := adapter.FromJournal(event)
+	if err != nil {
+		mixin.logger.Error("Failed to adapt event from journal", log.String("actor", mixin.Name()), log.Int("eventIndex", mixin.eventIndex), log.TypeOf("type", event), log.Error(err))
+		panic(fmt.Errorf("adapting event %d of %s: %w", mixin.eventIndex, mixin.Name(), err))
+	}
+
+	return adapted
+}
+
 type receiver interface {
 	Receive(message *actor.MessageEnvelope)
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"sync"
 	"testing"
+	"time"
 
 	"google.golang.org/protobuf/proto"
 
@@ -176,3 +177,138 @@ func TestRecovery(t *testing.T) {
 		})
 	}
 }
+
+func TestSnapshotEveryDeletesSupersededEvents(t *testing.T) {
+	provider := NewInMemoryProvider(1000)
+	store := &dataStore{providerState: provider}
+	rootContext := system.Root
+	props := actor.PropsFromProducer(makeActor,
+		actor.WithReceiverMiddleware(Using(store, SnapshotEvery(2))))
+
+	pid, err := rootContext.SpawnNamed(props, ActorName+".snapshots")
+	require.NoError(t, err)
+	for _, msg := range []string{"a", "b", "c", "d", "e"} {
+		rootContext.Send(pid, newMessage(msg))
+	}
+	_ = rootContext.PoisonFuture(pid).Wait()
+
+	// the snapshot taken before the fifth event supersedes the first four
+	snapshot, eventIndex, ok := provider.GetSnapshot(ActorName + ".snapshots")
+	require.True(t, ok)
+	assert.Equal(t, 4, eventIndex)
+	assert.Equal(t, "d", snapshot.(*Snapshot).state)
+	assert.Eventually(t, func() bool {
+		var replayed []string
+		provider.GetEvents(ActorName+".snapshots", 0, 0, func(e interface{}) {
+			replayed = append(replayed, e.(*Message).state)
+		})
+
+		return len(replayed) == 1 && replayed[0] == "e"
+	}, time.Second, 10*time.Millisecond)
+
+	pid, err = rootContext.SpawnNamed(props, ActorName+".snapshots")
+	require.NoError(t, err)
+	queryWg.Add(1)
+	rootContext.Send(pid, &Query{})
+	queryWg.Wait()
+	assert.Equal(t, "e", queryState)
+	_ = rootContext.PoisonFuture(pid).Wait()
+}
+
+func TestSnapshotEveryInterval(t *testing.T) {
+	strategy := intervalSnapshotStrategy(time.Minute)
+
+	assert.False(t, strategy.ShouldSnapshot(1, time.Now()))
+	assert.True(t, strategy.ShouldSnapshot(1, time.Now().Add(-2*time.Minute)))
+}
+
+type MessageV1 struct{ protoMsg }
+
+func TestEventAdapterUpgradesJournalEvents(t *testing.T) {
+	const name = ActorName + ".adapters"
+	provider := NewInMemoryProvider(1000)
+	provider.PersistEvent(name, 0, &MessageV1{protoMsg: protoMsg{state: "a"}})
+	provider.PersistEvent(name, 1, newMessage("b"))
+	provider.PersistEvent(name, 2, &MessageV1{protoMsg: protoMsg{state: "c"}})
+
+	var upgraded []string
+	props := actor.PropsFromProducer(makeActor,
+		actor.WithReceiverMiddleware(Using(&dataStore{providerState: provider},
+			WithEventAdapter((*MessageV1)(nil), EventAdapterFunc(func(event interface{}) (interface{}, error) {
+				old := event.(*MessageV1)
+				upgraded = append(upgraded, old.state)
+
+				return newMessage(old.state + "'"), nil
+			})))))
+
+	rootContext := system.Root
+	pid, err := rootContext.SpawnNamed(props, name)
+	require.NoError(t, err)
+	queryWg.Add(1)
+	rootContext.Send(pid, &Query{})
+	queryWg.Wait()
+	_ = rootContext.PoisonFuture(pid).Wait()
+
+	assert.Equal(t, []string{"a", "c"}, upgraded)
+	assert.Equal(t, "c'", queryState)
+}
+
+func TestSnapshotOnlyRecoversFromLatestSnapshot(t *testing.T) {
+	const name = ActorName + ".snapshotonly"
+	provider := NewInMemoryProvider(1000)
+	store := &dataStore{providerState: provider}
+	rootContext := system.Root
+	props := actor.PropsFromProducer(makeActor,
+		actor.WithReceiverMiddleware(Using(store, SnapshotOnly(), SnapshotEvery(2))))
+
+	pid, err := rootContext.SpawnNamed(props, name)
+	require.NoError(t, err)
+	for _, msg := range []string{"a", "b", "c", "d", "e"} {
+		rootContext.Send(pid, newMessage(msg))
+	}
+	_ = rootContext.PoisonFuture(pid).Wait()
+
+	// the snapshot is taken once the fourth change is applied, the fifth is lost
+	snapshot, eventIndex, ok := provider.GetSnapshot(name)
+	require.True(t, ok)
+	assert.Equal(t, 4, eventIndex)
+	assert.Equal(t, "d", snapshot.(*Snapshot).state)
+	provider.GetEvents(name, 0, 0, func(e interface{}) {
+		t.Errorf("no event should be journaled, got %v", e)
+	})
+
+	pid, err = rootContext.SpawnNamed(props, name)
+	require.NoError(t, err)
+	queryWg.Add(1)
+	rootContext.Send(pid, &Query{})
+	queryWg.Wait()
+	assert.Equal(t, "d", queryState)
+	_ = rootContext.PoisonFuture(pid).Wait()
+}
+
+func TestInMemoryProvider_ConcurrentUse(t *testing.T) {
+	provider := NewInMemoryProvider(1000)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			name := fmt.Sprintf("concurrent.%d", i%2)
+			for j := 0; j < 100; j++ {
+				provider.PersistEvent(name, j, newMessage("a"))
+				provider.PersistSnapshot(name, j, newSnapshot("a"))
+				provider.DeleteEvents(name, j-1)
+				provider.DeleteSnapshots(name, j-1)
+				_, _, _ = provider.GetSnapshot(name)
+				provider.GetEvents(name, 0, 0, func(interface{}) {})
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	// the events of all the writers of a name were kept in the same entry
+	provider.mu.RLock()
+	assert.Len(t, provider.store["concurrent.0"].events, 400)
+	provider.mu.RUnlock()
+}
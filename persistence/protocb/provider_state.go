@@ -92,7 +92,7 @@ func (state *cbState) PersistEvent(actorName string, eventIndex int, event proto
 }
 
 func (state *cbState) DeleteEvents(actorName string, inclusiveToIndex int) {
-	panic("implement me")
+	state.deleteRange(formatEventKey(actorName, 0), formatEventKey(actorName, inclusiveToIndex))
 }
 
 func (state *cbState) PersistSnapshot(actorName string, eventIndex int, snapshot proto.Message) {
@@ -102,7 +102,24 @@ func (state *cbState) PersistSnapshot(actorName string, eventIndex int, snapshot
 }
 
 func (state *cbState) DeleteSnapshots(actorName string, inclusiveToIndex int) {
-	panic("implement me")
+	state.deleteRange(formatSnapshotKey(actorName, 0), formatSnapshotKey(actorName, inclusiveToIndex))
+}
+
+func (state *cbState) deleteRange(fromKey string, toKey string) {
+	q := gocb.NewN1qlQuery("DELETE FROM `" + state.bucketName + "` b WHERE meta(b).id >= $1 and meta(b).id <= $2")
+
+	var p []interface{}
+	p = append(p, fromKey)
+	p = append(p, toKey)
+
+	rows, err := state.bucket.ExecuteN1qlQuery(q, p)
+	if err != nil {
+		log.Printf("Error executing N1ql: %v", err)
+		return
+	}
+	if err := rows.Close(); err != nil {
+		log.Printf("Error closing gocb reader: %v", err)
+	}
 }
 
 func (state *cbState) persistEnvelope(key string, envelope *envelope) {
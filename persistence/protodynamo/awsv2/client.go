@@ -0,0 +1,220 @@
+// Package awsv2 implements protodynamo.Client over the DynamoDB client of the AWS SDK for Go v2.
+// It is a separate module, so that the persistence package does not depend on the AWS SDK
+package awsv2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/asynkron/protoactor-go/persistence/protodynamo"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// ErrUnprocessedItems is returned by Client.BatchDelete when DynamoDB left items unprocessed after all the retries
+var ErrUnprocessedItems = errors.New("dynamodb: unprocessed items left after retries")
+
+// batchWriteLimit is the maximum number of requests of a BatchWriteItem call
+const batchWriteLimit = 25
+
+// the attributes of the items, the tables are keyed by PersistenceID as partition key and SequenceNr as sort key
+const (
+	persistenceIDAttribute = "PersistenceID"
+	sequenceNrAttribute    = "SequenceNr"
+	typeAttribute          = "Type"
+	payloadAttribute       = "Payload"
+)
+
+// API is the subset of the DynamoDB client used by Client, *dynamodb.Client implements it
+type API interface {
+	PutItem(ctx context.Context, params *dynamodb.PutItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error)
+	Query(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error)
+	BatchWriteItem(ctx context.Context, params *dynamodb.BatchWriteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchWriteItemOutput, error)
+}
+
+// Client stores the items of protodynamo in DynamoDB
+type Client struct {
+	api        API
+	maxRetries int
+	backoff    time.Duration
+}
+
+var _ protodynamo.Client = &Client{}
+
+type Option func(*Client)
+
+// WithRetries sets how many times the unprocessed items of a BatchWriteItem call are sent again, waiting backoff
+// doubled for each attempt. Default: 5 retries from 50ms
+func WithRetries(maxRetries int, backoff time.Duration) Option {
+	return func(client *Client) {
+		client.maxRetries = maxRetries
+		client.backoff = backoff
+	}
+}
+
+// New creates a client over api, usually dynamodb.NewFromConfig(cfg)
+func New(api API, options ...Option) *Client {
+	client := &Client{
+		api:        api,
+		maxRetries: 5,
+		backoff:    50 * time.Millisecond,
+	}
+	for _, option := range options {
+		option(client)
+	}
+
+	return client
+}
+
+// PutItem writes the item unless an item with the same keys exists, then it returns
+// protodynamo.ErrConditionalCheckFailed
+func (c *Client) PutItem(ctx context.Context, table string, item *protodynamo.Item) error {
+	attributes := key(item.PersistenceID, item.SequenceNr)
+	attributes[typeAttribute] = &types.AttributeValueMemberS{Value: item.Type}
+	attributes[payloadAttribute] = &types.AttributeValueMemberB{Value: item.Payload}
+
+	_, err := c.api.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:           aws.String(table),
+		Item:                attributes,
+		ConditionExpression: aws.String("attribute_not_exists(" + sequenceNrAttribute + ")"),
+	})
+
+	var conditionFailed *types.ConditionalCheckFailedException
+	if errors.As(err, &conditionFailed) {
+		return protodynamo.ErrConditionalCheckFailed
+	}
+
+	return err
+}
+
+// Query reads one page of the items of the persistence id, with consistent reads
+func (c *Client) Query(ctx context.Context, input *protodynamo.QueryInput) (*protodynamo.QueryOutput, error) {
+	query := &dynamodb.QueryInput{
+		TableName:              aws.String(input.Table),
+		KeyConditionExpression: aws.String(persistenceIDAttribute + " = :id AND " + sequenceNrAttribute + " BETWEEN :from AND :to"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":id":   &types.AttributeValueMemberS{Value: input.PersistenceID},
+			":from": number(input.From),
+			":to":   number(input.To),
+		},
+		ScanIndexForward: aws.Bool(!input.Descending),
+		ConsistentRead:   aws.Bool(true),
+	}
+	if input.Limit > 0 {
+		query.Limit = aws.Int32(input.Limit)
+	}
+	if input.StartAfter != nil {
+		query.ExclusiveStartKey = key(input.PersistenceID, *input.StartAfter)
+	}
+
+	res, err := c.api.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &protodynamo.QueryOutput{Items: make([]*protodynamo.Item, 0, len(res.Items))}
+	for _, attributes := range res.Items {
+		item, err := decodeItem(attributes)
+		if err != nil {
+			return nil, err
+		}
+		output.Items = append(output.Items, item)
+	}
+	if len(res.LastEvaluatedKey) > 0 {
+		last, err := sequenceNr(res.LastEvaluatedKey)
+		if err != nil {
+			return nil, err
+		}
+		output.LastSequenceNr = &last
+	}
+
+	return output, nil
+}
+
+// BatchDelete removes the items in BatchWriteItem calls of at most 25 items, retrying their unprocessed items
+func (c *Client) BatchDelete(ctx context.Context, table string, persistenceID string, sequenceNrs []int64) error {
+	for start := 0; start < len(sequenceNrs); start += batchWriteLimit {
+		end := start + batchWriteLimit
+		if end > len(sequenceNrs) {
+			end = len(sequenceNrs)
+		}
+
+		requests := make([]types.WriteRequest, 0, end-start)
+		for _, nr := range sequenceNrs[start:end] {
+			requests = append(requests, types.WriteRequest{
+				DeleteRequest: &types.DeleteRequest{Key: key(persistenceID, nr)},
+			})
+		}
+		if err := c.batchWrite(ctx, map[string][]types.WriteRequest{table: requests}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *Client) batchWrite(ctx context.Context, requests map[string][]types.WriteRequest) error {
+	for attempt := 0; ; attempt++ {
+		res, err := c.api.BatchWriteItem(ctx, &dynamodb.BatchWriteItemInput{RequestItems: requests})
+		if err != nil {
+			return err
+		}
+		if len(res.UnprocessedItems) == 0 {
+			return nil
+		}
+		if attempt >= c.maxRetries {
+			return fmt.Errorf("%w: %d attempts", ErrUnprocessedItems, attempt+1)
+		}
+
+		requests = res.UnprocessedItems
+		select {
+		case <-time.After(c.backoff << attempt):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func key(persistenceID string, sequenceNr int64) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		persistenceIDAttribute: &types.AttributeValueMemberS{Value: persistenceID},
+		sequenceNrAttribute:    number(sequenceNr),
+	}
+}
+
+func number(n int64) *types.AttributeValueMemberN {
+	return &types.AttributeValueMemberN{Value: strconv.FormatInt(n, 10)}
+}
+
+func sequenceNr(attributes map[string]types.AttributeValue) (int64, error) {
+	n, ok := attributes[sequenceNrAttribute].(*types.AttributeValueMemberN)
+	if !ok {
+		return 0, fmt.Errorf("dynamodb: item without number attribute %s", sequenceNrAttribute)
+	}
+
+	return strconv.ParseInt(n.Value, 10, 64)
+}
+
+func decodeItem(attributes map[string]types.AttributeValue) (*protodynamo.Item, error) {
+	nr, err := sequenceNr(attributes)
+	if err != nil {
+		return nil, err
+	}
+
+	item := &protodynamo.Item{SequenceNr: nr}
+	if id, ok := attributes[persistenceIDAttribute].(*types.AttributeValueMemberS); ok {
+		item.PersistenceID = id.Value
+	}
+	if typeName, ok := attributes[typeAttribute].(*types.AttributeValueMemberS); ok {
+		item.Type = typeName.Value
+	}
+	if payload, ok := attributes[payloadAttribute].(*types.AttributeValueMemberB); ok {
+		item.Payload = payload.Value
+	}
+
+	return item, nil
+}
@@ -0,0 +1,190 @@
+package awsv2
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/asynkron/protoactor-go/persistence/protodynamo"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAPI implements API over a single table, it leaves the first unprocessed requests of every BatchWriteItem call
+// unprocessed, unprocessed times
+type fakeAPI struct {
+	mu          sync.Mutex
+	items       map[int64]map[string]types.AttributeValue
+	puts        []*dynamodb.PutItemInput
+	queries     []*dynamodb.QueryInput
+	batches     []*dynamodb.BatchWriteItemInput
+	unprocessed int
+}
+
+func newFakeAPI() *fakeAPI {
+	return &fakeAPI{items: map[int64]map[string]types.AttributeValue{}}
+}
+
+func (f *fakeAPI) PutItem(_ context.Context, params *dynamodb.PutItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.puts = append(f.puts, params)
+
+	nr, err := sequenceNr(params.Item)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := f.items[nr]; ok && aws.ToString(params.ConditionExpression) == "attribute_not_exists(SequenceNr)" {
+		return nil, &types.ConditionalCheckFailedException{Message: aws.String("The conditional request failed")}
+	}
+	f.items[nr] = params.Item
+
+	return &dynamodb.PutItemOutput{}, nil
+}
+
+func (f *fakeAPI) Query(_ context.Context, params *dynamodb.QueryInput, _ ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.queries = append(f.queries, params)
+
+	from, _ := strconv.ParseInt(params.ExpressionAttributeValues[":from"].(*types.AttributeValueMemberN).Value, 10, 64)
+	to, _ := strconv.ParseInt(params.ExpressionAttributeValues[":to"].(*types.AttributeValueMemberN).Value, 10, 64)
+	forward := aws.ToBool(params.ScanIndexForward)
+	var nrs []int64
+	for nr := range f.items {
+		if nr < from || nr > to {
+			continue
+		}
+		if params.ExclusiveStartKey != nil {
+			start, _ := sequenceNr(params.ExclusiveStartKey)
+			if forward && nr <= start || !forward && nr >= start {
+				continue
+			}
+		}
+		nrs = append(nrs, nr)
+	}
+	sort.Slice(nrs, func(i, j int) bool { return nrs[i] < nrs[j] == forward })
+
+	res := &dynamodb.QueryOutput{}
+	// like DynamoDB, a page which reached the limit has a LastEvaluatedKey even when no item is left
+	if limit := int(aws.ToInt32(params.Limit)); limit > 0 && len(nrs) >= limit {
+		nrs = nrs[:limit]
+		res.LastEvaluatedKey = key("actor", nrs[limit-1])
+	}
+	for _, nr := range nrs {
+		res.Items = append(res.Items, f.items[nr])
+	}
+
+	return res, nil
+}
+
+func (f *fakeAPI) BatchWriteItem(_ context.Context, params *dynamodb.BatchWriteItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.BatchWriteItemOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.batches = append(f.batches, params)
+
+	res := &dynamodb.BatchWriteItemOutput{}
+	for table, requests := range params.RequestItems {
+		if f.unprocessed > 0 {
+			f.unprocessed--
+			res.UnprocessedItems = map[string][]types.WriteRequest{table: requests[:1]}
+			requests = requests[1:]
+		}
+		for _, request := range requests {
+			nr, _ := sequenceNr(request.DeleteRequest.Key)
+			delete(f.items, nr)
+		}
+	}
+
+	return res, nil
+}
+
+func TestClient_PutItem_ConditionalWrite(t *testing.T) {
+	api := newFakeAPI()
+	client := New(api)
+	item := &protodynamo.Item{PersistenceID: "actor", SequenceNr: 1, Type: "type", Payload: []byte("payload")}
+
+	require.NoError(t, client.PutItem(context.Background(), "events", item))
+	err := client.PutItem(context.Background(), "events", item)
+	assert.ErrorIs(t, err, protodynamo.ErrConditionalCheckFailed)
+
+	assert.Equal(t, "events", aws.ToString(api.puts[0].TableName))
+	assert.Equal(t, "attribute_not_exists(SequenceNr)", aws.ToString(api.puts[0].ConditionExpression))
+	stored, err := decodeItem(api.items[1])
+	require.NoError(t, err)
+	assert.Equal(t, item, stored)
+}
+
+func TestClient_Query_Pages(t *testing.T) {
+	api := newFakeAPI()
+	client := New(api)
+	for nr := int64(0); nr < 5; nr++ {
+		require.NoError(t, client.PutItem(context.Background(), "events", &protodynamo.Item{PersistenceID: "actor", SequenceNr: nr}))
+	}
+
+	input := &protodynamo.QueryInput{Table: "events", PersistenceID: "actor", From: 1, To: 4, Limit: 2}
+	var nrs []int64
+	for {
+		res, err := client.Query(context.Background(), input)
+		require.NoError(t, err)
+		for _, item := range res.Items {
+			nrs = append(nrs, item.SequenceNr)
+		}
+		if res.LastSequenceNr == nil {
+			break
+		}
+		input.StartAfter = res.LastSequenceNr
+	}
+
+	assert.Equal(t, []int64{1, 2, 3, 4}, nrs)
+	// the LastEvaluatedKey of a page is the ExclusiveStartKey of the next one
+	require.Len(t, api.queries, 3)
+	assert.Nil(t, api.queries[0].ExclusiveStartKey)
+	assert.Equal(t, key("actor", 2), api.queries[1].ExclusiveStartKey)
+	assert.True(t, aws.ToBool(api.queries[0].ConsistentRead))
+}
+
+func TestClient_BatchDelete_Chunks(t *testing.T) {
+	api := newFakeAPI()
+	client := New(api)
+	var nrs []int64
+	for nr := int64(0); nr < 60; nr++ {
+		require.NoError(t, client.PutItem(context.Background(), "events", &protodynamo.Item{PersistenceID: "actor", SequenceNr: nr}))
+		nrs = append(nrs, nr)
+	}
+
+	require.NoError(t, client.BatchDelete(context.Background(), "events", "actor", nrs))
+
+	assert.Empty(t, api.items)
+	require.Len(t, api.batches, 3)
+	for i, size := range []int{25, 25, 10} {
+		assert.Len(t, api.batches[i].RequestItems["events"], size)
+	}
+}
+
+func TestClient_BatchDelete_RetriesUnprocessedItems(t *testing.T) {
+	api := newFakeAPI()
+	api.unprocessed = 2
+	client := New(api, WithRetries(2, time.Millisecond))
+	for nr := int64(0); nr < 3; nr++ {
+		require.NoError(t, client.PutItem(context.Background(), "events", &protodynamo.Item{PersistenceID: "actor", SequenceNr: nr}))
+	}
+
+	require.NoError(t, client.BatchDelete(context.Background(), "events", "actor", []int64{0, 1, 2}))
+
+	assert.Empty(t, api.items)
+	require.Len(t, api.batches, 3)
+	// the retries only send the unprocessed items
+	assert.Len(t, api.batches[1].RequestItems["events"], 1)
+
+	api.unprocessed = 10
+	require.NoError(t, client.PutItem(context.Background(), "events", &protodynamo.Item{PersistenceID: "actor", SequenceNr: 3}))
+	err := client.BatchDelete(context.Background(), "events", "actor", []int64{3})
+	assert.ErrorIs(t, err, ErrUnprocessedItems)
+}
@@ -0,0 +1,12 @@
+module github.com/asynkron/protoactor-go/persistence/protodynamo/awsv2
+
+go 1.18
+
+replace github.com/asynkron/protoactor-go => ../../..
+
+require (
+	github.com/asynkron/protoactor-go v0.0.0-00010101000000-000000000000
+	github.com/aws/aws-sdk-go-v2 v1.17.3
+	github.com/aws/aws-sdk-go-v2/service/dynamodb v1.17.9
+	github.com/stretchr/testify v1.8.1
+)
@@ -0,0 +1,52 @@
+package protodynamo
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrConditionalCheckFailed is returned by Client.PutItem when an item with the same keys already exists
+var ErrConditionalCheckFailed = errors.New("dynamodb: conditional check failed")
+
+// Item is a journal or snapshot item. Tables are keyed by the persistence id as partition key (string)
+// and the sequence number as sort key (number)
+type Item struct {
+	PersistenceID string
+	SequenceNr    int64
+	Type          string // full name of the proto message stored in Payload
+	Payload       []byte
+}
+
+// QueryInput selects the items of one persistence id with a sequence number between From and To, both inclusive
+type QueryInput struct {
+	Table         string
+	PersistenceID string
+	From          int64
+	To            int64
+	Descending    bool
+	Limit         int32
+	// StartAfter continues a query after the sequence number of the last item of the previous page, when set
+	StartAfter *int64
+}
+
+// QueryOutput is a page of items, LastSequenceNr is set when more items are left to read
+type QueryOutput struct {
+	Items          []*Item
+	LastSequenceNr *int64
+}
+
+// Client is the subset of the DynamoDB API used to store events and snapshots.
+// This package does not depend on the AWS SDK, the awsv2 module implements Client over the DynamoDB client of the
+// AWS SDK for Go v2. An application implementing Client over another DynamoDB client follows the same rules:
+// PutItem sets the ConditionExpression attribute_not_exists(SequenceNr) and maps ConditionalCheckFailedException
+// to ErrConditionalCheckFailed, Query returns one page and maps its LastEvaluatedKey to LastSequenceNr,
+// and BatchDelete sends BatchWriteItem requests of at most 25 items, retrying their UnprocessedItems
+type Client interface {
+	// PutItem writes the item with the condition attribute_not_exists on the sort key,
+	// and returns ErrConditionalCheckFailed when the item already exists
+	PutItem(ctx context.Context, table string, item *Item) error
+	// Query reads a page of items with a key condition on the partition key and a BETWEEN on the sort key
+	Query(ctx context.Context, input *QueryInput) (*QueryOutput, error)
+	// BatchDelete removes the items of the persistence id with the sequence numbers, in BatchWriteItem requests
+	BatchDelete(ctx context.Context, table string, persistenceID string, sequenceNrs []int64) error
+}
@@ -0,0 +1,54 @@
+package protodynamo
+
+import (
+	"time"
+
+	"github.com/asynkron/protoactor-go/log"
+)
+
+type dynamoConfig struct {
+	eventsTable      string
+	snapshotsTable   string
+	snapshotInterval int
+	pageSize         int32
+	timeout          time.Duration
+	logger           log.Interface
+}
+
+type DynamoOption func(*dynamoConfig)
+
+// WithTables sets the tables storing the events and the snapshots. Default: "events" and "snapshots"
+func WithTables(events string, snapshots string) DynamoOption {
+	return func(config *dynamoConfig) {
+		config.eventsTable = events
+		config.snapshotsTable = snapshots
+	}
+}
+
+func WithSnapshot(interval int) DynamoOption {
+	return func(config *dynamoConfig) {
+		config.snapshotInterval = interval
+	}
+}
+
+// WithPageSize sets the number of events read per query during recovery. Default: 100
+func WithPageSize(size int32) DynamoOption {
+	return func(config *dynamoConfig) {
+		config.pageSize = size
+	}
+}
+
+// WithTimeout sets the timeout of each DynamoDB request. Default: 5s
+func WithTimeout(timeout time.Duration) DynamoOption {
+	return func(config *dynamoConfig) {
+		config.timeout = timeout
+	}
+}
+
+// WithLogger sets the logger of the provider, usually the logger of the actor system of the persistent actors
+// returned by ActorSystem.Logger. Default: the package logger
+func WithLogger(logger log.Interface) DynamoOption {
+	return func(config *dynamoConfig) {
+		config.logger = logger
+	}
+}
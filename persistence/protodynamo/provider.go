@@ -0,0 +1,39 @@
+package protodynamo
+
+import (
+	"time"
+
+	"github.com/asynkron/protoactor-go/persistence"
+)
+
+// Provider stores the events and snapshots of persistent actors in DynamoDB
+type Provider struct {
+	client Client
+	config *dynamoConfig
+}
+
+func (provider *Provider) GetState() persistence.ProviderState {
+	return &dynamoState{
+		Provider: provider,
+	}
+}
+
+// New creates a provider storing through the client, which adapts the DynamoDB client of the application
+func New(client Client, options ...DynamoOption) *Provider {
+	config := &dynamoConfig{
+		eventsTable:      "events",
+		snapshotsTable:   "snapshots",
+		snapshotInterval: 100,
+		pageSize:         100,
+		timeout:          5 * time.Second,
+		logger:           plog,
+	}
+	for _, option := range options {
+		option(config)
+	}
+
+	return &Provider{
+		client: client,
+		config: config,
+	}
+}
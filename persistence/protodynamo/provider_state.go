@@ -0,0 +1,177 @@
+package protodynamo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/asynkron/protoactor-go/log"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// dynamoState implements persistence.ProviderState. The persistence interfaces do not return errors, so failures
+// panic and are handled by the supervisor of the persistent actor, which recovers it again from the journal
+type dynamoState struct {
+	*Provider
+}
+
+func (state *dynamoState) Restart() {}
+
+func (state *dynamoState) GetSnapshotInterval() int {
+	return state.config.snapshotInterval
+}
+
+func (state *dynamoState) GetSnapshot(actorName string) (snapshot interface{}, eventIndex int, ok bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), state.config.timeout)
+	defer cancel()
+
+	res, err := state.client.Query(ctx, &QueryInput{
+		Table:         state.config.snapshotsTable,
+		PersistenceID: actorName,
+		From:          0,
+		To:            math.MaxInt64,
+		Descending:    true,
+		Limit:         1,
+	})
+	if err != nil {
+		state.fail("Failed to read snapshot", actorName, err)
+	}
+	if len(res.Items) == 0 {
+		return nil, 0, false
+	}
+
+	item := res.Items[0]
+
+	return state.unmarshal(actorName, item), int(item.SequenceNr), true
+}
+
+func (state *dynamoState) PersistSnapshot(actorName string, snapshotIndex int, snapshot proto.Message) {
+	err := state.put(state.config.snapshotsTable, actorName, snapshotIndex, snapshot)
+	if errors.Is(err, ErrConditionalCheckFailed) {
+		state.config.logger.Info("Snapshot already persisted", log.String("actor", actorName), log.Int("eventIndex", snapshotIndex))
+
+		return
+	}
+	if err != nil {
+		state.fail("Failed to persist snapshot", actorName, err)
+	}
+}
+
+func (state *dynamoState) DeleteSnapshots(actorName string, inclusiveToIndex int) {
+	state.deleteTo(state.config.snapshotsTable, actorName, inclusiveToIndex)
+}
+
+// GetEvents reads the events from eventIndexStart up to eventIndexEnd excluded in pages of the configured size,
+// eventIndexEnd 0 reads up to the last event
+func (state *dynamoState) GetEvents(actorName string, eventIndexStart int, eventIndexEnd int, callback func(e interface{})) {
+	to := int64(math.MaxInt64)
+	if eventIndexEnd != 0 {
+		to = int64(eventIndexEnd) - 1
+	}
+
+	err := state.query(state.config.eventsTable, actorName, int64(eventIndexStart), to, func(item *Item) {
+		callback(state.unmarshal(actorName, item))
+	})
+	if err != nil {
+		state.fail("Failed to read events", actorName, err)
+	}
+}
+
+// PersistEvent writes the event only if its sequence number is free, so that two activations of the same actor
+// can not overwrite each other's events. The activation losing the race fails and recovers the winner's events
+func (state *dynamoState) PersistEvent(actorName string, eventIndex int, event proto.Message) {
+	err := state.put(state.config.eventsTable, actorName, eventIndex, event)
+	if errors.Is(err, ErrConditionalCheckFailed) {
+		state.fail("Event sequence number already persisted by another activation", actorName, err)
+	}
+	if err != nil {
+		state.fail("Failed to persist event", actorName, err)
+	}
+}
+
+func (state *dynamoState) DeleteEvents(actorName string, inclusiveToIndex int) {
+	state.deleteTo(state.config.eventsTable, actorName, inclusiveToIndex)
+}
+
+func (state *dynamoState) put(table string, actorName string, index int, message proto.Message) error {
+	payload, err := proto.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), state.config.timeout)
+	defer cancel()
+
+	return state.client.PutItem(ctx, table, &Item{
+		PersistenceID: actorName,
+		SequenceNr:    int64(index),
+		Type:          string(proto.MessageName(message)),
+		Payload:       payload,
+	})
+}
+
+// query reads the items of actorName between from and to, one page per request
+func (state *dynamoState) query(table string, actorName string, from int64, to int64, f func(item *Item)) error {
+	input := &QueryInput{
+		Table:         table,
+		PersistenceID: actorName,
+		From:          from,
+		To:            to,
+		Limit:         state.config.pageSize,
+	}
+
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), state.config.timeout)
+		res, err := state.client.Query(ctx, input)
+		cancel()
+		if err != nil {
+			return err
+		}
+
+		for _, item := range res.Items {
+			f(item)
+		}
+
+		if res.LastSequenceNr == nil {
+			return nil
+		}
+		input.StartAfter = res.LastSequenceNr
+	}
+}
+
+func (state *dynamoState) deleteTo(table string, actorName string, inclusiveToIndex int) {
+	var sequenceNrs []int64
+	err := state.query(table, actorName, 0, int64(inclusiveToIndex), func(item *Item) {
+		sequenceNrs = append(sequenceNrs, item.SequenceNr)
+	})
+	if err == nil && len(sequenceNrs) > 0 {
+		ctx, cancel := context.WithTimeout(context.Background(), state.config.timeout)
+		err = state.client.BatchDelete(ctx, table, actorName, sequenceNrs)
+		cancel()
+	}
+	if err != nil {
+		state.fail("Failed to delete items", actorName, err)
+	}
+}
+
+func (state *dynamoState) unmarshal(actorName string, item *Item) proto.Message {
+	mt, err := protoregistry.GlobalTypes.FindMessageByName(protoreflect.FullName(item.Type))
+	if err != nil {
+		state.fail("Unknown stored message type", actorName, err)
+	}
+
+	message := mt.New().Interface()
+	if err := proto.Unmarshal(item.Payload, message); err != nil {
+		state.fail("Failed to unmarshal stored message", actorName, err)
+	}
+
+	return message
+}
+
+func (state *dynamoState) fail(msg string, actorName string, err error) {
+	state.config.logger.Error(msg, log.String("actor", actorName), log.Error(err))
+	panic(fmt.Errorf("%s for %s: %w", msg, actorName, err))
+}
@@ -0,0 +1,129 @@
+package protodynamo
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// memoryClient implements Client over maps, counting the queries sent
+type memoryClient struct {
+	mu      sync.Mutex
+	tables  map[string]map[string]map[int64]*Item
+	queries int
+}
+
+func newMemoryClient() *memoryClient {
+	return &memoryClient{tables: map[string]map[string]map[int64]*Item{}}
+}
+
+func (c *memoryClient) PutItem(_ context.Context, table string, item *Item) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.tables[table] == nil {
+		c.tables[table] = map[string]map[int64]*Item{}
+	}
+	partition := c.tables[table][item.PersistenceID]
+	if partition == nil {
+		partition = map[int64]*Item{}
+		c.tables[table][item.PersistenceID] = partition
+	}
+	if _, ok := partition[item.SequenceNr]; ok {
+		return ErrConditionalCheckFailed
+	}
+	partition[item.SequenceNr] = item
+
+	return nil
+}
+
+func (c *memoryClient) Query(_ context.Context, input *QueryInput) (*QueryOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.queries++
+
+	var items []*Item
+	for nr, item := range c.tables[input.Table][input.PersistenceID] {
+		if nr < input.From || nr > input.To {
+			continue
+		}
+		if input.StartAfter != nil && (!input.Descending && nr <= *input.StartAfter || input.Descending && nr >= *input.StartAfter) {
+			continue
+		}
+		items = append(items, item)
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if input.Descending {
+			return items[i].SequenceNr > items[j].SequenceNr
+		}
+		return items[i].SequenceNr < items[j].SequenceNr
+	})
+
+	res := &QueryOutput{Items: items}
+	if input.Limit > 0 && len(items) > int(input.Limit) {
+		res.Items = items[:input.Limit]
+		last := res.Items[len(res.Items)-1].SequenceNr
+		res.LastSequenceNr = &last
+	}
+
+	return res, nil
+}
+
+func (c *memoryClient) BatchDelete(_ context.Context, table string, persistenceID string, sequenceNrs []int64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, nr := range sequenceNrs {
+		delete(c.tables[table][persistenceID], nr)
+	}
+
+	return nil
+}
+
+func TestProvider_EventsAndSnapshots(t *testing.T) {
+	client := newMemoryClient()
+	state := New(client, WithPageSize(2)).GetState()
+
+	for i, s := range []string{"a", "b", "c", "d", "e"} {
+		state.PersistEvent("actor", i, wrapperspb.String(s))
+	}
+
+	var events []string
+	client.queries = 0
+	state.GetEvents("actor", 1, 0, func(e interface{}) {
+		events = append(events, e.(*wrapperspb.StringValue).Value)
+	})
+	assert.Equal(t, []string{"b", "c", "d", "e"}, events)
+	// the recovery reads in pages of two events
+	assert.Equal(t, 2, client.queries)
+
+	state.PersistSnapshot("actor", 2, wrapperspb.String("b"))
+	state.PersistSnapshot("actor", 4, wrapperspb.String("d"))
+	snapshot, eventIndex, ok := state.GetSnapshot("actor")
+	require.True(t, ok)
+	assert.Equal(t, 4, eventIndex)
+	assert.Equal(t, "d", snapshot.(*wrapperspb.StringValue).Value)
+
+	state.DeleteEvents("actor", 3)
+	state.DeleteSnapshots("actor", 3)
+	events = nil
+	state.GetEvents("actor", 0, 0, func(e interface{}) {
+		events = append(events, e.(*wrapperspb.StringValue).Value)
+	})
+	assert.Equal(t, []string{"e"}, events)
+	assert.Len(t, client.tables["snapshots"]["actor"], 1)
+}
+
+func TestProvider_PersistEventRejectsTakenSequenceNumber(t *testing.T) {
+	state := New(newMemoryClient()).GetState()
+	state.PersistEvent("actor", 0, wrapperspb.String("first activation"))
+
+	assert.Panics(t, func() {
+		state.PersistEvent("actor", 0, wrapperspb.String("second activation"))
+	})
+}
@@ -7,7 +7,11 @@ import (
 	"github.com/asynkron/protoactor-go/actor"
 )
 
-func Using(provider Provider) func(next actor.ReceiverFunc) actor.ReceiverFunc {
+// Using makes the actor persistent, storing its events and snapshots through the provider.
+// Without snapshot strategy options the actor snapshots every GetSnapshotInterval events of the provider
+func Using(provider Provider, opts ...Option) func(next actor.ReceiverFunc) actor.ReceiverFunc {
+	config := newConfig(opts...)
+
 	return func(next actor.ReceiverFunc) actor.ReceiverFunc {
 		fn := func(ctx actor.ReceiverContext, env *actor.MessageEnvelope) {
 			switch env.Message.(type) {
@@ -19,13 +23,17 @@ func Using(provider Provider) func(next actor.ReceiverFunc) actor.ReceiverFunc {
 				// check if the actor is persistent
 				if p, ok := ctx.Actor().(persistent); ok {
 					// initialize it
-					p.init(provider, ctx.(actor.Context))
+					p.init(provider, ctx.(actor.Context), config)
 				} else {
 					// not an persistent actor, bail out
 					log.Fatalf("Actor type %v is not persistent", reflect.TypeOf(ctx.Actor()))
 				}
 			default:
 				next(ctx, env)
+
+				if p, ok := ctx.Actor().(persistent); ok {
+					p.afterReceive()
+				}
 			}
 		}
 		return fn
@@ -0,0 +1,37 @@
+package persistence
+
+import "time"
+
+// SnapshotStrategy decides whether a persistent actor takes a snapshot after persisting the event at eventIndex
+type SnapshotStrategy interface {
+	ShouldSnapshot(eventIndex int, lastSnapshot time.Time) bool
+}
+
+// WithSnapshotStrategy adds a custom snapshot strategy, a snapshot is taken as soon as any of the strategies asks for one
+func WithSnapshotStrategy(strategy SnapshotStrategy) Option {
+	return func(c *config) {
+		c.snapshotStrategies = append(c.snapshotStrategies, strategy)
+	}
+}
+
+// SnapshotEvery takes a snapshot every given number of events
+func SnapshotEvery(events int) Option {
+	return WithSnapshotStrategy(eventSnapshotStrategy(events))
+}
+
+// SnapshotEveryInterval takes a snapshot on the first event persisted once the interval elapsed since the last snapshot
+func SnapshotEveryInterval(interval time.Duration) Option {
+	return WithSnapshotStrategy(intervalSnapshotStrategy(interval))
+}
+
+type eventSnapshotStrategy int
+
+func (s eventSnapshotStrategy) ShouldSnapshot(eventIndex int, _ time.Time) bool {
+	return s > 0 && eventIndex%int(s) == 0
+}
+
+type intervalSnapshotStrategy time.Duration
+
+func (s intervalSnapshotStrategy) ShouldSnapshot(_ int, lastSnapshot time.Time) bool {
+	return time.Since(lastSnapshot) >= time.Duration(s)
+}
@@ -5,7 +5,6 @@ const code = `{{ if .Services -}}
 package {{.PackageName}}
 
 import (
-	"errors"
 	"fmt"
 	"math"
 	"time"
@@ -44,7 +43,18 @@ func Get{{ $service.Name }}GrainClient(c *cluster.Cluster, id string) *{{ $servi
 	if id == "" {
 		panic(fmt.Errorf("empty id"))
 	}
-	return &{{ $service.Name }}GrainClient{Identity: id, cluster: c}
+	return &{{ $service.Name }}GrainClient{Identity: id, caller: c}
+}
+
+// New{{ $service.Name }}GrainClient instantiates a new {{ $service.Name }}GrainClient with given Identity, calling the grain through caller
+func New{{ $service.Name }}GrainClient(caller cluster.GrainCaller, id string) *{{ $service.Name }}GrainClient {
+	if caller == nil {
+		panic(fmt.Errorf("nil grain caller"))
+	}
+	if id == "" {
+		panic(fmt.Errorf("empty id"))
+	}
+	return &{{ $service.Name }}GrainClient{Identity: id, caller: caller}
 }
 
 // Get{{ $service.Name }}Kind instantiates a new cluster.Kind for {{ $service.Name }}
@@ -82,8 +92,8 @@ type {{ $service.Name }} interface {
 
 // {{ $service.Name }}GrainClient holds the base data for the {{ $service.Name }}Grain
 type {{ $service.Name }}GrainClient struct {
-	Identity      string
-	cluster *cluster.Cluster
+	Identity string
+	caller   cluster.GrainCaller
 }
 {{ range $method := $service.Methods}}
 // {{ $method.Name }} requests the execution on to the cluster with CallOptions
@@ -92,24 +102,16 @@ func (g *{{ $service.Name }}GrainClient) {{ $method.Name }}(r *{{ $method.Input.
 	if err != nil {
 		return nil, err
 	}
-	reqMsg := &cluster.GrainRequest{MethodIndex: {{ $method.Index }}, MessageData: bytes}
-	resp, err := g.cluster.Call(g.Identity, "{{ $service.Name }}", reqMsg, opts...)
+	resp, err := g.caller.CallGrain(g.Identity, "{{ $service.Name }}", {{ $method.Index }}, bytes, opts...)
 	if err != nil {
 		return nil, err
 	}
-	switch msg := resp.(type) {
-	case *cluster.GrainResponse:
-		result := &{{ $method.Output.Name }}{}
-		err = proto.Unmarshal(msg.MessageData, result)
-		if err != nil {
-			return nil, err
-		}
-		return result, nil
-	case *cluster.GrainErrorResponse:
-		return nil, errors.New(msg.Err)
-	default:
-		return nil, errors.New("unknown response")
+	result := &{{ $method.Output.Name }}{}
+	err = proto.Unmarshal(resp, result)
+	if err != nil {
+		return nil, err
 	}
+	return result, nil
 }
 {{ end }}
 
@@ -146,7 +148,7 @@ func (a *{{ $service.Name }}Actor) Receive(ctx actor.Context) {
 			req := &{{ $method.Input.Name }}{}
 			err := proto.Unmarshal(msg.MessageData, req)
 			if err != nil {
-				plog.Error("{{ $method.Name }}({{ $method.Input.Name }}) proto.Unmarshal failed.", logmod.Error(err))
+				a.ctx.Cluster().Logger().Error("{{ $method.Name }}({{ $method.Input.Name }}) proto.Unmarshal failed.", logmod.Error(err))
 				resp := &cluster.GrainErrorResponse{Err: err.Error()}
 				ctx.Respond(resp)
 				return
@@ -159,7 +161,7 @@ func (a *{{ $service.Name }}Actor) Receive(ctx actor.Context) {
 			}
 			bytes, err := proto.Marshal(r0)
 			if err != nil {
-				plog.Error("{{ $method.Name }}({{ $method.Input.Name }}) proto.Marshal failed", logmod.Error(err))
+				a.ctx.Cluster().Logger().Error("{{ $method.Name }}({{ $method.Input.Name }}) proto.Marshal failed", logmod.Error(err))
 				resp := &cluster.GrainErrorResponse{Err: err.Error()}
 				ctx.Respond(resp)
 				return
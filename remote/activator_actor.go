@@ -1,17 +1,25 @@
 package remote
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"github.com/asynkron/protoactor-go/actor"
 	"github.com/asynkron/protoactor-go/log"
 )
 
-// Register a known actor props by name
-func (r *Remote) Register(kind string, props *actor.Props) {
-	r.kinds[kind] = props
+// Register a known actor props by name, WithRestricted keeps other nodes from spawning it
+func (r *Remote) Register(kind string, props *actor.Props, opts ...KindOption) {
+	k := NewKind(kind, props, opts...)
+	r.kinds[k.Kind] = k.Props
+	if k.Restricted {
+		r.restrictedKinds[k.Kind] = true
+	} else {
+		delete(r.restrictedKinds, k.Kind)
+	}
 }
 
 // GetKnownKinds returns a slice of known actor "Kinds"
@@ -32,6 +40,9 @@ type activator struct {
 // Partition will then find next available Activator to spawn
 var ErrActivatorUnavailable = &ActivatorError{ResponseStatusCodeUNAVAILABLE.ToInt32(), true}
 
+// ErrActivatorForbidden is returned by the activator when asked to spawn a restricted kind
+var ErrActivatorForbidden = &ActivatorError{ResponseStatusCodeFORBIDDEN.ToInt32(), true}
+
 type ActivatorError struct {
 	Code       int32
 	DoNotPanic bool
@@ -62,17 +73,75 @@ func (r *Remote) Spawn(address, kind string, timeout time.Duration) (*ActorPidRe
 	return r.SpawnNamed(address, "", kind, timeout)
 }
 
-// SpawnNamed spawns a named remote actor of a given type at a given address
+// SpawnNamed spawns a named remote actor of a given type at a given address.
+//
+// It fails with ErrTimeout when the activator does not answer within timeout, with ErrActivatorUnavailable
+// when the activator cannot be reached, the endpoint terminates before it answers or the kind is not registered,
+// with ErrForbidden when the kind is restricted, and with
+// ErrProcessNameAlreadyExist, along with the response holding the existing PID, when the name is taken
 func (r *Remote) SpawnNamed(address, name, kind string, timeout time.Duration) (*ActorPidResponse, error) {
-	res, err := r.SpawnFuture(address, name, kind, timeout).Result()
-	if err != nil {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	return r.SpawnNamedCtx(ctx, address, name, kind)
+}
+
+// SpawnNamedCtx spawns a named remote actor of a given type at a given address, bounded by ctx.
+// It fails like SpawnNamed, with ErrTimeout when the deadline of ctx passes and with the error of ctx when it is cancelled
+func (r *Remote) SpawnNamedCtx(ctx context.Context, address, name, kind string) (*ActorPidResponse, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// watch the endpoint before sending, so a connection failure does not have to wait for the deadline
+	var terminated int32
+	sub := r.actorSystem.EventStream.Subscribe(func(evt interface{}) {
+		if e, ok := evt.(*EndpointTerminatedEvent); ok && e.Address == address {
+			atomic.StoreInt32(&terminated, 1)
+			cancel()
+		}
+	})
+	defer r.actorSystem.EventStream.Unsubscribe(sub)
+
+	f := r.actorSystem.Root.RequestFutureCtx(r.ActivatorForAddress(address), &ActorPidRequest{
+		Name: name,
+		Kind: kind,
+	}, ctx)
+	res, err := f.Result()
+
+	switch {
+	case atomic.LoadInt32(&terminated) == 1 && errors.Is(err, context.Canceled):
+		return nil, ErrActivatorUnavailable
+	case errors.Is(err, context.DeadlineExceeded):
+		return nil, ErrTimeout
+	}
+
+	return spawnResult(res, err)
+}
+
+func spawnResult(res interface{}, err error) (*ActorPidResponse, error) {
+	switch {
+	case errors.Is(err, actor.ErrTimeout):
+		return nil, ErrTimeout
+	case errors.Is(err, actor.ErrDeadLetter):
+		return nil, ErrActivatorUnavailable
+	case err != nil:
 		return nil, err
 	}
-	switch msg := res.(type) {
-	case *ActorPidResponse:
+
+	msg, ok := res.(*ActorPidResponse)
+	if !ok {
+		return nil, errors.New("remote: Unknown response when remote activating")
+	}
+
+	switch code := ResponseStatusCode(msg.StatusCode); code {
+	case ResponseStatusCodeOK:
 		return msg, nil
+	case ResponseStatusCodeUNAVAILABLE:
+		return nil, ErrActivatorUnavailable
+	case ResponseStatusCodePROCESSNAMEALREADYEXIST:
+		return msg, ErrProcessNameAlreadyExist
 	default:
-		return nil, errors.New("remote: Unknown response when remote activating")
+		return nil, code.AsError()
 	}
 }
 
@@ -87,19 +156,22 @@ func newActivatorActor(remote *Remote) actor.Producer {
 func (a *activator) Receive(context actor.Context) {
 	switch msg := context.Message().(type) {
 	case *actor.Started:
-		plog.Info("Started Activator")
+		a.remote.Logger().Info("Started Activator")
 	case *Ping:
 		context.Respond(&Pong{})
 	case *ActorPidRequest:
 		props, exist := a.remote.kinds[msg.Kind]
 
-		// if props not exist, return error and panic
+		// unknown and restricted kinds are rejected without spawning
 		if !exist {
-			response := &ActorPidResponse{
-				StatusCode: ResponseStatusCodeERROR.ToInt32(),
-			}
-			context.Respond(response)
-			panic(fmt.Errorf("no Props found for kind %s", msg.Kind))
+			a.remote.Logger().Warn("Activator rejected spawning an unknown kind", log.String("kind", msg.Kind), log.Stringer("sender", context.Sender()))
+			context.Respond(&ActorPidResponse{StatusCode: ErrActivatorUnavailable.Code})
+			return
+		}
+		if a.remote.restrictedKinds[msg.Kind] {
+			a.remote.Logger().Warn("Activator rejected spawning a restricted kind", log.String("kind", msg.Kind), log.Stringer("sender", context.Sender()))
+			context.Respond(&ActorPidResponse{StatusCode: ErrActivatorForbidden.Code})
+			return
 		}
 
 		name := msg.Name
@@ -138,6 +210,6 @@ func (a *activator) Receive(context actor.Context) {
 	case actor.SystemMessage, actor.AutoReceiveMessage:
 		// ignore
 	default:
-		plog.Error("Activator received unknown message", log.TypeOf("type", msg), log.Message(msg))
+		a.remote.Logger().Error("Activator received unknown message", log.TypeOf("type", msg), log.Message(msg))
 	}
 }
@@ -0,0 +1,86 @@
+package remote
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/stretchr/testify/assert"
+)
+
+func startSpawnRemotes(t *testing.T) (*Remote, string) {
+	server := actor.NewActorSystem()
+	serverRemote := NewRemote(server, Configure("localhost", 0, WithKinds(
+		NewKind("echo", actor.PropsFromFunc(func(ctx actor.Context) {})),
+		NewKind("internal", actor.PropsFromFunc(func(ctx actor.Context) {}), WithRestricted()),
+	)))
+	serverRemote.Start()
+
+	client := actor.NewActorSystem()
+	clientRemote := NewRemote(client, Configure("localhost", 0))
+	clientRemote.Start()
+
+	t.Cleanup(func() {
+		clientRemote.Shutdown(false)
+		serverRemote.Shutdown(false)
+	})
+
+	return clientRemote, server.Address()
+}
+
+func TestRemote_SpawnNamed_NameExists(t *testing.T) {
+	r, address := startSpawnRemotes(t)
+
+	res, err := r.SpawnNamed(address, "taken", "echo", 5*time.Second)
+	assert.NoError(t, err)
+
+	again, err := r.SpawnNamed(address, "taken", "echo", 5*time.Second)
+	assert.ErrorIs(t, err, ErrProcessNameAlreadyExist)
+	assert.Equal(t, res.Pid.Id, again.Pid.Id)
+}
+
+func TestRemote_SpawnNamed_RejectsUnknownAndRestrictedKinds(t *testing.T) {
+	r, address := startSpawnRemotes(t)
+
+	_, err := r.SpawnNamed(address, "unknown", "missing", 5*time.Second)
+	assert.ErrorIs(t, err, ErrActivatorUnavailable)
+
+	_, err = r.SpawnNamed(address, "internal", "internal", 5*time.Second)
+	assert.ErrorIs(t, err, ErrForbidden)
+
+	// the activator keeps serving requests
+	_, err = r.SpawnNamed(address, "allowed", "echo", 5*time.Second)
+	assert.NoError(t, err)
+}
+
+func TestRemote_SpawnNamed_EndpointTerminated(t *testing.T) {
+	r, _ := startSpawnRemotes(t)
+	address := "localhost:1"
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		r.actorSystem.EventStream.Publish(&EndpointTerminatedEvent{Address: address})
+	}()
+
+	_, err := r.SpawnNamed(address, "never", "echo", 10*time.Second)
+	assert.ErrorIs(t, err, ErrActivatorUnavailable)
+}
+
+func TestRemote_SpawnNamedCtx_Cancelled(t *testing.T) {
+	r, _ := startSpawnRemotes(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		cancel()
+	}()
+
+	_, err := r.SpawnNamedCtx(ctx, "localhost:1", "never", "echo")
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestSpawnResult_Timeout(t *testing.T) {
+	_, err := spawnResult(nil, actor.ErrTimeout)
+	assert.ErrorIs(t, err, ErrTimeout)
+}
@@ -0,0 +1,57 @@
+package remote
+
+import (
+	"errors"
+	"net"
+	"strings"
+)
+
+// ErrAddressDenied is returned when an endpoint to a remote address is refused by the address policy
+var ErrAddressDenied = errors.New("remote: address denied by policy")
+
+// AddressFilter decides whether endpoints may be opened to the remote address
+type AddressFilter func(address string) bool
+
+// allowsAddress applies EndpointDenyList, EndpointAllowList and EndpointFilter, in that order.
+// An empty allow list allows every address which is not denied
+func (rc *Config) allowsAddress(address string) bool {
+	if matchesAddress(rc.EndpointDenyList, address) {
+		return false
+	}
+
+	if len(rc.EndpointAllowList) > 0 && !matchesAddress(rc.EndpointAllowList, address) {
+		return false
+	}
+
+	if rc.EndpointFilter != nil {
+		return rc.EndpointFilter(address)
+	}
+
+	return true
+}
+
+// matchesAddress checks address against entries which are either a CIDR or an exact host:port
+func matchesAddress(entries []string, address string) bool {
+	var ip net.IP
+	if host, _, err := net.SplitHostPort(address); err == nil {
+		ip = net.ParseIP(host)
+	}
+
+	for _, entry := range entries {
+		if strings.Contains(entry, "/") {
+			if _, network, err := net.ParseCIDR(entry); err == nil {
+				if ip != nil && network.Contains(ip) {
+					return true
+				}
+
+				continue
+			}
+		}
+
+		if entry == address {
+			return true
+		}
+	}
+
+	return false
+}
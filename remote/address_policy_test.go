@@ -0,0 +1,32 @@
+package remote
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_AllowsAddress(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []ConfigOption
+		address string
+		allowed bool
+	}{
+		{"no policy", nil, "10.0.0.1:8080", true},
+		{"denied cidr", []ConfigOption{WithEndpointDenyList("10.0.0.0/8")}, "10.1.2.3:8080", false},
+		{"denied exact", []ConfigOption{WithEndpointDenyList("host:8080")}, "host:8080", false},
+		{"not in allow list", []ConfigOption{WithEndpointAllowList("192.168.0.0/16", "host:8080")}, "10.0.0.1:8080", false},
+		{"allowed cidr", []ConfigOption{WithEndpointAllowList("192.168.0.0/16")}, "192.168.1.1:8080", true},
+		{"allowed exact", []ConfigOption{WithEndpointAllowList("host:8080")}, "host:8080", true},
+		{"deny wins", []ConfigOption{WithEndpointAllowList("10.0.0.0/8"), WithEndpointDenyList("10.0.0.1:8080")}, "10.0.0.1:8080", false},
+		{"filter", []ConfigOption{WithEndpointFilter(func(address string) bool { return address != "host:8080" })}, "host:8080", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := Configure("localhost", 0, tt.options...)
+			assert.Equal(t, tt.allowed, config.allowsAddress(tt.address))
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package remote
+
+import "fmt"
+
+// decodeFunc deserializes a message of a resolved type
+type decodeFunc func(bytes []byte) (interface{}, error)
+
+// typeResolver is implemented by serializers which resolve a type name once for all the messages of the type
+type typeResolver interface {
+	resolve(typeName string) (decodeFunc, error)
+}
+
+// batchTypes resolves each type name of a received batch once, with the serializer of the first message of the type,
+// the counterpart of the type name lookup built by the endpoint writer. Types are resolved again for every batch,
+// so the types registered between batches are found, and a serializer replaced between batches is not used anymore
+type batchTypes struct {
+	names []string
+	types []batchType
+}
+
+type batchType struct {
+	resolved     bool
+	serializerID int32
+	decode       decodeFunc
+	err          error
+}
+
+func newBatchTypes(names []string) batchTypes {
+	return batchTypes{
+		names: names,
+		types: make([]batchType, len(names)),
+	}
+}
+
+// deserialize deserializes a message of the batch, it returns the same error as Deserialize
+func (b batchTypes) deserialize(typeID int32, serializerID int32, bytes []byte) (interface{}, error) {
+	if typeID < 0 || int(typeID) >= len(b.names) {
+		return nil, fmt.Errorf("remote: unknown type id %v", typeID)
+	}
+
+	t := &b.types[typeID]
+	if !t.resolved || t.serializerID != serializerID {
+		*t = batchType{resolved: true, serializerID: serializerID}
+		t.decode, t.err = resolveType(b.names[typeID], serializerID)
+	}
+	if t.err != nil {
+		return nil, t.err
+	}
+
+	return t.decode(bytes)
+}
+
+func resolveType(typeName string, serializerID int32) (decodeFunc, error) {
+	serializer, err := getSerializer(serializerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if r, ok := serializer.(typeResolver); ok {
+		return r.resolve(typeName)
+	}
+
+	return func(bytes []byte) (interface{}, error) {
+		return serializer.Deserialize(typeName, bytes)
+	}, nil
+}
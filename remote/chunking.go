@@ -0,0 +1,218 @@
+package remote
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// ErrInvalidChunk is returned when a received chunk does not fit the transfer it belongs to
+var ErrInvalidChunk = errors.New("remote: invalid message chunk")
+
+// ErrChunkLimit is returned when a received chunk exceeds Config.ChunkMaxTransferSize or Config.ChunkMaxBufferSize,
+// the transfer it belongs to is discarded
+var ErrChunkLimit = errors.New("remote: chunked transfer too large")
+
+// send sends the message on the stream. When the peer supports chunking, each message of a batch larger than
+// Config.ChunkSize is sent alone in ordered chunks, between the batches of the messages sent before and after it.
+// The chunks of a message are sent back to back, they keep the frames small but still hold the stream until the
+// whole message is sent, use Config.EndpointStreamCount so that other targets are sent on other streams meanwhile
+func (state *endpointWriter) send(stream RemoteConnection, message *RemoteMessage) error {
+	if !state.chunkingSupported || state.config.ChunkSize <= 0 {
+		return stream.Send(message)
+	}
+
+	parts, err := state.splitLarge(message)
+	if err != nil {
+		return err
+	}
+
+	for _, part := range parts {
+		if err := stream.Send(part); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// splitLarge splits a batch into the batches of consecutive small messages and the chunks of each large message
+func (state *endpointWriter) splitLarge(message *RemoteMessage) ([]*RemoteMessage, error) {
+	batch := message.GetMessageBatch()
+	if batch == nil {
+		return []*RemoteMessage{message}, nil
+	}
+
+	large := false
+	for _, envelope := range batch.Envelopes {
+		if len(envelope.MessageData) > state.config.ChunkSize {
+			large = true
+			break
+		}
+	}
+	if !large {
+		return []*RemoteMessage{message}, nil
+	}
+
+	// the envelopes index the type, target and sender names of the batch, every part keeps them
+	part := func(envelopes []*MessageEnvelope) *MessageBatch {
+		return &MessageBatch{
+			TypeNames: batch.TypeNames,
+			Targets:   batch.Targets,
+			Senders:   batch.Senders,
+			Envelopes: envelopes,
+		}
+	}
+
+	var parts []*RemoteMessage
+	start := 0
+	for i, envelope := range batch.Envelopes {
+		if len(envelope.MessageData) <= state.config.ChunkSize {
+			continue
+		}
+
+		if start < i {
+			parts = append(parts, &RemoteMessage{MessageType: &RemoteMessage_MessageBatch{MessageBatch: part(batch.Envelopes[start:i])}})
+		}
+		start = i + 1
+
+		// the streams of the endpoint send concurrently
+		transferID := atomic.AddUint64(&state.transferID, 1)
+		chunks, err := chunkBatch(transferID, part(batch.Envelopes[i:i+1]), state.config.ChunkSize)
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, chunks...)
+	}
+	if start < len(batch.Envelopes) {
+		parts = append(parts, &RemoteMessage{MessageType: &RemoteMessage_MessageBatch{MessageBatch: part(batch.Envelopes[start:])}})
+	}
+
+	return parts, nil
+}
+
+// chunkBatch serializes the batch and splits it in chunks of at most size bytes
+func chunkBatch(transferID uint64, batch *MessageBatch, size int) ([]*RemoteMessage, error) {
+	data, err := proto.Marshal(batch)
+	if err != nil {
+		return nil, err
+	}
+
+	count := (len(data) + size - 1) / size
+	chunks := make([]*RemoteMessage, 0, count)
+	for i := 0; i < count; i++ {
+		end := (i + 1) * size
+		if end > len(data) {
+			end = len(data)
+		}
+		chunks = append(chunks, &RemoteMessage{
+			MessageType: &RemoteMessage_Chunk{
+				Chunk: &MessageChunk{
+					TransferId: transferID,
+					Index:      uint32(i),
+					Count:      uint32(count),
+					Data:       data[i*size : end],
+				},
+			},
+		})
+	}
+
+	return chunks, nil
+}
+
+// chunkAssembler reassembles the chunked batches received on one stream.
+// Transfers not completed within timeout are discarded when the next chunk arrives. The chunks of a transfer must
+// arrive in order, so its buffer grows with the data received instead of the count announced by the peer.
+// A transfer is discarded once it exceeds maxTransfer bytes, or once the transfers of the stream exceed maxBuffered
+type chunkAssembler struct {
+	timeout     time.Duration
+	maxTransfer int
+	maxBuffered int
+	buffered    int
+	transfers   map[uint64]*chunkTransfer
+}
+
+type chunkTransfer struct {
+	data    []byte
+	next    uint32
+	count   uint32
+	started time.Time
+}
+
+func newChunkAssembler(timeout time.Duration, maxTransfer, maxBuffered int) *chunkAssembler {
+	return &chunkAssembler{
+		timeout:     timeout,
+		maxTransfer: maxTransfer,
+		maxBuffered: maxBuffered,
+		transfers:   make(map[uint64]*chunkTransfer),
+	}
+}
+
+// add records the chunk, and returns the batch once all the chunks of its transfer were received
+func (a *chunkAssembler) add(chunk *MessageChunk, now time.Time) (*MessageBatch, error) {
+	a.purge(now)
+
+	if chunk.Count == 0 || chunk.Index >= chunk.Count || len(chunk.Data) == 0 ||
+		(a.maxTransfer > 0 && int64(chunk.Count) > int64(a.maxTransfer)) {
+		return nil, fmt.Errorf("%w: chunk %v of %v", ErrInvalidChunk, chunk.Index, chunk.Count)
+	}
+
+	transfer, ok := a.transfers[chunk.TransferId]
+	if !ok {
+		transfer = &chunkTransfer{count: chunk.Count, started: now}
+		a.transfers[chunk.TransferId] = transfer
+	}
+	if chunk.Count != transfer.count || chunk.Index != transfer.next {
+		a.discard(chunk.TransferId)
+		return nil, fmt.Errorf("%w: chunk %v of %v does not match transfer %v", ErrInvalidChunk, chunk.Index, chunk.Count, chunk.TransferId)
+	}
+	if a.maxTransfer > 0 && len(transfer.data)+len(chunk.Data) > a.maxTransfer {
+		a.discard(chunk.TransferId)
+		return nil, fmt.Errorf("%w: transfer %v exceeds %v bytes", ErrChunkLimit, chunk.TransferId, a.maxTransfer)
+	}
+	if a.maxBuffered > 0 && a.buffered+len(chunk.Data) > a.maxBuffered {
+		a.discard(chunk.TransferId)
+		return nil, fmt.Errorf("%w: transfers of the stream exceed %v bytes", ErrChunkLimit, a.maxBuffered)
+	}
+
+	transfer.data = append(transfer.data, chunk.Data...)
+	transfer.next++
+	a.buffered += len(chunk.Data)
+	if transfer.next < transfer.count {
+		return nil, nil
+	}
+
+	data := transfer.data
+	a.discard(chunk.TransferId)
+
+	batch := &MessageBatch{}
+	if err := proto.Unmarshal(data, batch); err != nil {
+		return nil, err
+	}
+
+	return batch, nil
+}
+
+// discard forgets the transfer and releases its buffered bytes
+func (a *chunkAssembler) discard(id uint64) {
+	if transfer, ok := a.transfers[id]; ok {
+		a.buffered -= len(transfer.data)
+		delete(a.transfers, id)
+	}
+}
+
+// purge discards the transfers started more than timeout ago
+func (a *chunkAssembler) purge(now time.Time) {
+	if a.timeout <= 0 {
+		return
+	}
+
+	for id, transfer := range a.transfers {
+		if now.Sub(transfer.started) > a.timeout {
+			a.discard(id)
+		}
+	}
+}
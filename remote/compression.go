@@ -0,0 +1,42 @@
+package remote
+
+import (
+	"errors"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/encoding/gzip" // registers the gzip compressor and decompressor
+	"google.golang.org/grpc/status"
+)
+
+// configureCompression applies the process wide compression settings
+func configureCompression(config *Config) error {
+	if config.Compression != CompressionGzip {
+		return nil
+	}
+
+	return gzip.SetLevel(config.CompressionLevel)
+}
+
+// compressionCallOptions returns the call options enabling the configured compression
+func compressionCallOptions(config *Config) []grpc.CallOption {
+	switch config.Compression {
+	case CompressionGzip:
+		return []grpc.CallOption{grpc.UseCompressor(gzip.Name)}
+	default:
+		return nil
+	}
+}
+
+// isCompressionUnsupported returns true if the peer rejected the stream because it cannot decompress it
+func isCompressionUnsupported(err error) bool {
+	var st interface{ GRPCStatus() *status.Status }
+	if !errors.As(err, &st) {
+		return false
+	}
+
+	s := st.GRPCStatus()
+
+	return s.Code() == codes.Unimplemented && strings.Contains(s.Message(), "Decompressor is not installed")
+}
@@ -0,0 +1,59 @@
+package remote
+
+import (
+	"compress/gzip"
+	"testing"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+func TestRemote_GzipCompression_RoundTrip(t *testing.T) {
+	server := startEchoRemote(t)
+
+	client := actor.NewActorSystem()
+	clientRemote := NewRemote(client, Configure("localhost", 0, WithCompression(CompressionGzip, gzip.BestSpeed)))
+	clientRemote.Start()
+	defer clientRemote.Shutdown(false)
+
+	msg := actor.NewPID("somewhere", "something")
+	res, err := client.Root.RequestFuture(actor.NewPID(server.Address(), "echo"), msg, 5*time.Second).Result()
+
+	assert.NoError(t, err)
+	assert.True(t, msg.Equal(res.(*actor.PID)))
+}
+
+// recvStream is a client stream receiving the messages of its channel
+type recvStream struct {
+	grpc.ClientStream
+	messages chan *RemoteMessage
+}
+
+func (s *recvStream) Send(*RemoteMessage) error { return nil }
+
+func (s *recvStream) Recv() (*RemoteMessage, error) { return <-s.messages, nil }
+
+func TestGrpcConnection_SendWhileReceiving(t *testing.T) {
+	stream := &recvStream{messages: make(chan *RemoteMessage, 1)}
+	c := &grpcConnection{stream: stream, compressed: true}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			assert.NoError(t, c.Send(&RemoteMessage{}))
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		stream.messages <- &RemoteMessage{}
+		_, err := c.Recv()
+		assert.NoError(t, err)
+	}
+	<-done
+
+	// the first message is only kept for the fallback until the peer answered
+	assert.True(t, c.received)
+	assert.Nil(t, c.first)
+}
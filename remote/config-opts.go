@@ -1,6 +1,11 @@
 package remote
 
-import "google.golang.org/grpc"
+import (
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
 
 type ConfigOption func(config *Config)
 
@@ -11,13 +16,32 @@ func WithEndpointWriterBatchSize(batchSize int) ConfigOption {
 	}
 }
 
-// WithEndpointWriterQueueSize sets the queue size for the endpoint writer
+// WithEndpointWriterQueueSize sets the queue size for the endpoint writer.
+// Once this many messages are pending for an address, further messages are subject to backpressure.
+// System messages and internal ControlMessages, such as the cluster gossip, are not counted against it
+// and are sent ahead of the pending messages. User messages are always counted, whatever their priority
 func WithEndpointWriterQueueSize(queueSize int) ConfigOption {
 	return func(config *Config) {
 		config.EndpointWriterQueueSize = queueSize
 	}
 }
 
+// WithEndpointWriterBackpressureTimeout sets how long a sender blocks when the endpoint writer queue is full,
+// before the message is sent to dead letters
+func WithEndpointWriterBackpressureTimeout(timeout time.Duration) ConfigOption {
+	return func(config *Config) {
+		config.EndpointWriterBackpressureTimeout = timeout
+	}
+}
+
+// WithEndpointWriterDrainTimeout sets how long a stopping endpoint writer keeps sending queued messages,
+// before the remaining ones are sent to dead letters. See Config.EndpointWriterDrainTimeout for zero
+func WithEndpointWriterDrainTimeout(timeout time.Duration) ConfigOption {
+	return func(config *Config) {
+		config.EndpointWriterDrainTimeout = timeout
+	}
+}
+
 // WithEndpointManagerBatchSize sets the batch size for the endpoint manager
 func WithEndpointManagerBatchSize(batchSize int) ConfigOption {
 	return func(config *Config) {
@@ -46,6 +70,38 @@ func WithServerOptions(options ...grpc.ServerOption) ConfigOption {
 	}
 }
 
+// WithMutualTLS secures the gRPC transport with mutual TLS, using the same CA, certificate and key
+// for outgoing connections and the listener. serverNameOverride is the name expected in peer certificates,
+// empty uses the dialed host. Use it after WithDialOptions and WithServerOptions, which replace the options.
+// Invalid files or a certificate not signed by the CA make Start fail
+func WithMutualTLS(caFile, certFile, keyFile, serverNameOverride string) ConfigOption {
+	return func(config *Config) {
+		client, server, err := mutualTLS(caFile, certFile, keyFile, serverNameOverride)
+		if err != nil {
+			config.configErr = err
+			return
+		}
+
+		// the credentials replace the default insecure option, as the last transport credentials win
+		config.DialOptions = append(config.DialOptions, grpc.WithTransportCredentials(credentials.NewTLS(client)))
+		config.ServerOptions = append(config.ServerOptions, grpc.Creds(credentials.NewTLS(server)))
+	}
+}
+
+// WithStreamInterceptor adds gRPC stream interceptors to the remote client connections
+func WithStreamInterceptor(interceptors ...grpc.StreamClientInterceptor) ConfigOption {
+	return func(config *Config) {
+		config.StreamInterceptors = append(config.StreamInterceptors, interceptors...)
+	}
+}
+
+// WithUnaryInterceptor adds gRPC unary interceptors to the remote client connections
+func WithUnaryInterceptor(interceptors ...grpc.UnaryClientInterceptor) ConfigOption {
+	return func(config *Config) {
+		config.UnaryInterceptors = append(config.UnaryInterceptors, interceptors...)
+	}
+}
+
 // WithCallOptions sets the call options for the remote
 func WithCallOptions(options ...grpc.CallOption) ConfigOption {
 	return func(config *Config) {
@@ -53,18 +109,228 @@ func WithCallOptions(options ...grpc.CallOption) ConfigOption {
 	}
 }
 
-// WithAdvertisedHost sets the advertised host for the remote
+// WithCompression sets the compression used for outgoing streams and the level for gzip.
+// If the peer does not support the compression, the endpoint falls back to uncompressed streams
+func WithCompression(compression Compression, level int) ConfigOption {
+	return func(config *Config) {
+		config.Compression = compression
+		config.CompressionLevel = level
+	}
+}
+
+// WithDurableWatchReconnect sets the backoff before reconnecting to an address with durable watches, doubled for each
+// failed attempt, and the number of attempts after which its durable watchers are told the address terminated
+func WithDurableWatchReconnect(backoff time.Duration, maxAttempts int) ConfigOption {
+	return func(config *Config) {
+		config.DurableWatchBackoff = backoff
+		config.DurableWatchMaxReconnects = maxAttempts
+	}
+}
+
+// WithHeartbeat sets the interval between heartbeats sent to connected peers, and the number of heartbeats
+// that may be missed before an EndpointTerminatedEvent is published for a peer
+func WithHeartbeat(interval time.Duration, missThreshold int) ConfigOption {
+	return func(config *Config) {
+		config.HeartbeatInterval = interval
+		config.HeartbeatMissThreshold = missThreshold
+	}
+}
+
+// WithQuarantine quarantines an address for cooldown once endpoints to it failed failures times within window.
+// Messages to a quarantined address are dead lettered without dialing it
+func WithQuarantine(failures int, window time.Duration, cooldown time.Duration) ConfigOption {
+	return func(config *Config) {
+		config.QuarantineFailureThreshold = failures
+		config.QuarantineWindow = window
+		config.QuarantineCooldown = cooldown
+	}
+}
+
+// WithEndpointStatsInterval publishes an EndpointStatsEvent for each endpoint every interval, zero disables them
+func WithEndpointStatsInterval(interval time.Duration) ConfigOption {
+	return func(config *Config) {
+		config.EndpointStatsInterval = interval
+	}
+}
+
+// WithBatching makes endpoint writers coalesce messages until batchSize are pending, or for at most flushInterval,
+// before sending them as a single batch. Unlike WithEndpointWriterBatchSize, which bounds the messages taken from the
+// mailbox at once, batchSize counts the messages coalesced across mailbox batches
+func WithBatching(batchSize int, flushInterval time.Duration) ConfigOption {
+	return func(config *Config) {
+		config.BatchSize = batchSize
+		config.BatchFlushInterval = flushInterval
+	}
+}
+
+// WithEndpointMetricsAddressLabel sets whether endpoint metrics are labelled with the peer address
+func WithEndpointMetricsAddressLabel(enabled bool) ConfigOption {
+	return func(config *Config) {
+		config.EndpointMetricsAddressLabel = enabled
+	}
+}
+
+// WithEndpointConnectGracePeriod sets how long messages are kept while an endpoint is connecting
+func WithEndpointConnectGracePeriod(grace time.Duration) ConfigOption {
+	return func(config *Config) {
+		config.EndpointConnectGracePeriod = grace
+	}
+}
+
+// WithEndpointStreamCount sets the number of streams opened to each peer
+func WithEndpointStreamCount(count int) ConfigOption {
+	return func(config *Config) {
+		config.EndpointStreamCount = count
+	}
+}
+
+// WithGuaranteeOrdering enforces in order delivery of the messages from one sender to one target, see Config.GuaranteeOrdering
+func WithGuaranteeOrdering() ConfigOption {
+	return func(config *Config) {
+		config.GuaranteeOrdering = true
+	}
+}
+
+// WithDialTimeout sets how long dialing a peer and opening its stream may take
+func WithDialTimeout(timeout time.Duration) ConfigOption {
+	return func(config *Config) {
+		config.DialTimeout = timeout
+	}
+}
+
+// WithConnectTimeout sets how long to wait for the peer to answer the connect request
+func WithConnectTimeout(timeout time.Duration) ConfigOption {
+	return func(config *Config) {
+		config.ConnectTimeout = timeout
+	}
+}
+
+// WithConnectAuth sends the token and metadata with the connect request to every peer, see WithConnectAuthenticator
+func WithConnectAuth(token []byte, metadata map[string]string) ConfigOption {
+	return func(config *Config) {
+		config.ConnectAuthToken = token
+		config.ConnectMetadata = metadata
+	}
+}
+
+// WithConnectAuthenticator validates the connect requests of the peers, e.g. their auth token.
+// The peers for which authenticator returns an error are rejected with that error
+func WithConnectAuthenticator(authenticator func(*ConnectRequest) error) ConfigOption {
+	return func(config *Config) {
+		config.ConnectAuthenticator = authenticator
+	}
+}
+
+// WithEndpointAllowList only allows endpoints to addresses matching one of the entries, either a CIDR or an exact host:port
+func WithEndpointAllowList(entries ...string) ConfigOption {
+	return func(config *Config) {
+		config.EndpointAllowList = append(config.EndpointAllowList, entries...)
+	}
+}
+
+// WithEndpointDenyList refuses endpoints to addresses matching one of the entries, either a CIDR or an exact host:port
+func WithEndpointDenyList(entries ...string) ConfigOption {
+	return func(config *Config) {
+		config.EndpointDenyList = append(config.EndpointDenyList, entries...)
+	}
+}
+
+// WithEndpointFilter sets a predicate deciding whether endpoints may be opened to an address
+func WithEndpointFilter(filter AddressFilter) ConfigOption {
+	return func(config *Config) {
+		config.EndpointFilter = filter
+	}
+}
+
+// WithMaxMessageSize sets the largest serialized message in bytes sent to a peer
+func WithMaxMessageSize(size int) ConfigOption {
+	return func(config *Config) {
+		config.MaxMessageSize = size
+	}
+}
+
+// WithOutboundMiddleware adds middleware wrapping user messages sent to remote endpoints
+func WithOutboundMiddleware(middleware ...SenderMiddleware) ConfigOption {
+	return func(config *Config) {
+		config.OutboundMiddleware = append(config.OutboundMiddleware, middleware...)
+	}
+}
+
+// WithInboundMiddleware adds middleware wrapping user messages received from remote endpoints
+func WithInboundMiddleware(middleware ...ReceiverMiddleware) ConfigOption {
+	return func(config *Config) {
+		config.InboundMiddleware = append(config.InboundMiddleware, middleware...)
+	}
+}
+
+// WithRawUnknownMessages delivers messages of types not registered locally as UnknownRemoteMessage
+func WithRawUnknownMessages() ConfigOption {
+	return func(config *Config) {
+		config.RawUnknownMessages = true
+	}
+}
+
+// WithTransport replaces the default gRPC transport
+func WithTransport(transport Transport) ConfigOption {
+	return func(config *Config) {
+		config.Transport = transport
+	}
+}
+
+// WithChunking sends the messages larger than size bytes in chunks of size bytes, such as snapshots exceeding the
+// frame size of the stream. The chunks this node receives for a message not completed within transferTimeout are
+// discarded, zero keeps the default. Peers which do not reassemble chunks get the messages whole
+func WithChunking(size int, transferTimeout time.Duration) ConfigOption {
+	return func(config *Config) {
+		config.ChunkSize = size
+		if transferTimeout > 0 {
+			config.ChunkTransferTimeout = transferTimeout
+		}
+	}
+}
+
+// WithChunkLimits bounds the chunks this node receives, maxTransferSize is the largest message in bytes reassembled
+// from chunks and maxBufferSize the most bytes buffered for the incomplete messages of one stream
+func WithChunkLimits(maxTransferSize, maxBufferSize int) ConfigOption {
+	return func(config *Config) {
+		config.ChunkMaxTransferSize = maxTransferSize
+		config.ChunkMaxBufferSize = maxBufferSize
+	}
+}
+
+// WithSerializerFallback sets the serializers tried in order for a message type, such as protobuf, then gob, then
+// json, instead of only the DefaultSerializerID. The serializer is resolved once per message type
+func WithSerializerFallback(serializerIDs ...int32) ConfigOption {
+	return func(config *Config) {
+		config.SerializerFallback = serializerIDs
+	}
+}
+
+// WithAdvertisedHost sets the advertised host for the remote, either a host or a host:port.
+// PIDs of the actor system and connect requests carry the advertised address, while the server keeps listening on
+// the configured host and port
 func WithAdvertisedHost(address string) ConfigOption {
 	return func(config *Config) {
 		config.AdvertisedHost = address
 	}
 }
 
+// WithAdvertisedPort sets the port peers dial when it differs from the bound port, such as a port published by a
+// container runtime or a node port
+func WithAdvertisedPort(port int) ConfigOption {
+	return func(config *Config) {
+		config.AdvertisedPort = port
+	}
+}
+
 // WithKinds adds the kinds to the remote
 func WithKinds(kinds ...*Kind) ConfigOption {
 	return func(config *Config) {
 		for _, k := range kinds {
 			config.Kinds[k.Kind] = k.Props
+			if k.Restricted {
+				config.RestrictedKinds[k.Kind] = true
+			}
 		}
 	}
 }
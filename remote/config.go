@@ -1,22 +1,52 @@
 package remote
 
 import (
+	"compress/gzip"
 	"fmt"
+	"net"
+	"strconv"
+	"time"
 
 	"github.com/asynkron/protoactor-go/actor"
 	"google.golang.org/grpc"
 )
 
+// Compression selects how remote message batches are compressed on the wire
+type Compression int
+
+const (
+	// CompressionNone sends message batches uncompressed
+	CompressionNone Compression = iota
+	// CompressionGzip compresses message batches using gzip
+	CompressionGzip
+)
+
 func defaultConfig() *Config {
 	return &Config{
-		AdvertisedHost:           "",
-		DialOptions:              []grpc.DialOption{grpc.WithInsecure()},
-		EndpointWriterBatchSize:  1000,
-		EndpointManagerBatchSize: 1000,
-		EndpointWriterQueueSize:  1000000,
-		EndpointManagerQueueSize: 1000000,
-		Kinds:                    make(map[string]*actor.Props),
-		MaxRetryCount:            5,
+		AdvertisedHost:              "",
+		DialOptions:                 []grpc.DialOption{grpc.WithInsecure()},
+		EndpointWriterBatchSize:     1000,
+		EndpointManagerBatchSize:    1000,
+		EndpointWriterQueueSize:     1000000,
+		EndpointManagerQueueSize:    1000000,
+		Kinds:                       make(map[string]*actor.Props),
+		RestrictedKinds:             make(map[string]bool),
+		MaxRetryCount:               5,
+		Compression:                 CompressionNone,
+		CompressionLevel:            gzip.DefaultCompression,
+		HeartbeatInterval:           30 * time.Second,
+		HeartbeatMissThreshold:      3,
+		BatchSize:                   1000,
+		BatchFlushInterval:          0,
+		EndpointMetricsAddressLabel: true,
+		EndpointStreamCount:         1,
+		DialTimeout:                 10 * time.Second,
+		ConnectTimeout:              10 * time.Second,
+		ChunkTransferTimeout:        30 * time.Second,
+		ChunkMaxTransferSize:        64 * 1024 * 1024,
+		ChunkMaxBufferSize:          256 * 1024 * 1024,
+		DurableWatchBackoff:         time.Second,
+		DurableWatchMaxReconnects:   10,
 	}
 }
 
@@ -33,6 +63,36 @@ func (rc Config) Address() string {
 	return fmt.Sprintf("%v:%v", rc.Host, rc.Port)
 }
 
+// advertisedAddress is the address PIDs of this node carry and peers dial, bound is the address the server listens on
+func (rc Config) advertisedAddress(bound string) string {
+	if rc.AdvertisedHost == "" && rc.AdvertisedPort == 0 {
+		return bound
+	}
+
+	host, port, err := net.SplitHostPort(bound)
+	if err != nil {
+		host, port = bound, ""
+	}
+
+	if rc.AdvertisedHost != "" {
+		if h, p, err := net.SplitHostPort(rc.AdvertisedHost); err == nil {
+			host, port = h, p
+		} else {
+			host = rc.AdvertisedHost
+		}
+	}
+
+	if rc.AdvertisedPort != 0 {
+		port = strconv.Itoa(rc.AdvertisedPort)
+	}
+
+	if port == "" {
+		return host
+	}
+
+	return net.JoinHostPort(host, port)
+}
+
 // Configure configures the remote
 func Configure(host string, port int, options ...ConfigOption) *Config {
 	c := newConfig(options...)
@@ -46,7 +106,8 @@ func Configure(host string, port int, options ...ConfigOption) *Config {
 type Config struct {
 	Host                     string
 	Port                     int
-	AdvertisedHost           string
+	AdvertisedHost           string // host, or host:port, peers dial instead of the bound one, such as the NAT or node address
+	AdvertisedPort           int    // port peers dial instead of the bound one, zero keeps the port of AdvertisedHost or the bound port
 	ServerOptions            []grpc.ServerOption
 	CallOptions              []grpc.CallOption
 	DialOptions              []grpc.DialOption
@@ -56,4 +117,129 @@ type Config struct {
 	EndpointManagerQueueSize int
 	Kinds                    map[string]*actor.Props
 	MaxRetryCount            int
+
+	// RestrictedKinds are the kinds which are not spawned on activation requests from other nodes
+	RestrictedKinds map[string]bool
+
+	// EndpointWriterBackpressureTimeout is how long a sender blocks once EndpointWriterQueueSize messages
+	// are pending for an address. Zero rejects the message immediately.
+	EndpointWriterBackpressureTimeout time.Duration
+
+	// EndpointWriterDrainTimeout is how long a stopping endpoint writer keeps sending queued messages
+	// before dead lettering the rest. Zero does not wait: the messages the writer already coalesced are still
+	// sent once, the messages queued in its mailbox are dead lettered immediately
+	EndpointWriterDrainTimeout time.Duration
+
+	// Compression is the compression used for outgoing streams, CompressionLevel applies to gzip
+	Compression      Compression
+	CompressionLevel int
+
+	// DurableWatchBackoff is the delay before reconnecting to an address with durable watches after its endpoint
+	// terminated, doubled for each failed attempt. After DurableWatchMaxReconnects failed attempts the address is
+	// given up, and its durable watchers receive Terminated with AddressTerminated
+	DurableWatchBackoff       time.Duration
+	DurableWatchMaxReconnects int
+
+	// HeartbeatInterval is how often an endpoint writer pings its peer, zero disables heartbeats.
+	// HeartbeatMissThreshold is the number of missed heartbeats after which the peer is considered lost, counted at the
+	// interval the peer announced on connect
+	HeartbeatInterval      time.Duration
+	HeartbeatMissThreshold int
+
+	// BatchSize and BatchFlushInterval make an endpoint writer coalesce messages, across mailbox batches, until
+	// BatchSize messages are pending or for at most BatchFlushInterval, before sending them as one batch.
+	// Zero BatchFlushInterval sends every mailbox batch immediately.
+	// Unlike EndpointWriterBatchSize, which bounds how many messages the writer takes from its mailbox at once,
+	// BatchSize counts the messages coalesced across mailbox batches that are sent without waiting for the interval
+	BatchSize          int
+	BatchFlushInterval time.Duration
+
+	// EndpointMetricsAddressLabel adds the peer address as a label to endpoint metrics.
+	// Disable it for fleets with many peers to keep metric cardinality low
+	EndpointMetricsAddressLabel bool
+
+	// EndpointConnectGracePeriod is how long messages are kept while an endpoint is connecting. Once elapsed,
+	// queued messages are dead lettered with an EndpointNotConnected reason while retrying. Zero keeps them
+	EndpointConnectGracePeriod time.Duration
+
+	// EndpointStreamCount is the number of streams opened to each peer. Messages are sharded across them
+	// by target, so messages to the same target keep their order
+	EndpointStreamCount int
+
+	// GuaranteeOrdering enforces that messages from one sender to one target are received in the order they were sent,
+	// with gaps for messages dead lettered while reconnecting. On top of pinning each target to one stream, a writer
+	// stopping because its endpoint terminated dead letters its queued messages instead of sending them while a new
+	// endpoint may already be sending
+	GuaranteeOrdering bool
+
+	// DialTimeout bounds dialing a peer and opening the stream, ConnectTimeout bounds the connect handshake
+	// once the stream is open. Zero waits indefinitely
+	DialTimeout    time.Duration
+	ConnectTimeout time.Duration
+
+	// ConnectAuthToken and ConnectMetadata are sent with the connect request to every peer.
+	// ConnectAuthenticator validates the connect requests received, a peer whose request it fails is rejected before
+	// any message is received from it, and its endpoint terminates with ErrConnectRejected. A stream sending messages
+	// before an accepted connect request is closed with ErrNotAuthenticated
+	ConnectAuthToken     []byte
+	ConnectMetadata      map[string]string
+	ConnectAuthenticator func(*ConnectRequest) error
+
+	// EndpointAllowList and EndpointDenyList restrict which addresses endpoints are opened to,
+	// entries are either a CIDR or an exact host:port. EndpointFilter allows dynamic policies
+	EndpointAllowList []string
+	EndpointDenyList  []string
+	EndpointFilter    AddressFilter
+
+	// SerializerFallback are the ids of the serializers tried in order for messages which do not implement
+	// SerializerIdentifiable. The first serializer the peer decodes which serializes a message of a type is kept for
+	// that type and endpoint, and its id is sent with the message. Empty serializes every message with
+	// DefaultSerializerID
+	SerializerFallback []int32
+
+	// ChunkSize is the size in bytes above which a serialized message is sent alone, in ordered chunks of ChunkSize
+	// bytes reassembled by the peer, so that it does not exceed the frame size of the stream. Zero disables chunking.
+	// ChunkTransferTimeout is how long the chunks received for an incomplete message are kept
+	ChunkSize            int
+	ChunkTransferTimeout time.Duration
+
+	// ChunkMaxTransferSize is the largest message in bytes reassembled from the chunks received, and ChunkMaxBufferSize
+	// the most bytes buffered for the incomplete messages of one stream. Transfers exceeding them are discarded
+	ChunkMaxTransferSize int
+	ChunkMaxBufferSize   int
+
+	// MaxMessageSize is the largest serialized message in bytes sent to a peer, larger messages are dead lettered.
+	// Zero disables the limit
+	MaxMessageSize int
+
+	// OutboundMiddleware wraps every user message sent to a remote endpoint before serialization,
+	// InboundMiddleware wraps every user message received before delivery. Both run in the configured order
+	OutboundMiddleware []SenderMiddleware
+	InboundMiddleware  []ReceiverMiddleware
+
+	// StreamInterceptors and UnaryInterceptors are added to the gRPC client connections, after any interceptors
+	// in DialOptions. The remote stream, including its connect handshake, passes through StreamInterceptors
+	StreamInterceptors []grpc.StreamClientInterceptor
+	UnaryInterceptors  []grpc.UnaryClientInterceptor
+
+	// QuarantineFailureThreshold is the number of endpoint failures to an address within QuarantineWindow after which
+	// the address is quarantined for QuarantineCooldown: messages to it are dead lettered and it is not dialed.
+	// Once the cooldown elapsed, one endpoint tries to connect once. Zero disables quarantines
+	QuarantineFailureThreshold int
+	QuarantineWindow           time.Duration
+	QuarantineCooldown         time.Duration
+
+	// EndpointStatsInterval is how often an EndpointStatsEvent is published for each endpoint, zero disables them
+	EndpointStatsInterval time.Duration
+
+	// RawUnknownMessages delivers messages whose type is not registered locally as UnknownRemoteMessage,
+	// instead of failing to deserialize them. Use it for actors forwarding messages they do not need to decode
+	RawUnknownMessages bool
+
+	// configErr is set by options which failed, and reported by Start
+	configErr error
+
+	// Transport carries messages between endpoints, nil uses gRPC.
+	// ServerOptions, CallOptions, DialOptions and Compression only apply to the gRPC transport
+	Transport Transport
 }
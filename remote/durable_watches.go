@@ -0,0 +1,87 @@
+package remote
+
+import (
+	"sync"
+
+	"github.com/asynkron/protoactor-go/actor"
+)
+
+// durableWatches tracks the durable watches on remote PIDs. They outlive the endpoint they were sent through,
+// and are sent again when the endpoint to their address reconnects
+type durableWatches struct {
+	mu      sync.Mutex
+	watches map[string]map[string]*actor.PIDSet // key is the watchee address, then the watching PID id
+}
+
+func newDurableWatches() *durableWatches {
+	return &durableWatches{
+		watches: make(map[string]map[string]*actor.PIDSet),
+	}
+}
+
+func (dw *durableWatches) add(watcher, watchee *actor.PID) {
+	dw.mu.Lock()
+	defer dw.mu.Unlock()
+
+	byWatcher, ok := dw.watches[watchee.Address]
+	if !ok {
+		byWatcher = make(map[string]*actor.PIDSet)
+		dw.watches[watchee.Address] = byWatcher
+	}
+	if pidSet, ok := byWatcher[watcher.Id]; ok {
+		pidSet.Add(watchee)
+	} else {
+		byWatcher[watcher.Id] = actor.NewPIDSet(watchee)
+	}
+}
+
+func (dw *durableWatches) remove(watcher, watchee *actor.PID) {
+	dw.mu.Lock()
+	defer dw.mu.Unlock()
+
+	byWatcher, ok := dw.watches[watchee.Address]
+	if !ok {
+		return
+	}
+	if pidSet, ok := byWatcher[watcher.Id]; ok {
+		pidSet.Remove(watchee)
+		if pidSet.Len() == 0 {
+			delete(byWatcher, watcher.Id)
+		}
+	}
+	if len(byWatcher) == 0 {
+		delete(dw.watches, watchee.Address)
+	}
+}
+
+func (dw *durableWatches) contains(watcherID string, watchee *actor.PID) bool {
+	dw.mu.Lock()
+	defer dw.mu.Unlock()
+
+	pidSet, ok := dw.watches[watchee.Address][watcherID]
+
+	return ok && pidSet.Contains(watchee)
+}
+
+func (dw *durableWatches) any(address string) bool {
+	dw.mu.Lock()
+	defer dw.mu.Unlock()
+
+	return len(dw.watches[address]) > 0
+}
+
+// forEach calls f for every durable watch on a PID of address, outside of the lock
+func (dw *durableWatches) forEach(address string, f func(watcherID string, watchee *actor.PID)) {
+	dw.mu.Lock()
+	watches := make(map[string][]*actor.PID, len(dw.watches[address]))
+	for id, pidSet := range dw.watches[address] {
+		watches[id] = append([]*actor.PID(nil), pidSet.Values()...)
+	}
+	dw.mu.Unlock()
+
+	for id, watchees := range watches {
+		for _, watchee := range watchees {
+			f(id, watchee)
+		}
+	}
+}
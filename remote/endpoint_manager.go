@@ -1,6 +1,7 @@
 package remote
 
 import (
+	"errors"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -47,6 +48,9 @@ func (el *endpointLazy) Get() *endpoint {
 type endpoint struct {
 	writer  *actor.PID
 	watcher *actor.PID
+	// writerTerminated tells the writer its endpoint was removed, ahead of the EndpointTerminatedEvent queued in its mailbox
+	writerTerminated *int32
+	stats            *endpointStats
 }
 
 func (ep *endpoint) Address() string {
@@ -59,17 +63,39 @@ type endpointManager struct {
 	endpointSub               *eventstream.Subscription
 	endpointSupervisor        *actor.PID
 	activator                 *actor.PID
-	stopped                   bool
+	stopped                   int32
 	endpointReaderConnections *sync.Map
+	states                    *endpointStates
+	durableWatches            *durableWatches
+	// disconnected holds the addresses closed with Remote.Disconnect
+	disconnected *sync.Map
+	// statsDone stops publishing EndpointStatsEvent
+	statsDone  chan struct{}
+	quarantine *endpointQuarantine
+
+	// reconnects counts the failed attempts to reconnect to the addresses with durable watches
+	mu         sync.Mutex
+	reconnects map[string]int
 }
 
 func newEndpointManager(r *Remote) *endpointManager {
-	return &endpointManager{
+	em := &endpointManager{
 		connections:               &sync.Map{},
 		remote:                    r,
-		stopped:                   false,
 		endpointReaderConnections: &sync.Map{},
+		states:                    newEndpointStates(endpointStateRetention(r.config)),
+		durableWatches:            newDurableWatches(),
+		disconnected:              &sync.Map{},
+		reconnects:                make(map[string]int),
 	}
+	em.quarantine = newEndpointQuarantine(r, func(address string) {
+		// other addresses are dialed once a message is sent to them
+		if em.durableWatches.any(address) {
+			em.reconnect(address)
+		}
+	})
+
+	return em
 }
 
 func (em *endpointManager) start() {
@@ -84,11 +110,14 @@ func (em *endpointManager) start() {
 		})
 	em.startActivator()
 	em.startSupervisor()
+	if interval := em.remote.config.EndpointStatsInterval; interval > 0 {
+		em.statsDone = em.startStats(interval)
+	}
 
 	if err := em.waiting(3 * time.Second); err != nil {
 		panic(err)
 	}
-	plog.Info("Started EndpointManager")
+	em.remote.Logger().Info("Started EndpointManager")
 }
 
 func (em *endpointManager) waiting(timeout time.Duration) error {
@@ -100,14 +129,17 @@ func (em *endpointManager) waiting(timeout time.Duration) error {
 }
 
 func (em *endpointManager) stop() {
-	em.stopped = true
+	atomic.StoreInt32(&em.stopped, 1)
 	r := em.remote
 	r.actorSystem.EventStream.Unsubscribe(em.endpointSub)
+	if em.statsDone != nil {
+		close(em.statsDone)
+	}
 	if err := em.stopActivator(); err != nil {
-		plog.Error("stop endpoint activator failed", log.Error(err))
+		em.remote.Logger().Error("stop endpoint activator failed", log.Error(err))
 	}
 	if err := em.stopSupervisor(); err != nil {
-		plog.Error("stop endpoint supervisor failed", log.Error(err))
+		em.remote.Logger().Error("stop endpoint supervisor failed", log.Error(err))
 	}
 	em.endpointSub = nil
 	em.connections = nil
@@ -119,7 +151,7 @@ func (em *endpointManager) stop() {
 			return true
 		})
 	}
-	plog.Info("Stopped EndpointManager")
+	em.remote.Logger().Info("Stopped EndpointManager")
 }
 
 func (em *endpointManager) startActivator() {
@@ -160,43 +192,195 @@ func (em *endpointManager) stopSupervisor() error {
 func (em *endpointManager) endpointEvent(evn interface{}) {
 	switch msg := evn.(type) {
 	case *EndpointTerminatedEvent:
-		plog.Debug("EndpointManager received endpoint terminated event, removing endpoint", log.Message(evn))
+		em.remote.Logger().Debug("EndpointManager received endpoint terminated event, removing endpoint", log.Message(evn))
+		em.states.set(msg.Address, EndpointStatus{State: EndpointTerminated, LastError: msg.Err})
+		em.remote.actorSystem.ProcessRegistry.InvalidateAddress(msg.Address)
 		em.removeEndpoint(msg)
+		if errors.Is(msg.Err, ErrConnectRejected) {
+			// a rejected connect is not retried, the address stays disconnected until ConnectTo
+			em.disconnected.Store(msg.Address, struct{}{})
+		} else if !errors.Is(msg.Err, ErrEndpointDisconnected) && !errors.Is(msg.Err, ErrAddressDenied) {
+			em.quarantine.failed(msg.Address, msg.Err)
+		}
+		if em.durableWatches.any(msg.Address) {
+			em.scheduleReconnect(msg.Address)
+		}
 	case *EndpointConnectedEvent:
+		em.states.set(msg.Address, EndpointStatus{State: EndpointConnected})
+		em.quarantine.connected(msg.Address)
+		em.mu.Lock()
+		delete(em.reconnects, msg.Address)
+		em.mu.Unlock()
 		endpoint := em.ensureConnected(msg.Address)
 		em.remote.actorSystem.Root.Send(endpoint.watcher, msg)
+		// watching again is harmless, and covers a connection replaced while the watches were sent
+		em.rewatch(msg.Address, endpoint)
+	}
+}
+
+func (em *endpointManager) isStopped() bool {
+	return atomic.LoadInt32(&em.stopped) == 1
+}
+
+// scheduleReconnect reconnects to address after a backoff doubled for each failed attempt, so that the durable watches
+// on it are sent again. Once Config.DurableWatchMaxReconnects attempts failed, the address is given up
+func (em *endpointManager) scheduleReconnect(address string) {
+	config := em.remote.config
+
+	em.mu.Lock()
+	attempt := em.reconnects[address] + 1
+	giveUp := attempt > config.DurableWatchMaxReconnects
+	if giveUp {
+		delete(em.reconnects, address)
+	} else {
+		em.reconnects[address] = attempt
+	}
+	em.mu.Unlock()
+
+	if giveUp {
+		em.remote.Logger().Info("EndpointManager gave up reconnecting for durable watches", log.String("address", address), log.Int("attempts", attempt-1))
+		em.terminateDurableWatches(address)
+
+		return
+	}
+
+	backoff := config.DurableWatchBackoff
+	for i := 1; i < attempt && backoff < time.Minute; i++ {
+		backoff *= 2
+	}
+	// the timer also keeps the reconnect outside of the publisher, which may be the endpoint writer itself
+	time.AfterFunc(backoff, func() {
+		em.reconnect(address)
+	})
+}
+
+// terminateDurableWatches tells the durable watchers of the PIDs of address that it terminated, and drops the watches
+func (em *endpointManager) terminateDurableWatches(address string) {
+	em.durableWatches.forEach(address, func(watcherID string, watchee *actor.PID) {
+		watcher := em.remote.actorSystem.NewLocalPID(watcherID)
+		em.durableWatches.remove(watcher, watchee)
+		if ref, ok := em.remote.actorSystem.ProcessRegistry.GetLocal(watcherID); ok {
+			ref.SendSystemMessage(watcher, &actor.Terminated{
+				Who: watchee,
+				Why: actor.TerminatedReason_AddressTerminated,
+			})
+		}
+	})
+}
+
+// reconnect opens a new endpoint to address, so that the durable watches on it are sent again once it connects
+func (em *endpointManager) reconnect(address string) {
+	if em.isStopped() || em.isDisconnected(address) || em.isQuarantined(address) {
+		return
+	}
+	em.remote.Logger().Info("EndpointManager reconnecting for durable watches", log.String("address", address))
+	em.ensureConnected(address)
+}
+
+// rewatch sends the durable watches on address again through the reconnected endpoint. The remote node answers
+// with Terminated for the PIDs which are gone, the others keep being watched
+func (em *endpointManager) rewatch(address string, endpoint *endpoint) {
+	em.durableWatches.forEach(address, func(watcherID string, watchee *actor.PID) {
+		watcher := em.remote.actorSystem.NewLocalPID(watcherID)
+		if _, ok := em.remote.actorSystem.ProcessRegistry.GetLocal(watcherID); !ok {
+			em.durableWatches.remove(watcher, watchee)
+
+			return
+		}
+		em.remote.actorSystem.Root.Send(endpoint.watcher, &remoteWatch{
+			Watcher: watcher,
+			Watchee: watchee,
+			Durable: true,
+		})
+	})
+}
+
+// connect opens the endpoint to address, allowing it again if it was disconnected
+func (em *endpointManager) connect(address string) {
+	if em.isStopped() {
+		return
 	}
+	em.disconnected.Delete(address)
+	em.ensureConnected(address)
+}
+
+// disconnect terminates the endpoint to address, new endpoints to it fail with ErrEndpointDisconnected until connect
+func (em *endpointManager) disconnect(address string) {
+	em.disconnected.Store(address, struct{}{})
+	em.remote.actorSystem.EventStream.Publish(&EndpointTerminatedEvent{
+		Address: address,
+		Err:     ErrEndpointDisconnected,
+	})
+}
+
+func (em *endpointManager) isDisconnected(address string) bool {
+	_, ok := em.disconnected.Load(address)
+
+	return ok
+}
+
+// isQuarantined tells whether messages to address are dead lettered without dialing it
+func (em *endpointManager) isQuarantined(address string) bool {
+	return em.quarantine.state(address) == QuarantineOpen
+}
+
+// isUnreachable tells whether no endpoint is opened to address, as it is disconnected or quarantined
+func (em *endpointManager) isUnreachable(address string) bool {
+	return em.isDisconnected(address) || em.isQuarantined(address)
 }
 
 func (em *endpointManager) remoteTerminate(msg *remoteTerminate) {
-	if em.stopped {
+	if em.isStopped() {
 		return
 	}
+	em.durableWatches.remove(msg.Watcher, msg.Watchee)
 	address := msg.Watchee.Address
+	if em.isUnreachable(address) {
+		return
+	}
 	endpoint := em.ensureConnected(address)
 	em.remote.actorSystem.Root.Send(endpoint.watcher, msg)
 }
 
 func (em *endpointManager) remoteWatch(msg *remoteWatch) {
-	if em.stopped {
+	if em.isStopped() {
 		return
 	}
+	if msg.Durable {
+		em.durableWatches.add(msg.Watcher, msg.Watchee)
+	}
 	address := msg.Watchee.Address
+	if em.isUnreachable(address) {
+		// durable watches are sent once the quarantine half opens or the address is connected again, others
+		// terminate like on a lost endpoint
+		if ref, ok := em.remote.actorSystem.ProcessRegistry.GetLocal(msg.Watcher.Id); ok && !msg.Durable {
+			ref.SendSystemMessage(msg.Watcher, &actor.Terminated{
+				Who: msg.Watchee,
+				Why: actor.TerminatedReason_AddressTerminated,
+			})
+		}
+
+		return
+	}
 	endpoint := em.ensureConnected(address)
 	em.remote.actorSystem.Root.Send(endpoint.watcher, msg)
 }
 
 func (em *endpointManager) remoteUnwatch(msg *remoteUnwatch) {
-	if em.stopped {
+	if em.isStopped() {
 		return
 	}
+	em.durableWatches.remove(msg.Watcher, msg.Watchee)
 	address := msg.Watchee.Address
+	if em.isUnreachable(address) {
+		return
+	}
 	endpoint := em.ensureConnected(address)
 	em.remote.actorSystem.Root.Send(endpoint.watcher, msg)
 }
 
 func (em *endpointManager) remoteDeliver(msg *remoteDeliver) {
-	if em.stopped {
+	if em.isStopped() {
 		// send to deadletter
 		em.remote.actorSystem.EventStream.Publish(&actor.DeadLetterEvent{
 			PID:     msg.target,
@@ -206,15 +390,43 @@ func (em *endpointManager) remoteDeliver(msg *remoteDeliver) {
 		return
 	}
 	address := msg.target.Address
+	if em.isDisconnected(address) {
+		rejectDisconnected(em.remote, msg)
+
+		return
+	}
+	if em.isQuarantined(address) {
+		rejectQuarantined(em.remote, msg)
+
+		return
+	}
 	endpoint := em.ensureConnected(address)
 	em.remote.actorSystem.Root.Send(endpoint.writer, msg)
 }
 
+// rejectDisconnected dead letters a message to a disconnected address
+func rejectDisconnected(remote *Remote, rd *remoteDeliver) {
+	if rd.sender != nil && remote.actorSystem.Config.DeadLetterResponse {
+		remote.actorSystem.Root.Send(rd.sender, &actor.DeadLetterResponse{Target: rd.target})
+
+		return
+	}
+
+	remote.actorSystem.EventStream.Publish(&actor.DeadLetterEvent{
+		PID:     rd.target,
+		Message: rd.message,
+		Sender:  rd.sender,
+	})
+}
+
 func (em *endpointManager) ensureConnected(address string) *endpoint {
 	e, ok := em.connections.Load(address)
 	if !ok {
 		el := NewEndpointLazy(em, address)
-		e, _ = em.connections.LoadOrStore(address, el)
+		var loaded bool
+		if e, loaded = em.connections.LoadOrStore(address, el); !loaded {
+			em.states.set(address, EndpointStatus{State: EndpointConnecting})
+		}
 	}
 	el := e.(*endpointLazy)
 	return el.Get()
@@ -249,7 +461,8 @@ func (em *endpointManager) removeEndpoint(msg *EndpointTerminatedEvent) {
 		if atomic.CompareAndSwapUint32(&le.unloaded, 0, 1) {
 			em.connections.Delete(msg.Address)
 			ep := le.Get()
-			plog.Debug("Sending EndpointTerminatedEvent to EndpointWatcher ans EndpointWriter", log.String("address", msg.Address))
+			atomic.StoreInt32(ep.writerTerminated, 1)
+			em.remote.Logger().Debug("Sending EndpointTerminatedEvent to EndpointWatcher ans EndpointWriter", log.String("address", msg.Address))
 			em.remote.actorSystem.Root.Send(ep.watcher, msg)
 			em.remote.actorSystem.Root.Send(ep.writer, msg)
 		}
@@ -268,24 +481,35 @@ func newEndpointSupervisor(remote *Remote) actor.Actor {
 
 func (state *endpointSupervisor) Receive(ctx actor.Context) {
 	if address, ok := ctx.Message().(string); ok {
-		plog.Debug("EndpointSupervisor spawning EndpointWriter and EndpointWatcher", log.String("address", address))
+		state.remote.Logger().Debug("EndpointSupervisor spawning EndpointWriter and EndpointWatcher", log.String("address", address))
+		terminated := new(int32)
+		stats := &endpointStats{mailbox: &endpointWriterMailboxRef{}}
 		e := &endpoint{
-			writer:  state.spawnEndpointWriter(state.remote, address, ctx),
-			watcher: state.spawnEndpointWatcher(state.remote, address, ctx),
+			writer:           state.spawnEndpointWriter(state.remote, address, terminated, stats, ctx),
+			watcher:          state.spawnEndpointWatcher(state.remote, address, ctx),
+			writerTerminated: terminated,
+			stats:            stats,
 		}
 		ctx.Respond(e)
 	}
 }
 
 func (state *endpointSupervisor) HandleFailure(actorSystem *actor.ActorSystem, supervisor actor.Supervisor, child *actor.PID, rs *actor.RestartStatistics, reason interface{}, message interface{}) {
-	plog.Debug("EndpointSupervisor handling failure", log.Object("reason", reason), log.Message(message))
+	state.remote.Logger().Debug("EndpointSupervisor handling failure", log.Object("reason", reason), log.Message(message))
 	supervisor.RestartChildren(child)
 }
 
-func (state *endpointSupervisor) spawnEndpointWriter(remote *Remote, address string, ctx actor.Context) *actor.PID {
+func (state *endpointSupervisor) spawnEndpointWriter(remote *Remote, address string, terminated *int32, stats *endpointStats, ctx actor.Context) *actor.PID {
 	props := actor.
-		PropsFromProducer(endpointWriterProducer(remote, address, remote.config),
-			actor.WithMailbox(endpointWriterMailboxProducer(remote.config.EndpointWriterBatchSize, remote.config.EndpointWriterQueueSize)))
+		PropsFromProducer(endpointWriterProducer(remote, address, remote.config, stats, terminated),
+			actor.WithMailbox(stats.mailbox.capture(endpointWriterMailboxProducer(
+				remote.config.EndpointWriterBatchSize,
+				remote.config.EndpointWriterQueueSize,
+				remote.config.EndpointWriterBackpressureTimeout,
+				func(rd *remoteDeliver) {
+					rejectBackpressure(remote, address, rd)
+				},
+				remote.Logger()))))
 	pid := ctx.Spawn(props)
 	return pid
 }
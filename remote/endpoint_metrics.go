@@ -0,0 +1,81 @@
+package remote
+
+import (
+	"context"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/asynkron/protoactor-go/metrics"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// endpointMetrics records the metrics of an endpoint writer, all methods are no-ops when metrics are disabled
+type endpointMetrics struct {
+	instruments *metrics.ActorMetrics
+	labels      []attribute.KeyValue
+}
+
+func newEndpointMetrics(remote *Remote, address string) *endpointMetrics {
+	m := actor.GetMetrics(remote.actorSystem)
+	if m == nil || !m.Enabled() {
+		return nil
+	}
+
+	instruments := m.Instruments()
+	if instruments == nil {
+		return nil
+	}
+
+	labels := []attribute.KeyValue{
+		attribute.String("address", remote.actorSystem.Address()),
+	}
+	if remote.config.EndpointMetricsAddressLabel {
+		labels = append(labels, attribute.String("remoteaddress", address))
+	}
+
+	return &endpointMetrics{
+		instruments: instruments,
+		labels:      labels,
+	}
+}
+
+func (m *endpointMetrics) connected() {
+	if m == nil {
+		return
+	}
+
+	m.instruments.EndpointConnectedCount.Add(context.Background(), 1, m.labels...)
+}
+
+func (m *endpointMetrics) disconnected() {
+	if m == nil {
+		return
+	}
+
+	m.instruments.EndpointConnectedCount.Add(context.Background(), -1, m.labels...)
+}
+
+func (m *endpointMetrics) sent(messages int, bytes int) {
+	if m == nil {
+		return
+	}
+
+	ctx := context.Background()
+	m.instruments.EndpointMessagesSentCount.Add(ctx, int64(messages), m.labels...)
+	m.instruments.EndpointBytesSentCount.Add(ctx, int64(bytes), m.labels...)
+}
+
+func (m *endpointMetrics) serializationError() {
+	if m == nil {
+		return
+	}
+
+	m.instruments.EndpointSerializationErrorCount.Add(context.Background(), 1, m.labels...)
+}
+
+func (m *endpointMetrics) reconnect() {
+	if m == nil {
+		return
+	}
+
+	m.instruments.EndpointReconnectCount.Add(context.Background(), 1, m.labels...)
+}
@@ -3,42 +3,45 @@ package remote
 import (
 	"errors"
 	"io"
+	"time"
 
 	"google.golang.org/protobuf/proto"
 
 	"github.com/asynkron/protoactor-go/actor"
 	"github.com/asynkron/protoactor-go/log"
-	"golang.org/x/net/context"
 )
 
+// ErrNotAuthenticated closes a stream whose peer sent messages before its connect request was accepted,
+// see Config.ConnectAuthenticator
+var ErrNotAuthenticated = errors.New("remote: message received before an accepted connect request")
+
 type endpointReader struct {
 	suspended bool
 	remote    *Remote
-}
-
-func (s *endpointReader) mustEmbedUnimplementedRemotingServer() {
-	// TODO implement me
-	panic("implement me")
-}
-
-func (s *endpointReader) ListProcesses(ctx context.Context, request *ListProcessesRequest) (*ListProcessesResponse, error) {
-	panic("implement me")
-}
-
-func (s *endpointReader) GetProcessDiagnostics(ctx context.Context, request *GetProcessDiagnosticsRequest) (*GetProcessDiagnosticsResponse, error) {
-	panic("implement me")
+	// inbound is the Config.InboundMiddleware chain
+	inbound ReceiverFunc
 }
 
 func newEndpointReader(r *Remote) *endpointReader {
-	return &endpointReader{
+	s := &endpointReader{
 		remote: r,
 	}
+	s.inbound = makeReceiverMiddlewareChain(r.config.InboundMiddleware, s.deliver)
+
+	return s
 }
 
-func (s *endpointReader) Receive(stream Remoting_ReceiveServer) error {
+func (s *endpointReader) Receive(stream RemoteStream) error {
 	disconnectChan := make(chan bool, 1)
 	s.remote.edpManager.endpointReaderConnections.Store(stream, disconnectChan)
+
+	// without an authenticator any peer may send messages, otherwise only once its connect request was accepted
+	authenticated := s.remote.config.ConnectAuthenticator == nil
+	chunks := newChunkAssembler(s.remote.config.ChunkTransferTimeout, s.remote.config.ChunkMaxTransferSize, s.remote.config.ChunkMaxBufferSize)
+	heartbeat := newHeartbeatMonitor()
+
 	defer func() {
+		heartbeat.stop()
 		close(disconnectChan)
 	}()
 
@@ -46,18 +49,18 @@ func (s *endpointReader) Receive(stream Remoting_ReceiveServer) error {
 		// endpointManager sends true
 		// endpointReader sends false
 		if <-disconnectChan {
-			plog.Debug("EndpointReader is telling to remote that it's leaving")
+			s.remote.Logger().Debug("EndpointReader is telling to remote that it's leaving")
 			err := stream.Send(&RemoteMessage{
 				MessageType: &RemoteMessage_DisconnectRequest{
 					DisconnectRequest: &DisconnectRequest{},
 				},
 			})
 			if err != nil {
-				plog.Error("EndpointReader failed to send disconnection message", log.Error(err))
+				s.remote.Logger().Error("EndpointReader failed to send disconnection message", log.Error(err))
 			}
 		} else {
 			s.remote.edpManager.endpointReaderConnections.Delete(stream)
-			plog.Debug("EndpointReader removed active endpoint from endpointManager")
+			s.remote.Logger().Debug("EndpointReader removed active endpoint from endpointManager")
 		}
 	}()
 
@@ -65,53 +68,95 @@ func (s *endpointReader) Receive(stream Remoting_ReceiveServer) error {
 		msg, err := stream.Recv()
 		switch {
 		case errors.Is(err, io.EOF):
-			plog.Info("EndpointReader stream closed")
+			s.remote.Logger().Info("EndpointReader stream closed")
 			disconnectChan <- false
 			return nil
 		case err != nil:
-			plog.Info("EndpointReader failed to read", log.Error(err))
+			s.remote.Logger().Info("EndpointReader failed to read", log.Error(err))
 			return err
 		case s.suspended:
 			continue
 		}
 
+		_, isHeartbeat := msg.MessageType.(*RemoteMessage_Heartbeat)
+		heartbeat.seen(isHeartbeat)
+
 		switch t := msg.MessageType.(type) {
+		case *RemoteMessage_Heartbeat:
+			// liveness is recorded above, nothing else to do
 		case *RemoteMessage_ConnectRequest:
-			plog.Debug("EndpointReader received connect request", log.Message(t.ConnectRequest))
+			s.remote.Logger().Debug("EndpointReader received connect request", log.Message(t.ConnectRequest))
 			c := t.ConnectRequest
-			_, err := s.OnConnectRequest(stream, c)
+			if sc := c.GetServerConnection(); sc != nil {
+				heartbeat.setAddress(sc.Address)
+			}
+			closeStream, err := s.OnConnectRequest(stream, c)
 			if err != nil {
-				plog.Error("EndpointReader failed to handle connect request", log.Error(err))
+				s.remote.Logger().Error("EndpointReader failed to handle connect request", log.Error(err))
 				return err
 			}
+			if closeStream {
+				return nil
+			}
+			if c.GetServerConnection() != nil {
+				authenticated = true
+				s.monitorHeartbeats(heartbeat, c)
+			}
 		case *RemoteMessage_MessageBatch:
+			if !authenticated {
+				s.remote.Logger().Warn("EndpointReader received messages before an accepted connect request")
+				return ErrNotAuthenticated
+			}
 			m := t.MessageBatch
 			err := s.onMessageBatch(m)
 			if err != nil {
 				return err
 			}
+		case *RemoteMessage_Chunk:
+			if !authenticated {
+				s.remote.Logger().Warn("EndpointReader received messages before an accepted connect request")
+				return ErrNotAuthenticated
+			}
+			m, err := chunks.add(t.Chunk, time.Now())
+			if err != nil {
+				s.remote.Logger().Warn("EndpointReader failed to reassemble chunked message", log.Error(err))
+				continue
+			}
+			if m == nil {
+				continue
+			}
+			if err := s.onMessageBatch(m); err != nil {
+				return err
+			}
 		default:
 			{
-				plog.Warn("EndpointReader received unknown message type")
+				s.remote.Logger().Warn("EndpointReader received unknown message type")
 			}
 		}
 	}
 }
 
-func (s *endpointReader) OnConnectRequest(stream Remoting_ReceiveServer, c *ConnectRequest) (bool, error) {
+func (s *endpointReader) OnConnectRequest(stream RemoteStream, c *ConnectRequest) (bool, error) {
 	switch tt := c.ConnectionType.(type) {
 	case *ConnectRequest_ServerConnection:
 		{
 			sc := tt.ServerConnection
+			if s.rejectConnection(stream, c, sc) {
+				return true, nil
+			}
 			s.onServerConnection(stream, sc)
 		}
 	case *ConnectRequest_ClientConnection:
 		{
 			// TODO implement me
-			plog.Error("ClientConnection not implemented")
+			s.remote.Logger().Error("ClientConnection not implemented")
+			// client connections can not be authenticated, they would open a way around the authenticator
+			if s.remote.config.ConnectAuthenticator != nil {
+				return true, nil
+			}
 		}
 	default:
-		plog.Error("EndpointReader received unknown connection type")
+		s.remote.Logger().Error("EndpointReader received unknown connection type")
 		return true, nil
 	}
 	return false, nil
@@ -122,6 +167,7 @@ func (s *endpointReader) onMessageBatch(m *MessageBatch) error {
 		sender *actor.PID
 		target *actor.PID
 	)
+	types := newBatchTypes(m.TypeNames)
 
 	for _, envelope := range m.Envelopes {
 		data := envelope.MessageData
@@ -129,13 +175,16 @@ func (s *endpointReader) onMessageBatch(m *MessageBatch) error {
 		sender = deserializeSender(sender, envelope.Sender, envelope.SenderRequestId, m.Senders)
 		target = deserializeTarget(target, envelope.Target, envelope.TargetRequestId, m.Targets)
 		if target == nil {
-			plog.Error("EndpointReader received message with unknown target", log.Int("target", int(envelope.Target)), log.Int("targetRequestId", int(envelope.TargetRequestId)))
+			s.remote.Logger().Error("EndpointReader received message with unknown target", log.Int("target", int(envelope.Target)), log.Int("targetRequestId", int(envelope.TargetRequestId)))
 			return errors.New("unknown target")
 		}
 
-		message, err := Deserialize(data, m.TypeNames[envelope.TypeId], envelope.SerializerId)
+		message, err := types.deserialize(envelope.TypeId, envelope.SerializerId, data)
+		if errors.Is(err, ErrUnknownMessageType) && s.remote.config.RawUnknownMessages {
+			message, err = &UnknownRemoteMessage{TypeName: m.TypeNames[envelope.TypeId], Bytes: data, SerializerId: envelope.SerializerId}, nil
+		}
 		if err != nil {
-			plog.Error("EndpointReader failed to deserialize", log.Error(err))
+			s.remote.Logger().Error("EndpointReader failed to deserialize", log.Error(err))
 			return err
 		}
 
@@ -150,6 +199,7 @@ func (s *endpointReader) onMessageBatch(m *MessageBatch) error {
 			rt := &remoteTerminate{
 				Watchee: msg.Who,
 				Watcher: target,
+				Why:     msg.Why,
 			}
 			s.remote.edpManager.remoteTerminate(rt)
 		case actor.SystemMessage:
@@ -157,28 +207,42 @@ func (s *endpointReader) onMessageBatch(m *MessageBatch) error {
 			ref.SendSystemMessage(target, msg)
 		default:
 			var header map[string]string
-
-			// fast path
-			if sender == nil && envelope.MessageHeader == nil {
-				s.remote.actorSystem.Root.Send(target, message)
-				continue
-			}
-
-			// slow path
 			if envelope.MessageHeader != nil {
 				header = envelope.MessageHeader.HeaderData
 			}
-			localEnvelope := &actor.MessageEnvelope{
+
+			remoteEnvelope := &RemoteEnvelope{
 				Header:  header,
 				Message: message,
 				Sender:  sender,
+				Target:  target,
+			}
+			if s.inbound != nil {
+				s.inbound(remoteEnvelope)
+			} else {
+				s.deliver(remoteEnvelope)
 			}
-			s.remote.actorSystem.Root.Send(target, localEnvelope)
 		}
 	}
 	return nil
 }
 
+func (s *endpointReader) deliver(envelope *RemoteEnvelope) {
+	// fast path
+	if envelope.Sender == nil && envelope.Header == nil {
+		s.remote.actorSystem.Root.Send(envelope.Target, envelope.Message)
+		return
+	}
+
+	// slow path
+	localEnvelope := &actor.MessageEnvelope{
+		Header:  envelope.Header,
+		Message: envelope.Message,
+		Sender:  envelope.Sender,
+	}
+	s.remote.actorSystem.Root.Send(envelope.Target, localEnvelope)
+}
+
 func deserializeSender(pid *actor.PID, index int32, requestId uint32, arr []*actor.PID) *actor.PID {
 	if index == 0 {
 		pid = nil
@@ -206,9 +270,55 @@ func deserializeTarget(pid *actor.PID, index int32, requestId uint32, arr []*act
 	return pid
 }
 
-func (s *endpointReader) onServerConnection(stream Remoting_ReceiveServer, sc *ServerConnection) {
+// rejectConnection answers the connect request with the error of Config.ConnectAuthenticator, if it fails.
+// It returns true when the peer was rejected
+func (s *endpointReader) rejectConnection(stream RemoteStream, c *ConnectRequest, sc *ServerConnection) bool {
+	if s.remote.config.ConnectAuthenticator == nil {
+		return false
+	}
+
+	reason := s.remote.config.ConnectAuthenticator(c)
+	if reason == nil {
+		return false
+	}
+
+	s.remote.Logger().Warn("EndpointReader rejected connect request", log.String("address", sc.Address), log.String("systemId", sc.SystemId), log.Error(reason))
+	err := stream.Send(
+		&RemoteMessage{
+			MessageType: &RemoteMessage_ConnectResponse{
+				ConnectResponse: &ConnectResponse{
+					MemberId:  s.remote.actorSystem.ID,
+					Rejection: reason.Error(),
+				},
+			},
+		})
+	if err != nil {
+		s.remote.Logger().Error("EndpointReader failed to send ConnectResponse message", log.Error(err))
+	}
+
+	return true
+}
+
+// monitorHeartbeats terminates the endpoint of the peer once it missed Config.HeartbeatMissThreshold of the
+// heartbeats it announced on connect. Peers which do not announce their interval are expected to use ours
+func (s *endpointReader) monitorHeartbeats(heartbeat *heartbeatMonitor, c *ConnectRequest) {
+	interval := time.Duration(c.HeartbeatIntervalMs) * time.Millisecond
+	if interval <= 0 {
+		interval = s.remote.config.HeartbeatInterval
+	}
+
+	heartbeat.start(interval, s.remote.config.HeartbeatMissThreshold, func(address string) {
+		s.remote.Logger().Info("EndpointReader missed heartbeats from remote", log.String("address", address))
+		s.remote.actorSystem.EventStream.Publish(&EndpointTerminatedEvent{
+			Address: address,
+			Err:     ErrHeartbeatMissed,
+		})
+	})
+}
+
+func (s *endpointReader) onServerConnection(stream RemoteStream, sc *ServerConnection) {
 	if s.remote.BlockList().IsBlocked(sc.SystemId) {
-		plog.Debug("EndpointReader is blocked", log.String("systemId", sc.SystemId))
+		s.remote.Logger().Debug("EndpointReader is blocked", log.String("systemId", sc.SystemId))
 
 		err := stream.Send(
 			&RemoteMessage{
@@ -220,7 +330,7 @@ func (s *endpointReader) onServerConnection(stream Remoting_ReceiveServer, sc *S
 				},
 			})
 		if err != nil {
-			plog.Error("EndpointReader failed to send ConnectResponse message", log.Error(err))
+			s.remote.Logger().Error("EndpointReader failed to send ConnectResponse message", log.Error(err))
 		}
 
 		address := sc.Address
@@ -234,13 +344,16 @@ func (s *endpointReader) onServerConnection(stream Remoting_ReceiveServer, sc *S
 			&RemoteMessage{
 				MessageType: &RemoteMessage_ConnectResponse{
 					ConnectResponse: &ConnectResponse{
-						Blocked:  false,
-						MemberId: s.remote.actorSystem.ID,
+						Blocked:            false,
+						MemberId:           s.remote.actorSystem.ID,
+						HeartbeatSupported: true,
+						ChunkingSupported:  true,
+						SerializerIds:      serializerIDs(),
 					},
 				},
 			})
 		if err != nil {
-			plog.Error("EndpointReader failed to send ConnectResponse message", log.Error(err))
+			s.remote.Logger().Error("EndpointReader failed to send ConnectResponse message", log.Error(err))
 		}
 	}
 }
@@ -248,6 +361,6 @@ func (s *endpointReader) onServerConnection(stream Remoting_ReceiveServer, sc *S
 func (s *endpointReader) suspend(toSuspend bool) {
 	s.suspended = toSuspend
 	if toSuspend {
-		plog.Debug("Suspended EndpointReader")
+		s.remote.Logger().Debug("Suspended EndpointReader")
 	}
 }
@@ -0,0 +1,168 @@
+package remote
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func newTestEndpointReader(config *Config, received func(*RemoteEnvelope)) *endpointReader {
+	return &endpointReader{
+		remote:  &Remote{actorSystem: actor.NewActorSystem(), config: config},
+		inbound: received,
+	}
+}
+
+func BenchmarkEndpointReader_OnMessageBatch(b *testing.B) {
+	target := actor.NewPID("localhost", "target")
+	data, err := proto.Marshal(target)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	batch := &MessageBatch{
+		TypeNames: []string{string(proto.MessageName(target))},
+		Targets:   []*actor.PID{target},
+	}
+	for i := 0; i < 100; i++ {
+		batch.Envelopes = append(batch.Envelopes, &MessageEnvelope{MessageData: data, SerializerId: DefaultSerializerID})
+	}
+
+	count := 0
+	reader := newTestEndpointReader(Configure("localhost", 0), func(*RemoteEnvelope) { count++ })
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := reader.onMessageBatch(batch); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.StopTimer()
+
+	if count != 100*b.N {
+		b.Fatalf("received %v messages", count)
+	}
+}
+
+type lateRegisteredMessage struct {
+	Text string
+}
+
+func TestEndpointReader_ResolvesTypesPerBatch(t *testing.T) {
+	registerSerializer(t, 31, NewGobSerializer())
+
+	target := actor.NewPID("localhost", "target")
+	protoData, err := proto.Marshal(target)
+	require.NoError(t, err)
+	jsonData, err := newJsonSerializer().Serialize(target)
+	require.NoError(t, err)
+	gobData, err := NewGobSerializer().Serialize(&lateRegisteredMessage{Text: "hello"})
+	require.NoError(t, err)
+
+	batch := &MessageBatch{
+		TypeNames: []string{string(proto.MessageName(target)), gobTypeName(reflect.TypeOf(&lateRegisteredMessage{}))},
+		Targets:   []*actor.PID{target},
+		Envelopes: []*MessageEnvelope{
+			// the same type is resolved for each serializer it is received with
+			{TypeId: 0, MessageData: protoData, SerializerId: DefaultSerializerID},
+			{TypeId: 0, MessageData: jsonData, SerializerId: 1},
+			{TypeId: 1, MessageData: gobData, SerializerId: 31},
+		},
+	}
+
+	var received []interface{}
+	reader := newTestEndpointReader(Configure("localhost", 0, WithRawUnknownMessages()), func(env *RemoteEnvelope) {
+		received = append(received, env.Message)
+	})
+
+	require.NoError(t, reader.onMessageBatch(batch))
+	require.Len(t, received, 3)
+	assert.True(t, proto.Equal(target, received[0].(*actor.PID)))
+	expected, err := Deserialize(jsonData, batch.TypeNames[0], 1)
+	require.NoError(t, err)
+	assert.Equal(t, expected, received[1])
+	assert.IsType(t, &UnknownRemoteMessage{}, received[2])
+
+	// a type registered after a batch is found by the next one
+	RegisterGobType(&lateRegisteredMessage{})
+	received = nil
+	require.NoError(t, reader.onMessageBatch(batch))
+	require.Len(t, received, 3)
+	assert.Equal(t, &lateRegisteredMessage{Text: "hello"}, received[2])
+}
+
+// scriptedStream is received the given messages, then the end of the stream
+type scriptedStream struct {
+	received []*RemoteMessage
+	sent     []*RemoteMessage
+}
+
+func (s *scriptedStream) Send(m *RemoteMessage) error {
+	s.sent = append(s.sent, m)
+
+	return nil
+}
+
+func (s *scriptedStream) Recv() (*RemoteMessage, error) {
+	if len(s.received) == 0 {
+		return nil, io.EOF
+	}
+	m := s.received[0]
+	s.received = s.received[1:]
+
+	return m, nil
+}
+
+func TestEndpointReader_RejectsMessagesBeforeHandshake(t *testing.T) {
+	target := actor.NewPID("localhost", "target")
+	data, err := proto.Marshal(target)
+	require.NoError(t, err)
+	batch := &RemoteMessage{MessageType: &RemoteMessage_MessageBatch{MessageBatch: &MessageBatch{
+		TypeNames: []string{string(proto.MessageName(target))},
+		Targets:   []*actor.PID{target},
+		Envelopes: []*MessageEnvelope{{MessageData: data, SerializerId: DefaultSerializerID}},
+	}}}
+	connect := func(token string) *RemoteMessage {
+		return &RemoteMessage{MessageType: &RemoteMessage_ConnectRequest{ConnectRequest: &ConnectRequest{
+			ConnectionType: &ConnectRequest_ServerConnection{ServerConnection: &ServerConnection{Address: "peer", SystemId: "peer"}},
+			AuthToken:      []byte(token),
+		}}}
+	}
+
+	var received int
+	config := Configure("localhost", 0, WithConnectAuthenticator(func(c *ConnectRequest) error {
+		if string(c.AuthToken) != "secret" {
+			return errors.New("invalid credentials")
+		}
+
+		return nil
+	}))
+	reader := newTestEndpointReader(config, func(*RemoteEnvelope) { received++ })
+	reader.remote.edpManager = &endpointManager{endpointReaderConnections: &sync.Map{}}
+	reader.remote.blocklist = NewBlockList()
+
+	// a peer skipping the handshake is disconnected
+	err = reader.Receive(&scriptedStream{received: []*RemoteMessage{batch}})
+	assert.ErrorIs(t, err, ErrNotAuthenticated)
+
+	// a client connection can not be authenticated
+	client := &RemoteMessage{MessageType: &RemoteMessage_ConnectRequest{ConnectRequest: &ConnectRequest{
+		ConnectionType: &ConnectRequest_ClientConnection{ClientConnection: &ClientConnection{}},
+	}}}
+	assert.NoError(t, reader.Receive(&scriptedStream{received: []*RemoteMessage{client, batch}}))
+
+	// a rejected peer is disconnected before its messages flow
+	assert.NoError(t, reader.Receive(&scriptedStream{received: []*RemoteMessage{connect("guess"), batch}}))
+	assert.Equal(t, 0, received)
+
+	assert.NoError(t, reader.Receive(&scriptedStream{received: []*RemoteMessage{connect("secret"), batch}}))
+	assert.Equal(t, 1, received)
+}
@@ -0,0 +1,150 @@
+package remote
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrEndpointDisconnected is the error of the endpoints to an address closed with Remote.Disconnect
+var ErrEndpointDisconnected = errors.New("remote: endpoint disconnected")
+
+// EndpointState is the connection state of the endpoint to a remote address
+type EndpointState int
+
+const (
+	// EndpointConnecting is the state of an endpoint which has not connected yet
+	EndpointConnecting EndpointState = iota
+	// EndpointConnected is the state of an endpoint with an open connection
+	EndpointConnected
+	// EndpointTerminated is the state of an endpoint whose connection failed or was closed
+	EndpointTerminated
+)
+
+func (s EndpointState) String() string {
+	switch s {
+	case EndpointConnecting:
+		return "Connecting"
+	case EndpointConnected:
+		return "Connected"
+	case EndpointTerminated:
+		return "Terminated"
+	default:
+		return "Unknown"
+	}
+}
+
+// EndpointStatus describes the endpoint to a remote address
+type EndpointStatus struct {
+	State EndpointState
+	// LastError is the error which terminated the endpoint, if any
+	LastError error
+}
+
+// terminatedEndpointRetention is how long the status of a terminated endpoint is kept when it is not quarantined
+const terminatedEndpointRetention = time.Minute
+
+type endpointStateEntry struct {
+	status       EndpointStatus
+	terminatedAt time.Time
+}
+
+// endpointStates tracks the status of endpoints from the endpoint events.
+// The status of a terminated endpoint is forgotten once it was kept for retention
+type endpointStates struct {
+	mu        sync.RWMutex
+	states    map[string]endpointStateEntry
+	retention time.Duration
+	lastSweep time.Time
+}
+
+func newEndpointStates(retention time.Duration) *endpointStates {
+	return &endpointStates{
+		states:    make(map[string]endpointStateEntry),
+		retention: retention,
+		lastSweep: time.Now(),
+	}
+}
+
+// endpointStateRetention keeps the status of a terminated endpoint until its quarantine expired
+func endpointStateRetention(config *Config) time.Duration {
+	if config.QuarantineFailureThreshold > 0 {
+		return terminatedEndpointRetention + config.QuarantineCooldown
+	}
+
+	return terminatedEndpointRetention
+}
+
+func (es *endpointStates) set(address string, status EndpointStatus) {
+	es.mu.Lock()
+	defer es.mu.Unlock()
+
+	now := time.Now()
+	entry := endpointStateEntry{status: status}
+	if status.State == EndpointTerminated {
+		entry.terminatedAt = now
+	}
+	es.states[address] = entry
+
+	if now.Sub(es.lastSweep) >= es.retention {
+		es.sweep(now)
+	}
+}
+
+// sweep forgets the terminated endpoints kept for retention
+func (es *endpointStates) sweep(now time.Time) {
+	for address, entry := range es.states {
+		if es.expired(entry, now) {
+			delete(es.states, address)
+		}
+	}
+	es.lastSweep = now
+}
+
+func (es *endpointStates) expired(entry endpointStateEntry, now time.Time) bool {
+	return entry.status.State == EndpointTerminated && now.Sub(entry.terminatedAt) >= es.retention
+}
+
+func (es *endpointStates) get(address string) (EndpointStatus, bool) {
+	es.mu.RLock()
+	defer es.mu.RUnlock()
+
+	entry, ok := es.states[address]
+	if !ok || es.expired(entry, time.Now()) {
+		return EndpointStatus{}, false
+	}
+
+	return entry.status, true
+}
+
+// EndpointState returns the status of the endpoint to address, false if no endpoint was opened to it, or if it
+// terminated over a minute ago and is not quarantined
+func (r *Remote) EndpointState(address string) (EndpointStatus, bool) {
+	if r.edpManager == nil {
+		return EndpointStatus{}, false
+	}
+
+	return r.edpManager.states.get(address)
+}
+
+// ConnectTo opens the endpoint to address ahead of the first message sent to it, and allows endpoints to it
+// again after Disconnect. Use EndpointState to follow the connection
+func (r *Remote) ConnectTo(address string) error {
+	if !r.config.allowsAddress(address) {
+		return ErrAddressDenied
+	}
+
+	r.edpManager.connect(address)
+
+	return nil
+}
+
+// Disconnect closes the endpoint to address, and refuses to open endpoints to it until ConnectTo is called.
+// Messages to address are dead lettered, and its watchers are told it terminated
+func (r *Remote) Disconnect(address string) {
+	r.edpManager.disconnect(address)
+}
+
+func (r *Remote) isDisconnected(address string) bool {
+	return r.edpManager != nil && r.edpManager.isDisconnected(address)
+}
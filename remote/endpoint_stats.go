@@ -0,0 +1,90 @@
+package remote
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// EndpointStatsEvent is published every Config.EndpointStatsInterval for each open endpoint
+type EndpointStatsEvent struct {
+	Address   string
+	Connected bool
+	// QueueDepth is the number of messages waiting to be sent to the peer
+	QueueDepth int
+	// SentMessages and SentBytes count the messages sent since the endpoint was opened
+	SentMessages uint64
+	SentBytes    uint64
+	// LastError is the last error connecting or sending to the peer, nil if there was none
+	LastError error
+}
+
+// endpointStats counts the messages sent by an endpoint writer, it is shared with the endpoint manager
+type endpointStats struct {
+	sentMessages uint64
+	sentBytes    uint64
+	// lastError holds an endpointError, atomic.Value only stores values of one concrete type
+	lastError atomic.Value
+	mailbox   *endpointWriterMailboxRef
+}
+
+type endpointError struct {
+	err error
+}
+
+func (s *endpointStats) sent(messages int, bytes int) {
+	atomic.AddUint64(&s.sentMessages, uint64(messages))
+	atomic.AddUint64(&s.sentBytes, uint64(bytes))
+}
+
+func (s *endpointStats) failed(err error) {
+	s.lastError.Store(endpointError{err: err})
+}
+
+func (s *endpointStats) lastErr() error {
+	e, _ := s.lastError.Load().(endpointError)
+
+	return e.err
+}
+
+// startStats publishes an EndpointStatsEvent for each endpoint every interval, until the returned channel is closed
+func (em *endpointManager) startStats(interval time.Duration) chan struct{} {
+	done := make(chan struct{})
+	ticker := time.NewTicker(interval)
+
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				em.publishStats()
+			}
+		}
+	}()
+
+	return done
+}
+
+func (em *endpointManager) publishStats() {
+	em.connections.Range(func(key, value interface{}) bool {
+		// endpoints still being spawned are reported on the next tick
+		ep, ok := value.(*endpointLazy).endpoint.Load().(*endpoint)
+		if !ok {
+			return true
+		}
+
+		address := key.(string)
+		status, _ := em.states.get(address)
+		em.remote.actorSystem.EventStream.Publish(&EndpointStatsEvent{
+			Address:      address,
+			Connected:    status.State == EndpointConnected,
+			QueueDepth:   ep.stats.mailbox.userMessageCount(),
+			SentMessages: atomic.LoadUint64(&ep.stats.sentMessages),
+			SentBytes:    atomic.LoadUint64(&ep.stats.sentBytes),
+			LastError:    ep.stats.lastErr(),
+		})
+
+		return true
+	})
+}
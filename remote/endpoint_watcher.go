@@ -25,7 +25,7 @@ type endpointWatcher struct {
 }
 
 func (state *endpointWatcher) initialize() {
-	plog.Info("Started EndpointWatcher", log.String("address", state.address))
+	state.remote.Logger().Info("Started EndpointWatcher", log.String("address", state.address))
 	state.watched = make(map[string]*actor.PIDSet)
 }
 
@@ -49,7 +49,7 @@ func (state *endpointWatcher) connected(ctx actor.Context) {
 
 		terminated := &actor.Terminated{
 			Who: msg.Watchee,
-			Why: actor.TerminatedReason_Stopped,
+			Why: msg.Why,
 		}
 		ref, ok := state.remote.actorSystem.ProcessRegistry.GetLocal(msg.Watcher.Id)
 		if ok {
@@ -58,7 +58,7 @@ func (state *endpointWatcher) connected(ctx actor.Context) {
 	case *EndpointConnectedEvent:
 		// Already connected, pass
 	case *EndpointTerminatedEvent:
-		plog.Info("EndpointWatcher handling terminated",
+		state.remote.Logger().Info("EndpointWatcher handling terminated",
 			log.String("address", state.address), log.Int("watched", len(state.watched)))
 
 		for id, pidSet := range state.watched {
@@ -66,6 +66,11 @@ func (state *endpointWatcher) connected(ctx actor.Context) {
 			ref, ok := state.remote.actorSystem.ProcessRegistry.GetLocal(id)
 			if ok {
 				pidSet.ForEach(func(i int, pid *actor.PID) {
+					// durable watches are sent again when the endpoint reconnects
+					if state.remote.edpManager.durableWatches.contains(id, pid) {
+						return
+					}
+
 					// create a terminated event for the Watched actor
 					terminated := &actor.Terminated{
 						Who: pid,
@@ -119,13 +124,18 @@ func (state *endpointWatcher) connected(ctx actor.Context) {
 	case actor.SystemMessage, actor.AutoReceiveMessage:
 		// ignore
 	default:
-		plog.Error("EndpointWatcher received unknown message", log.String("address", state.address), log.Message(msg))
+		state.remote.Logger().Error("EndpointWatcher received unknown message", log.String("address", state.address), log.Message(msg))
 	}
 }
 
 func (state *endpointWatcher) terminated(ctx actor.Context) {
 	switch msg := ctx.Message().(type) {
 	case *remoteWatch:
+		if msg.Durable {
+			// sent again when the endpoint reconnects
+			return
+		}
+
 		// try to find the watcher ExtensionID in the local actor registry
 		ref, ok := state.remote.actorSystem.ProcessRegistry.GetLocal(msg.Watcher.Id)
 
@@ -139,14 +149,14 @@ func (state *endpointWatcher) terminated(ctx actor.Context) {
 			ref.SendSystemMessage(msg.Watcher, terminated)
 		}
 	case *EndpointConnectedEvent:
-		plog.Info("EndpointWatcher handling restart", log.String("address", state.address))
+		state.remote.Logger().Info("EndpointWatcher handling restart", log.String("address", state.address))
 		state.behavior.Become(state.connected)
 	case *remoteTerminate, *EndpointTerminatedEvent, *remoteUnwatch:
 		// pass
-		plog.Error("EndpointWatcher receive message for already terminated endpoint", log.String("address", state.address), log.Message(msg))
+		state.remote.Logger().Error("EndpointWatcher receive message for already terminated endpoint", log.String("address", state.address), log.Message(msg))
 	case actor.SystemMessage, actor.AutoReceiveMessage:
 		// ignore
 	default:
-		plog.Error("EndpointWatcher received unknown message", log.String("address", state.address), log.TypeOf("type", msg), log.Message(msg))
+		state.remote.Logger().Error("EndpointWatcher received unknown message", log.String("address", state.address), log.TypeOf("type", msg), log.Message(msg))
 	}
 }
@@ -2,48 +2,119 @@ package remote
 
 import (
 	"errors"
+	"fmt"
+	"hash/fnv"
 	"io"
+	"reflect"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/asynkron/protoactor-go/actor"
 	"github.com/asynkron/protoactor-go/log"
-	"golang.org/x/net/context"
-	"google.golang.org/grpc"
 	"google.golang.org/protobuf/proto"
 )
 
-func endpointWriterProducer(remote *Remote, address string, config *Config) actor.Producer {
+func endpointWriterProducer(remote *Remote, address string, config *Config, stats *endpointStats, terminated *int32) actor.Producer {
 	return func() actor.Actor {
-		return &endpointWriter{
-			address: address,
-			config:  config,
-			remote:  remote,
+		w := &endpointWriter{
+			address:    address,
+			config:     config,
+			remote:     remote,
+			mailbox:    stats.mailbox,
+			terminated: terminated,
+			stats:      stats,
+			metrics:    newEndpointMetrics(remote, address),
 		}
+		w.outbound = makeSenderMiddlewareChain(config.OutboundMiddleware, func(envelope *RemoteEnvelope) {
+			w.outboundResult = envelope
+		})
+
+		return w
 	}
 }
 
 type endpointWriter struct {
 	config  *Config
 	address string
-	conn    *grpc.ClientConn
-	stream  Remoting_ReceiveClient
+	// stream is the first stream of the pool, it carries heartbeats. streams holds Config.EndpointStreamCount streams
+	stream  RemoteConnection
+	streams []RemoteConnection
 	remote  *Remote
+	// heartbeatSupported is set when the peer announced it understands heartbeats
+	heartbeatSupported bool
+	heartbeatDone      chan struct{}
+	// chunkingSupported is set when the peer announced it reassembles chunks, transferID numbers the chunked messages
+	// and is incremented atomically, by the streams sending concurrently
+	chunkingSupported bool
+	transferID        uint64
+	// peerSerializers holds the serializer ids the peer announced it decodes, nil when it did not announce them
+	peerSerializers map[int32]bool
+	// fallbackSerializers holds the serializer of the Config.SerializerFallback chain resolved per message type for
+	// the peer
+	fallbackSerializers map[reflect.Type]int32
+	// buffer holds coalesced messages until the next flush
+	buffer     []interface{}
+	flushTimer *time.Timer
+	metrics    *endpointMetrics
+	connected  bool
+	// closing is set when the writer closes its own streams, which then do not terminate the endpoint
+	closing int32
+	// terminated is set by the endpoint manager once the endpoint is removed, before the writer is told to stop
+	terminated *int32
+	mailbox    *endpointWriterMailboxRef
+	stats      *endpointStats
+	// outbound is the Config.OutboundMiddleware chain, outboundResult is set when it reaches the end
+	outbound       SenderFunc
+	outboundResult *RemoteEnvelope
 }
 
+// batchFlushTick is sent by the endpoint writer to itself when Config.BatchFlushInterval has elapsed
+type batchFlushTick struct{}
+
+// ErrConnectTimeout is returned when the peer did not answer the connect request within Config.ConnectTimeout
+var ErrConnectTimeout = errors.New("remote: connect handshake timed out")
+
+// ErrConnectRejected is returned when the peer rejected the connect request, see Config.ConnectAuthenticator.
+// The connection is not retried, the address is disconnected until Remote.ConnectTo is called
+var ErrConnectRejected = errors.New("remote: connect rejected by peer")
+
 type restartAfterConnectFailure struct {
 	err error
 }
 
 func (state *endpointWriter) initialize(ctx actor.Context) {
 	now := time.Now()
-	plog.Info("Started EndpointWriter. connecting", log.String("address", state.address))
+	state.remote.Logger().Info("Started EndpointWriter. connecting", log.String("address", state.address))
 
 	var err error
 
 	for i := 0; i < state.remote.config.MaxRetryCount; i++ {
 		err = state.initializeInternal()
+		if errors.Is(err, ErrAddressDenied) {
+			state.remote.Logger().Warn("EndpointWriter address denied by policy", log.String("address", state.address))
+			break
+		}
+		if errors.Is(err, ErrEndpointDisconnected) {
+			state.remote.Logger().Info("EndpointWriter address is disconnected", log.String("address", state.address))
+			break
+		}
+		if errors.Is(err, ErrConnectRejected) {
+			state.remote.Logger().Error("EndpointWriter connect rejected", log.String("address", state.address), log.Error(err))
+			break
+		}
+		if err != nil && state.remote.QuarantineState(state.address) == QuarantineHalfOpen {
+			// a half open quarantine tries to connect once
+			state.remote.Logger().Info("EndpointWriter failed to connect to quarantined address", log.String("address", state.address), log.Error(err))
+			break
+		}
 		if err != nil {
-			plog.Error("EndpointWriter failed to connect", log.String("address", state.address), log.Error(err), log.Int("retry", i))
+			state.remote.Logger().Error("EndpointWriter failed to connect", log.String("address", state.address), log.Error(err), log.Int("retry", i))
+			state.metrics.reconnect()
+			state.stats.failed(err)
+			if grace := state.config.EndpointConnectGracePeriod; grace > 0 && time.Since(now) >= grace {
+				state.rejectUnconnected()
+			}
 			// Wait 2 seconds to restart and retry
 			// Replace with Exponential Backoff
 			time.Sleep(2 * time.Second)
@@ -56,6 +127,7 @@ func (state *endpointWriter) initialize(ctx actor.Context) {
 	if err != nil {
 		terminated := &EndpointTerminatedEvent{
 			Address: state.address,
+			Err:     err,
 		}
 		state.remote.actorSystem.EventStream.Publish(terminated)
 
@@ -74,24 +146,147 @@ func (state *endpointWriter) initialize(ctx actor.Context) {
 
 	}
 
-	plog.Info("EndpointWriter connected", log.String("address", state.address), log.Duration("cost", time.Since(now)))
+	if state.heartbeatSupported && state.config.HeartbeatInterval > 0 {
+		state.heartbeatDone = startHeartbeat(state.remote.actorSystem, ctx.Self(), state.config.HeartbeatInterval)
+	}
+
+	state.remote.Logger().Info("EndpointWriter connected", log.String("address", state.address), log.Duration("cost", time.Since(now)))
 }
 
 func (state *endpointWriter) initializeInternal() error {
-	conn, err := grpc.Dial(state.address, state.config.DialOptions...)
+	if !state.config.allowsAddress(state.address) {
+		return ErrAddressDenied
+	}
+	if state.remote.isDisconnected(state.address) {
+		return ErrEndpointDisconnected
+	}
+
+	// the pool is connected as a unit, if any stream fails all of them are closed and retried together
+	count := state.config.EndpointStreamCount
+	if count < 1 {
+		count = 1
+	}
+	streams := make([]RemoteConnection, 0, count)
+	for i := 0; i < count; i++ {
+		stream, err := state.connectStream()
+		if err != nil {
+			for _, s := range streams {
+				_ = s.CloseSend()
+				_ = s.Close()
+			}
+
+			return err
+		}
+		streams = append(streams, stream)
+	}
+
+	state.stream = streams[0]
+	state.streams = streams
+	for _, stream := range streams {
+		go state.receiveLoop(stream)
+	}
+
+	state.connected = true
+	state.metrics.connected()
+
+	connected := &EndpointConnectedEvent{Address: state.address}
+	state.remote.actorSystem.EventStream.Publish(connected)
+	return nil
+}
+
+func (state *endpointWriter) connectStream() (RemoteConnection, error) {
+	stream, err := state.remote.transport.Dial(state.address)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	state.conn = conn
-	c := NewRemotingClient(conn)
-	stream, err := c.Receive(context.Background(), state.config.CallOptions...)
+
+	connection, err := state.handshake(stream)
 	if err != nil {
-		plog.Error("EndpointWriter failed to create receive stream", log.String("address", state.address), log.Error(err))
-		return err
+		_ = stream.Close()
+
+		return nil, err
 	}
-	state.stream = stream
 
-	err = stream.Send(&RemoteMessage{
+	switch t := connection.MessageType.(type) {
+	case *RemoteMessage_ConnectResponse:
+		state.remote.Logger().Debug("Received connect response", log.String("fromAddress", state.address))
+		if t.ConnectResponse.Rejection != "" {
+			_ = stream.Close()
+
+			return nil, fmt.Errorf("%w: %s", ErrConnectRejected, t.ConnectResponse.Rejection)
+		}
+		state.heartbeatSupported = t.ConnectResponse.HeartbeatSupported
+		state.chunkingSupported = t.ConnectResponse.ChunkingSupported
+		state.peerSerializers = serializerSet(t.ConnectResponse.SerializerIds)
+		state.fallbackSerializers = nil
+		// TODO: handle blocked status received from remote server
+		break
+	default:
+		state.remote.Logger().Error("EndpointWriter got invalid connect response", log.String("address", state.address), log.TypeOf("type", connection.MessageType))
+		_ = stream.Close()
+
+		return nil, errors.New("invalid connect response")
+	}
+
+	return stream, nil
+}
+
+// receiveLoop watches the stream for disconnects, it returns once the stream has completed, failed or the peer sent
+// a DisconnectRequest. A completed stream or a DisconnectRequest means the peer shut down gracefully
+func (state *endpointWriter) receiveLoop(stream RemoteConnection) {
+	graceful := true
+
+	var err error
+	for disconnected := false; !disconnected; {
+		var msg *RemoteMessage
+		msg, err = stream.Recv()
+		switch {
+		case errors.Is(err, io.EOF):
+			state.remote.Logger().Debug("EndpointWriter stream completed", log.String("address", state.address))
+			disconnected = true
+		case err != nil:
+			state.remote.Logger().Error("EndpointWriter lost connection", log.String("address", state.address), log.Error(err))
+			graceful = false
+			disconnected = true
+		default:
+			switch msg.MessageType.(type) {
+			case *RemoteMessage_DisconnectRequest:
+				state.remote.Logger().Info("EndpointWriter got DisconnectRequest form remote", log.String("address", state.address))
+				disconnected = true
+			default:
+				state.remote.Logger().Debug("EndpointWriter ignored unexpected message", log.String("address", state.address), log.TypeOf("type", msg.MessageType))
+			}
+		}
+	}
+
+	// a stream closed by the writer belongs to an endpoint which already terminated, the address may be connected again
+	if atomic.LoadInt32(&state.closing) == 1 {
+		return
+	}
+
+	terminated := &EndpointTerminatedEvent{
+		Address:  state.address,
+		Graceful: graceful,
+	}
+	if !graceful {
+		terminated.Err = err
+	}
+	state.remote.actorSystem.EventStream.Publish(terminated)
+}
+
+// handshake sends the connect request and waits for the response, for at most Config.ConnectTimeout
+func (state *endpointWriter) handshake(stream RemoteConnection) (*RemoteMessage, error) {
+	var timedOut int32
+	if state.config.ConnectTimeout > 0 {
+		// closing the stream unblocks both Send and Recv
+		timer := time.AfterFunc(state.config.ConnectTimeout, func() {
+			atomic.StoreInt32(&timedOut, 1)
+			_ = stream.Close()
+		})
+		defer timer.Stop()
+	}
+
+	err := stream.Send(&RemoteMessage{
 		MessageType: &RemoteMessage_ConnectRequest{
 			ConnectRequest: &ConnectRequest{
 				ConnectionType: &ConnectRequest_ServerConnection{
@@ -100,61 +295,32 @@ func (state *endpointWriter) initializeInternal() error {
 						Address:  state.remote.actorSystem.Address(),
 					},
 				},
+				AuthToken:           state.config.ConnectAuthToken,
+				Metadata:            state.config.ConnectMetadata,
+				SerializerIds:       serializerIDs(),
+				HeartbeatIntervalMs: state.config.HeartbeatInterval.Milliseconds(),
 			},
 		},
 	})
-	if err != nil {
-		plog.Error("EndpointWriter failed to send connect request", log.String("address", state.address), log.Error(err))
-		return err
+	if err == nil {
+		var connection *RemoteMessage
+		if connection, err = stream.Recv(); err == nil {
+			return connection, nil
+		}
 	}
 
-	connection, err := stream.Recv()
-	if err != nil {
-		plog.Error("EndpointWriter failed to receive connect response", log.String("address", state.address), log.Error(err))
-		return err
+	if atomic.LoadInt32(&timedOut) == 1 {
+		err = ErrConnectTimeout
 	}
+	state.remote.Logger().Error("EndpointWriter failed to connect to remote", log.String("address", state.address), log.Error(err))
 
-	switch connection.MessageType.(type) {
-	case *RemoteMessage_ConnectResponse:
-		plog.Debug("Received connect response", log.String("fromAddress", state.address))
-		// TODO: handle blocked status received from remote server
-		break
-	default:
-		plog.Error("EndpointWriter got invalid connect response", log.String("address", state.address), log.TypeOf("type", connection.MessageType))
-		return errors.New("invalid connect response")
-	}
-
-	go func() {
-		for {
-			_, err := stream.Recv()
-			switch {
-			case errors.Is(err, io.EOF):
-				plog.Debug("EndpointWriter stream completed", log.String("address", state.address))
-				return
-			case err != nil:
-				plog.Error("EndpointWriter lost connection", log.String("address", state.address), log.Error(err))
-				terminated := &EndpointTerminatedEvent{
-					Address: state.address,
-				}
-				state.remote.actorSystem.EventStream.Publish(terminated)
-				return
-			default: // DisconnectRequest
-				plog.Info("EndpointWriter got DisconnectRequest form remote", log.String("address", state.address))
-				terminated := &EndpointTerminatedEvent{
-					Address: state.address,
-				}
-				state.remote.actorSystem.EventStream.Publish(terminated)
-			}
-		}
-	}()
-
-	connected := &EndpointConnectedEvent{Address: state.address}
-	state.remote.actorSystem.EventStream.Publish(connected)
-	return nil
+	return nil, err
 }
 
-func (state *endpointWriter) sendEnvelopes(msg []interface{}, ctx actor.Context) {
-	envelopes := make([]*MessageEnvelope, len(msg))
+// buildBatch serializes the messages of one shard. It returns a nil message when there is nothing to send,
+// and stopped when the batch contained an EndpointTerminatedEvent
+func (state *endpointWriter) buildBatch(msg []interface{}, ctx actor.Context) (batch *RemoteMessage, count int, size int, stopped bool) {
+	envelopes := make([]*MessageEnvelope, 0, len(msg))
 
 	// type name uniqueness map name string to type index
 	typeNames := make(map[string]int32)
@@ -167,62 +333,92 @@ func (state *endpointWriter) sendEnvelopes(msg []interface{}, ctx actor.Context)
 	senderNamesArr := make([]*actor.PID, 0)
 
 	var (
-		header       *MessageHeader
-		typeID       int32
-		targetID     int32
-		senderID     int32
-		serializerID int32
+		header   *MessageHeader
+		typeID   int32
+		targetID int32
+		senderID int32
 	)
 
-	for i, tmp := range msg {
+	for _, tmp := range msg {
 		switch unwrapped := tmp.(type) {
 		case *EndpointTerminatedEvent, EndpointTerminatedEvent:
-			plog.Debug("Handling array wrapped terminate event", log.String("address", state.address), log.Object("msg", unwrapped))
+			state.remote.Logger().Debug("Handling array wrapped terminate event", log.String("address", state.address), log.Object("msg", unwrapped))
+			state.setTerminated()
 			ctx.Stop(ctx.Self())
-			return
+			return nil, 0, 0, true
+		case nil:
+			// sent before a partial failure, see sendEnvelopes
+			continue
 		}
 
 		rd, _ := tmp.(*remoteDeliver)
 
 		if state.stream == nil { // not connected yet since first connection attempt failed and we are waiting for the retry
-			if rd.sender != nil {
-				state.remote.actorSystem.Root.Send(rd.sender, &actor.DeadLetterResponse{Target: rd.target})
-			} else {
-				state.remote.actorSystem.EventStream.Publish(&actor.DeadLetterEvent{Message: rd.message, Sender: rd.sender, PID: rd.target})
-			}
+			state.deadLetter(rd)
 			continue
 		}
 
-		if rd.header == nil || rd.header.Length() == 0 {
+		if state.config.GuaranteeOrdering && state.isTerminated() {
+			// a new endpoint to the address may already be sending, queued messages must not arrive after its messages
+			state.deadLetter(rd)
+			continue
+		}
+
+		if actor.DeadlineExceeded(rd.header) {
+			// nobody waits for the response anymore
+			state.deadLetter(rd)
+			continue
+		}
+
+		var headerData map[string]string
+		if rd.header != nil && rd.header.Length() > 0 {
+			headerData = rd.header.ToMap()
+		}
+
+		message, sender, target := rd.message, rd.sender, rd.target
+		if state.outbound != nil {
+			envelope := state.applyOutbound(&RemoteEnvelope{Header: headerData, Message: message, Sender: sender, Target: target})
+			if envelope == nil {
+				continue
+			}
+			headerData, message, sender, target = envelope.Header, envelope.Message, envelope.Sender, envelope.Target
+		}
+
+		if len(headerData) == 0 {
 			header = nil
 		} else {
 			header = &MessageHeader{
-				HeaderData: rd.header.ToMap(),
+				HeaderData: headerData,
 			}
 		}
 
 		// if the message can be translated to a serialization representation, we do this here
 		// this only apply to root level messages and never to nested child objects inside the message
-		message := rd.message
 		if v, ok := message.(RootSerializable); ok {
 			message = v.Serialize()
 		}
 
-		bytes, typeName, err := Serialize(message, serializerID)
+		bytes, typeName, serializerID, err := state.serializeFor(message, rd.serializerID)
 		if err != nil {
-			panic(err)
+			state.rejectUnserializable(rd, message, err)
+			continue
 		}
+		if state.config.MaxMessageSize > 0 && len(bytes) > state.config.MaxMessageSize {
+			state.rejectTooLarge(rd, message, typeName, len(bytes))
+			continue
+		}
+		size += len(bytes)
 		typeID, typeNamesArr = addToLookup(typeNames, typeName, typeNamesArr)
-		targetID, targetNamesArr = addToTargetLookup(targetNames, rd.target, targetNamesArr)
-		targetRequestID := rd.target.RequestId
+		targetID, targetNamesArr = addToTargetLookup(targetNames, target, targetNamesArr)
+		targetRequestID := target.RequestId
 
-		senderID, senderNamesArr = addToSenderLookup(senderNames, rd.sender, senderNamesArr)
+		senderID, senderNamesArr = addToSenderLookup(senderNames, sender, senderNamesArr)
 		senderRequestID := uint32(0)
-		if rd.sender != nil {
-			senderRequestID = rd.sender.RequestId
+		if sender != nil {
+			senderRequestID = sender.RequestId
 		}
 
-		envelopes[i] = &MessageEnvelope{
+		envelopes = append(envelopes, &MessageEnvelope{
 			MessageHeader:   header,
 			MessageData:     bytes,
 			Sender:          senderID,
@@ -231,10 +427,14 @@ func (state *endpointWriter) sendEnvelopes(msg []interface{}, ctx actor.Context)
 			SerializerId:    serializerID,
 			TargetRequestId: targetRequestID,
 			SenderRequestId: senderRequestID,
-		}
+		})
 	}
 
-	err := state.stream.Send(&RemoteMessage{
+	if len(envelopes) == 0 {
+		return nil, 0, 0, false
+	}
+
+	return &RemoteMessage{
 		MessageType: &RemoteMessage_MessageBatch{
 			MessageBatch: &MessageBatch{
 				TypeNames: typeNamesArr,
@@ -243,11 +443,232 @@ func (state *endpointWriter) sendEnvelopes(msg []interface{}, ctx actor.Context)
 				Envelopes: envelopes,
 			},
 		},
-	})
-	if err != nil {
-		ctx.Stash()
-		plog.Debug("gRPC Failed to send", log.String("address", state.address), log.Error(err))
-		panic("restart it")
+	}, len(envelopes), size, false
+}
+
+func (state *endpointWriter) sendEnvelopes(msg []interface{}, ctx actor.Context) error {
+	shards := state.shard(msg)
+	batches := make([]*RemoteMessage, len(shards))
+	count, size := 0, 0
+
+	for i, shard := range shards {
+		batch, c, n, stopped := state.buildBatch(shard, ctx)
+		if stopped {
+			return nil
+		}
+		batches[i] = batch
+		count += c
+		size += n
+	}
+
+	if errs := state.sendBatches(batches); errs != nil {
+		// the batch is retried on the failed streams only, the messages of the others were delivered
+		state.clearSent(msg, errs)
+		err := firstError(errs)
+		state.stats.failed(err)
+		state.remote.Logger().Debug("gRPC Failed to send", log.String("address", state.address), log.Error(err))
+
+		return err
+	}
+
+	if count > 0 {
+		state.metrics.sent(count, size)
+		state.stats.sent(count, size)
+	}
+
+	return nil
+}
+
+// shard splits the messages across the stream pool by target, keeping the order of messages to each target
+func (state *endpointWriter) shard(msg []interface{}) [][]interface{} {
+	if len(state.streams) <= 1 {
+		return [][]interface{}{msg}
+	}
+
+	shards := make([][]interface{}, len(state.streams))
+	for _, m := range msg {
+		if m == nil {
+			continue
+		}
+		i := state.shardOf(m)
+		shards[i] = append(shards[i], m)
+	}
+
+	return shards
+}
+
+// shardOf returns the index of the stream carrying m, messages to the same target always use the same stream
+func (state *endpointWriter) shardOf(m interface{}) int {
+	rd, ok := m.(*remoteDeliver)
+	if !ok {
+		return 0
+	}
+
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(rd.target.Address))
+	_, _ = h.Write([]byte(rd.target.Id))
+
+	return int(h.Sum32() % uint32(len(state.streams)))
+}
+
+// clearSent removes the messages of the shards which were sent from msg, so that retrying msg does not
+// deliver them a second time after the messages which follow them
+func (state *endpointWriter) clearSent(msg []interface{}, errs []error) {
+	if len(errs) <= 1 {
+		return
+	}
+
+	for i, m := range msg {
+		if m != nil && errs[state.shardOf(m)] == nil {
+			msg[i] = nil
+		}
+	}
+}
+
+// sendBatches sends each batch on the stream of its shard, concurrently when several streams are used.
+// It returns nil when all batches were sent, or the error of each shard otherwise
+func (state *endpointWriter) sendBatches(batches []*RemoteMessage) []error {
+	if len(batches) == 1 {
+		if batches[0] == nil {
+			return nil
+		}
+		if err := state.send(state.stream, batches[0]); err != nil {
+			return []error{err}
+		}
+
+		return nil
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(batches))
+	for i, batch := range batches {
+		if batch == nil {
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, batch *RemoteMessage) {
+			defer wg.Done()
+			errs[i] = state.send(state.streams[i], batch)
+		}(i, batch)
+	}
+	wg.Wait()
+
+	if firstError(errs) != nil {
+		return errs
+	}
+
+	return nil
+}
+
+func firstError(errs []error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// applyOutbound runs the envelope through the outbound middleware, returning nil when delivery was short-circuited
+func (state *endpointWriter) applyOutbound(envelope *RemoteEnvelope) *RemoteEnvelope {
+	state.outbound(envelope)
+	result := state.outboundResult
+	state.outboundResult = nil
+
+	return result
+}
+
+func (state *endpointWriter) deadLetter(rd *remoteDeliver) {
+	// a durable watch is sent again once the endpoint reconnects, its watcher must not be told the PID was not found
+	if w, ok := rd.message.(*actor.Watch); ok && state.remote.edpManager != nil &&
+		state.remote.edpManager.durableWatches.contains(w.Watcher.Id, rd.target) {
+		return
+	}
+
+	if rd.sender != nil && state.remote.actorSystem.Config.DeadLetterResponse {
+		state.remote.actorSystem.Root.Send(rd.sender, &actor.DeadLetterResponse{Target: rd.target})
+	} else {
+		state.remote.actorSystem.EventStream.Publish(&actor.DeadLetterEvent{Message: rd.message, Sender: rd.sender, PID: rd.target})
+	}
+}
+
+// coalesce buffers messages until Config.BatchSize messages are pending or Config.BatchFlushInterval has elapsed
+func (state *endpointWriter) coalesce(msg []interface{}, ctx actor.Context) {
+	flush := false
+
+	for i, m := range msg {
+		switch m.(type) {
+		case *batchFlushTick:
+			state.flushTimer = nil
+			flush = true
+		case *remoteDeliver:
+			state.buffer = append(state.buffer, m)
+			if len(state.buffer) >= state.config.BatchSize {
+				state.flush(ctx, msg[i+1:])
+			}
+		default:
+			// control messages are not delayed
+			state.buffer = append(state.buffer, m)
+			flush = true
+		}
+	}
+
+	if flush {
+		state.flush(ctx, nil)
+	} else if len(state.buffer) > 0 && state.flushTimer == nil {
+		system, self := state.remote.actorSystem, ctx.Self()
+		state.flushTimer = time.AfterFunc(state.config.BatchFlushInterval, func() {
+			system.Root.Send(self, &batchFlushTick{})
+		})
+	}
+}
+
+// flush sends all buffered messages as a single batch, if it fails the batch is retried along with the
+// messages which follow it in the current message
+func (state *endpointWriter) flush(ctx actor.Context, rest []interface{}) {
+	batch := state.takeBuffer()
+	if len(batch) == 0 {
+		return
+	}
+
+	if err := state.sendEnvelopes(batch, ctx); err != nil {
+		state.retry(append(batch, rest...))
+	}
+}
+
+// retry hands a batch which failed to send back to the mailbox and restarts the writer to reconnect. The batch is
+// sent again ahead of the queued messages, those which no longer fit in Config.EndpointWriterQueueSize are rejected
+// with a RemoteBackpressure reason
+func (state *endpointWriter) retry(batch []interface{}) {
+	state.mailbox.retry(batch)
+	panic("restart it")
+}
+
+func (state *endpointWriter) takeBuffer() []interface{} {
+	if state.flushTimer != nil {
+		state.flushTimer.Stop()
+		state.flushTimer = nil
+	}
+
+	batch := state.buffer
+	state.buffer = nil
+
+	return batch
+}
+
+func (state *endpointWriter) deadLetterBatch(batch []interface{}) {
+	for _, m := range batch {
+		if rd, ok := m.(*remoteDeliver); ok {
+			state.deadLetter(rd)
+		}
+	}
+}
+
+func (state *endpointWriter) sendHeartbeat() {
+	if err := state.stream.Send(heartbeatMessage); err != nil {
+		state.remote.Logger().Debug("EndpointWriter failed to send heartbeat", log.String("address", state.address), log.Error(err))
 	}
 }
 
@@ -264,7 +685,7 @@ func addToLookup(m map[string]int32, name string, a []string) (int32, []string)
 
 func addToTargetLookup(m map[string]int32, pid *actor.PID, arr []*actor.PID) (int32, []*actor.PID) {
 	max := int32(len(m))
-	key := pid.Address + "/" + pid.Id
+	key := pid.Key()
 	id, ok := m[key]
 	if !ok {
 		c, _ := proto.Clone(pid).(*actor.PID)
@@ -282,7 +703,7 @@ func addToSenderLookup(m map[string]int32, pid *actor.PID, arr []*actor.PID) (in
 	}
 
 	max := int32(len(m))
-	key := pid.Address + "/" + pid.Id
+	key := pid.Key()
 	id, ok := m[key]
 	if !ok {
 		c, _ := proto.Clone(pid).(*actor.PID)
@@ -299,40 +720,305 @@ func (state *endpointWriter) Receive(ctx actor.Context) {
 	case *actor.Started:
 		state.initialize(ctx)
 	case *actor.Stopped:
-		plog.Debug("EndpointWriter stopped", log.String("address", state.address))
+		state.remote.Logger().Debug("EndpointWriter stopped", log.String("address", state.address))
+		switch {
+		case state.config.GuaranteeOrdering && state.isTerminated():
+			// a new endpoint to the address may already be sending, queued messages must not arrive after its messages
+			state.rejectTerminated()
+		case state.config.EndpointWriterDrainTimeout > 0:
+			state.drain(ctx)
+		default:
+			state.flushBuffered(ctx)
+			state.discardQueued()
+		}
 		state.closeClientConn()
 	case *actor.Restarting:
-		plog.Debug("EndpointWriter restarting", log.String("address", state.address))
+		state.remote.Logger().Debug("EndpointWriter restarting", log.String("address", state.address))
+		state.flushBuffered(ctx)
 		state.closeClientConn()
 	case *EndpointTerminatedEvent:
-		plog.Info("EndpointWriter received EndpointTerminatedEvent, stopping", log.String("address", state.address))
+		state.remote.Logger().Info("EndpointWriter received EndpointTerminatedEvent, stopping", log.String("address", state.address))
+		state.setTerminated()
 		ctx.Stop(ctx.Self())
+	case *heartbeatTick:
+		if state.stream != nil {
+			state.sendHeartbeat()
+		}
 	case *restartAfterConnectFailure:
-		plog.Debug("EndpointWriter initiating self-restart after failing to connect and a delay", log.String("address", state.address))
+		state.remote.Logger().Debug("EndpointWriter initiating self-restart after failing to connect and a delay", log.String("address", state.address))
 		panic(msg.err)
+	case priorityBatch:
+		// control messages are never coalesced, they must not wait behind buffered user messages
+		if err := state.sendEnvelopes(msg, ctx); err != nil {
+			state.retry(msg)
+		}
 	case []interface{}:
-		state.sendEnvelopes(msg, ctx)
+		if state.config.BatchFlushInterval > 0 {
+			state.coalesce(msg, ctx)
+		} else if err := state.sendEnvelopes(msg, ctx); err != nil {
+			state.retry(msg)
+		}
 	case actor.SystemMessage, actor.AutoReceiveMessage:
 		// ignore
 	default:
-		plog.Error("EndpointWriter received unknown message", log.String("address", state.address), log.TypeOf("type", msg), log.Message(msg))
+		state.remote.Logger().Error("EndpointWriter received unknown message", log.String("address", state.address), log.TypeOf("type", msg), log.Message(msg))
+	}
+}
+
+// flushBuffered sends coalesced messages while stopping or restarting, messages which cannot be sent are dead lettered
+func (state *endpointWriter) flushBuffered(ctx actor.Context) {
+	batch := state.takeBuffer()
+	if len(batch) == 0 {
+		return
+	}
+
+	if err := state.sendEnvelopes(batch, ctx); err != nil {
+		state.deadLetterBatch(batch)
+	}
+}
+
+// drain sends the messages still queued for the peer when stopping, for at most Config.EndpointWriterDrainTimeout.
+// Whatever cannot be sent in time is dead lettered with a RemoteDrainTimeout reason
+func (state *endpointWriter) drain(ctx actor.Context) {
+	deadline := time.Now().Add(state.config.EndpointWriterDrainTimeout)
+	batch := state.takeBuffer()
+
+	for {
+		deliveries := make([]interface{}, 0, len(batch))
+		for _, m := range batch {
+			if _, ok := m.(*remoteDeliver); ok {
+				deliveries = append(deliveries, m)
+			}
+		}
+
+		if len(deliveries) > 0 {
+			if state.stream == nil || time.Now().After(deadline) || state.sendEnvelopes(deliveries, ctx) != nil {
+				state.rejectDrain(deliveries)
+				break
+			}
+		}
+
+		var ok bool
+		if batch, ok = state.mailbox.popBatch(); !ok {
+			return
+		}
+	}
+
+	state.discardQueued()
+}
+
+// discardQueued dead letters the messages still queued for the peer with a RemoteDrainTimeout reason
+func (state *endpointWriter) discardQueued() {
+	for {
+		batch, ok := state.mailbox.popBatch()
+		if !ok {
+			return
+		}
+		state.rejectDrain(batch)
+	}
+}
+
+func (state *endpointWriter) setTerminated() {
+	atomic.StoreInt32(state.terminated, 1)
+}
+
+func (state *endpointWriter) isTerminated() bool {
+	return atomic.LoadInt32(state.terminated) == 1
+}
+
+// rejectTerminated dead letters the messages still queued when the endpoint terminated
+func (state *endpointWriter) rejectTerminated() {
+	state.deadLetterBatch(state.takeBuffer())
+	for {
+		batch, ok := state.mailbox.popBatch()
+		if !ok {
+			return
+		}
+		state.deadLetterBatch(batch)
+	}
+}
+
+func (state *endpointWriter) rejectDrain(batch []interface{}) {
+	state.remote.Logger().Info("EndpointWriter could not drain queued messages", log.String("address", state.address), log.Int("count", len(batch)))
+
+	for _, m := range batch {
+		if rd, ok := m.(*remoteDeliver); ok {
+			state.remote.actorSystem.EventStream.Publish(&actor.DeadLetterEvent{
+				PID: rd.target,
+				Message: &RemoteDrainTimeout{
+					Address: state.address,
+					Message: rd.message,
+				},
+				Sender: rd.sender,
+			})
+		}
+	}
+}
+
+// rejectUnconnected dead letters the messages queued while the endpoint has not connected
+// within Config.EndpointConnectGracePeriod, control messages are kept
+func (state *endpointWriter) rejectUnconnected() {
+	var keep []interface{}
+	rejected := 0
+
+	for {
+		batch, ok := state.mailbox.popBatch()
+		if !ok {
+			break
+		}
+
+		for _, m := range batch {
+			rd, ok := m.(*remoteDeliver)
+			if !ok {
+				keep = append(keep, m)
+				continue
+			}
+
+			rejected++
+			state.remote.actorSystem.EventStream.Publish(&actor.DeadLetterEvent{
+				PID: rd.target,
+				Message: &EndpointNotConnected{
+					Address: state.address,
+					Message: rd.message,
+				},
+				Sender: rd.sender,
+			})
+		}
+	}
+
+	state.mailbox.requeue(keep)
+	if rejected > 0 {
+		state.remote.Logger().Info("EndpointWriter not connected within grace period, dead lettered queued messages", log.String("address", state.address), log.Int("count", rejected))
 	}
 }
 
 func (state *endpointWriter) closeClientConn() {
-	plog.Info("EndpointWriter closing client connection", log.String("address", state.address))
-	if state.stream != nil {
-		err := state.stream.CloseSend()
+	state.remote.Logger().Info("EndpointWriter closing client connection", log.String("address", state.address))
+	atomic.StoreInt32(&state.closing, 1)
+	if state.heartbeatDone != nil {
+		close(state.heartbeatDone)
+		state.heartbeatDone = nil
+	}
+	if state.connected {
+		state.connected = false
+		state.metrics.disconnected()
+	}
+	for _, stream := range state.streams {
+		err := stream.CloseSend()
 		if err != nil {
-			plog.Error("EndpointWriter error when closing the stream", log.Error(err))
+			state.remote.Logger().Error("EndpointWriter error when closing the stream", log.Error(err))
 		}
-		state.stream = nil
-	}
-	if state.conn != nil {
-		err := state.conn.Close()
+		err = stream.Close()
 		if err != nil {
-			plog.Error("EndpointWriter error when closing the client conn", log.Error(err))
+			state.remote.Logger().Error("EndpointWriter error when closing the client conn", log.Error(err))
+		}
+	}
+	state.stream = nil
+	state.streams = nil
+}
+
+// rejectTooLarge dead letters a message which serialized to more than Config.MaxMessageSize bytes
+func (state *endpointWriter) rejectTooLarge(rd *remoteDeliver, message interface{}, typeName string, size int) {
+	state.remote.Logger().Error("EndpointWriter message exceeds maximum size", log.String("address", state.address), log.String("type", typeName), log.Int("size", size))
+	state.remote.actorSystem.EventStream.Publish(&actor.DeadLetterEvent{
+		PID: rd.target,
+		Message: &MessageTooLarge{
+			Address:  state.address,
+			Message:  message,
+			TypeName: typeName,
+			Size:     size,
+		},
+		Sender: rd.sender,
+	})
+}
+
+// rejectUnserializable dead letters a message which could not be serialized, without failing its batch
+func (state *endpointWriter) rejectUnserializable(rd *remoteDeliver, message interface{}, err error) {
+	state.metrics.serializationError()
+	state.remote.Logger().Error("EndpointWriter failed to serialize message", log.String("address", state.address), log.TypeOf("type", message), log.Error(err))
+	state.remote.actorSystem.EventStream.Publish(&actor.DeadLetterEvent{
+		PID: rd.target,
+		Message: &SerializationError{
+			Address: state.address,
+			Message: message,
+			Err:     err,
+		},
+		Sender: rd.sender,
+	})
+}
+
+// serializeFor serializes the message with a serializer the peer decodes. A message requiring a serializer, see
+// SerializerIdentifiable, is only serialized when the peer supports it. Otherwise the first serializer of the
+// fallback chain the peer supports is used, or DefaultSerializerID
+func (state *endpointWriter) serializeFor(message interface{}, serializerID int32) ([]byte, string, int32, error) {
+	if serializerID < 0 && state.remote.serializers != nil {
+		t := reflect.TypeOf(message)
+		if id, ok := state.fallbackSerializers[t]; ok {
+			serializerID = id
+		} else {
+			bytes, typeName, id, err := state.remote.serializers.serialize(message, state.peerSupports)
+			if err == nil {
+				if state.fallbackSerializers == nil {
+					state.fallbackSerializers = make(map[reflect.Type]int32)
+				}
+				state.fallbackSerializers[t] = id
+			}
+			if !errors.Is(err, ErrSerializerNotSupported) {
+				return bytes, typeName, id, err
+			}
 		}
-		state.conn = nil
 	}
+	if serializerID < 0 {
+		serializerID = DefaultSerializerID
+	}
+	if !state.peerSupports(serializerID) {
+		return nil, "", serializerID, fmt.Errorf("%w: %v is not decoded by %v", ErrSerializerNotSupported, serializerID, state.address)
+	}
+
+	bytes, typeName, err := serialize(message, serializerID)
+
+	return bytes, typeName, serializerID, err
+}
+
+// peerSupports tells whether the peer decodes the messages of the serializer, peers which did not announce their
+// serializers are assumed to decode them all
+func (state *endpointWriter) peerSupports(serializerID int32) bool {
+	return state.peerSerializers == nil || state.peerSerializers[serializerID]
+}
+
+func serializerSet(ids []int32) map[int32]bool {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	set := make(map[int32]bool, len(ids))
+	for _, id := range ids {
+		set[id] = true
+	}
+
+	return set
+}
+
+// serialize is Serialize, returning the panics of the serializer as errors
+func serialize(message interface{}, serializerID int32) (bytes []byte, typeName string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("remote: serializer panicked: %v", r)
+		}
+	}()
+
+	return Serialize(message, serializerID)
+}
+
+// rejectBackpressure dead letters a message which did not fit in the endpoint writer queue
+func rejectBackpressure(remote *Remote, address string, rd *remoteDeliver) {
+	remote.Logger().Debug("EndpointWriter queue is full, rejecting message", log.String("address", address), log.TypeOf("type", rd.message))
+	remote.actorSystem.EventStream.Publish(&actor.DeadLetterEvent{
+		PID: rd.target,
+		Message: &RemoteBackpressure{
+			Address: address,
+			Message: rd.message,
+		},
+		Sender: rd.sender,
+	})
 }
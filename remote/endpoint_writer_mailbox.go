@@ -3,6 +3,7 @@ package remote
 import (
 	"runtime"
 	"sync/atomic"
+	"time"
 
 	"github.com/asynkron/protoactor-go/actor"
 
@@ -21,8 +22,30 @@ const (
 	mailboxHasMoreMessages int32 = iota
 )
 
+// priorityBatch is a batch of control messages taken from the priority lane of the endpoint writer mailbox
+type priorityBatch []interface{}
+
+// ControlMessage is implemented by internal messages, such as the cluster gossip, which are sent to remote endpoints
+// ahead of the queued user messages and are not subject to backpressure. It is not meant for user messages,
+// which would bypass Config.EndpointWriterQueueSize
+type ControlMessage interface {
+	RemoteControlMessage()
+}
+
+// isPriorityMessage tells whether a message is control traffic, which is sent ahead of the queued user messages:
+// system messages such as watches and terminations, and ControlMessages
+func isPriorityMessage(message interface{}) bool {
+	switch message.(type) {
+	case actor.SystemMessage, ControlMessage:
+		return true
+	}
+
+	return false
+}
+
 type endpointWriterMailbox struct {
 	userMailbox     *goring.Queue
+	priorityMailbox *goring.Queue
 	systemMailbox   *mpsc.Queue
 	schedulerStatus int32
 	hasMoreMessages int32
@@ -30,14 +53,93 @@ type endpointWriterMailbox struct {
 	batchSize       int
 	dispatcher      actor.Dispatcher
 	suspended       bool
+	queueSize       int64
+	blockTimeout    time.Duration
+	capacity        chan struct{}
+	overflow        func(rd *remoteDeliver)
+	logger          log.Interface
+
+	// pending counts the messages of the user mailbox and of the retried batches, a slot is reserved before a
+	// message is pushed so concurrent senders cannot exceed queueSize
+	pending int64
+	// retries holds the batches which failed to send, they are invoked again ahead of the user mailbox.
+	// priorityRetries holds their control messages, which are invoked first and not counted in pending
+	retries         [][]interface{}
+	priorityRetries []interface{}
 }
 
 func (m *endpointWriterMailbox) PostUserMessage(message interface{}) {
+	// control messages skip both the backpressure and the queued user messages
+	if rd, ok := message.(*remoteDeliver); ok && isPriorityMessage(rd.message) {
+		m.priorityMailbox.Push(message)
+		m.schedule()
+		return
+	}
+
+	// only remote deliveries are subject to backpressure, control messages must always get through
+	if rd, ok := message.(*remoteDeliver); !ok {
+		atomic.AddInt64(&m.pending, 1)
+	} else if !m.waitForCapacity() {
+		m.overflow(rd)
+		return
+	}
+
 	// batching mailbox only use the message part
 	m.userMailbox.Push(message)
 	m.schedule()
 }
 
+// waitForCapacity reserves a slot for one more message in the user mailbox, it returns false if there is none.
+// If the high-water mark is reached, it blocks the sender for at most blockTimeout.
+func (m *endpointWriterMailbox) waitForCapacity() bool {
+	if m.reserve() {
+		return true
+	}
+
+	if m.blockTimeout <= 0 {
+		return false
+	}
+
+	timer := time.NewTimer(m.blockTimeout)
+	defer timer.Stop()
+
+	for !m.reserve() {
+		select {
+		case <-m.capacity:
+		case <-timer.C:
+			return false
+		}
+	}
+
+	// pass the signal on to any other blocked sender
+	m.signalCapacity(0)
+
+	return true
+}
+
+// reserve takes a slot in the user mailbox without blocking, it returns false if the high-water mark is reached
+func (m *endpointWriterMailbox) reserve() bool {
+	for {
+		pending := atomic.LoadInt64(&m.pending)
+		if m.queueSize > 0 && pending >= m.queueSize {
+			return false
+		}
+		if atomic.CompareAndSwapInt64(&m.pending, pending, pending+1) {
+			return true
+		}
+	}
+}
+
+// signalCapacity releases the slots of the messages taken out of the mailbox and wakes up a blocked sender
+func (m *endpointWriterMailbox) signalCapacity(released int) {
+	atomic.AddInt64(&m.pending, -int64(released))
+
+	select {
+	case m.capacity <- struct{}{}:
+	default:
+	}
+}
+
 func (m *endpointWriterMailbox) PostSystemMessage(message interface{}) {
 	m.systemMailbox.Push(message)
 	m.schedule()
@@ -80,7 +182,7 @@ func (m *endpointWriterMailbox) run() {
 	var msg interface{}
 	defer func() {
 		if r := recover(); r != nil {
-			plog.Info("[ACTOR] Recovering", log.Object("actor", m.invoker), log.Object("reason", r), log.Stack())
+			m.logger.Info("[ACTOR] Recovering", log.Object("actor", m.invoker), log.Object("reason", r), log.Stack())
 			m.invoker.EscalateFailure(r, msg)
 		}
 	}()
@@ -93,6 +195,9 @@ func (m *endpointWriterMailbox) run() {
 				m.suspended = true
 			case *actor.ResumeMailbox:
 				m.suspended = false
+			case *heartbeatTick:
+				// handled by the endpoint writer itself, the actor context does not know it
+				m.invoker.InvokeUserMessage(msg)
 			default:
 				m.invoker.InvokeSystemMessage(msg)
 			}
@@ -105,8 +210,21 @@ func (m *endpointWriterMailbox) run() {
 			return
 		}
 
-		var ok bool
-		if msg, ok = m.userMailbox.PopMany(int64(m.batchSize)); ok {
+		if batch, ok := m.popPriority(); ok {
+			msg = priorityBatch(batch)
+			m.invoker.InvokeUserMessage(msg)
+			continue
+		}
+
+		if batch, ok := m.popRetry(); ok {
+			msg = batch
+			m.invoker.InvokeUserMessage(msg)
+			continue
+		}
+
+		if batch, ok := m.userMailbox.PopMany(int64(m.batchSize)); ok {
+			msg = batch
+			m.signalCapacity(len(batch))
 			m.invoker.InvokeUserMessage(msg)
 		} else {
 			return
@@ -116,20 +234,151 @@ func (m *endpointWriterMailbox) run() {
 	}
 }
 
+// popBatch removes the next batch of pending user messages, it must only be called from within the mailbox processing
+func (m *endpointWriterMailbox) popBatch() ([]interface{}, bool) {
+	if batch, ok := m.popPriority(); ok {
+		return batch, true
+	}
+
+	if batch, ok := m.popRetry(); ok {
+		return batch, true
+	}
+
+	batch, ok := m.userMailbox.PopMany(int64(m.batchSize))
+	if ok {
+		m.signalCapacity(len(batch))
+	}
+
+	return batch, ok
+}
+
+// popPriority removes the next batch of control messages, those of failed batches first
+func (m *endpointWriterMailbox) popPriority() ([]interface{}, bool) {
+	if len(m.priorityRetries) > 0 {
+		batch := m.priorityRetries
+		m.priorityRetries = nil
+
+		return batch, true
+	}
+
+	return m.priorityMailbox.PopMany(int64(m.batchSize))
+}
+
+// retry puts a batch which failed to send back in front of the user mailbox, to be invoked again once the endpoint
+// writer restarted. As when posted, control messages are sent first and are not counted against the queue size.
+// The remote deliveries which no longer fit in the queue are rejected as on overflow, so a peer
+// which keeps failing does not accumulate messages. It must only be called from within the mailbox processing
+func (m *endpointWriterMailbox) retry(batch []interface{}) {
+	kept := make([]interface{}, 0, len(batch))
+	for _, message := range batch {
+		switch msg := message.(type) {
+		case nil:
+			// already sent
+		case *remoteDeliver:
+			if isPriorityMessage(msg.message) {
+				m.priorityRetries = append(m.priorityRetries, msg)
+				continue
+			}
+			if !m.reserve() {
+				m.overflow(msg)
+				continue
+			}
+			kept = append(kept, msg)
+		default:
+			atomic.AddInt64(&m.pending, 1)
+			kept = append(kept, msg)
+		}
+	}
+
+	if len(kept) > 0 {
+		m.retries = append(m.retries, kept)
+	}
+}
+
+func (m *endpointWriterMailbox) popRetry() ([]interface{}, bool) {
+	if len(m.retries) == 0 {
+		return nil, false
+	}
+
+	batch := m.retries[0]
+	m.retries[0] = nil
+	m.retries = m.retries[1:]
+	m.signalCapacity(len(batch))
+
+	return batch, true
+}
+
+// endpointWriterMailboxRef gives the endpoint writer access to its mailbox, so it can drain it when stopping
+type endpointWriterMailboxRef struct {
+	mailbox *endpointWriterMailbox
+}
+
+func (r *endpointWriterMailboxRef) capture(producer actor.MailboxProducer) actor.MailboxProducer {
+	return func() actor.Mailbox {
+		mb := producer()
+		r.mailbox, _ = mb.(*endpointWriterMailbox)
+
+		return mb
+	}
+}
+
+func (r *endpointWriterMailboxRef) popBatch() ([]interface{}, bool) {
+	if r == nil || r.mailbox == nil {
+		return nil, false
+	}
+
+	return r.mailbox.popBatch()
+}
+
+// requeue puts messages taken with popBatch back at the end of the mailbox, it must only be called from within the mailbox processing
+func (r *endpointWriterMailboxRef) requeue(messages []interface{}) {
+	if r == nil || r.mailbox == nil {
+		return
+	}
+
+	for _, m := range messages {
+		atomic.AddInt64(&r.mailbox.pending, 1)
+		r.mailbox.userMailbox.Push(m)
+	}
+}
+
+// retry hands a batch which failed to send back to the mailbox, see endpointWriterMailbox.retry
+func (r *endpointWriterMailboxRef) retry(batch []interface{}) {
+	if r == nil || r.mailbox == nil {
+		return
+	}
+
+	r.mailbox.retry(batch)
+}
+
+func (r *endpointWriterMailboxRef) userMessageCount() int {
+	if r == nil || r.mailbox == nil {
+		return 0
+	}
+
+	return r.mailbox.UserMessageCount()
+}
+
 func (m *endpointWriterMailbox) UserMessageCount() int {
-	return int(m.userMailbox.Length())
+	return int(m.priorityMailbox.Length() + atomic.LoadInt64(&m.pending))
 }
 
-func endpointWriterMailboxProducer(batchSize, initialSize int) actor.MailboxProducer {
+func endpointWriterMailboxProducer(batchSize, queueSize int, blockTimeout time.Duration, overflow func(rd *remoteDeliver), logger log.Interface) actor.MailboxProducer {
 	return func() actor.Mailbox {
-		userMailbox := goring.New(int64(initialSize))
+		userMailbox := goring.New(int64(queueSize))
 		systemMailbox := mpsc.New()
 		return &endpointWriterMailbox{
 			userMailbox:     userMailbox,
+			priorityMailbox: goring.New(10),
 			systemMailbox:   systemMailbox,
 			hasMoreMessages: mailboxHasNoMessages,
 			schedulerStatus: mailboxIdle,
 			batchSize:       batchSize,
+			queueSize:       int64(queueSize),
+			blockTimeout:    blockTimeout,
+			capacity:        make(chan struct{}, 1),
+			overflow:        overflow,
+			logger:          logger,
 		}
 	}
 }
@@ -0,0 +1,168 @@
+package remote
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type idleDispatcher struct{}
+
+func (idleDispatcher) Schedule(func()) {}
+func (idleDispatcher) Throughput() int { return 0 }
+
+func TestEndpointWriterMailbox_RejectsWhenFull(t *testing.T) {
+	var rejected []*remoteDeliver
+	mb := endpointWriterMailboxProducer(10, 2, 0, func(rd *remoteDeliver) {
+		rejected = append(rejected, rd)
+	}, plog)()
+	mb.RegisterHandlers(nil, idleDispatcher{})
+
+	for i := 0; i < 3; i++ {
+		mb.PostUserMessage(&remoteDeliver{message: i})
+	}
+
+	assert.Equal(t, 2, mb.UserMessageCount())
+	assert.Len(t, rejected, 1)
+	assert.Equal(t, 2, rejected[0].message)
+
+	// control messages are never rejected
+	mb.PostUserMessage(&EndpointTerminatedEvent{})
+	assert.Equal(t, 3, mb.UserMessageCount())
+}
+
+func TestEndpointWriterMailbox_BlocksUntilCapacity(t *testing.T) {
+	mb := endpointWriterMailboxProducer(10, 1, time.Second, func(rd *remoteDeliver) {
+		t.Error("message should not be rejected")
+	}, plog)().(*endpointWriterMailbox)
+	mb.RegisterHandlers(nil, idleDispatcher{})
+
+	mb.PostUserMessage(&remoteDeliver{message: 1})
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		mb.userMailbox.Pop()
+		mb.signalCapacity(1)
+	}()
+
+	mb.PostUserMessage(&remoteDeliver{message: 2})
+	assert.Equal(t, 1, mb.UserMessageCount())
+}
+
+type recordingInvoker struct {
+	messages []interface{}
+}
+
+func (r *recordingInvoker) InvokeSystemMessage(interface{}) {}
+func (r *recordingInvoker) InvokeUserMessage(message interface{}) {
+	r.messages = append(r.messages, message)
+}
+func (r *recordingInvoker) EscalateFailure(interface{}, interface{}) {}
+
+func TestEndpointWriterMailbox_SendsControlMessagesFirst(t *testing.T) {
+	mb := endpointWriterMailboxProducer(10, 2, 0, func(rd *remoteDeliver) {
+		t.Error("control messages should not be rejected")
+	}, plog)().(*endpointWriterMailbox)
+	invoker := &recordingInvoker{}
+	mb.RegisterHandlers(invoker, idleDispatcher{})
+
+	mb.PostUserMessage(&remoteDeliver{message: 1})
+	mb.PostUserMessage(&remoteDeliver{message: 2})
+
+	// the user mailbox is full, control messages still get through
+	watch := &remoteDeliver{message: &actor.Watch{}}
+	mb.PostUserMessage(watch)
+	assert.Equal(t, 3, mb.UserMessageCount())
+
+	mb.processMessages()
+
+	assert.Equal(t, []interface{}{
+		priorityBatch{watch},
+		[]interface{}{&remoteDeliver{message: 1}, &remoteDeliver{message: 2}},
+	}, invoker.messages)
+}
+
+func TestIsPriorityMessage(t *testing.T) {
+	assert.True(t, isPriorityMessage(&actor.Terminated{}))
+	assert.True(t, isPriorityMessage(&actor.Unwatch{}))
+	assert.False(t, isPriorityMessage(&actor.PID{}))
+	assert.False(t, isPriorityMessage("hello"))
+	// a user message cannot skip the backpressure by raising its priority
+	assert.False(t, isPriorityMessage(urgentMessage{}))
+}
+
+type urgentMessage struct{}
+
+func (urgentMessage) GetPriority() int8 { return actor.DefaultPriority + 1 }
+
+func TestEndpointWriterMailbox_ConcurrentSendersStayWithinQueueSize(t *testing.T) {
+	var rejected int32
+	mb := endpointWriterMailboxProducer(10, 10, 0, func(rd *remoteDeliver) {
+		atomic.AddInt32(&rejected, 1)
+	}, plog)()
+	mb.RegisterHandlers(nil, idleDispatcher{})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				mb.PostUserMessage(&remoteDeliver{message: j})
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 10, mb.UserMessageCount())
+	assert.Equal(t, int32(790), atomic.LoadInt32(&rejected))
+}
+
+func TestEndpointWriterMailbox_RetriesFailedBatchWithinQueueSize(t *testing.T) {
+	var rejected []*remoteDeliver
+	mb := endpointWriterMailboxProducer(10, 2, 0, func(rd *remoteDeliver) {
+		rejected = append(rejected, rd)
+	}, plog)().(*endpointWriterMailbox)
+	mb.RegisterHandlers(nil, idleDispatcher{})
+
+	mb.PostUserMessage(&remoteDeliver{message: 1})
+	terminated := &EndpointTerminatedEvent{}
+	mb.retry([]interface{}{&remoteDeliver{message: 2}, nil, &remoteDeliver{message: 3}, terminated})
+
+	// the retried batch goes first, the delivery which no longer fits is rejected
+	assert.Equal(t, 3, mb.UserMessageCount())
+	require.Len(t, rejected, 1)
+	assert.Equal(t, 3, rejected[0].message)
+
+	batch, ok := mb.popBatch()
+	require.True(t, ok)
+	assert.Equal(t, []interface{}{&remoteDeliver{message: 2}, terminated}, batch)
+	batch, ok = mb.popBatch()
+	require.True(t, ok)
+	assert.Equal(t, []interface{}{&remoteDeliver{message: 1}}, batch)
+	assert.Equal(t, 0, mb.UserMessageCount())
+}
+
+func TestEndpointWriterMailbox_RetriesControlMessagesFirstWithoutCountingThem(t *testing.T) {
+	mb := endpointWriterMailboxProducer(10, 1, 0, func(rd *remoteDeliver) {
+		t.Error("control messages should not be rejected")
+	}, plog)().(*endpointWriterMailbox)
+	mb.RegisterHandlers(nil, idleDispatcher{})
+
+	mb.PostUserMessage(&remoteDeliver{message: 1})
+	watch := &remoteDeliver{message: &actor.Watch{}}
+	mb.retry(priorityBatch{watch})
+	assert.Equal(t, 1, mb.UserMessageCount())
+
+	batch, ok := mb.popBatch()
+	require.True(t, ok)
+	assert.Equal(t, []interface{}{watch}, batch)
+	batch, ok = mb.popBatch()
+	require.True(t, ok)
+	assert.Equal(t, []interface{}{&remoteDeliver{message: 1}}, batch)
+}
@@ -0,0 +1,525 @@
+package remote
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeConnection struct {
+	sent []*RemoteMessage
+	err  error
+}
+
+func (c *fakeConnection) Send(m *RemoteMessage) error {
+	if c.err != nil {
+		return c.err
+	}
+	c.sent = append(c.sent, m)
+
+	return nil
+}
+
+func (c *fakeConnection) Recv() (*RemoteMessage, error) { return nil, errors.New("not implemented") }
+
+func (c *fakeConnection) CloseSend() error { return nil }
+
+func (c *fakeConnection) Close() error { return nil }
+
+func newTestEndpointWriter(system *actor.ActorSystem, stream RemoteConnection, messages ...interface{}) *endpointWriter {
+	config := Configure("localhost", 0, WithEndpointWriterDrainTimeout(time.Second))
+	mailbox := &endpointWriterMailboxRef{}
+	mb := mailbox.capture(endpointWriterMailboxProducer(config.EndpointWriterBatchSize, 10, 0, nil, plog))()
+	mb.RegisterHandlers(nil, idleDispatcher{})
+	for _, m := range messages {
+		mb.PostUserMessage(m)
+	}
+
+	writer := &endpointWriter{
+		address:    "peer",
+		config:     config,
+		remote:     &Remote{actorSystem: system, config: config},
+		mailbox:    mailbox,
+		terminated: new(int32),
+		stats:      &endpointStats{mailbox: mailbox},
+	}
+	if stream != nil {
+		writer.stream = stream
+		writer.streams = []RemoteConnection{stream}
+	}
+
+	return writer
+}
+
+func TestEndpointWriter_DrainSendsQueuedMessages(t *testing.T) {
+	system := actor.NewActorSystem()
+	stream := &fakeConnection{}
+	target := actor.NewPID("peer", "target")
+	writer := newTestEndpointWriter(system, stream,
+		&remoteDeliver{message: target, target: target, serializerID: -1},
+		&remoteDeliver{message: target, target: target, serializerID: -1},
+	)
+
+	writer.drain(nil)
+
+	assert.Len(t, stream.sent, 1)
+	assert.Len(t, stream.sent[0].GetMessageBatch().Envelopes, 2)
+}
+
+func TestEndpointWriter_DrainDeadLettersOnFailure(t *testing.T) {
+	system := actor.NewActorSystem()
+	stream := &fakeConnection{err: errors.New("broken")}
+	target := actor.NewPID("peer", "target")
+
+	var rejected []*RemoteDrainTimeout
+	system.EventStream.Subscribe(func(evt interface{}) {
+		if dl, ok := evt.(*actor.DeadLetterEvent); ok {
+			if m, ok := dl.Message.(*RemoteDrainTimeout); ok {
+				rejected = append(rejected, m)
+			}
+		}
+	})
+
+	writer := newTestEndpointWriter(system, stream,
+		&remoteDeliver{message: target, target: target, serializerID: -1},
+		&remoteDeliver{message: target, target: target, serializerID: -1},
+	)
+
+	writer.drain(nil)
+
+	assert.Len(t, rejected, 2)
+	assert.Equal(t, "peer", rejected[0].Address)
+}
+
+// messageContext is the context of a message handled by an endpoint writer in tests, its other methods are not implemented
+type messageContext struct {
+	actor.Context
+	message interface{}
+}
+
+func (c messageContext) Message() interface{} { return c.message }
+
+func TestEndpointWriter_StopWithoutDrainTimeout(t *testing.T) {
+	system := actor.NewActorSystem()
+	stream := &fakeConnection{}
+	target := actor.NewPID("peer", "target")
+
+	var rejected []*RemoteDrainTimeout
+	system.EventStream.Subscribe(func(evt interface{}) {
+		if dl, ok := evt.(*actor.DeadLetterEvent); ok {
+			if m, ok := dl.Message.(*RemoteDrainTimeout); ok {
+				rejected = append(rejected, m)
+			}
+		}
+	})
+
+	writer := newTestEndpointWriter(system, stream, &remoteDeliver{message: target, target: target, serializerID: -1})
+	writer.config.EndpointWriterDrainTimeout = 0
+	writer.buffer = []interface{}{&remoteDeliver{message: target, target: target, serializerID: -1}}
+
+	writer.Receive(messageContext{message: &actor.Stopped{}})
+
+	// the coalesced message is sent, the queued one is dead lettered
+	assert.Len(t, stream.sent, 1)
+	assert.Len(t, stream.sent[0].GetMessageBatch().Envelopes, 1)
+	assert.Len(t, rejected, 1)
+	assert.Equal(t, 0, writer.mailbox.userMessageCount())
+}
+
+func TestEndpointWriter_SerializationErrorDeadLettersOnlyTheMessage(t *testing.T) {
+	system := actor.NewActorSystem()
+	stream := &fakeConnection{}
+	target := actor.NewPID("peer", "target")
+
+	var rejected []*SerializationError
+	system.EventStream.Subscribe(func(evt interface{}) {
+		if dl, ok := evt.(*actor.DeadLetterEvent); ok {
+			if m, ok := dl.Message.(*SerializationError); ok {
+				rejected = append(rejected, m)
+			}
+		}
+	})
+
+	writer := newTestEndpointWriter(system, stream)
+	err := writer.sendEnvelopes([]interface{}{
+		&remoteDeliver{message: target, target: target, serializerID: -1},
+		&remoteDeliver{message: "not a proto message", target: target, serializerID: -1},
+		&remoteDeliver{message: target, target: target, serializerID: -1},
+	}, nil)
+
+	assert.NoError(t, err)
+	assert.Len(t, stream.sent, 1)
+	assert.Len(t, stream.sent[0].GetMessageBatch().Envelopes, 2)
+	assert.Len(t, rejected, 1)
+	assert.Equal(t, "not a proto message", rejected[0].Message)
+	assert.Error(t, rejected[0].Err)
+}
+
+func TestEndpointWriter_SkipsMessagesPastTheirDeadline(t *testing.T) {
+	system := actor.NewActorSystem()
+	stream := &fakeConnection{}
+	target := actor.NewPID("peer", "target")
+
+	responses := make(chan interface{}, 1)
+	sender := system.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+		if _, ok := ctx.Message().(*actor.DeadLetterResponse); ok {
+			responses <- ctx.Message()
+		}
+	}))
+
+	expired, pending := actor.NewMessageHeader(), actor.NewMessageHeader()
+	actor.SetDeadline(expired, time.Now().Add(-time.Millisecond))
+	actor.SetDeadline(pending, time.Now().Add(time.Minute))
+
+	writer := newTestEndpointWriter(system, stream)
+	err := writer.sendEnvelopes([]interface{}{
+		&remoteDeliver{header: expired, message: target, target: target, sender: sender, serializerID: -1},
+		&remoteDeliver{header: pending, message: target, target: target, sender: sender, serializerID: -1},
+	}, nil)
+
+	assert.NoError(t, err)
+	assert.Len(t, stream.sent, 1)
+	assert.Len(t, stream.sent[0].GetMessageBatch().Envelopes, 1)
+	select {
+	case <-responses:
+	case <-time.After(time.Second):
+		t.Fatal("the sender of the expired message was not answered")
+	}
+}
+
+func TestEndpointWriter_SelectsSerializersThePeerSupports(t *testing.T) {
+	system := actor.NewActorSystem()
+	target := actor.NewPID("peer", "target")
+
+	var rejected []*SerializationError
+	system.EventStream.Subscribe(func(evt interface{}) {
+		if dl, ok := evt.(*actor.DeadLetterEvent); ok {
+			if m, ok := dl.Message.(*SerializationError); ok {
+				rejected = append(rejected, m)
+			}
+		}
+	})
+
+	send := func(peerSerializers []int32) *MessageBatch {
+		stream := &fakeConnection{}
+		writer := newTestEndpointWriter(system, stream)
+		writer.remote.serializers = newSerializerChain([]int32{1, DefaultSerializerID})
+		writer.peerSerializers = serializerSet(peerSerializers)
+		err := writer.sendEnvelopes([]interface{}{
+			&remoteDeliver{message: target, target: target, serializerID: -1},
+			&remoteDeliver{message: target, target: target, serializerID: 1},
+		}, nil)
+		assert.NoError(t, err)
+		assert.Len(t, stream.sent, 1)
+
+		return stream.sent[0].GetMessageBatch()
+	}
+
+	// peers which did not announce their serializers are assumed to support them all
+	batch := send(nil)
+	assert.Len(t, batch.Envelopes, 2)
+	assert.Equal(t, int32(1), batch.Envelopes[0].SerializerId)
+	assert.Empty(t, rejected)
+
+	batch = send([]int32{DefaultSerializerID})
+	assert.Len(t, batch.Envelopes, 1)
+	assert.Equal(t, DefaultSerializerID, batch.Envelopes[0].SerializerId)
+	assert.Len(t, rejected, 1)
+	assert.ErrorIs(t, rejected[0].Err, ErrSerializerNotSupported)
+}
+
+func TestEndpointWriter_SerializerFallbackPerEndpoint(t *testing.T) {
+	system := actor.NewActorSystem()
+	chain := newSerializerChain([]int32{1, DefaultSerializerID})
+	target := actor.NewPID("peer", "target")
+
+	send := func(writer *endpointWriter, stream *fakeConnection) int32 {
+		err := writer.sendEnvelopes([]interface{}{&remoteDeliver{message: target, target: target, serializerID: -1}}, nil)
+		assert.NoError(t, err)
+		batch := stream.sent[len(stream.sent)-1].GetMessageBatch()
+		assert.Len(t, batch.Envelopes, 1)
+
+		return batch.Envelopes[0].SerializerId
+	}
+
+	restrictedStream := &fakeConnection{}
+	restricted := newTestEndpointWriter(system, restrictedStream)
+	restricted.remote.serializers = chain
+	restricted.peerSerializers = serializerSet([]int32{DefaultSerializerID})
+
+	stream := &fakeConnection{}
+	writer := newTestEndpointWriter(system, stream)
+	writer.remote.serializers = chain
+
+	// the serializer resolved for a peer which only decodes DefaultSerializerID is not used for the other peers
+	assert.Equal(t, DefaultSerializerID, send(restricted, restrictedStream))
+	assert.Equal(t, int32(1), send(writer, stream))
+	assert.Equal(t, DefaultSerializerID, send(restricted, restrictedStream))
+	assert.Equal(t, int32(1), send(writer, stream))
+}
+
+// blockingConnection never answers the connect request
+type blockingConnection struct {
+	closed chan struct{}
+}
+
+func (c *blockingConnection) Send(*RemoteMessage) error { return nil }
+
+func (c *blockingConnection) Recv() (*RemoteMessage, error) {
+	<-c.closed
+	return nil, errors.New("closed")
+}
+
+func (c *blockingConnection) CloseSend() error { return nil }
+
+func (c *blockingConnection) Close() error {
+	select {
+	case <-c.closed:
+	default:
+		close(c.closed)
+	}
+
+	return nil
+}
+
+func TestEndpointWriter_HandshakeTimeout(t *testing.T) {
+	system := actor.NewActorSystem()
+	writer := newTestEndpointWriter(system, nil)
+	writer.config.ConnectTimeout = 10 * time.Millisecond
+
+	_, err := writer.handshake(&blockingConnection{closed: make(chan struct{})})
+
+	assert.ErrorIs(t, err, ErrConnectTimeout)
+}
+
+type scriptedConnection struct {
+	fakeConnection
+	err error
+}
+
+func (c *scriptedConnection) Recv() (*RemoteMessage, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	return &RemoteMessage{MessageType: &RemoteMessage_DisconnectRequest{DisconnectRequest: &DisconnectRequest{}}}, nil
+}
+
+func TestEndpointWriter_ReceiveLoopExits(t *testing.T) {
+	for name, err := range map[string]error{"eof": io.EOF, "error": errors.New("broken"), "disconnect": nil} {
+		t.Run(name, func(t *testing.T) {
+			system := actor.NewActorSystem()
+			writer := newTestEndpointWriter(system, nil)
+
+			var terminated []*EndpointTerminatedEvent
+			system.EventStream.Subscribe(func(evt interface{}) {
+				if e, ok := evt.(*EndpointTerminatedEvent); ok {
+					terminated = append(terminated, e)
+				}
+			})
+
+			done := make(chan struct{})
+			go func() {
+				writer.receiveLoop(&scriptedConnection{err: err})
+				close(done)
+			}()
+
+			select {
+			case <-done:
+			case <-time.After(time.Second):
+				t.Fatal("receive loop did not exit")
+			}
+
+			if assert.Len(t, terminated, 1) {
+				assert.Equal(t, name != "error", terminated[0].Graceful)
+			}
+		})
+	}
+}
+
+// channelConnection receives the messages of its channel, closing the connection completes the stream
+type channelConnection struct {
+	fakeConnection
+	recv chan *RemoteMessage
+	once sync.Once
+}
+
+func (c *channelConnection) Recv() (*RemoteMessage, error) {
+	if m, ok := <-c.recv; ok {
+		return m, nil
+	}
+
+	return nil, io.EOF
+}
+
+func (c *channelConnection) Close() error {
+	c.once.Do(func() { close(c.recv) })
+
+	return nil
+}
+
+func TestEndpointWriter_ReceiveLoopIgnoresUnexpectedMessages(t *testing.T) {
+	system := actor.NewActorSystem()
+	conn := &channelConnection{recv: make(chan *RemoteMessage)}
+	writer := newTestEndpointWriter(system, conn)
+
+	var terminated int32
+	system.EventStream.Subscribe(func(evt interface{}) {
+		if _, ok := evt.(*EndpointTerminatedEvent); ok {
+			atomic.AddInt32(&terminated, 1)
+		}
+	})
+
+	baseline := runtime.NumGoroutine()
+	done := make(chan struct{})
+	go func() {
+		writer.receiveLoop(conn)
+		close(done)
+	}()
+
+	conn.recv <- &RemoteMessage{MessageType: &RemoteMessage_ConnectResponse{ConnectResponse: &ConnectResponse{}}}
+	select {
+	case <-done:
+		t.Fatal("receive loop exited on a message which is no DisconnectRequest")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// terminating the endpoint closes the stream, which stops the receive loop
+	writer.closeClientConn()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("receive loop did not exit")
+	}
+	// polled in the test goroutine, as assert.Eventually runs the condition in a goroutine of its own
+	for deadline := time.Now().Add(time.Second); runtime.NumGoroutine() > baseline && time.Now().Before(deadline); {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.LessOrEqual(t, runtime.NumGoroutine(), baseline)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&terminated))
+}
+
+func TestEndpointWriter_MessageTooLarge(t *testing.T) {
+	system := actor.NewActorSystem()
+	stream := &fakeConnection{}
+	small := actor.NewPID("peer", "small")
+	large := actor.NewPID("peer", "a-pid-with-a-much-longer-identifier")
+	writer := newTestEndpointWriter(system, stream)
+	writer.config.MaxMessageSize = 20
+
+	var rejected []*MessageTooLarge
+	system.EventStream.Subscribe(func(evt interface{}) {
+		if dl, ok := evt.(*actor.DeadLetterEvent); ok {
+			if m, ok := dl.Message.(*MessageTooLarge); ok {
+				rejected = append(rejected, m)
+			}
+		}
+	})
+
+	err := writer.sendEnvelopes([]interface{}{
+		&remoteDeliver{message: small, target: small, serializerID: -1},
+		&remoteDeliver{message: large, target: large, serializerID: -1},
+	}, nil)
+
+	assert.NoError(t, err)
+	assert.Len(t, stream.sent[0].GetMessageBatch().Envelopes, 1)
+	assert.Len(t, rejected, 1)
+	assert.Equal(t, "actor.PID", rejected[0].TypeName)
+	assert.Greater(t, rejected[0].Size, 20)
+}
+
+func TestEndpointWriter_ShardsByTarget(t *testing.T) {
+	system := actor.NewActorSystem()
+	streams := []*fakeConnection{{}, {}, {}}
+	writer := newTestEndpointWriter(system, streams[0])
+	writer.streams = []RemoteConnection{streams[0], streams[1], streams[2]}
+
+	var msg []interface{}
+	for i := 0; i < 30; i++ {
+		target := actor.NewPID("peer", fmt.Sprint(i%10))
+		msg = append(msg, &remoteDeliver{message: actor.NewPID("peer", fmt.Sprint(i)), target: target, serializerID: -1})
+	}
+
+	assert.NoError(t, writer.sendEnvelopes(msg, nil))
+
+	total := 0
+	shardOf := map[string]int{}
+	for i, stream := range streams {
+		for _, m := range stream.sent {
+			batch := m.GetMessageBatch()
+			total += len(batch.Envelopes)
+			for _, target := range batch.Targets {
+				_, seen := shardOf[target.Id]
+				assert.False(t, seen, "target %v sent on several streams", target.Id)
+				shardOf[target.Id] = i
+			}
+		}
+	}
+	assert.Equal(t, 30, total)
+	assert.Len(t, shardOf, 10)
+}
+
+func TestEndpointWriter_RetriesOnlyTheFailedShards(t *testing.T) {
+	system := actor.NewActorSystem()
+	streams := []*fakeConnection{{}, {err: errors.New("broken")}}
+	writer := newTestEndpointWriter(system, streams[0])
+	writer.streams = []RemoteConnection{streams[0], streams[1]}
+
+	var msg []interface{}
+	for i := 0; i < 10; i++ {
+		target := actor.NewPID("peer", fmt.Sprint(i))
+		msg = append(msg, &remoteDeliver{message: target, target: target, serializerID: -1})
+	}
+
+	assert.Error(t, writer.sendEnvelopes(msg, nil))
+	streams[1].err = nil
+	assert.NoError(t, writer.sendEnvelopes(msg, nil))
+
+	delivered := map[string]int{}
+	for _, stream := range streams {
+		for _, m := range stream.sent {
+			batch := m.GetMessageBatch()
+			for _, envelope := range batch.Envelopes {
+				delivered[batch.Targets[envelope.Target].Id]++
+			}
+		}
+	}
+	assert.Len(t, delivered, 10)
+	for target, count := range delivered {
+		assert.Equal(t, 1, count, "target %v", target)
+	}
+}
+
+func TestEndpointWriter_RejectUnconnected(t *testing.T) {
+	system := actor.NewActorSystem()
+	target := actor.NewPID("peer", "target")
+	writer := newTestEndpointWriter(system, nil,
+		&remoteDeliver{message: target, target: target, serializerID: -1},
+		&EndpointTerminatedEvent{Address: "peer"},
+		&remoteDeliver{message: target, target: target, serializerID: -1},
+	)
+
+	var rejected []*actor.DeadLetterEvent
+	system.EventStream.Subscribe(func(evt interface{}) {
+		if dl, ok := evt.(*actor.DeadLetterEvent); ok {
+			if _, ok := dl.Message.(*EndpointNotConnected); ok {
+				rejected = append(rejected, dl)
+			}
+		}
+	})
+
+	writer.rejectUnconnected()
+
+	assert.Len(t, rejected, 2)
+	assert.Equal(t, target, rejected[0].PID)
+	remaining, _ := writer.mailbox.popBatch()
+	assert.Equal(t, []interface{}{&EndpointTerminatedEvent{Address: "peer"}}, remaining)
+}
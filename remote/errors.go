@@ -6,6 +6,7 @@ var (
 	ErrProcessNameAlreadyExist = &ResponseError{ResponseStatusCodePROCESSNAMEALREADYEXIST}
 	ErrDeadLetter              = &ResponseError{ResponseStatusCodeDeadLetter}
 	ErrUnknownError            = &ResponseError{ResponseStatusCodeERROR}
+	ErrForbidden               = &ResponseError{ResponseStatusCodeFORBIDDEN}
 )
 
 // ResponseError is an error type.
@@ -0,0 +1,95 @@
+package remote
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+var (
+	gobTypes   = map[string]reflect.Type{}
+	gobTypesMu sync.RWMutex
+)
+
+// RegisterGobType registers the types of the given messages with the gob serializer, so that messages of these
+// types are deserialized by name. The types of the messages serialized with gob are registered as well
+func RegisterGobType(messages ...interface{}) {
+	gobTypesMu.Lock()
+	defer gobTypesMu.Unlock()
+
+	for _, message := range messages {
+		t := reflect.TypeOf(message)
+		gobTypes[gobTypeName(t)] = t
+	}
+}
+
+// gobTypeName is the package qualified name of the type, such as *github.com/org/app/messages.Hello
+func gobTypeName(t reflect.Type) string {
+	if t.Kind() == reflect.Ptr {
+		return "*" + gobTypeName(t.Elem())
+	}
+
+	if t.PkgPath() == "" {
+		return t.String()
+	}
+
+	return t.PkgPath() + "." + t.Name()
+}
+
+type gobSerializer struct{}
+
+// NewGobSerializer creates a serializer writing messages with encoding/gob, for message types which are not
+// protobuf messages. Register it under an id with Remote.RegisterSerializer and list it in WithSerializerFallback,
+// the receiving nodes resolve the types registered with RegisterGobType
+func NewGobSerializer() Serializer {
+	return &gobSerializer{}
+}
+
+func (g *gobSerializer) Serialize(msg interface{}) ([]byte, error) {
+	if msg == nil {
+		return nil, fmt.Errorf("msg must not be nil")
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(msg); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (g *gobSerializer) Deserialize(typeName string, b []byte) (interface{}, error) {
+	gobTypesMu.RLock()
+	t, ok := gobTypes[typeName]
+	gobTypesMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %v", ErrUnknownMessageType, typeName)
+	}
+
+	instance := reflect.New(t)
+	if err := gob.NewDecoder(bytes.NewReader(b)).DecodeValue(instance); err != nil {
+		return nil, err
+	}
+
+	return instance.Elem().Interface(), nil
+}
+
+func (g *gobSerializer) GetTypeName(msg interface{}) (string, error) {
+	if msg == nil {
+		return "", fmt.Errorf("msg must not be nil")
+	}
+
+	t := reflect.TypeOf(msg)
+	name := gobTypeName(t)
+
+	gobTypesMu.RLock()
+	_, ok := gobTypes[name]
+	gobTypesMu.RUnlock()
+	if !ok {
+		RegisterGobType(msg)
+	}
+
+	return name, nil
+}
@@ -0,0 +1,115 @@
+package remote
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+)
+
+// ErrHeartbeatMissed terminates an endpoint whose peer missed Config.HeartbeatMissThreshold heartbeats
+var ErrHeartbeatMissed = errors.New("remote: missed heartbeats")
+
+// heartbeatTick is sent to the system mailbox of the endpoint writer to emit a heartbeat on its stream,
+// so it is neither batched with nor queued behind the user messages
+type heartbeatTick struct{}
+
+var heartbeatMessage = &RemoteMessage{
+	MessageType: &RemoteMessage_Heartbeat{
+		Heartbeat: &Heartbeat{},
+	},
+}
+
+// startHeartbeat periodically tells the endpoint writer to ping its peer, until the returned channel is closed
+func startHeartbeat(system *actor.ActorSystem, writer *actor.PID, interval time.Duration) chan struct{} {
+	done := make(chan struct{})
+	process, ok := system.ProcessRegistry.GetLocal(writer.Id)
+	if !ok {
+		return done
+	}
+	ticker := time.NewTicker(interval)
+
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				process.SendSystemMessage(writer, &heartbeatTick{})
+			}
+		}
+	}()
+
+	return done
+}
+
+// heartbeatMonitor tracks liveness of an inbound stream.
+// It only starts enforcing once the peer has sent a heartbeat, so older peers which never send any are left alone
+type heartbeatMonitor struct {
+	lastSeen int64
+	enabled  int32
+	started  int32
+	address  atomic.Value
+	once     sync.Once
+	done     chan struct{}
+}
+
+func newHeartbeatMonitor() *heartbeatMonitor {
+	return &heartbeatMonitor{
+		lastSeen: time.Now().UnixNano(),
+		done:     make(chan struct{}),
+	}
+}
+
+func (m *heartbeatMonitor) setAddress(address string) {
+	m.address.Store(address)
+}
+
+// seen records that a message was received from the peer
+func (m *heartbeatMonitor) seen(heartbeat bool) {
+	atomic.StoreInt64(&m.lastSeen, time.Now().UnixNano())
+	if heartbeat {
+		atomic.StoreInt32(&m.enabled, 1)
+	}
+}
+
+// start checks for missed heartbeats, calling onMissed at most once. Only the first call starts the monitor,
+// interval is the one the peer sends heartbeats with
+func (m *heartbeatMonitor) start(interval time.Duration, threshold int, onMissed func(address string)) {
+	if interval <= 0 || threshold <= 0 || !atomic.CompareAndSwapInt32(&m.started, 0, 1) {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-m.done:
+				return
+			case <-ticker.C:
+				if atomic.LoadInt32(&m.enabled) == 0 {
+					continue
+				}
+
+				since := time.Since(time.Unix(0, atomic.LoadInt64(&m.lastSeen)))
+				if since > interval*time.Duration(threshold) {
+					address, _ := m.address.Load().(string)
+					onMissed(address)
+
+					return
+				}
+			}
+		}
+	}()
+}
+
+func (m *heartbeatMonitor) stop() {
+	m.once.Do(func() {
+		close(m.done)
+	})
+}
@@ -0,0 +1,89 @@
+package remote
+
+import (
+	"testing"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeartbeatMonitor_IgnoresPeersWithoutHeartbeats(t *testing.T) {
+	missed := make(chan string, 1)
+	m := newHeartbeatMonitor()
+	m.setAddress("peer")
+	m.start(5*time.Millisecond, 2, func(address string) {
+		missed <- address
+	})
+	defer m.stop()
+
+	m.seen(false)
+
+	select {
+	case <-missed:
+		t.Fatal("peer without heartbeat support must not be considered lost")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestHeartbeatMonitor_ReportsMissedHeartbeats(t *testing.T) {
+	missed := make(chan string, 1)
+	m := newHeartbeatMonitor()
+	m.setAddress("peer")
+	m.start(5*time.Millisecond, 2, func(address string) {
+		missed <- address
+	})
+	defer m.stop()
+
+	m.seen(true)
+
+	select {
+	case address := <-missed:
+		assert.Equal(t, "peer", address)
+	case <-time.After(time.Second):
+		t.Fatal("expected missed heartbeats to be reported")
+	}
+}
+
+func TestEndpointReader_MonitorsTheIntervalOfThePeer(t *testing.T) {
+	system := actor.NewActorSystem()
+	config := Configure("localhost", 0, WithHeartbeat(time.Hour, 2))
+	reader := &endpointReader{remote: &Remote{actorSystem: system, config: config}}
+
+	missed := make(chan *EndpointTerminatedEvent, 1)
+	system.EventStream.Subscribe(func(evt interface{}) {
+		if e, ok := evt.(*EndpointTerminatedEvent); ok {
+			missed <- e
+		}
+	})
+
+	m := newHeartbeatMonitor()
+	m.setAddress("peer")
+	defer m.stop()
+	reader.monitorHeartbeats(m, &ConnectRequest{HeartbeatIntervalMs: 5})
+	m.seen(true)
+
+	// the peer announced 5ms, our own interval of an hour must not apply
+	select {
+	case e := <-missed:
+		assert.Equal(t, "peer", e.Address)
+		assert.ErrorIs(t, e.Err, ErrHeartbeatMissed)
+	case <-time.After(time.Second):
+		t.Fatal("expected missed heartbeats to be reported")
+	}
+}
+
+func TestEndpointWriterMailbox_DeliversHeartbeatTicksAheadOfUserMessages(t *testing.T) {
+	mb := endpointWriterMailboxProducer(10, 10, 0, nil, plog)().(*endpointWriterMailbox)
+	invoker := &recordingInvoker{}
+	mb.RegisterHandlers(invoker, idleDispatcher{})
+
+	mb.PostUserMessage(&remoteDeliver{message: 1})
+	mb.PostSystemMessage(&heartbeatTick{})
+	mb.processMessages()
+
+	if assert.Len(t, invoker.messages, 2) {
+		assert.Equal(t, &heartbeatTick{}, invoker.messages[0])
+		assert.IsType(t, []interface{}{}, invoker.messages[1])
+	}
+}
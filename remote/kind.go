@@ -6,12 +6,29 @@ import "github.com/asynkron/protoactor-go/actor"
 type Kind struct {
 	Kind  string
 	Props *actor.Props
+	// Restricted kinds are not spawned on activation requests from other nodes, which are answered with ErrForbidden
+	Restricted bool
+}
+
+// KindOption configures a kind
+type KindOption func(kind *Kind)
+
+// WithRestricted keeps the kind from being spawned on activation requests from other nodes
+func WithRestricted() KindOption {
+	return func(kind *Kind) {
+		kind.Restricted = true
+	}
 }
 
 // NewKind creates a new kind configuration
-func NewKind(kind string, props *actor.Props) *Kind {
-	return &Kind{
+func NewKind(kind string, props *actor.Props, opts ...KindOption) *Kind {
+	k := &Kind{
 		Kind:  kind,
 		Props: props,
 	}
+	for _, opt := range opts {
+		opt(k)
+	}
+
+	return k
 }
@@ -12,3 +12,12 @@ var plog = log.New(log.DebugLevel, "[REMOTE]")
 func SetLogLevel(level log.Level) {
 	plog.SetLevel(level)
 }
+
+// Logger returns the logger of the actor system when one was set with actor.WithLogger, or the package logger
+func (r *Remote) Logger() log.Interface {
+	if logger := r.actorSystem.Config.Logger; logger != nil {
+		return logger
+	}
+
+	return plog
+}
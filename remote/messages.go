@@ -4,15 +4,59 @@ import "github.com/asynkron/protoactor-go/actor"
 
 type EndpointTerminatedEvent struct {
 	Address string
+	// Graceful is set when the peer closed the connection itself, rather than the connection being lost
+	Graceful bool
+	// Err is the error which terminated the endpoint, if any
+	Err error
 }
 
 type EndpointConnectedEvent struct {
 	Address string
 }
 
+// RemoteBackpressure is published as the message of a DeadLetterEvent when a message is rejected
+// because the endpoint writer queue for Address reached Config.EndpointWriterQueueSize
+type RemoteBackpressure struct {
+	Address string
+	Message interface{}
+}
+
+// RemoteDrainTimeout is published as the message of a DeadLetterEvent when a message was still queued
+// for Address when its endpoint writer stopped, and could not be sent within Config.EndpointWriterDrainTimeout.
+// With a zero drain timeout, every message still queued is dead lettered this way
+type RemoteDrainTimeout struct {
+	Address string
+	Message interface{}
+}
+
+// EndpointNotConnected is published as the message of a DeadLetterEvent when a message was queued for Address
+// and the endpoint did not connect within Config.EndpointConnectGracePeriod. Such messages are never delivered
+type EndpointNotConnected struct {
+	Address string
+	Message interface{}
+}
+
+// MessageTooLarge is published as the message of a DeadLetterEvent when a message to Address serialized
+// to Size bytes, more than Config.MaxMessageSize
+type MessageTooLarge struct {
+	Address  string
+	Message  interface{}
+	TypeName string
+	Size     int
+}
+
+// SerializationError is published as the message of a DeadLetterEvent when a message to Address could not
+// be serialized. The other messages of its batch are sent
+type SerializationError struct {
+	Address string
+	Message interface{}
+	Err     error
+}
+
 type remoteWatch struct {
 	Watcher *actor.PID
 	Watchee *actor.PID
+	Durable bool
 }
 
 type remoteUnwatch struct {
@@ -31,6 +75,20 @@ type remoteDeliver struct {
 type remoteTerminate struct {
 	Watcher *actor.PID
 	Watchee *actor.PID
+	Why     actor.TerminatedReason
+}
+
+// UnknownRemoteMessage is received in place of a message whose type is not registered locally,
+// when the remote is configured WithRawUnknownMessages. Sending it to a remote pid forwards the bytes unchanged
+type UnknownRemoteMessage struct {
+	TypeName     string
+	Bytes        []byte
+	SerializerId int32
+}
+
+// SerializerID returns the id of the serializer the message was serialized with
+func (m *UnknownRemoteMessage) SerializerID() int32 {
+	return m.SerializerId
 }
 
 type JsonMessage struct {
@@ -0,0 +1,55 @@
+package remote
+
+import "github.com/asynkron/protoactor-go/actor"
+
+// RemoteEnvelope is a user message crossing the remote boundary, as seen by middleware.
+// Middleware may change any field, Header may be nil when the message has no header
+type RemoteEnvelope struct {
+	Header  map[string]string
+	Message interface{}
+	Sender  *actor.PID
+	Target  *actor.PID
+}
+
+// SenderFunc passes an outbound envelope on towards serialization
+type SenderFunc func(envelope *RemoteEnvelope)
+
+// SenderMiddleware wraps the outbound step of the endpoint writer.
+// Middleware runs in the order it is configured, before the message is serialized.
+// Delivery is short-circuited by returning without calling next, the message is then dropped
+// and it is up to the middleware to dead letter or report it. next must be called synchronously
+type SenderMiddleware func(next SenderFunc) SenderFunc
+
+// ReceiverFunc passes an inbound envelope on towards its local target
+type ReceiverFunc func(envelope *RemoteEnvelope)
+
+// ReceiverMiddleware wraps the delivery of user messages by the endpoint reader, after deserialization.
+// Middleware runs in the order it is configured, and short-circuits delivery by returning without calling next.
+// System messages are never passed to middleware
+type ReceiverMiddleware func(next ReceiverFunc) ReceiverFunc
+
+func makeSenderMiddlewareChain(middleware []SenderMiddleware, last SenderFunc) SenderFunc {
+	if len(middleware) == 0 {
+		return nil
+	}
+
+	h := middleware[len(middleware)-1](last)
+	for i := len(middleware) - 2; i >= 0; i-- {
+		h = middleware[i](h)
+	}
+
+	return h
+}
+
+func makeReceiverMiddlewareChain(middleware []ReceiverMiddleware, last ReceiverFunc) ReceiverFunc {
+	if len(middleware) == 0 {
+		return nil
+	}
+
+	h := middleware[len(middleware)-1](last)
+	for i := len(middleware) - 2; i >= 0; i-- {
+		h = middleware[i](h)
+	}
+
+	return h
+}
@@ -0,0 +1,52 @@
+package remote
+
+import (
+	"testing"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEndpointWriter_OutboundMiddleware(t *testing.T) {
+	system := actor.NewActorSystem()
+	stream := &fakeConnection{}
+	writer := newTestEndpointWriter(system, stream)
+
+	var order []string
+	stamp := func(name string) SenderMiddleware {
+		return func(next SenderFunc) SenderFunc {
+			return func(envelope *RemoteEnvelope) {
+				order = append(order, name)
+				if envelope.Header == nil {
+					envelope.Header = map[string]string{}
+				}
+				envelope.Header["trace"] += name
+				next(envelope)
+			}
+		}
+	}
+	reject := func(next SenderFunc) SenderFunc {
+		return func(envelope *RemoteEnvelope) {
+			if envelope.Target.Id != "rejected" {
+				next(envelope)
+			}
+		}
+	}
+	writer.outbound = makeSenderMiddlewareChain([]SenderMiddleware{stamp("a"), stamp("b"), reject}, func(envelope *RemoteEnvelope) {
+		writer.outboundResult = envelope
+	})
+
+	accepted := actor.NewPID("peer", "accepted")
+	rejected := actor.NewPID("peer", "rejected")
+	err := writer.sendEnvelopes([]interface{}{
+		&remoteDeliver{message: accepted, target: accepted, serializerID: -1},
+		&remoteDeliver{message: rejected, target: rejected, serializerID: -1},
+	}, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "a", "b"}, order)
+	batch := stream.sent[0].GetMessageBatch()
+	assert.Len(t, batch.Envelopes, 1)
+	assert.Equal(t, "accepted", batch.Targets[0].Id)
+	assert.Equal(t, "ab", batch.Envelopes[0].MessageHeader.HeaderData["trace"])
+}
@@ -0,0 +1,54 @@
+package remote
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+type protoJsonSerializer struct {
+	marshal   protojson.MarshalOptions
+	unmarshal protojson.UnmarshalOptions
+}
+
+// NewProtoJsonSerializer creates a serializer writing messages with the canonical proto JSON mapping,
+// so type and field names match other protoactor implementations. Register it under an id with
+// Remote.RegisterSerializer, and select it for a message type by implementing SerializerIdentifiable
+func NewProtoJsonSerializer() Serializer {
+	return &protoJsonSerializer{
+		unmarshal: protojson.UnmarshalOptions{DiscardUnknown: true},
+	}
+}
+
+func (p *protoJsonSerializer) Serialize(msg interface{}) ([]byte, error) {
+	if message, ok := msg.(*JsonMessage); ok {
+		return []byte(message.Json), nil
+	} else if message, ok := msg.(proto.Message); ok {
+		return p.marshal.Marshal(message)
+	}
+	return nil, fmt.Errorf("msg must be proto.Message")
+}
+
+func (p *protoJsonSerializer) Deserialize(typeName string, bytes []byte) (interface{}, error) {
+	n, err := protoregistry.GlobalTypes.FindMessageByName(protoreflect.FullName(typeName))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnknownMessageType, typeName)
+	}
+
+	pm := n.New().Interface()
+
+	err = p.unmarshal.Unmarshal(bytes, pm)
+	return pm, err
+}
+
+func (p *protoJsonSerializer) GetTypeName(msg interface{}) (string, error) {
+	if message, ok := msg.(*JsonMessage); ok {
+		return message.TypeName, nil
+	} else if message, ok := msg.(proto.Message); ok {
+		return string(proto.MessageName(message)), nil
+	}
+	return "", fmt.Errorf("msg must be proto.Message")
+}
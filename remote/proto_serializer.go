@@ -27,12 +27,26 @@ func (p *protoSerializer) Serialize(msg interface{}) ([]byte, error) {
 }
 
 func (p *protoSerializer) Deserialize(typeName string, bytes []byte) (interface{}, error) {
-	n, _ := protoregistry.GlobalTypes.FindMessageByName(protoreflect.FullName(typeName))
+	decode, err := p.resolve(typeName)
+	if err != nil {
+		return nil, err
+	}
+
+	return decode(bytes)
+}
+
+func (p *protoSerializer) resolve(typeName string) (decodeFunc, error) {
+	n, err := protoregistry.GlobalTypes.FindMessageByName(protoreflect.FullName(typeName))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnknownMessageType, typeName)
+	}
 
-	pm := n.New().Interface()
+	return func(bytes []byte) (interface{}, error) {
+		pm := n.New().Interface()
+		err := proto.Unmarshal(bytes, pm)
 
-	err := proto.Unmarshal(bytes, pm)
-	return pm, err
+		return pm, err
+	}, nil
 }
 
 func (protoSerializer) GetTypeName(msg interface{}) (string, error) {
@@ -0,0 +1,231 @@
+package remote
+
+import (
+	"sync"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/asynkron/protoactor-go/log"
+)
+
+// QuarantineState is the circuit breaker state of the endpoints to a remote address
+type QuarantineState int
+
+const (
+	// QuarantineClosed lets endpoints to the address connect
+	QuarantineClosed QuarantineState = iota
+	// QuarantineOpen dead letters the messages to the address without dialing it, until Config.QuarantineCooldown elapsed
+	QuarantineOpen
+	// QuarantineHalfOpen lets one endpoint try to connect once, closing the quarantine if it connects
+	// and opening it again if it fails
+	QuarantineHalfOpen
+)
+
+func (s QuarantineState) String() string {
+	switch s {
+	case QuarantineClosed:
+		return "Closed"
+	case QuarantineOpen:
+		return "Open"
+	case QuarantineHalfOpen:
+		return "HalfOpen"
+	default:
+		return "Unknown"
+	}
+}
+
+// EndpointQuarantineEvent is published when the quarantine of Address changes state.
+// Err is the error of the endpoint failure which opened the quarantine
+type EndpointQuarantineEvent struct {
+	Address string
+	State   QuarantineState
+	Err     error
+}
+
+// EndpointQuarantined is published as the message of a DeadLetterEvent when a message was sent to Address
+// while it is quarantined
+type EndpointQuarantined struct {
+	Address string
+	Message interface{}
+}
+
+type quarantineEntry struct {
+	state    QuarantineState
+	failures []time.Time
+}
+
+// endpointQuarantine opens the quarantine of an address after Config.QuarantineFailureThreshold endpoint failures
+// within Config.QuarantineWindow. Closed entries whose failures left the window are swept on lookup
+type endpointQuarantine struct {
+	mu        sync.Mutex
+	entries   map[string]*quarantineEntry
+	lastSweep time.Time
+	remote    *Remote
+	// onHalfOpen is called once the cooldown of an address elapsed
+	onHalfOpen func(address string)
+}
+
+func newEndpointQuarantine(remote *Remote, onHalfOpen func(address string)) *endpointQuarantine {
+	return &endpointQuarantine{
+		entries:    make(map[string]*quarantineEntry),
+		lastSweep:  time.Now(),
+		remote:     remote,
+		onHalfOpen: onHalfOpen,
+	}
+}
+
+func (q *endpointQuarantine) enabled() bool {
+	return q.remote.config.QuarantineFailureThreshold > 0
+}
+
+// state returns the quarantine state of address
+func (q *endpointQuarantine) state(address string) QuarantineState {
+	if !q.enabled() {
+		return QuarantineClosed
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.sweep(time.Now())
+	if entry, ok := q.entries[address]; ok {
+		return entry.state
+	}
+
+	return QuarantineClosed
+}
+
+// sweep removes the closed entries without failures within the window, at most once per window.
+// Open and half open entries are removed once the address connects
+func (q *endpointQuarantine) sweep(now time.Time) {
+	window := q.remote.config.QuarantineWindow
+	if now.Sub(q.lastSweep) < window {
+		return
+	}
+
+	for address, entry := range q.entries {
+		if entry.state != QuarantineClosed {
+			continue
+		}
+		if n := len(entry.failures); n == 0 || now.Sub(entry.failures[n-1]) >= window {
+			delete(q.entries, address)
+		}
+	}
+	q.lastSweep = now
+}
+
+// failed records an endpoint failure to address, and opens its quarantine if it flaps
+func (q *endpointQuarantine) failed(address string, err error) {
+	if !q.enabled() {
+		return
+	}
+
+	q.mu.Lock()
+	q.sweep(time.Now())
+	entry, ok := q.entries[address]
+	if !ok {
+		entry = &quarantineEntry{}
+		q.entries[address] = entry
+	}
+
+	open := false
+	switch entry.state {
+	case QuarantineOpen:
+		// endpoints opened before the quarantine are still failing
+	case QuarantineHalfOpen:
+		open = true
+	case QuarantineClosed:
+		now := time.Now()
+		failures := entry.failures[:0]
+		for _, at := range entry.failures {
+			if now.Sub(at) < q.remote.config.QuarantineWindow {
+				failures = append(failures, at)
+			}
+		}
+		entry.failures = append(failures, now)
+		open = len(entry.failures) >= q.remote.config.QuarantineFailureThreshold
+	}
+	if open {
+		entry.state = QuarantineOpen
+		entry.failures = nil
+	}
+	q.mu.Unlock()
+
+	if open {
+		q.remote.Logger().Warn("Endpoint quarantined", log.String("address", address), log.Error(err))
+		q.publish(address, QuarantineOpen, err)
+		time.AfterFunc(q.remote.config.QuarantineCooldown, func() {
+			q.halfOpen(address)
+		})
+	}
+}
+
+// connected closes the quarantine of address
+func (q *endpointQuarantine) connected(address string) {
+	if !q.enabled() {
+		return
+	}
+
+	q.mu.Lock()
+	entry, ok := q.entries[address]
+	if ok {
+		delete(q.entries, address)
+	}
+	q.mu.Unlock()
+
+	if ok && entry.state != QuarantineClosed {
+		q.remote.Logger().Info("Endpoint quarantine closed", log.String("address", address))
+		q.publish(address, QuarantineClosed, nil)
+	}
+}
+
+func (q *endpointQuarantine) halfOpen(address string) {
+	q.mu.Lock()
+	entry, ok := q.entries[address]
+	if !ok || entry.state != QuarantineOpen {
+		q.mu.Unlock()
+
+		return
+	}
+	entry.state = QuarantineHalfOpen
+	q.mu.Unlock()
+
+	q.publish(address, QuarantineHalfOpen, nil)
+	q.onHalfOpen(address)
+}
+
+func (q *endpointQuarantine) publish(address string, state QuarantineState, err error) {
+	q.remote.actorSystem.EventStream.Publish(&EndpointQuarantineEvent{
+		Address: address,
+		State:   state,
+		Err:     err,
+	})
+}
+
+// rejectQuarantined dead letters a message to a quarantined address
+func rejectQuarantined(remote *Remote, rd *remoteDeliver) {
+	address := rd.target.Address
+	if rd.sender != nil && remote.actorSystem.Config.DeadLetterResponse {
+		remote.actorSystem.Root.Send(rd.sender, &actor.DeadLetterResponse{Target: rd.target})
+
+		return
+	}
+
+	remote.actorSystem.EventStream.Publish(&actor.DeadLetterEvent{
+		PID: rd.target,
+		Message: &EndpointQuarantined{
+			Address: address,
+			Message: rd.message,
+		},
+		Sender: rd.sender,
+	})
+}
+
+// QuarantineState returns the quarantine state of the endpoints to address
+func (r *Remote) QuarantineState(address string) QuarantineState {
+	if r.edpManager == nil {
+		return QuarantineClosed
+	}
+
+	return r.edpManager.quarantine.state(address)
+}
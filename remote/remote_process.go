@@ -19,8 +19,14 @@ func newProcess(pid *actor.PID, r *Remote) actor.Process {
 var _ actor.Process = &process{}
 
 func (ref *process) SendUserMessage(pid *actor.PID, message interface{}) {
-	header, msg, sender := actor.UnwrapEnvelope(message)
-	ref.remote.SendMessage(pid, header, msg, sender, -1)
+	_, msg, sender := actor.UnwrapEnvelope(message)
+	// the request scope does not outlive the process, the remote actor receives its values as headers
+	header := actor.UnwrapEnvelopeHeaderWithScope(message)
+	serializerID := int32(-1)
+	if v, ok := msg.(SerializerIdentifiable); ok {
+		serializerID = v.SerializerID()
+	}
+	ref.remote.SendMessage(pid, header, msg, sender, serializerID)
 }
 
 func (ref *process) SendSystemMessage(pid *actor.PID, message interface{}) {
@@ -30,6 +36,7 @@ func (ref *process) SendSystemMessage(pid *actor.PID, message interface{}) {
 		rw := &remoteWatch{
 			Watcher: msg.Watcher,
 			Watchee: pid,
+			Durable: msg.Durable,
 		}
 		// endpointManager.remoteWatch(rw)
 		ref.remote.edpManager.remoteWatch(rw)
@@ -11,6 +11,7 @@ const (
 	ResponseStatusCodePROCESSNAMEALREADYEXIST
 	ResponseStatusCodeERROR
 	ResponseStatusCodeDeadLetter
+	ResponseStatusCodeFORBIDDEN
 	ResponseStatusCodeMAX // just a boundary.
 )
 
@@ -24,6 +25,7 @@ func init() {
 	responseNames[ResponseStatusCodePROCESSNAMEALREADYEXIST] = "ResponseStatusCodePROCESSNAMEALREADYEXIST"
 	responseNames[ResponseStatusCodeERROR] = "ResponseStatusCodeERROR"
 	responseNames[ResponseStatusCodeDeadLetter] = "ResponseStatusCodeDeadLetter"
+	responseNames[ResponseStatusCodeFORBIDDEN] = "ResponseStatusCodeFORBIDDEN"
 }
 
 func (c ResponseStatusCode) ToInt32() int32 {
@@ -52,6 +54,8 @@ func (c ResponseStatusCode) AsError() *ResponseError {
 		return ErrUnknownError
 	case ResponseStatusCodeDeadLetter:
 		return ErrDeadLetter
+	case ResponseStatusCodeFORBIDDEN:
+		return ErrForbidden
 	default:
 		return &ResponseError{c}
 	}
@@ -1,8 +1,27 @@
 package remote
 
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrSerializerIDExists is returned when registering a serializer with an id that is already in use
+var ErrSerializerIDExists = errors.New("remote: serializer id already registered")
+
+// ErrInvalidSerializerID is returned when registering a serializer with a negative id
+var ErrInvalidSerializerID = errors.New("remote: invalid serializer id")
+
+// ErrSerializerNotSupported is returned when a message requires a serializer the peer did not announce on connect
+var ErrSerializerNotSupported = errors.New("remote: serializer not supported by peer")
+
+// ErrUnknownMessageType is returned when deserializing a message whose type is not registered locally
+var ErrUnknownMessageType = errors.New("remote: unknown message type")
+
 var (
 	DefaultSerializerID int32
 	serializers         []Serializer
+	serializersMu       sync.RWMutex
 )
 
 func init() {
@@ -10,24 +29,113 @@ func init() {
 	RegisterSerializer(newJsonSerializer())
 }
 
+// RegisterSerializer registers the serializer with the next free id
 func RegisterSerializer(serializer Serializer) {
+	serializersMu.Lock()
+	defer serializersMu.Unlock()
+
 	serializers = append(serializers, serializer)
 }
 
+// RegisterSerializer registers the serializer under the given id.
+//
+// The id is what is written to MessageEnvelope.SerializerId on the wire, so both peers must agree on it.
+// Ids are never overwritten: if the id is already taken, ErrSerializerIDExists is returned and the existing
+// registration is left untouched. A negative id returns ErrInvalidSerializerID.
+//
+// The registry is process wide, like the package level RegisterSerializer: the serializer is registered for all the
+// Remote instances of the process, not only for r.
+func (r *Remote) RegisterSerializer(id int32, s Serializer) error {
+	serializersMu.Lock()
+	defer serializersMu.Unlock()
+
+	if id < 0 {
+		return fmt.Errorf("%w: %v", ErrInvalidSerializerID, id)
+	}
+
+	if int(id) < len(serializers) {
+		if serializers[id] != nil {
+			return fmt.Errorf("%w: %v", ErrSerializerIDExists, id)
+		}
+		serializers[id] = s
+
+		return nil
+	}
+
+	for int(id) > len(serializers) {
+		serializers = append(serializers, nil)
+	}
+	serializers = append(serializers, s)
+
+	return nil
+}
+
+// serializerIDs returns the ids of the registered serializers, announced to the peers on connect
+func serializerIDs() []int32 {
+	serializersMu.RLock()
+	defer serializersMu.RUnlock()
+
+	ids := make([]int32, 0, len(serializers))
+	for id, s := range serializers {
+		if s != nil {
+			ids = append(ids, int32(id))
+		}
+	}
+
+	return ids
+}
+
+func getSerializer(serializerID int32) (Serializer, error) {
+	serializersMu.RLock()
+	defer serializersMu.RUnlock()
+
+	if serializerID < 0 || int(serializerID) >= len(serializers) || serializers[serializerID] == nil {
+		return nil, fmt.Errorf("remote: unknown serializer id %v", serializerID)
+	}
+
+	return serializers[serializerID], nil
+}
+
 type Serializer interface {
 	Serialize(msg interface{}) ([]byte, error)
+	// Deserialize returns an error wrapping ErrUnknownMessageType when typeName is not registered
 	Deserialize(typeName string, bytes []byte) (interface{}, error)
 	GetTypeName(msg interface{}) (string, error)
 }
 
+// SerializerIdentifiable is implemented by messages which must be serialized with a specific serializer,
+// instead of the DefaultSerializerID
+type SerializerIdentifiable interface {
+	SerializerID() int32
+}
+
 func Serialize(message interface{}, serializerID int32) ([]byte, string, error) {
-	res, err := serializers[serializerID].Serialize(message)
-	typeName, err := serializers[serializerID].GetTypeName(message)
+	if raw, ok := message.(*UnknownRemoteMessage); ok {
+		return raw.Bytes, raw.TypeName, nil
+	}
+
+	serializer, err := getSerializer(serializerID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	res, err := serializer.Serialize(message)
+	if err != nil {
+		return nil, "", err
+	}
+
+	typeName, err := serializer.GetTypeName(message)
+
 	return res, typeName, err
 }
 
 func Deserialize(message []byte, typeName string, serializerID int32) (interface{}, error) {
-	return serializers[serializerID].Deserialize(typeName, message)
+	serializer, err := getSerializer(serializerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return serializer.Deserialize(typeName, message)
 }
 
 // RootSerializable is the root level in-process representation of a message
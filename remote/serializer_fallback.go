@@ -0,0 +1,48 @@
+package remote
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ErrNoSerializer is returned when none of the serializers of the fallback chain serializes a message
+var ErrNoSerializer = errors.New("remote: no serializer for message")
+
+// serializerChain resolves the serializer of a message from the serializers of WithSerializerFallback, the first
+// serializer which serializes the message is used. The endpoint writers keep the resolved serializer per message
+// type, as it depends on the serializers the peer decodes
+type serializerChain struct {
+	ids []int32
+}
+
+func newSerializerChain(ids []int32) *serializerChain {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	return &serializerChain{ids: ids}
+}
+
+// serialize serializes the message with the first serializer of the chain which serializes it, and returns the id of
+// that serializer. Only the serializers for which supports returns true are used, a nil supports allows all of them. When supports
+// rejects all the serializers of the chain, the error wraps ErrSerializerNotSupported
+func (c *serializerChain) serialize(message interface{}, supports func(serializerID int32) bool) ([]byte, string, int32, error) {
+	var lastErr error
+	for _, id := range c.ids {
+		if supports != nil && !supports(id) {
+			continue
+		}
+
+		bytes, typeName, err := serialize(message, id)
+		if err == nil {
+			return bytes, typeName, id, nil
+		}
+		lastErr = err
+	}
+	if lastErr == nil {
+		return nil, "", -1, fmt.Errorf("%w: none of %v", ErrSerializerNotSupported, c.ids)
+	}
+
+	return nil, "", -1, fmt.Errorf("%w %v: %v", ErrNoSerializer, reflect.TypeOf(message), lastErr)
+}
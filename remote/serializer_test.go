@@ -5,6 +5,7 @@ import (
 
 	"github.com/asynkron/protoactor-go/actor"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 //func TestJsonSerializer_round_trip(t *testing.T) {
@@ -55,3 +56,94 @@ func TestProtobufSerializer_Serialize_PID(t *testing.T) {
 	assert.Equal(t, "actor.PID", typeName)
 	assert.True(t, m.Equal(typed))
 }
+
+// registerSerializer registers the serializer in the process wide registry for the duration of the test
+func registerSerializer(t testing.TB, id int32, s Serializer) {
+	require.NoError(t, (&Remote{}).RegisterSerializer(id, s))
+	t.Cleanup(func() {
+		serializersMu.Lock()
+		defer serializersMu.Unlock()
+
+		serializers[id] = nil
+		for len(serializers) > 0 && serializers[len(serializers)-1] == nil {
+			serializers = serializers[:len(serializers)-1]
+		}
+	})
+}
+
+func TestRemote_RegisterSerializer_rejects_collision(t *testing.T) {
+	r := &Remote{}
+
+	err := r.RegisterSerializer(0, newJsonSerializer())
+	assert.ErrorIs(t, err, ErrSerializerIDExists)
+
+	err = r.RegisterSerializer(-1, newJsonSerializer())
+	assert.ErrorIs(t, err, ErrInvalidSerializerID)
+
+	s, _ := getSerializer(0)
+	assert.IsType(t, &protoSerializer{}, s)
+}
+
+func TestRemote_RegisterSerializer_custom_id(t *testing.T) {
+	system := actor.NewActorSystem()
+	m := system.NewLocalPID("foo")
+
+	registerSerializer(t, 10, newProtoSerializer())
+
+	b, typeName, err := Serialize(m, 10)
+	assert.NoError(t, err)
+
+	res, err := Deserialize(b, typeName, 10)
+	assert.NoError(t, err)
+	assert.True(t, m.Equal(res.(*actor.PID)))
+
+	_, _, err = Serialize(m, 5)
+	assert.Error(t, err)
+}
+
+func TestProtoJsonSerializer_round_trip(t *testing.T) {
+	registerSerializer(t, 20, NewProtoJsonSerializer())
+
+	m := actor.NewPID("localhost:8090", "foo")
+	b, typeName, err := Serialize(m, 20)
+	assert.NoError(t, err)
+	assert.Equal(t, "actor.PID", typeName)
+	assert.JSONEq(t, `{"Address":"localhost:8090","Id":"foo"}`, string(b))
+
+	res, err := Deserialize(b, typeName, 20)
+	assert.NoError(t, err)
+	assert.True(t, m.Equal(res.(*actor.PID)))
+
+	_, err = Deserialize(b, "elsewhere.Unknown", 20)
+	assert.ErrorIs(t, err, ErrUnknownMessageType)
+}
+
+type gobTestMessage struct {
+	Name  string
+	Count int
+}
+
+func TestSerializerChain_FallsBackPerType(t *testing.T) {
+	registerSerializer(t, 30, NewGobSerializer())
+	chain := newSerializerChain([]int32{0, 30})
+
+	// protobuf messages keep the protobuf serializer
+	pid := actor.NewPID("localhost:8090", "foo")
+	_, typeName, id, err := chain.serialize(pid, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(0), id)
+	assert.Equal(t, "actor.PID", typeName)
+
+	// other messages fall back to gob
+	m := &gobTestMessage{Name: "foo", Count: 3}
+	b, typeName, id, err := chain.serialize(m, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(30), id)
+
+	res, err := Deserialize(b, typeName, id)
+	assert.NoError(t, err)
+	assert.Equal(t, m, res)
+
+	_, _, _, err = newSerializerChain([]int32{0}).serialize(m, nil)
+	assert.ErrorIs(t, err, ErrNoSerializer)
+}
@@ -1,16 +1,15 @@
 package remote
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
-	"net"
 	"time"
 
 	"github.com/asynkron/protoactor-go/extensions"
 
 	"github.com/asynkron/protoactor-go/actor"
 	"github.com/asynkron/protoactor-go/log"
-	"google.golang.org/grpc"
 	"google.golang.org/grpc/grpclog"
 )
 
@@ -18,25 +17,39 @@ var extensionId = extensions.NextExtensionID()
 
 type Remote struct {
 	actorSystem  *actor.ActorSystem
-	s            *grpc.Server
+	transport    Transport
+	listener     Listener
 	edpReader    *endpointReader
 	edpManager   *endpointManager
 	config       *Config
 	kinds        map[string]*actor.Props
 	activatorPid *actor.PID
 	blocklist    *BlockList
+	serializers  *serializerChain
+
+	// restrictedKinds are registered kinds which the activator does not spawn
+	restrictedKinds map[string]bool
 }
 
 func NewRemote(actorSystem *actor.ActorSystem, config *Config) *Remote {
 	r := &Remote{
-		actorSystem: actorSystem,
-		config:      config,
-		kinds:       make(map[string]*actor.Props),
-		blocklist:   NewBlockList(),
+		actorSystem:     actorSystem,
+		config:          config,
+		kinds:           make(map[string]*actor.Props),
+		restrictedKinds: make(map[string]bool),
+		blocklist:       NewBlockList(),
+		transport:       config.Transport,
+		serializers:     newSerializerChain(config.SerializerFallback),
+	}
+	if r.transport == nil {
+		r.transport = newGrpcTransport(config, r.Logger())
 	}
 	for k, v := range config.Kinds {
 		r.kinds[k] = v
 	}
+	for k, restricted := range config.RestrictedKinds {
+		r.restrictedKinds[k] = restricted
+	}
 
 	actorSystem.Extensions.Register(r)
 
@@ -59,30 +72,31 @@ func (r *Remote) BlockList() *BlockList { return r.blocklist }
 // Start the remote server
 func (r *Remote) Start() {
 	grpclog.SetLoggerV2(grpclog.NewLoggerV2(ioutil.Discard, ioutil.Discard, ioutil.Discard))
-	lis, err := net.Listen("tcp", r.config.Address())
+	if r.config.configErr != nil {
+		panic(fmt.Errorf("invalid remote configuration: %w", r.config.configErr))
+	}
+	if err := configureCompression(r.config); err != nil {
+		panic(fmt.Errorf("failed to configure compression: %v", err))
+	}
+
+	r.edpReader = newEndpointReader(r)
+	lis, err := r.transport.Listen(r.config.Address(), r.edpReader.Receive)
 	if err != nil {
 		panic(fmt.Errorf("failed to listen: %v", err))
 	}
+	r.listener = lis
 
-	var address string
-	if r.config.AdvertisedHost != "" {
-		address = r.config.AdvertisedHost
-	} else {
-		address = lis.Addr().String()
-	}
+	address := r.config.advertisedAddress(lis.Address())
 
 	r.actorSystem.ProcessRegistry.RegisterAddressResolver(r.remoteHandler)
 	r.actorSystem.ProcessRegistry.Address = address
-	plog.Info("Starting remote with address", log.String("address", address))
+	r.Logger().Info("Starting remote with address", log.String("address", address))
 
 	r.edpManager = newEndpointManager(r)
 	r.edpManager.start()
 
-	r.s = grpc.NewServer(r.config.ServerOptions...)
-	r.edpReader = newEndpointReader(r)
-	RegisterRemotingServer(r.s, r.edpReader)
-	plog.Info("Starting Proto.Actor server", log.String("address", address))
-	go r.s.Serve(lis)
+	r.Logger().Info("Starting Proto.Actor server", log.String("address", address))
+	go lis.Serve()
 }
 
 func (r *Remote) Shutdown(graceful bool) {
@@ -96,23 +110,49 @@ func (r *Remote) Shutdown(graceful bool) {
 		// TODO: grpc not stopping
 		c := make(chan bool, 1)
 		go func() {
-			r.s.GracefulStop()
+			r.listener.GracefulStop()
 			c <- true
 		}()
 
 		select {
 		case <-c:
-			plog.Info("Stopped Proto.Actor server")
+			r.Logger().Info("Stopped Proto.Actor server")
 		case <-time.After(time.Second * 10):
-			r.s.Stop()
-			plog.Info("Stopped Proto.Actor server", log.String("err", "timeout"))
+			r.listener.Stop()
+			r.Logger().Info("Stopped Proto.Actor server", log.String("err", "timeout"))
 		}
 	} else {
-		r.s.Stop()
-		plog.Info("Killed Proto.Actor server")
+		r.listener.Stop()
+		r.Logger().Info("Killed Proto.Actor server")
+	}
+}
+
+// ShutdownGracefully shuts down the remote as part of actor.ActorSystem.ShutdownGracefully,
+// letting endpoint writers flush their queued messages until ctx is done
+func (r *Remote) ShutdownGracefully(ctx context.Context) error {
+	if r.listener == nil {
+		return nil
+	}
+
+	done := make(chan struct{})
+	go func() {
+		r.Shutdown(true)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		r.listener.Stop()
+		return fmt.Errorf("remote shutdown: %w", ctx.Err())
 	}
 }
 
+var _ actor.GracefulShutdowner = &Remote{}
+
+// SendMessage sends the message to the remote pid, serializing it with the serializer registered under serializerID.
+// A negative serializerID selects DefaultSerializerID.
 func (r *Remote) SendMessage(pid *actor.PID, header actor.ReadonlyMessageHeader, message interface{}, sender *actor.PID, serializerID int32) {
 	rd := &remoteDeliver{
 		header:       header,
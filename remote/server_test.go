@@ -1,11 +1,21 @@
 package remote
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
 	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/asynkron/protoactor-go/actor"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
 )
 
 func TestStart(t *testing.T) {
@@ -21,10 +31,33 @@ func TestConfig_WithAdvertisedHost(t *testing.T) {
 	config := Configure("localhost", 0, WithAdvertisedHost("Banana"))
 	remote := NewRemote(system, config)
 	remote.Start()
-	assert.Equal(t, "Banana", system.Address())
+	_, port, err := net.SplitHostPort(remote.listener.Address())
+	assert.NoError(t, err)
+	assert.Equal(t, "Banana:"+port, system.Address())
 	remote.Shutdown(true)
 }
 
+func TestConfig_AdvertisedAddress(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []ConfigOption
+		address string
+	}{
+		{"bound", nil, "0.0.0.0:8080"},
+		{"host", []ConfigOption{WithAdvertisedHost("node-1")}, "node-1:8080"},
+		{"host and port", []ConfigOption{WithAdvertisedHost("node-1:30080")}, "node-1:30080"},
+		{"port", []ConfigOption{WithAdvertisedPort(30080)}, "0.0.0.0:30080"},
+		{"port overrides host port", []ConfigOption{WithAdvertisedHost("node-1:30080"), WithAdvertisedPort(30081)}, "node-1:30081"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := Configure("0.0.0.0", 8080, tt.options...)
+			assert.Equal(t, tt.address, config.advertisedAddress(config.Address()))
+		})
+	}
+}
+
 func TestRemote_Register(t *testing.T) {
 	system := actor.NewActorSystem()
 	config := Configure("localhost", 0, WithKinds(
@@ -222,3 +255,817 @@ func TestRemote_RegisterViaStruct(t *testing.T) {
 //	endpointSupervisorProcess.AssertNotCalled(suite.T(), "SendSystemMessage", mock.Anything, mock.Anything)
 //	endpointSupervisorProcess.AssertExpectations(suite.T())
 //}
+
+// startEchoRemote starts a remote with an "echo" actor responding with any PID it receives
+func startEchoRemote(t *testing.T) *actor.ActorSystem {
+	system := actor.NewActorSystem()
+	remote := NewRemote(system, Configure("localhost", 0))
+	remote.Start()
+	t.Cleanup(func() {
+		remote.Shutdown(false)
+	})
+
+	_, _ = system.Root.SpawnNamed(actor.PropsFromFunc(func(ctx actor.Context) {
+		if msg, ok := ctx.Message().(*actor.PID); ok {
+			ctx.Respond(msg)
+		}
+	}), "echo")
+
+	return system
+}
+
+func TestRemote_Batching_RoundTrip(t *testing.T) {
+	server := startEchoRemote(t)
+
+	client := actor.NewActorSystem()
+	clientRemote := NewRemote(client, Configure("localhost", 0, WithBatching(10, 5*time.Millisecond)))
+	clientRemote.Start()
+	defer clientRemote.Shutdown(false)
+
+	echo := actor.NewPID(server.Address(), "echo")
+	futures := make([]*actor.Future, 25)
+	for i := range futures {
+		futures[i] = client.Root.RequestFuture(echo, actor.NewPID("somewhere", fmt.Sprint(i)), 5*time.Second)
+	}
+
+	for i, f := range futures {
+		res, err := f.Result()
+		assert.NoError(t, err)
+		assert.Equal(t, fmt.Sprint(i), res.(*actor.PID).Id)
+	}
+}
+
+func TestRemote_EndpointState(t *testing.T) {
+	server := startEchoRemote(t)
+
+	client := actor.NewActorSystem()
+	clientRemote := NewRemote(client, Configure("localhost", 0))
+	clientRemote.Start()
+	defer clientRemote.Shutdown(false)
+
+	_, ok := clientRemote.EndpointState(server.Address())
+	assert.False(t, ok)
+
+	_, err := client.Root.RequestFuture(actor.NewPID(server.Address(), "echo"), actor.NewPID("somewhere", "state"), 5*time.Second).Result()
+	assert.NoError(t, err)
+
+	status, ok := clientRemote.EndpointState(server.Address())
+	assert.True(t, ok)
+	assert.Equal(t, EndpointConnected, status.State)
+
+	client.EventStream.Publish(&EndpointTerminatedEvent{Address: server.Address(), Err: ErrHeartbeatMissed})
+	status, _ = clientRemote.EndpointState(server.Address())
+	assert.Equal(t, EndpointTerminated, status.State)
+	assert.Equal(t, ErrHeartbeatMissed, status.LastError)
+}
+
+func TestEndpointStates_ForgetsTerminatedEndpoints(t *testing.T) {
+	states := newEndpointStates(20 * time.Millisecond)
+	states.set("connected", EndpointStatus{State: EndpointConnected})
+	states.set("terminated", EndpointStatus{State: EndpointTerminated, LastError: ErrHeartbeatMissed})
+
+	status, ok := states.get("terminated")
+	assert.True(t, ok)
+	assert.Equal(t, ErrHeartbeatMissed, status.LastError)
+
+	time.Sleep(30 * time.Millisecond)
+	_, ok = states.get("terminated")
+	assert.False(t, ok)
+
+	states.set("other", EndpointStatus{State: EndpointConnecting})
+	states.mu.RLock()
+	assert.Len(t, states.states, 2)
+	states.mu.RUnlock()
+	_, ok = states.get("connected")
+	assert.True(t, ok)
+}
+
+func TestRemote_SendWithHeaders_RoundTrip(t *testing.T) {
+	server := startEchoRemote(t)
+	// replies to the PID it receives with the headers it received, and one of its own
+	_, _ = server.Root.SpawnNamed(actor.PropsFromFunc(func(ctx actor.Context) {
+		if replyTo, ok := ctx.Message().(*actor.PID); ok {
+			headers := ctx.MessageHeader().Clone()
+			headers.Set("server", "seen")
+			ctx.SendWithHeaders(replyTo, replyTo, headers)
+		}
+	}), "headers")
+
+	client := actor.NewActorSystem()
+	clientRemote := NewRemote(client, Configure("localhost", 0))
+	clientRemote.Start()
+	defer clientRemote.Shutdown(false)
+
+	received := make(chan map[string]string, 1)
+	replyTo := client.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+		if _, ok := ctx.Message().(*actor.PID); ok {
+			received <- ctx.MessageHeader().ToMap()
+		}
+	}))
+
+	headers := actor.NewMessageHeader()
+	headers.Set("trace-id", "42")
+	client.Root.SendWithHeaders(actor.NewPID(server.Address(), "headers"), replyTo, headers)
+
+	select {
+	case header := <-received:
+		assert.Equal(t, map[string]string{"trace-id": "42", "server": "seen"}, header)
+	case <-time.After(5 * time.Second):
+		t.Fatal("reply with headers not received")
+	}
+}
+
+func TestRemote_EndpointStatsEvent(t *testing.T) {
+	server := startEchoRemote(t)
+
+	client := actor.NewActorSystem()
+	clientRemote := NewRemote(client, Configure("localhost", 0, WithEndpointStatsInterval(20*time.Millisecond)))
+	clientRemote.Start()
+	defer clientRemote.Shutdown(false)
+
+	events := make(chan *EndpointStatsEvent, 100)
+	sub := client.EventStream.Subscribe(func(evt interface{}) {
+		if stats, ok := evt.(*EndpointStatsEvent); ok {
+			select {
+			case events <- stats:
+			default:
+			}
+		}
+	})
+	defer client.EventStream.Unsubscribe(sub)
+
+	echo := actor.NewPID(server.Address(), "echo")
+	for i := 0; i < 3; i++ {
+		_, err := client.Root.RequestFuture(echo, actor.NewPID("somewhere", fmt.Sprint(i)), 5*time.Second).Result()
+		assert.NoError(t, err)
+	}
+
+	deadline := time.After(5 * time.Second)
+	for {
+		select {
+		case stats := <-events:
+			if stats.SentMessages < 3 {
+				continue
+			}
+			assert.Equal(t, server.Address(), stats.Address)
+			assert.True(t, stats.Connected)
+			assert.Equal(t, 0, stats.QueueDepth)
+			assert.Greater(t, stats.SentBytes, uint64(0))
+			assert.NoError(t, stats.LastError)
+
+			return
+		case <-deadline:
+			t.Fatal("no EndpointStatsEvent counting the sent messages")
+		}
+	}
+}
+
+func TestRemote_Quarantine(t *testing.T) {
+	server := startEchoRemote(t)
+
+	client := actor.NewActorSystem()
+	clientRemote := NewRemote(client, Configure("localhost", 0, WithQuarantine(2, time.Minute, 200*time.Millisecond)))
+	clientRemote.Start()
+	defer clientRemote.Shutdown(false)
+
+	address := server.Address()
+	states := make(chan QuarantineState, 10)
+	quarantined := make(chan *EndpointQuarantined, 10)
+	sub := client.EventStream.Subscribe(func(evt interface{}) {
+		switch e := evt.(type) {
+		case *EndpointQuarantineEvent:
+			states <- e.State
+		case *actor.DeadLetterEvent:
+			if q, ok := e.Message.(*EndpointQuarantined); ok {
+				quarantined <- q
+			}
+		}
+	})
+	defer client.EventStream.Unsubscribe(sub)
+
+	expectState := func(expected QuarantineState) {
+		select {
+		case state := <-states:
+			assert.Equal(t, expected, state)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("quarantine did not become %v", expected)
+		}
+	}
+
+	// the endpoint flaps
+	client.EventStream.Publish(&EndpointTerminatedEvent{Address: address, Err: ErrHeartbeatMissed})
+	assert.Equal(t, QuarantineClosed, clientRemote.QuarantineState(address))
+	client.EventStream.Publish(&EndpointTerminatedEvent{Address: address, Err: ErrHeartbeatMissed})
+	expectState(QuarantineOpen)
+
+	echo := actor.NewPID(address, "echo")
+	client.Root.Send(echo, actor.NewPID("somewhere", "quarantined"))
+	select {
+	case q := <-quarantined:
+		assert.Equal(t, address, q.Address)
+	case <-time.After(5 * time.Second):
+		t.Fatal("message to a quarantined address was not dead lettered")
+	}
+
+	expectState(QuarantineHalfOpen)
+	_, err := client.Root.RequestFuture(echo, actor.NewPID("somewhere", "half-open"), 5*time.Second).Result()
+	assert.NoError(t, err)
+	expectState(QuarantineClosed)
+}
+
+func TestRemote_Quarantine_WatchTerminates(t *testing.T) {
+	server := startEchoRemote(t)
+
+	client := actor.NewActorSystem()
+	clientRemote := NewRemote(client, Configure("localhost", 0, WithQuarantine(2, time.Minute, time.Minute)))
+	clientRemote.Start()
+	defer clientRemote.Shutdown(false)
+
+	address := server.Address()
+	client.EventStream.Publish(&EndpointTerminatedEvent{Address: address, Err: ErrHeartbeatMissed})
+	client.EventStream.Publish(&EndpointTerminatedEvent{Address: address, Err: ErrHeartbeatMissed})
+	assert.Equal(t, QuarantineOpen, clientRemote.QuarantineState(address))
+
+	// watching a PID of a quarantined address terminates it for the watcher right away
+	echo := actor.NewPID(address, "echo")
+	terminated := make(chan *actor.Terminated, 1)
+	client.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+		switch msg := ctx.Message().(type) {
+		case *actor.Started:
+			ctx.Watch(echo)
+		case *actor.Terminated:
+			terminated <- msg
+		}
+	}))
+
+	select {
+	case msg := <-terminated:
+		assert.True(t, msg.Who.Equal(echo))
+		assert.Equal(t, actor.TerminatedReason_AddressTerminated, msg.Why)
+	case <-time.After(5 * time.Second):
+		t.Fatal("watch of a quarantined address was not terminated")
+	}
+}
+
+func TestEndpointQuarantine_SweepsExpiredFailures(t *testing.T) {
+	r := &Remote{config: &Config{QuarantineFailureThreshold: 3, QuarantineWindow: 20 * time.Millisecond}}
+	q := newEndpointQuarantine(r, func(string) {})
+
+	q.failed("flapping", ErrHeartbeatMissed)
+	q.mu.Lock()
+	assert.Len(t, q.entries, 1)
+	q.mu.Unlock()
+
+	time.Sleep(30 * time.Millisecond)
+	assert.Equal(t, QuarantineClosed, q.state("other"))
+	q.mu.Lock()
+	assert.Empty(t, q.entries)
+	q.mu.Unlock()
+}
+
+func TestRemote_ConnectToAndDisconnect(t *testing.T) {
+	server := startEchoRemote(t)
+
+	client := actor.NewActorSystem()
+	clientRemote := NewRemote(client, Configure("localhost", 0))
+	clientRemote.Start()
+	defer clientRemote.Shutdown(false)
+
+	connected := func() bool {
+		status, _ := clientRemote.EndpointState(server.Address())
+		return status.State == EndpointConnected
+	}
+
+	// the endpoint connects before any message is sent
+	assert.NoError(t, clientRemote.ConnectTo(server.Address()))
+	assert.Eventually(t, connected, 5*time.Second, 10*time.Millisecond)
+
+	clientRemote.Disconnect(server.Address())
+	status, _ := clientRemote.EndpointState(server.Address())
+	assert.Equal(t, EndpointTerminated, status.State)
+	assert.Equal(t, ErrEndpointDisconnected, status.LastError)
+
+	echo := actor.NewPID(server.Address(), "echo")
+	_, err := client.Root.RequestFuture(echo, actor.NewPID("somewhere", "disconnected"), 200*time.Millisecond).Result()
+	assert.Error(t, err)
+	assert.False(t, connected())
+
+	// watching a disconnected address terminates without opening an endpoint
+	terminated := make(chan *actor.Terminated, 1)
+	client.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+		switch msg := ctx.Message().(type) {
+		case *actor.Started:
+			ctx.Watch(echo)
+		case *actor.Terminated:
+			terminated <- msg
+		}
+	}))
+	select {
+	case msg := <-terminated:
+		assert.Equal(t, actor.TerminatedReason_AddressTerminated, msg.Why)
+	case <-time.After(time.Second):
+		t.Fatal("the watcher of a disconnected address was not terminated")
+	}
+	status, _ = clientRemote.EndpointState(server.Address())
+	assert.Equal(t, EndpointTerminated, status.State)
+
+	assert.NoError(t, clientRemote.ConnectTo(server.Address()))
+	_, err = client.Root.RequestFuture(echo, actor.NewPID("somewhere", "reconnected"), 5*time.Second).Result()
+	assert.NoError(t, err)
+}
+
+func TestRemote_ConnectAuthenticator(t *testing.T) {
+	server := actor.NewActorSystem()
+	serverRemote := NewRemote(server, Configure("localhost", 0, WithConnectAuthenticator(func(c *ConnectRequest) error {
+		if string(c.AuthToken) != "secret" || c.Metadata["tenant"] != "acme" {
+			return errors.New("invalid credentials")
+		}
+
+		return nil
+	})))
+	serverRemote.Start()
+	defer serverRemote.Shutdown(false)
+	_, _ = server.Root.SpawnNamed(actor.PropsFromFunc(func(ctx actor.Context) {
+		if msg, ok := ctx.Message().(*actor.PID); ok {
+			ctx.Respond(msg)
+		}
+	}), "echo")
+	echo := actor.NewPID(server.Address(), "echo")
+
+	client := actor.NewActorSystem()
+	clientRemote := NewRemote(client, Configure("localhost", 0, WithConnectAuth([]byte("secret"), map[string]string{"tenant": "acme"})))
+	clientRemote.Start()
+	defer clientRemote.Shutdown(false)
+
+	_, err := client.Root.RequestFuture(echo, actor.NewPID("somewhere", "authenticated"), 5*time.Second).Result()
+	assert.NoError(t, err)
+
+	rejected := actor.NewActorSystem()
+	rejectedRemote := NewRemote(rejected, Configure("localhost", 0, WithConnectAuth([]byte("guess"), nil)))
+	rejectedRemote.Start()
+	defer rejectedRemote.Shutdown(false)
+
+	terminated := make(chan *EndpointTerminatedEvent, 1)
+	rejected.EventStream.Subscribe(func(evt interface{}) {
+		if e, ok := evt.(*EndpointTerminatedEvent); ok {
+			terminated <- e
+		}
+	})
+
+	// the endpoint terminates with the rejection without retrying
+	assert.NoError(t, rejectedRemote.ConnectTo(server.Address()))
+	select {
+	case e := <-terminated:
+		assert.ErrorIs(t, e.Err, ErrConnectRejected)
+		assert.Contains(t, e.Err.Error(), "invalid credentials")
+	case <-time.After(time.Second):
+		t.Fatal("the rejected endpoint did not terminate")
+	}
+
+	// messages to the rejecting address are dead lettered instead of connecting again
+	_, err = rejected.Root.RequestFuture(actor.NewPID(server.Address(), "echo"), actor.NewPID("somewhere", "rejected"), 200*time.Millisecond).Result()
+	assert.Error(t, err)
+	select {
+	case e := <-terminated:
+		t.Fatalf("the rejected connect was retried: %v", e.Err)
+	case <-time.After(200 * time.Millisecond):
+	}
+	status, _ := rejectedRemote.EndpointState(server.Address())
+	assert.ErrorIs(t, status.LastError, ErrConnectRejected)
+}
+
+func TestRemote_StreamInterceptor(t *testing.T) {
+	server := startEchoRemote(t)
+
+	var streams, sent int32
+	interceptor := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		atomic.AddInt32(&streams, 1)
+		s, err := streamer(ctx, desc, cc, method, opts...)
+
+		return &countingClientStream{ClientStream: s, sent: &sent}, err
+	}
+
+	client := actor.NewActorSystem()
+	clientRemote := NewRemote(client, Configure("localhost", 0, WithStreamInterceptor(interceptor)))
+	clientRemote.Start()
+	defer clientRemote.Shutdown(false)
+
+	_, err := client.Root.RequestFuture(actor.NewPID(server.Address(), "echo"), actor.NewPID("somewhere", "intercepted"), 5*time.Second).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&streams))
+	// connect request and message batch
+	assert.GreaterOrEqual(t, atomic.LoadInt32(&sent), int32(2))
+}
+
+type countingClientStream struct {
+	grpc.ClientStream
+	sent *int32
+}
+
+func (s *countingClientStream) SendMsg(m interface{}) error {
+	atomic.AddInt32(s.sent, 1)
+
+	return s.ClientStream.SendMsg(m)
+}
+
+func TestRemote_StopFuture(t *testing.T) {
+	server := startEchoRemote(t)
+	var stoppedHandled int32
+	_, _ = server.Root.SpawnNamed(actor.PropsFromFunc(func(ctx actor.Context) {
+		if _, ok := ctx.Message().(*actor.Stopped); ok {
+			atomic.StoreInt32(&stoppedHandled, 1)
+		}
+	}), "quick")
+	stopping := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+	_, _ = server.Root.SpawnNamed(actor.PropsFromFunc(func(ctx actor.Context) {
+		switch ctx.Message().(type) {
+		case *actor.Stopping:
+			close(stopping)
+		case *actor.Stopped:
+			<-release
+		}
+	}), "slow")
+
+	client := actor.NewActorSystem()
+	clientRemote := NewRemote(client, Configure("localhost", 0))
+	clientRemote.Start()
+	defer clientRemote.Shutdown(false)
+
+	// the remote node confirms the stop once the Stopped handler ran
+	res, err := client.Root.StopFuture(actor.NewPID(server.Address(), "quick")).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, actor.TerminatedReason_Stopped, res.(*actor.Terminated).Why)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&stoppedHandled))
+
+	// an actor which does not finish stopping is reported terminated with its endpoint
+	future := client.Root.StopFuture(actor.NewPID(server.Address(), "slow"))
+	<-stopping
+	client.EventStream.Publish(&EndpointTerminatedEvent{Address: server.Address()})
+	res, err = future.Result()
+	assert.NoError(t, err)
+	assert.Equal(t, actor.TerminatedReason_AddressTerminated, res.(*actor.Terminated).Why)
+}
+
+func TestRemote_WatchDurable_SurvivesEndpointTermination(t *testing.T) {
+	server := startEchoRemote(t)
+	other, _ := server.Root.SpawnNamed(actor.PropsFromFunc(func(ctx actor.Context) {}), "other")
+
+	client := actor.NewActorSystem()
+	clientRemote := NewRemote(client, Configure("localhost", 0))
+	clientRemote.Start()
+	defer clientRemote.Shutdown(false)
+
+	echo := actor.NewPID(server.Address(), "echo")
+	terminated := make(chan *actor.Terminated, 2)
+	_ = client.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+		switch msg := ctx.Message().(type) {
+		case *actor.Started:
+			ctx.WatchDurable(echo)
+			ctx.Watch(other)
+		case *actor.Terminated:
+			terminated <- msg
+		}
+	}))
+
+	_, err := client.Root.RequestFuture(echo, actor.NewPID("somewhere", "durable"), 5*time.Second).Result()
+	assert.NoError(t, err)
+
+	client.EventStream.Publish(&EndpointTerminatedEvent{Address: server.Address()})
+
+	// only the plain watch is told the address terminated
+	msg := <-terminated
+	assert.Equal(t, other.Id, msg.Who.Id)
+	assert.Equal(t, actor.TerminatedReason_AddressTerminated, msg.Why)
+
+	assert.Eventually(t, func() bool {
+		status, _ := clientRemote.EndpointState(server.Address())
+		return status.State == EndpointConnected
+	}, 5*time.Second, 10*time.Millisecond)
+
+	server.Root.Stop(echo)
+
+	select {
+	case msg = <-terminated:
+		assert.Equal(t, echo.Id, msg.Who.Id)
+		// the watch may reach the remote node after the actor stopped
+		assert.Contains(t, []actor.TerminatedReason{actor.TerminatedReason_Stopped, actor.TerminatedReason_NotFound}, msg.Why)
+	case <-time.After(5 * time.Second):
+		t.Fatal("durable watch was not established again")
+	}
+}
+
+func TestRemote_WatchDurable_GivesUpAfterMaxReconnects(t *testing.T) {
+	const address = "localhost:1"
+	client := actor.NewActorSystem()
+	clientRemote := NewRemote(client, Configure("localhost", 0,
+		WithEndpointDenyList(address),
+		WithDurableWatchReconnect(time.Millisecond, 2)))
+	clientRemote.Start()
+	defer clientRemote.Shutdown(false)
+
+	var attempts int32
+	client.EventStream.Subscribe(func(evt interface{}) {
+		if e, ok := evt.(*EndpointTerminatedEvent); ok && e.Address == address {
+			atomic.AddInt32(&attempts, 1)
+		}
+	})
+
+	gone := actor.NewPID(address, "gone")
+	terminated := make(chan *actor.Terminated, 1)
+	_ = client.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+		switch msg := ctx.Message().(type) {
+		case *actor.Started:
+			ctx.WatchDurable(gone)
+		case *actor.Terminated:
+			terminated <- msg
+		}
+	}))
+
+	select {
+	case msg := <-terminated:
+		assert.Equal(t, gone.Id, msg.Who.Id)
+		assert.Equal(t, actor.TerminatedReason_AddressTerminated, msg.Why)
+	case <-time.After(5 * time.Second):
+		t.Fatal("durable watcher was not told the address terminated")
+	}
+
+	// the first endpoint and two reconnects, then the watch is dropped
+	assert.False(t, clientRemote.edpManager.durableWatches.any(address))
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&attempts))
+}
+
+func TestRemote_RawUnknownMessages(t *testing.T) {
+	proxy := actor.NewActorSystem()
+	proxyRemote := NewRemote(proxy, Configure("localhost", 0, WithRawUnknownMessages()))
+	proxyRemote.Start()
+	defer proxyRemote.Shutdown(false)
+
+	received := make(chan *UnknownRemoteMessage, 1)
+	_, _ = proxy.Root.SpawnNamed(actor.PropsFromFunc(func(ctx actor.Context) {
+		if msg, ok := ctx.Message().(*UnknownRemoteMessage); ok {
+			received <- msg
+		}
+	}), "proxy")
+
+	client := actor.NewActorSystem()
+	clientRemote := NewRemote(client, Configure("localhost", 0))
+	clientRemote.Start()
+	defer clientRemote.Shutdown(false)
+
+	// forwarding a raw message sends its bytes unchanged
+	sent := &UnknownRemoteMessage{TypeName: "elsewhere.Unknown", Bytes: []byte{0x0a, 0x02, 'h', 'i'}, SerializerId: 0}
+	client.Root.Send(actor.NewPID(proxy.Address(), "proxy"), sent)
+
+	select {
+	case msg := <-received:
+		assert.Equal(t, sent, msg)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the unknown message was not delivered")
+	}
+}
+
+// assertOrderedDelivery sends a monotonic sequence from one sender to several targets while the endpoint is
+// terminated repeatedly, and asserts each target received its sequence in order. Messages may be lost while reconnecting
+func assertOrderedDelivery(t *testing.T, options ...ConfigOption) {
+	const targets, count = 4, 2000
+
+	server := actor.NewActorSystem()
+	serverRemote := NewRemote(server, Configure("localhost", 0, options...))
+	serverRemote.Start()
+	defer serverRemote.Shutdown(false)
+
+	last := make([]int64, targets)
+	var unordered int32
+	for i := 0; i < targets; i++ {
+		i := i
+		_, _ = server.Root.SpawnNamed(actor.PropsFromFunc(func(ctx actor.Context) {
+			if msg, ok := ctx.Message().(*actor.PID); ok {
+				seq, _ := strconv.ParseInt(msg.Id, 10, 64)
+				if seq <= atomic.LoadInt64(&last[i]) {
+					atomic.AddInt32(&unordered, 1)
+				}
+				atomic.StoreInt64(&last[i], seq)
+			}
+		}), fmt.Sprint("target", i))
+	}
+
+	client := actor.NewActorSystem()
+	clientRemote := NewRemote(client, Configure("localhost", 0, options...))
+	clientRemote.Start()
+	defer clientRemote.Shutdown(false)
+
+	done := make(chan struct{})
+	churned := make(chan struct{})
+	go func() {
+		defer close(churned)
+		for {
+			select {
+			case <-done:
+				return
+			case <-time.After(20 * time.Millisecond):
+				client.EventStream.Publish(&EndpointTerminatedEvent{Address: server.Address()})
+			}
+		}
+	}()
+
+	sender := client.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {
+		if seq, ok := ctx.Message().(int); ok {
+			for i := 0; i < targets; i++ {
+				ctx.Send(actor.NewPID(server.Address(), fmt.Sprint("target", i)), actor.NewPID("seq", fmt.Sprint(seq)))
+			}
+		}
+	}))
+	for i := 1; i <= count; i++ {
+		client.Root.Send(sender, i)
+		if i%100 == 0 {
+			time.Sleep(5 * time.Millisecond)
+		}
+	}
+	close(done)
+	<-churned
+
+	// the final messages are sent once the churn stopped, and must arrive
+	seq := count
+	assert.Eventually(t, func() bool {
+		seq++
+		client.Root.Send(sender, seq)
+		for i := range last {
+			if atomic.LoadInt64(&last[i]) <= count {
+				return false
+			}
+		}
+		return true
+	}, 10*time.Second, 100*time.Millisecond)
+	assert.Zero(t, atomic.LoadInt32(&unordered))
+}
+
+func TestRemote_GuaranteeOrdering(t *testing.T) {
+	assertOrderedDelivery(t, WithGuaranteeOrdering(), WithEndpointStreamCount(4))
+}
+
+func TestRemote_GuaranteeOrdering_Batching(t *testing.T) {
+	assertOrderedDelivery(t, WithGuaranteeOrdering(), WithEndpointStreamCount(4), WithBatching(50, time.Millisecond),
+		WithEndpointWriterDrainTimeout(time.Second))
+}
+
+func TestRemote_Forward_PreservesSenderAcrossNodes(t *testing.T) {
+	startNode := func() *actor.ActorSystem {
+		system := actor.NewActorSystem()
+		remote := NewRemote(system, Configure("localhost", 0))
+		remote.Start()
+		t.Cleanup(func() {
+			remote.Shutdown(false)
+		})
+
+		return system
+	}
+	a, b, c := startNode(), startNode(), startNode()
+
+	senders := make(chan *actor.PID, 1)
+	_, _ = c.Root.SpawnNamed(actor.PropsFromFunc(func(ctx actor.Context) {
+		if msg, ok := ctx.Message().(*actor.PID); ok {
+			senders <- ctx.Sender()
+			ctx.Respond(msg)
+		}
+	}), "responder")
+
+	responder := actor.NewPID(c.Address(), "responder")
+	_, _ = a.Root.SpawnNamed(actor.PropsFromFunc(func(ctx actor.Context) {
+		if _, ok := ctx.Message().(*actor.PID); ok {
+			ctx.Forward(responder)
+		}
+	}), "forwarder")
+
+	// B asks A, A forwards to C, and C answers B directly
+	res, err := b.Root.RequestFuture(actor.NewPID(a.Address(), "forwarder"), actor.NewPID("somewhere", "forwarded"), 5*time.Second).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, "forwarded", res.(*actor.PID).Id)
+
+	sender := <-senders
+	assert.Equal(t, b.Address(), sender.Address)
+}
+
+func TestRemote_Chunking_RoundTrip(t *testing.T) {
+	server := startEchoRemote(t)
+
+	var sent int32
+	interceptor := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			return nil, err
+		}
+
+		return &countingClientStream{ClientStream: stream, sent: &sent}, nil
+	}
+
+	client := actor.NewActorSystem()
+	clientRemote := NewRemote(client, Configure("localhost", 0, WithChunking(16*1024, time.Second), WithStreamInterceptor(interceptor)))
+	clientRemote.Start()
+	defer clientRemote.Shutdown(false)
+
+	echo := actor.NewPID(server.Address(), "echo")
+	_, err := client.Root.RequestFuture(echo, actor.NewPID("somewhere", "small"), 5*time.Second).Result()
+	assert.NoError(t, err)
+	before := atomic.LoadInt32(&sent)
+
+	large := actor.NewPID("somewhere", strings.Repeat("x", 100*1024))
+	res, err := client.Root.RequestFuture(echo, large, 5*time.Second).Result()
+	assert.NoError(t, err)
+	assert.True(t, large.Equal(res.(*actor.PID)))
+	// the large message was sent in seven chunks
+	assert.Equal(t, int32(7), atomic.LoadInt32(&sent)-before)
+}
+
+func TestRemote_Chunking_Streams(t *testing.T) {
+	server := startEchoRemote(t)
+
+	client := actor.NewActorSystem()
+	clientRemote := NewRemote(client, Configure("localhost", 0, WithChunking(16*1024, time.Second), WithEndpointStreamCount(4)))
+	clientRemote.Start()
+	defer clientRemote.Shutdown(false)
+
+	// the messages to several targets are sharded across the streams, which chunk them concurrently
+	echoes := make([]*actor.PID, 8)
+	for i := range echoes {
+		pid, err := server.Root.SpawnNamed(actor.PropsFromFunc(func(ctx actor.Context) {
+			if msg, ok := ctx.Message().(*actor.PID); ok {
+				ctx.Respond(msg)
+			}
+		}), "echo"+strconv.Itoa(i))
+		require.NoError(t, err)
+		echoes[i] = actor.NewPID(server.Address(), pid.Id)
+	}
+
+	futures := make([]*actor.Future, 0, 40)
+	larges := make([]*actor.PID, 0, 40)
+	for i := 0; i < 40; i++ {
+		large := actor.NewPID("somewhere", strings.Repeat(strconv.Itoa(i%10), 50*1024))
+		larges = append(larges, large)
+		futures = append(futures, client.Root.RequestFuture(echoes[i%len(echoes)], large, 5*time.Second))
+	}
+	for i, future := range futures {
+		res, err := future.Result()
+		assert.NoError(t, err)
+		assert.True(t, larges[i].Equal(res.(*actor.PID)))
+	}
+}
+
+func TestChunkAssembler(t *testing.T) {
+	batch := &MessageBatch{TypeNames: []string{strings.Repeat("x", 100)}}
+	chunks, err := chunkBatch(1, batch, 30)
+	assert.NoError(t, err)
+	assert.Len(t, chunks, 4)
+
+	now := time.Now()
+	assembler := newChunkAssembler(time.Second, 1000, 1000)
+	for _, chunk := range chunks[:3] {
+		res, err := assembler.add(chunk.GetChunk(), now)
+		assert.NoError(t, err)
+		assert.Nil(t, res)
+	}
+	res, err := assembler.add(chunks[3].GetChunk(), now)
+	assert.NoError(t, err)
+	assert.Equal(t, batch.TypeNames, res.TypeNames)
+
+	// an incomplete transfer is discarded once the timeout elapsed
+	_, _ = assembler.add(chunks[0].GetChunk(), now)
+	_, _ = assembler.add(&MessageChunk{TransferId: 2, Count: 2, Data: []byte{1}}, now.Add(2*time.Second))
+	assert.Len(t, assembler.transfers, 1)
+	assert.Contains(t, assembler.transfers, uint64(2))
+}
+
+func TestChunkAssembler_Limits(t *testing.T) {
+	now := time.Now()
+	assembler := newChunkAssembler(time.Second, 100, 150)
+
+	// the announced count is not trusted to allocate
+	_, err := assembler.add(&MessageChunk{TransferId: 1, Count: 0xFFFFFFFF, Data: []byte{1}}, now)
+	assert.ErrorIs(t, err, ErrInvalidChunk)
+
+	// chunks out of order discard the transfer
+	_, err = assembler.add(&MessageChunk{TransferId: 2, Index: 1, Count: 3, Data: []byte{1}}, now)
+	assert.ErrorIs(t, err, ErrInvalidChunk)
+	assert.Empty(t, assembler.transfers)
+
+	// a transfer larger than the limit is discarded
+	data := make([]byte, 60)
+	_, err = assembler.add(&MessageChunk{TransferId: 3, Index: 0, Count: 2, Data: data}, now)
+	assert.NoError(t, err)
+	_, err = assembler.add(&MessageChunk{TransferId: 3, Index: 1, Count: 2, Data: data}, now)
+	assert.ErrorIs(t, err, ErrChunkLimit)
+	assert.Empty(t, assembler.transfers)
+	assert.Equal(t, 0, assembler.buffered)
+
+	// so are the transfers exceeding the buffer of the stream
+	for id := uint64(4); id < 6; id++ {
+		_, err = assembler.add(&MessageChunk{TransferId: id, Index: 0, Count: 2, Data: data}, now)
+		assert.NoError(t, err)
+	}
+	_, err = assembler.add(&MessageChunk{TransferId: 6, Index: 0, Count: 2, Data: data}, now)
+	assert.ErrorIs(t, err, ErrChunkLimit)
+	assert.Len(t, assembler.transfers, 2)
+	assert.Equal(t, 120, assembler.buffered)
+}
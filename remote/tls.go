@@ -0,0 +1,63 @@
+package remote
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// mutualTLS builds the client and server TLS configuration for mutual authentication from the same files.
+// The certificate chain is verified against the CA up front, so a misconfiguration is reported at startup
+func mutualTLS(caFile, certFile, keyFile, serverNameOverride string) (client *tls.Config, server *tls.Config, err error) {
+	ca, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, nil, fmt.Errorf("remote: failed to read CA: %w", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, nil, errors.New("remote: no certificates found in CA file " + caFile)
+	}
+
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, nil, fmt.Errorf("remote: failed to load key pair: %w", err)
+	}
+
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		return nil, nil, fmt.Errorf("remote: failed to parse certificate: %w", err)
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, c := range cert.Certificate[1:] {
+		if ic, err := x509.ParseCertificate(c); err == nil {
+			intermediates.AddCert(ic)
+		}
+	}
+
+	if _, err := leaf.Verify(x509.VerifyOptions{
+		Roots:         pool,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}); err != nil {
+		return nil, nil, fmt.Errorf("remote: certificate is not signed by the CA: %w", err)
+	}
+
+	client = &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      pool,
+		ServerName:   serverNameOverride,
+		MinVersion:   tls.VersionTLS12,
+	}
+	server = &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	return client, server, nil
+}
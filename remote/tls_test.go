@@ -0,0 +1,112 @@
+package remote
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/stretchr/testify/assert"
+)
+
+// writeCert writes a certificate signed by parent, or self signed when parent is nil
+func writeCert(t *testing.T, dir, name string, template *x509.Certificate, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	assert.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, name+".pem"), pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, name+".key"), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0o600))
+
+	cert, err := x509.ParseCertificate(der)
+	assert.NoError(t, err)
+
+	return cert, key
+}
+
+func writeTestPKI(t *testing.T) string {
+	dir := t.TempDir()
+	ca := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caCert, caKey := writeCert(t, dir, "ca", ca, nil, nil)
+
+	writeCert(t, dir, "node", &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "node"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}, caCert, caKey)
+
+	// a second CA which did not sign the node certificate
+	writeCert(t, dir, "other", &x509.Certificate{
+		SerialNumber:          big.NewInt(3),
+		Subject:               pkix.Name{CommonName: "other-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, nil, nil)
+
+	return dir
+}
+
+func TestWithMutualTLS_RoundTrip(t *testing.T) {
+	dir := writeTestPKI(t)
+	option := WithMutualTLS(filepath.Join(dir, "ca.pem"), filepath.Join(dir, "node.pem"), filepath.Join(dir, "node.key"), "localhost")
+
+	server := actor.NewActorSystem()
+	serverRemote := NewRemote(server, Configure("localhost", 0, option))
+	serverRemote.Start()
+	defer serverRemote.Shutdown(false)
+	_, _ = server.Root.SpawnNamed(actor.PropsFromFunc(func(ctx actor.Context) {
+		if msg, ok := ctx.Message().(*actor.PID); ok {
+			ctx.Respond(msg)
+		}
+	}), "echo")
+
+	client := actor.NewActorSystem()
+	clientRemote := NewRemote(client, Configure("localhost", 0, option))
+	clientRemote.Start()
+	defer clientRemote.Shutdown(false)
+
+	res, err := client.Root.RequestFuture(actor.NewPID(server.Address(), "echo"), actor.NewPID("somewhere", "tls"), 5*time.Second).Result()
+	assert.NoError(t, err)
+	assert.Equal(t, "tls", res.(*actor.PID).Id)
+}
+
+func TestWithMutualTLS_FailsFast(t *testing.T) {
+	dir := writeTestPKI(t)
+	config := Configure("localhost", 0, WithMutualTLS(filepath.Join(dir, "other.pem"), filepath.Join(dir, "node.pem"), filepath.Join(dir, "node.key"), ""))
+	assert.Error(t, config.configErr)
+
+	remote := NewRemote(actor.NewActorSystem(), config)
+	assert.Panics(t, remote.Start)
+}
@@ -0,0 +1,46 @@
+package remote
+
+// RemoteStream is a bidirectional stream of RemoteMessages between two endpoints
+type RemoteStream interface {
+	Send(message *RemoteMessage) error
+	Recv() (*RemoteMessage, error)
+}
+
+// RemoteConnection is an outbound stream opened by a Transport
+type RemoteConnection interface {
+	RemoteStream
+
+	// CloseSend tells the peer no more messages will be sent
+	CloseSend() error
+
+	// Close releases the underlying connection
+	Close() error
+}
+
+// StreamHandler processes an inbound stream until it completes
+type StreamHandler func(stream RemoteStream) error
+
+// Listener accepts inbound streams for a Transport
+type Listener interface {
+	// Address returns the address the listener is bound to
+	Address() string
+
+	// Serve accepts inbound streams until the listener is stopped
+	Serve() error
+
+	// GracefulStop stops accepting streams and waits for the active ones to complete
+	GracefulStop()
+
+	// Stop closes all streams immediately
+	Stop()
+}
+
+// Transport opens the connections used by endpoints to exchange messages.
+// gRPC is used unless another transport is configured using WithTransport
+type Transport interface {
+	// Dial opens a stream to the endpoint listening on address
+	Dial(address string) (RemoteConnection, error)
+
+	// Listen binds to address, handing every inbound stream to handler
+	Listen(address string, handler StreamHandler) (Listener, error)
+}
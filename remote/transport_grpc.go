@@ -0,0 +1,261 @@
+package remote
+
+import (
+	"net"
+	"sync"
+	"time"
+
+	"github.com/asynkron/protoactor-go/log"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+type grpcTransport struct {
+	config *Config
+	logger log.Interface
+}
+
+var _ Transport = &grpcTransport{}
+
+func newGrpcTransport(config *Config, logger log.Interface) Transport {
+	return &grpcTransport{
+		config: config,
+		logger: logger,
+	}
+}
+
+func (t *grpcTransport) Dial(address string) (RemoteConnection, error) {
+	c := &grpcConnection{
+		transport:  t,
+		address:    address,
+		compressed: len(compressionCallOptions(t.config)) > 0,
+	}
+	if err := c.open(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (t *grpcTransport) Listen(address string, handler StreamHandler) (Listener, error) {
+	lis, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, err
+	}
+
+	s := grpc.NewServer(t.config.ServerOptions...)
+	RegisterRemotingServer(s, &grpcRemotingServer{handler: handler})
+
+	return &grpcListener{
+		listener: lis,
+		server:   s,
+	}, nil
+}
+
+// dialOptions adds the configured interceptors to Config.DialOptions, chaining them after interceptors already set there
+func (t *grpcTransport) dialOptions() []grpc.DialOption {
+	if len(t.config.StreamInterceptors) == 0 && len(t.config.UnaryInterceptors) == 0 {
+		return t.config.DialOptions
+	}
+
+	options := make([]grpc.DialOption, 0, len(t.config.DialOptions)+2)
+	options = append(options, t.config.DialOptions...)
+	if len(t.config.StreamInterceptors) > 0 {
+		options = append(options, grpc.WithChainStreamInterceptor(t.config.StreamInterceptors...))
+	}
+	if len(t.config.UnaryInterceptors) > 0 {
+		options = append(options, grpc.WithChainUnaryInterceptor(t.config.UnaryInterceptors...))
+	}
+
+	return options
+}
+
+func (t *grpcTransport) callOptions(compressed bool) []grpc.CallOption {
+	if !compressed {
+		return t.config.CallOptions
+	}
+
+	compression := compressionCallOptions(t.config)
+	options := make([]grpc.CallOption, 0, len(t.config.CallOptions)+len(compression))
+	options = append(options, t.config.CallOptions...)
+
+	return append(options, compression...)
+}
+
+// grpcConnection is a client stream of the Remoting service.
+// If the peer cannot decompress the stream, it transparently reconnects without compression
+type grpcConnection struct {
+	transport *grpcTransport
+	address   string
+
+	// mu guards the fields below, Send and Recv are called from different goroutines and the fallback to an
+	// uncompressed stream replaces the stream while Send may use it
+	mu         sync.Mutex
+	conn       *grpc.ClientConn
+	stream     Remoting_ReceiveClient
+	cancel     context.CancelFunc
+	compressed bool
+	// first is the first message sent, replayed when falling back to an uncompressed stream
+	first    *RemoteMessage
+	received bool
+	closed   bool
+}
+
+func (c *grpcConnection) open() error {
+	dialCtx := context.Background()
+	if c.transport.config.DialTimeout > 0 {
+		var cancel context.CancelFunc
+		dialCtx, cancel = context.WithTimeout(dialCtx, c.transport.config.DialTimeout)
+		defer cancel()
+	}
+
+	conn, err := grpc.DialContext(dialCtx, c.address, c.transport.dialOptions()...)
+	if err != nil {
+		return err
+	}
+
+	// the stream outlives the dial, so DialTimeout only cancels it while it is being established
+	streamCtx, cancel := context.WithCancel(context.Background())
+	var timer *time.Timer
+	if c.transport.config.DialTimeout > 0 {
+		timer = time.AfterFunc(c.transport.config.DialTimeout, cancel)
+	}
+
+	stream, err := NewRemotingClient(conn).Receive(streamCtx, c.transport.callOptions(c.compressed)...)
+	if timer != nil && !timer.Stop() && err == nil {
+		err = context.DeadlineExceeded
+	}
+	if err != nil {
+		c.transport.logger.Error("EndpointWriter failed to create receive stream", log.String("address", c.address), log.Error(err))
+		cancel()
+		_ = conn.Close()
+
+		return err
+	}
+
+	c.conn = conn
+	c.stream = stream
+	c.cancel = cancel
+
+	return nil
+}
+
+func (c *grpcConnection) Send(message *RemoteMessage) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.first == nil && !c.received {
+		c.first = message
+	}
+
+	return c.stream.Send(message)
+}
+
+func (c *grpcConnection) Recv() (*RemoteMessage, error) {
+	c.mu.Lock()
+	stream := c.stream
+	c.mu.Unlock()
+
+	msg, err := stream.Recv()
+	if err != nil && isCompressionUnsupported(err) {
+		replaced, fallbackErr := c.fallback()
+		if fallbackErr != nil {
+			return nil, fallbackErr
+		}
+		if replaced != nil {
+			msg, err = replaced.Recv()
+		}
+	}
+
+	if err == nil {
+		c.mu.Lock()
+		if !c.received {
+			c.received = true
+			c.first = nil
+		}
+		c.mu.Unlock()
+	}
+
+	return msg, err
+}
+
+// fallback replaces a compressed stream the peer cannot decompress by an uncompressed one, replaying the first
+// message. It returns a nil stream when the stream is not replaced, as it received messages or was closed.
+// The lock is not held while receiving from the new stream, so that Close can interrupt it
+func (c *grpcConnection) fallback() (Remoting_ReceiveClient, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.received || !c.compressed || c.closed {
+		return nil, nil
+	}
+
+	c.transport.logger.Warn("EndpointWriter peer does not support compression, falling back to uncompressed stream", log.String("address", c.address))
+	_ = c.close()
+	c.compressed = false
+
+	if err := c.open(); err != nil {
+		return nil, err
+	}
+
+	if c.first != nil {
+		if err := c.stream.Send(c.first); err != nil {
+			return nil, err
+		}
+	}
+
+	return c.stream, nil
+}
+
+func (c *grpcConnection) CloseSend() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.stream.CloseSend()
+}
+
+func (c *grpcConnection) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.closed = true
+
+	return c.close()
+}
+
+func (c *grpcConnection) close() error {
+	c.cancel()
+
+	return c.conn.Close()
+}
+
+type grpcListener struct {
+	listener net.Listener
+	server   *grpc.Server
+}
+
+func (l *grpcListener) Address() string {
+	return l.listener.Addr().String()
+}
+
+func (l *grpcListener) Serve() error {
+	return l.server.Serve(l.listener)
+}
+
+func (l *grpcListener) GracefulStop() {
+	l.server.GracefulStop()
+}
+
+func (l *grpcListener) Stop() {
+	l.server.Stop()
+}
+
+type grpcRemotingServer struct {
+	UnimplementedRemotingServer
+	handler StreamHandler
+}
+
+func (s *grpcRemotingServer) Receive(stream Remoting_ReceiveServer) error {
+	return s.handler(stream)
+}
@@ -1,6 +1,10 @@
 package router
 
 import (
+	"fmt"
+	"sync"
+	"time"
+
 	"github.com/asynkron/protoactor-go/actor"
 )
 
@@ -52,3 +56,76 @@ func (config *broadcastPoolRouter) CreateRouterState() State {
 func (config *broadcastGroupRouter) CreateRouterState() State {
 	return &broadcastRouterState{}
 }
+
+// BroadcastFailure is a routee which did not ack a BroadcastWithAck, and the reason
+type BroadcastFailure struct {
+	PID *actor.PID
+	// Err is actor.ErrTimeout if the routee did not reply in time, actor.ErrDeadLetter if the routee is gone,
+	// or a *RouteeFailedError if the routee failed while the broadcast was pending
+	Err error
+}
+
+// BroadcastAck is the response to BroadcastWithAck
+type BroadcastAck struct {
+	Acked  []*actor.PID
+	Failed []BroadcastFailure
+}
+
+// RouteeFailedError is reported for a routee which failed before acking a broadcast
+type RouteeFailedError struct {
+	Reason interface{}
+}
+
+func (e *RouteeFailedError) Error() string {
+	return fmt.Sprintf("routee failed: %v", e.Reason)
+}
+
+// RequestBroadcastWithAck sends message to all routees of the router as a request. The routees ack by responding,
+// the future completes with a BroadcastAck once all routees acked, or timeout elapsed
+func RequestBroadcastWithAck(sender actor.SenderContext, router *actor.PID, message interface{}, timeout time.Duration) *actor.Future {
+	// the router responds once timeout elapsed, leave it time to do so
+	return sender.RequestFuture(router, &BroadcastWithAck{Message: message, Timeout: timeout}, timeout+time.Second)
+}
+
+// broadcastWithAck requests the message from every routee, and responds to the sender with a BroadcastAck
+func broadcastWithAck(context actor.Context, routees *actor.PIDSet, m *BroadcastWithAck) {
+	system := context.ActorSystem()
+	replyTo := context.Sender()
+	pids := append([]*actor.PID(nil), routees.Values()...)
+	watched := actor.NewPIDSet(pids...)
+
+	// failures of routees are only published on the event stream
+	var failures sync.Map
+	sub := system.EventStream.Subscribe(func(evt interface{}) {
+		if e, ok := evt.(*actor.SupervisorEvent); ok && watched.Contains(e.Child) {
+			failures.Store(e.Child.Id, e.Reason)
+		}
+	})
+
+	futures := make([]*actor.Future, len(pids))
+	for i, pid := range pids {
+		futures[i] = actor.NewFuture(system, m.Timeout)
+		context.RequestWithCustomSender(pid, m.Message, futures[i].PID())
+	}
+
+	go func() {
+		defer system.EventStream.Unsubscribe(sub)
+
+		ack := &BroadcastAck{}
+		for i, f := range futures {
+			_, err := f.Result()
+			if err == nil {
+				ack.Acked = append(ack.Acked, pids[i])
+				continue
+			}
+			if reason, ok := failures.Load(pids[i].Id); ok {
+				err = &RouteeFailedError{Reason: reason}
+			}
+			ack.Failed = append(ack.Failed, BroadcastFailure{PID: pids[i], Err: err})
+		}
+
+		if replyTo != nil {
+			system.Root.Send(replyTo, ack)
+		}
+	}()
+}
@@ -38,3 +38,43 @@ func TestBroadcastRouterThreadSafe(t *testing.T) {
 
 	wg.Wait()
 }
+
+func TestBroadcastWithAck(t *testing.T) {
+	ack := func(c actor.Context) {
+		switch c.Message() {
+		case "fail":
+			panic("failing routee")
+		case "ack", "ignore":
+			if c.Message() == "ack" {
+				c.Respond(true)
+			}
+		}
+	}
+	acking := system.Root.Spawn(actor.PropsFromFunc(ack))
+	dead := system.Root.Spawn(actor.PropsFromFunc(ack))
+	_ = system.Root.StopFuture(dead).Wait()
+
+	grp := system.Root.Spawn(NewBroadcastGroup(acking, dead))
+	res, err := RequestBroadcastWithAck(system.Root, grp, "ack", 100*time.Millisecond).Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := res.(*BroadcastAck)
+	if len(result.Acked) != 1 || !result.Acked[0].Equal(acking) {
+		t.Errorf("expected %v to ack, got %v", acking, result.Acked)
+	}
+	if len(result.Failed) != 1 || result.Failed[0].Err != actor.ErrDeadLetter {
+		t.Errorf("expected the stopped routee to be dead lettered, got %v", result.Failed)
+	}
+
+	failing := system.Root.Spawn(actor.PropsFromFunc(ack))
+	grp = system.Root.Spawn(NewBroadcastGroup(failing))
+	res, _ = RequestBroadcastWithAck(system.Root, grp, "fail", 100*time.Millisecond).Result()
+	result = res.(*BroadcastAck)
+	if len(result.Failed) != 1 {
+		t.Fatalf("expected the failing routee to be reported, got %v", result.Failed)
+	}
+	if _, ok := result.Failed[0].Err.(*RouteeFailedError); !ok {
+		t.Errorf("expected a RouteeFailedError, got %v", result.Failed[0].Err)
+	}
+}
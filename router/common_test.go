@@ -1,6 +1,7 @@
 package router
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"log"
@@ -80,18 +81,42 @@ func (m *mockContext) Stash() {
 	m.Called()
 }
 
+func (m *mockContext) SetReady() {
+	m.Called()
+}
+
 func (m *mockContext) Watch(pid *actor.PID) {
 	m.Called(pid)
 }
 
+func (m *mockContext) WatchDurable(pid *actor.PID) {
+	m.Called(pid)
+}
+
 func (m *mockContext) Unwatch(pid *actor.PID) {
 	m.Called(pid)
 }
 
+func (m *mockContext) SetTimer(key string, d time.Duration, message interface{}) {
+	m.Called(key, d, message)
+}
+
+func (m *mockContext) SetRecurringTimer(key string, interval time.Duration, message interface{}) {
+	m.Called(key, interval, message)
+}
+
+func (m *mockContext) CancelTimer(key string) {
+	m.Called(key)
+}
+
 func (m *mockContext) SetReceiveTimeout(d time.Duration) {
 	m.Called(d)
 }
 
+func (m *mockContext) SetReceiveTimeoutOnce(d time.Duration) {
+	m.Called(d)
+}
+
 func (m *mockContext) CancelReceiveTimeout() {
 	m.Called()
 }
@@ -104,6 +129,20 @@ func (m *mockContext) ReenterAfter(f *actor.Future, cont func(res interface{}, e
 	m.Called(f, cont)
 }
 
+func (m *mockContext) BehaviorStackSize() int {
+	args := m.Called()
+	return args.Int(0)
+}
+
+func (m *mockContext) RequestScope() actor.RequestScope {
+	args := m.Called()
+	return args.Get(0).(actor.RequestScope)
+}
+
+func (m *mockContext) RequestWithRetry(pid *actor.PID, message interface{}, opts actor.RetryOptions, cont func(res interface{}, err error)) {
+	m.Called(pid, message, opts, cont)
+}
+
 //
 // Interface: SenderContext
 //
@@ -124,6 +163,10 @@ func (m *mockContext) Send(pid *actor.PID, message interface{}) {
 	p.SendUserMessage(pid, message)
 }
 
+func (m *mockContext) SendWithHeaders(pid *actor.PID, message interface{}, headers actor.ReadonlyMessageHeader) {
+	m.Called(pid, message, headers)
+}
+
 func (m *mockContext) Request(pid *actor.PID, message interface{}) {
 	args := m.Called()
 	p, _ := system.ProcessRegistry.Get(pid)
@@ -154,6 +197,11 @@ func (m *mockContext) RequestFuture(pid *actor.PID, message interface{}, timeout
 	return args.Get(0).(*actor.Future)
 }
 
+func (m *mockContext) RequestFutureCtx(pid *actor.PID, message interface{}, ctx context.Context) *actor.Future {
+	args := m.Called()
+	return args.Get(0).(*actor.Future)
+}
+
 //
 // Interface: ReceiverContext
 //
@@ -198,6 +246,11 @@ func (m *mockContext) Poison(pid *actor.PID) {
 	m.Called(pid)
 }
 
+func (m *mockContext) PoisonDrain(pid *actor.PID, timeout time.Duration) *actor.Future {
+	args := m.Called(pid, timeout)
+	return args.Get(0).(*actor.Future)
+}
+
 func (m *mockContext) PoisonFuture(pid *actor.PID) *actor.Future {
 	args := m.Called(pid)
 	return args.Get(0).(*actor.Future)
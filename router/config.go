@@ -48,6 +48,10 @@ func (config *PoolRouter) OnStarted(context actor.Context, props *actor.Props, s
 	state.SetRoutees(&routees)
 }
 
+func (config *PoolRouter) poolSize() int {
+	return config.PoolSize
+}
+
 func (config *PoolRouter) RouterType() RouterType {
 	return PoolRouterType
 }
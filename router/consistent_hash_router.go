@@ -2,21 +2,98 @@ package router
 
 import (
 	"log"
+	"math"
 
 	"github.com/asynkron/protoactor-go/actor"
 	"github.com/serialx/hashring"
 )
 
+// Hashable is implemented by messages routed by a consistent hash router, HashKey returns the key they are routed by
+type Hashable interface {
+	HashKey() string
+}
+
+// Hasher is the former Hashable, messages implementing it are routed by Hash
+//
+// Deprecated: implement Hashable instead.
 type Hasher interface {
 	Hash() string
 }
 
+// KeyExtractor returns the hash key of a message, false if the message can not be routed
+type KeyExtractor func(message interface{}) (string, bool)
+
+// ConsistentHashOption configures a consistent hash router
+type ConsistentHashOption func(config *consistentHashConfig)
+
+// WithHashFunc sets the function hashing message keys and routees onto the ring, md5 is used by default
+func WithHashFunc(hash func(data []byte) uint32) ConsistentHashOption {
+	return func(config *consistentHashConfig) {
+		config.hashFunc = func(data []byte) hashring.HashKey {
+			return hashring.Uint32HashKey(hash(data))
+		}
+	}
+}
+
+// WithKeyExtractor sets the function returning the hash key of messages which implement neither Hashable nor Hasher.
+// The key of a message is taken from HashKey first, then from Hash, then from the extractor
+func WithKeyExtractor(extractor KeyExtractor) ConsistentHashOption {
+	return func(config *consistentHashConfig) {
+		config.keyOf = extractor
+	}
+}
+
+// WithDefaultRoutee sets the routee receiving the messages which have no hash key, they are dead lettered by default
+func WithDefaultRoutee(pid *actor.PID) ConsistentHashOption {
+	return func(config *consistentHashConfig) {
+		config.defaultRoutee = pid
+	}
+}
+
+type consistentHashConfig struct {
+	hashFunc hashring.HashFunc
+	keyOf    KeyExtractor
+	// defaultRoutee receives the messages without a hash key, nil dead letters them
+	defaultRoutee *actor.PID
+	// loadFactor bounds the load of a routee to loadFactor times the average load, zero leaves loads unbounded
+	loadFactor float64
+}
+
+func newConsistentHashConfig(loadFactor float64, options ...ConsistentHashOption) consistentHashConfig {
+	config := consistentHashConfig{
+		loadFactor: loadFactor,
+	}
+	for _, option := range options {
+		option(&config)
+	}
+
+	return config
+}
+
+// hashKey returns the key message is routed by, see WithKeyExtractor for the precedence
+func (config *consistentHashConfig) hashKey(message interface{}) (string, bool) {
+	switch msg := message.(type) {
+	case Hashable:
+		return msg.HashKey(), true
+	case Hasher:
+		return msg.Hash(), true
+	}
+
+	if config.keyOf != nil {
+		return config.keyOf(message)
+	}
+
+	return "", false
+}
+
 type consistentHashGroupRouter struct {
 	GroupRouter
+	config consistentHashConfig
 }
 
 type consistentHashPoolRouter struct {
 	PoolRouter
+	config consistentHashConfig
 }
 
 type hashmapContainer struct {
@@ -26,6 +103,7 @@ type hashmapContainer struct {
 type consistentHashRouterState struct {
 	hmc    *hashmapContainer
 	sender actor.SenderContext
+	config consistentHashConfig
 }
 
 func (state *consistentHashRouterState) SetSender(sender actor.SenderContext) {
@@ -43,7 +121,11 @@ func (state *consistentHashRouterState) SetRoutees(routees *actor.PIDSet) {
 		hmc.routeeMap[nodeName] = pid
 	})
 	// initialize hashring for mapping message keys to node names
-	hmc.hashring = hashring.New(nodes)
+	if state.config.hashFunc != nil {
+		hmc.hashring = hashring.NewWithHash(nodes, state.config.hashFunc)
+	} else {
+		hmc.hashring = hashring.New(nodes)
+	}
 	state.hmc = &hmc
 }
 
@@ -58,24 +140,73 @@ func (state *consistentHashRouterState) GetRoutees() *actor.PIDSet {
 
 func (state *consistentHashRouterState) RouteMessage(message interface{}) {
 	_, uwpMsg, _ := actor.UnwrapEnvelope(message)
-	switch msg := uwpMsg.(type) {
-	case Hasher:
-		key := msg.Hash()
-		hmc := state.hmc
+	key, ok := state.config.hashKey(uwpMsg)
+	if !ok {
+		state.routeUnkeyed(message)
+		return
+	}
 
-		node, ok := hmc.hashring.GetNode(key)
-		if !ok {
-			log.Printf("[ROUTING] Consistent has router failed to derminate routee: %v", key)
-			return
-		}
-		if routee, ok := hmc.routeeMap[node]; ok {
-			state.sender.Send(routee, message)
-		} else {
-			log.Println("[ROUTING] Consistent router failed to resolve node", node)
+	hmc := state.hmc
+	node, ok := state.nodeFor(hmc, key)
+	if !ok {
+		log.Printf("[ROUTING] Consistent has router failed to derminate routee: %v", key)
+		return
+	}
+	if routee, ok := hmc.routeeMap[node]; ok {
+		state.sender.Send(routee, message)
+	} else {
+		log.Println("[ROUTING] Consistent router failed to resolve node", node)
+	}
+}
+
+// routeUnkeyed sends a message without a hash key to the default routee, or to dead letters
+func (state *consistentHashRouterState) routeUnkeyed(message interface{}) {
+	if state.config.defaultRoutee != nil {
+		state.sender.Send(state.config.defaultRoutee, message)
+		return
+	}
+
+	system := state.sender.ActorSystem()
+	system.DeadLetter.SendUserMessage(state.sender.Self(), message)
+}
+
+// nodeFor returns the node of the key on the ring. With bounded loads, nodes at capacity are skipped
+// in ring order, the capacity being loadFactor times the average load, rounded up
+func (state *consistentHashRouterState) nodeFor(hmc *hashmapContainer, key string) (string, bool) {
+	if state.config.loadFactor <= 0 {
+		return hmc.hashring.GetNode(key)
+	}
+
+	nodes, ok := hmc.hashring.GetNodes(key, len(hmc.routeeMap))
+	if !ok {
+		return "", false
+	}
+
+	loads := make([]int, len(nodes))
+	total := 0
+	for i, node := range nodes {
+		loads[i] = state.load(hmc.routeeMap[node])
+		total += loads[i]
+	}
+
+	capacity := int(math.Ceil(state.config.loadFactor * float64(total+1) / float64(len(nodes))))
+	for i, node := range nodes {
+		if loads[i] < capacity {
+			return node, true
 		}
-	default:
-		log.Println("[ROUTING] Message must implement router.Hasher", msg)
 	}
+
+	return nodes[0], true
+}
+
+// load returns the number of messages queued for a routee. Only local routees report their load
+func (state *consistentHashRouterState) load(pid *actor.PID) int {
+	process, _ := state.sender.ActorSystem().ProcessRegistry.Get(pid)
+	if ap, ok := process.(*actor.ActorProcess); ok {
+		return ap.UserMessageCount()
+	}
+
+	return 0
 }
 
 func (state *consistentHashRouterState) InvokeRouterManagementMessage(msg ManagementMessage, sender *actor.PID) {
@@ -83,18 +214,42 @@ func (state *consistentHashRouterState) InvokeRouterManagementMessage(msg Manage
 
 func NewConsistentHashPool(size int, opts ...actor.PropsOption) *actor.Props {
 	return (&actor.Props{}).
-		Configure(actor.WithSpawnFunc(spawner(&consistentHashPoolRouter{PoolRouter{PoolSize: size}}))).
+		Configure(actor.WithSpawnFunc(spawner(&consistentHashPoolRouter{PoolRouter: PoolRouter{PoolSize: size}}))).
 		Configure(opts...)
 }
 
 func NewConsistentHashGroup(routees ...*actor.PID) *actor.Props {
-	return (&actor.Props{}).Configure(actor.WithSpawnFunc(spawner(&consistentHashGroupRouter{GroupRouter{Routees: actor.NewPIDSet(routees...)}})))
+	return (&actor.Props{}).Configure(actor.WithSpawnFunc(spawner(&consistentHashGroupRouter{GroupRouter: GroupRouter{Routees: actor.NewPIDSet(routees...)}})))
+}
+
+// NewBoundedConsistentHashPool creates a consistent hash pool where no routee is loaded over loadFactor times
+// the average load, messages for a full routee spill to the next routee on the ring.
+// The load of a routee is the number of messages queued in its mailbox, a zero loadFactor leaves loads unbounded.
+// opts configure the props of the pool routees
+func NewBoundedConsistentHashPool(size int, loadFactor float64, options []ConsistentHashOption, opts ...actor.PropsOption) *actor.Props {
+	config := newConsistentHashConfig(loadFactor, options...)
+
+	return (&actor.Props{}).
+		Configure(actor.WithSpawnFunc(spawner(&consistentHashPoolRouter{PoolRouter{PoolSize: size}, config}))).
+		Configure(opts...)
+}
+
+// NewBoundedConsistentHashGroup creates a consistent hash group where no routee is loaded over loadFactor times
+// the average load, messages for a full routee spill to the next routee on the ring.
+// Remote routees do not report their load, and are never considered full. A zero loadFactor leaves loads unbounded.
+// opts configure the props of the router
+func NewBoundedConsistentHashGroup(loadFactor float64, routees []*actor.PID, options []ConsistentHashOption, opts ...actor.PropsOption) *actor.Props {
+	config := newConsistentHashConfig(loadFactor, options...)
+
+	return (&actor.Props{}).
+		Configure(actor.WithSpawnFunc(spawner(&consistentHashGroupRouter{GroupRouter{Routees: actor.NewPIDSet(routees...)}, config}))).
+		Configure(opts...)
 }
 
 func (config *consistentHashPoolRouter) CreateRouterState() State {
-	return &consistentHashRouterState{}
+	return &consistentHashRouterState{config: config.config}
 }
 
 func (config *consistentHashGroupRouter) CreateRouterState() State {
-	return &consistentHashRouterState{}
+	return &consistentHashRouterState{config: config.config}
 }
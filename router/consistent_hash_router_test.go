@@ -1,6 +1,7 @@
 package router_test
 
 import (
+	"hash/fnv"
 	"strconv"
 	"sync"
 	"sync/atomic"
@@ -98,3 +99,153 @@ func TestConcurrency(t *testing.T) {
 	system.Root.Send(pid, &getRoutees{rpid})
 	wait.Wait()
 }
+
+func TestBoundedConsistentHashGroup_SpillsOverFullRoutees(t *testing.T) {
+	release := make(chan struct{})
+	var mu sync.Mutex
+	received := map[string]int{}
+	var done sync.WaitGroup
+	done.Add(12)
+
+	props := actor.PropsFromFunc(func(ctx actor.Context) {
+		if _, ok := ctx.Message().(string); ok {
+			<-release
+			mu.Lock()
+			received[ctx.Self().Id]++
+			mu.Unlock()
+			done.Done()
+		}
+	})
+	routees := []*actor.PID{system.Root.Spawn(props), system.Root.Spawn(props), system.Root.Spawn(props)}
+
+	var hashed int32
+	r := system.Root.Spawn(router.NewBoundedConsistentHashGroup(1.5, routees, []router.ConsistentHashOption{
+		router.WithKeyExtractor(func(message interface{}) (string, bool) {
+			return "hot", true
+		}),
+		router.WithHashFunc(func(data []byte) uint32 {
+			atomic.AddInt32(&hashed, 1)
+			h := fnv.New32a()
+			_, _ = h.Write(data)
+			return h.Sum32()
+		}),
+	}))
+
+	for i := 0; i < 12; i++ {
+		system.Root.Send(r, "work")
+	}
+	close(release)
+	done.Wait()
+
+	if len(received) < 2 {
+		t.Fatalf("expected the hot key to spill over to other routees, got %v", received)
+	}
+	for id, n := range received {
+		if n > 7 {
+			t.Errorf("routee %s exceeded its bounded load with %d messages", id, n)
+		}
+	}
+	if atomic.LoadInt32(&hashed) == 0 {
+		t.Error("expected the injected hash function to be used")
+	}
+}
+
+type keyedMessage struct {
+	key string
+}
+
+func (m *keyedMessage) HashKey() string {
+	return m.key
+}
+
+func TestConsistentHashGroup_KeyPrecedence(t *testing.T) {
+	received := make(chan string, 4)
+	props := actor.PropsFromFunc(func(ctx actor.Context) {
+		switch ctx.Message().(type) {
+		case *keyedMessage, string:
+			received <- ctx.Self().Id
+		}
+	})
+	routees := []*actor.PID{system.Root.Spawn(props), system.Root.Spawn(props), system.Root.Spawn(props)}
+	fallback := system.Root.Spawn(props)
+
+	var extracted int32
+	r := system.Root.Spawn(router.NewBoundedConsistentHashGroup(0, routees, []router.ConsistentHashOption{
+		router.WithKeyExtractor(func(message interface{}) (string, bool) {
+			atomic.AddInt32(&extracted, 1)
+			s, ok := message.(string)
+			return s, ok && s != ""
+		}),
+		router.WithDefaultRoutee(fallback),
+	}))
+
+	// HashKey takes precedence over the extractor
+	system.Root.Send(r, &keyedMessage{key: "a"})
+	system.Root.Send(r, &keyedMessage{key: "a"})
+	first, second := <-received, <-received
+	if first != second || first == fallback.Id {
+		t.Errorf("expected the same key to reach the same routee, got %s and %s", first, second)
+	}
+	if atomic.LoadInt32(&extracted) != 0 {
+		t.Error("expected the extractor not to be called for Hashable messages")
+	}
+
+	system.Root.Send(r, "b")
+	if id := <-received; id == fallback.Id {
+		t.Error("expected the extracted key to be routed on the ring")
+	}
+
+	system.Root.Send(r, "")
+	if id := <-received; id != fallback.Id {
+		t.Errorf("expected a message without a key to reach the default routee, got %s", id)
+	}
+}
+
+func TestConsistentHashGroup_DeadLettersUnkeyed(t *testing.T) {
+	system := actor.NewActorSystem()
+	deadLetters := make(chan interface{}, 1)
+	sub := system.EventStream.Subscribe(func(evt interface{}) {
+		if e, ok := evt.(*actor.DeadLetterEvent); ok {
+			deadLetters <- e.Message
+		}
+	})
+	defer system.EventStream.Unsubscribe(sub)
+
+	routee := system.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {}))
+	r := system.Root.Spawn(router.NewConsistentHashGroup(routee))
+	system.Root.Send(r, "no key")
+
+	select {
+	case msg := <-deadLetters:
+		if msg != "no key" {
+			t.Errorf("unexpected dead letter %v", msg)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the message without a key to be dead lettered")
+	}
+}
+
+func TestBoundedConsistentHashPool_ConfiguresRoutees(t *testing.T) {
+	received := make(chan string, 1)
+	r := system.Root.Spawn(router.NewBoundedConsistentHashPool(2, 1.5, []router.ConsistentHashOption{
+		router.WithKeyExtractor(func(message interface{}) (string, bool) {
+			s, ok := message.(string)
+			return s, ok
+		}),
+	}, actor.WithFunc(func(ctx actor.Context) {
+		if s, ok := ctx.Message().(string); ok {
+			received <- s
+		}
+	})))
+	defer system.Root.Stop(r)
+
+	system.Root.Send(r, "work")
+	select {
+	case s := <-received:
+		if s != "work" {
+			t.Errorf("expected the routee to receive work, got %s", s)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the message to reach a routee spawned from the pool props")
+	}
+}
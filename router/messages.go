@@ -1,5 +1,11 @@
 package router
 
+import (
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+)
+
 type ManagementMessage interface {
 	ManagementMessage()
 }
@@ -8,8 +14,33 @@ type BroadcastMessage struct {
 	Message interface{}
 }
 
+// BroadcastWithAck sends Message to all routees as a request, the router responds with a BroadcastAck
+// once all routees responded, or Timeout elapsed
+type BroadcastWithAck struct {
+	Message interface{}
+	Timeout time.Duration
+}
+
+// SetRoutees replaces all the routees of a router at once, so no message is routed to a partial set.
+// Routees not in PIDs any more are unwatched, and stopped if the router spawned them. A pool router spawns
+// the routees missing from PIDs to keep its pool size
+type SetRoutees struct {
+	PIDs []*actor.PID
+}
+
+// GetPoolSize asks a router for its number of routees, the router responds with a PoolSize
+type GetPoolSize struct{}
+
+// PoolSize is the response to GetPoolSize
+type PoolSize struct {
+	Size int
+}
+
 func (*AddRoutee) ManagementMessage()        {}
 func (*RemoveRoutee) ManagementMessage()     {}
 func (*GetRoutees) ManagementMessage()       {}
 func (*AdjustPoolSize) ManagementMessage()   {}
 func (*BroadcastMessage) ManagementMessage() {}
+func (*GetPoolSize) ManagementMessage()      {}
+func (*SetRoutees) ManagementMessage()       {}
+func (*BroadcastWithAck) ManagementMessage() {}
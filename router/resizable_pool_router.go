@@ -0,0 +1,89 @@
+package router
+
+import (
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/asynkron/protoactor-go/scheduler"
+)
+
+type resizablePoolRouter struct {
+	PoolRouter
+	minSize        int
+	maxSize        int
+	queueThreshold int
+	cooldown       time.Duration
+}
+
+type resizablePoolState struct {
+	roundRobinState
+	cancel scheduler.CancelFunc
+}
+
+// resizePool is sent to the router actor to check whether the pool should be resized
+type resizePool struct{}
+
+// NewResizablePool creates a round robin pool starting with minSize routees. Once per cooldown, a routee is added
+// when the routees have more than queueThreshold messages queued on average, up to maxSize routees.
+// An idle routee is removed when no messages are queued, down to minSize routees, and stops once it drained
+func NewResizablePool(minSize, maxSize, queueThreshold int, cooldown time.Duration, opts ...actor.PropsOption) *actor.Props {
+	if maxSize < minSize {
+		maxSize = minSize
+	}
+
+	return (&actor.Props{}).
+		Configure(actor.WithSpawnFunc(spawner(&resizablePoolRouter{
+			PoolRouter:     PoolRouter{PoolSize: minSize},
+			minSize:        minSize,
+			maxSize:        maxSize,
+			queueThreshold: queueThreshold,
+			cooldown:       cooldown,
+		}))).
+		Configure(opts...)
+}
+
+func (config *resizablePoolRouter) CreateRouterState() State {
+	return &resizablePoolState{}
+}
+
+func (config *resizablePoolRouter) OnStarted(context actor.Context, props *actor.Props, state State) {
+	config.PoolRouter.OnStarted(context, props, state)
+
+	s := scheduler.NewTimerScheduler(context.ActorSystem().Root)
+	state.(*resizablePoolState).cancel = s.SendRepeatedly(config.cooldown, config.cooldown, context.Self(), &resizePool{})
+}
+
+// resize adds or removes a single routee depending on the messages queued for the routees
+func (config *resizablePoolRouter) resize(context actor.Context, props *actor.Props, state State) {
+	routees := state.GetRoutees()
+	size := routees.Len()
+
+	queued := 0
+	routees.ForEach(func(_ int, pid *actor.PID) {
+		process, _ := context.ActorSystem().ProcessRegistry.Get(pid)
+		if ap, ok := process.(*actor.ActorProcess); ok {
+			queued += ap.UserMessageCount()
+		}
+	})
+
+	switch {
+	case size < config.maxSize && queued > size*config.queueThreshold:
+		r := routees.Clone()
+		r.Add(context.Spawn(props))
+		state.SetRoutees(r)
+
+	case size > config.minSize && queued == 0:
+		r := routees.Clone()
+		pid := r.Get(size - 1)
+		r.Remove(pid)
+		state.SetRoutees(r)
+		// the poison pill is queued after any message routed before the routee was removed
+		context.Send(pid, &actor.PoisonPill{})
+	}
+}
+
+func (state *resizablePoolState) stop() {
+	if state.cancel != nil {
+		state.cancel()
+	}
+}
@@ -0,0 +1,35 @@
+package router
+
+import (
+	"testing"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResizablePool_GrowsAndShrinks(t *testing.T) {
+	release := make(chan struct{})
+	props := NewResizablePool(1, 3, 2, 20*time.Millisecond, actor.WithFunc(func(c actor.Context) {
+		if _, ok := c.Message().(int); ok {
+			<-release
+		}
+	}))
+	pid := system.Root.Spawn(props)
+	defer system.Root.Stop(pid)
+
+	poolSize := func() int {
+		res, err := system.Root.RequestFuture(pid, &GetPoolSize{}, time.Second).Result()
+		assert.NoError(t, err)
+		return res.(*PoolSize).Size
+	}
+	assert.Equal(t, 1, poolSize())
+
+	for i := 0; i < 50; i++ {
+		system.Root.Send(pid, i)
+	}
+	assert.Eventually(t, func() bool { return poolSize() == 3 }, 2*time.Second, 10*time.Millisecond)
+
+	close(release)
+	assert.Eventually(t, func() bool { return poolSize() == 1 }, 2*time.Second, 10*time.Millisecond)
+}
@@ -9,3 +9,27 @@ type State interface {
 	GetRoutees() *actor.PIDSet
 	SetSender(sender actor.SenderContext)
 }
+
+// replaceRoutees sets the routees of state to pids in a single step. Removed routees are unwatched, the children of
+// the router among them are stopped once they processed the messages routed to them
+func replaceRoutees(context actor.Context, state State, pids []*actor.PID) {
+	previous := state.GetRoutees()
+	routees := actor.NewPIDSet(pids...)
+	routees.ForEach(func(_ int, pid *actor.PID) {
+		if !previous.Contains(pid) {
+			context.Watch(pid)
+		}
+	})
+	state.SetRoutees(routees)
+
+	children := actor.NewPIDSet(context.Children()...)
+	previous.ForEach(func(_ int, pid *actor.PID) {
+		if routees.Contains(pid) {
+			return
+		}
+		context.Unwatch(pid)
+		if children.Contains(pid) {
+			context.Send(pid, &actor.PoisonPill{})
+		}
+	})
+}
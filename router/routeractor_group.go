@@ -38,6 +38,12 @@ func (a *groupRouterActor) Receive(context actor.Context) {
 		r.Remove(m.PID)
 		a.state.SetRoutees(r)
 
+	case *SetRoutees:
+		replaceRoutees(context, a.state, m.PIDs)
+
+	case *BroadcastWithAck:
+		broadcastWithAck(context, a.state.GetRoutees(), m)
+
 	case *BroadcastMessage:
 		msg := m.Message
 		sender := context.Sender()
@@ -53,5 +59,8 @@ func (a *groupRouterActor) Receive(context actor.Context) {
 		})
 
 		context.Respond(&Routees{PIDs: routees})
+
+	case *GetPoolSize:
+		context.Respond(&PoolSize{Size: a.state.GetRoutees().Len()})
 	}
 }
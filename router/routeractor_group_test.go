@@ -61,6 +61,27 @@ func TestGroupRouterActor_Receive_RemoveRoute(t *testing.T) {
 	mock.AssertExpectationsForObjects(t, state, c)
 }
 
+func TestGroupRouterActor_Receive_SetRoutees(t *testing.T) {
+	state := new(testRouterState)
+
+	a := groupRouterActor{state: state}
+
+	p1 := system.NewLocalPID("p1")
+	p2 := system.NewLocalPID("p2")
+	p3 := system.NewLocalPID("p3")
+	c := new(mockContext)
+	c.On("Message").Return(&SetRoutees{PIDs: []*actor.PID{p2, p3}})
+	c.On("Watch", p3).Once()
+	c.On("Unwatch", p1).Once()
+	c.On("Children").Return([]*actor.PID{})
+
+	state.On("GetRoutees").Return(actor.NewPIDSet(p1, p2))
+	state.On("SetRoutees", actor.NewPIDSet(p2, p3)).Once()
+
+	a.Receive(c)
+	mock.AssertExpectationsForObjects(t, state, c)
+}
+
 func TestGroupRouterActor_Receive_BroadcastMessage(t *testing.T) {
 	state := new(testRouterState)
 	a := groupRouterActor{state: state}
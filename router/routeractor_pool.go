@@ -49,6 +49,12 @@ func (a *poolRouterActor) Receive(context actor.Context) {
 		time.Sleep(time.Millisecond * 1)
 		context.Send(m.PID, &actor.PoisonPill{})
 
+	case *SetRoutees:
+		a.setRoutees(context, m.PIDs)
+
+	case *BroadcastWithAck:
+		broadcastWithAck(context, a.state.GetRoutees(), m)
+
 	case *BroadcastMessage:
 		msg := m.Message
 		sender := context.Sender()
@@ -64,6 +70,20 @@ func (a *poolRouterActor) Receive(context actor.Context) {
 		})
 
 		context.Respond(&Routees{PIDs: routees})
+
+	case *GetPoolSize:
+		context.Respond(&PoolSize{Size: a.state.GetRoutees().Len()})
+
+	case *resizePool:
+		if config, ok := a.config.(*resizablePoolRouter); ok {
+			config.resize(context, a.props, a.state)
+		}
+
+	case *actor.Stopping:
+		if state, ok := a.state.(*resizablePoolState); ok {
+			state.stop()
+		}
+
 	case *actor.Terminated:
 		r := a.state.GetRoutees()
 		if r.Remove(m.Who) {
@@ -71,3 +91,15 @@ func (a *poolRouterActor) Receive(context actor.Context) {
 		}
 	}
 }
+
+// setRoutees replaces the routees of the pool with pids, spawning the routees missing to keep the pool size
+func (a *poolRouterActor) setRoutees(context actor.Context, pids []*actor.PID) {
+	routees := actor.NewPIDSet(pids...)
+	if pool, ok := a.config.(interface{ poolSize() int }); ok {
+		for routees.Len() < pool.poolSize() {
+			routees.Add(context.Spawn(a.props))
+		}
+	}
+
+	replaceRoutees(context, a.state, routees.Values())
+}
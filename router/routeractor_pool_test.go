@@ -2,9 +2,12 @@ package router
 
 import (
 	"testing"
+	"time"
 
 	"github.com/asynkron/protoactor-go/actor"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestPoolRouterActor_Receive_AddRoute(t *testing.T) {
@@ -62,6 +65,33 @@ func TestPoolRouterActor_Receive_RemoveRoute(t *testing.T) {
 	mock.AssertExpectationsForObjects(t, state, c)
 }
 
+func TestPoolRouterActor_Receive_SetRoutees(t *testing.T) {
+	state := new(testRouterState)
+
+	a := poolRouterActor{state: state}
+
+	// p1 was spawned by the pool and is stopped, p2 was not and is only unwatched
+	p1, pr1 := spawnMockProcess("p1")
+	defer removeMockProcess(p1)
+	pr1.On("SendUserMessage", p1, &actor.PoisonPill{}).Once()
+
+	p2 := system.NewLocalPID("p2")
+	p3 := system.NewLocalPID("p3")
+	c := new(mockContext)
+	c.On("Message").Return(&SetRoutees{PIDs: []*actor.PID{p3}})
+	c.On("Watch", p3).Once()
+	c.On("Unwatch", p1).Once()
+	c.On("Unwatch", p2).Once()
+	c.On("Children").Return([]*actor.PID{p1})
+	c.On("Send").Once()
+
+	state.On("GetRoutees").Return(actor.NewPIDSet(p1, p2))
+	state.On("SetRoutees", actor.NewPIDSet(p3)).Once()
+
+	a.Receive(c)
+	mock.AssertExpectationsForObjects(t, state, c, pr1)
+}
+
 func TestPoolRouterActor_Receive_BroadcastMessage(t *testing.T) {
 	state := new(testRouterState)
 	a := poolRouterActor{state: state}
@@ -89,3 +119,37 @@ func TestPoolRouterActor_Receive_BroadcastMessage(t *testing.T) {
 	a.Receive(c)
 	mock.AssertExpectationsForObjects(t, state, c, child)
 }
+
+func TestRoundRobinPool_SetRouteesKeepsThePoolSize(t *testing.T) {
+	props := NewRoundRobinPool(3, actor.WithFunc(func(ctx actor.Context) {}))
+	r := system.Root.Spawn(props)
+	defer system.Root.Stop(r)
+
+	routees := func() []*actor.PID {
+		res, err := system.Root.RequestFuture(r, &GetRoutees{}, time.Second).Result()
+		require.NoError(t, err)
+
+		return res.(*Routees).PIDs
+	}
+
+	previous := routees()
+	require.Len(t, previous, 3)
+	external := system.Root.Spawn(actor.PropsFromFunc(func(ctx actor.Context) {}))
+	defer system.Root.Stop(external)
+
+	system.Root.Send(r, &SetRoutees{PIDs: []*actor.PID{previous[0], external}})
+
+	current := actor.NewPIDSet(routees()...)
+	assert.Equal(t, 3, current.Len())
+	assert.True(t, current.Contains(previous[0]))
+	assert.True(t, current.Contains(external))
+
+	// the owned routees left out are stopped
+	for _, pid := range previous[1:] {
+		assert.False(t, current.Contains(pid))
+		assert.Eventually(t, func() bool {
+			_, ok := system.ProcessRegistry.Get(pid)
+			return !ok
+		}, time.Second, 10*time.Millisecond)
+	}
+}
@@ -0,0 +1,153 @@
+package router
+
+import (
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+)
+
+// ScatterGatherResponse is the response of a scatter gather router, holding the replies received in time
+// in the order they arrived
+type ScatterGatherResponse struct {
+	Responses []interface{}
+}
+
+type scatterGatherGroupRouter struct {
+	GroupRouter
+	first   int
+	timeout time.Duration
+}
+
+type scatterGatherPoolRouter struct {
+	PoolRouter
+	first   int
+	timeout time.Duration
+}
+
+type scatterGatherRouterState struct {
+	routees *actor.PIDSet
+	sender  actor.SenderContext
+	first   int
+	timeout time.Duration
+}
+
+func (state *scatterGatherRouterState) SetSender(sender actor.SenderContext) {
+	state.sender = sender
+}
+
+func (state *scatterGatherRouterState) SetRoutees(routees *actor.PIDSet) {
+	state.routees = routees
+}
+
+func (state *scatterGatherRouterState) GetRoutees() *actor.PIDSet {
+	return state.routees
+}
+
+func (state *scatterGatherRouterState) RouteMessage(message interface{}) {
+	header, msg, sender := actor.UnwrapEnvelope(message)
+	routees := state.routees
+
+	// nobody is waiting for the replies
+	if sender == nil {
+		routees.ForEach(func(i int, pid *actor.PID) {
+			state.sender.Send(pid, message)
+		})
+		return
+	}
+
+	system := state.sender.ActorSystem()
+	expected := state.first
+	if expected <= 0 || expected > routees.Len() {
+		expected = routees.Len()
+	}
+	collector := system.Root.Copy().WithSystemSpawns().Spawn(actor.PropsFromProducer(func() actor.Actor {
+		return &scatterGatherCollector{
+			replyTo:  sender,
+			expected: expected,
+			timeout:  state.timeout,
+		}
+	}))
+
+	routees.ForEach(func(i int, pid *actor.PID) {
+		env := &actor.MessageEnvelope{
+			Message: msg,
+			Sender:  collector,
+		}
+		if header != nil {
+			for _, key := range header.Keys() {
+				env.SetHeader(key, header.Get(key))
+			}
+		}
+		state.sender.Send(pid, env)
+	})
+}
+
+// scatterGatherTimeout is sent to the collector once the timeout elapsed
+type scatterGatherTimeout struct{}
+
+// scatterGatherCollector gathers the replies of the routees, and responds once enough arrived or the timeout elapsed.
+// Replies arriving after that are dead lettered, as the collector stopped
+type scatterGatherCollector struct {
+	replyTo   *actor.PID
+	expected  int
+	timeout   time.Duration
+	timer     *time.Timer
+	responses []interface{}
+}
+
+func (c *scatterGatherCollector) Receive(ctx actor.Context) {
+	switch msg := ctx.Message().(type) {
+	case *actor.Started:
+		if c.expected == 0 {
+			c.complete(ctx)
+			return
+		}
+		self, root := ctx.Self(), ctx.ActorSystem().Root
+		c.timer = time.AfterFunc(c.timeout, func() {
+			root.Send(self, &scatterGatherTimeout{})
+		})
+
+	case *scatterGatherTimeout:
+		c.complete(ctx)
+
+	case actor.SystemMessage, actor.AutoReceiveMessage, *actor.DeadLetterResponse:
+
+	default:
+		c.responses = append(c.responses, msg)
+		if len(c.responses) == c.expected {
+			c.complete(ctx)
+		}
+	}
+}
+
+func (c *scatterGatherCollector) complete(ctx actor.Context) {
+	if c.timer != nil {
+		c.timer.Stop()
+	}
+	ctx.Send(c.replyTo, &ScatterGatherResponse{Responses: c.responses})
+	ctx.Stop(ctx.Self())
+}
+
+// NewScatterGatherFirstPool creates a pool sending each request to all routees, and responding with
+// a ScatterGatherResponse once first replies arrived, or with the replies received once timeout elapsed.
+// A first of zero waits for all routees
+func NewScatterGatherFirstPool(first int, timeout time.Duration, size int, opts ...actor.PropsOption) *actor.Props {
+	return (&actor.Props{}).
+		Configure(actor.WithSpawnFunc(spawner(&scatterGatherPoolRouter{PoolRouter{PoolSize: size}, first, timeout}))).
+		Configure(opts...)
+}
+
+// NewScatterGatherFirstGroup creates a group sending each request to all routees, and responding with
+// a ScatterGatherResponse once first replies arrived, or with the replies received once timeout elapsed.
+// A first of zero waits for all routees
+func NewScatterGatherFirstGroup(first int, timeout time.Duration, routees ...*actor.PID) *actor.Props {
+	return (&actor.Props{}).Configure(actor.WithSpawnFunc(spawner(&scatterGatherGroupRouter{GroupRouter{Routees: actor.NewPIDSet(routees...)}, first, timeout})))
+}
+
+func (config *scatterGatherPoolRouter) CreateRouterState() State {
+	return &scatterGatherRouterState{first: config.first, timeout: config.timeout}
+}
+
+func (config *scatterGatherGroupRouter) CreateRouterState() State {
+	return &scatterGatherRouterState{first: config.first, timeout: config.timeout}
+}
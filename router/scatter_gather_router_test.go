@@ -0,0 +1,87 @@
+package router
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/asynkron/protoactor-go/actor"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScatterGatherFirst(t *testing.T) {
+	slow := make(chan struct{})
+	defer close(slow)
+	reply := func(delay bool) *actor.Props {
+		return actor.PropsFromFunc(func(c actor.Context) {
+			if msg, ok := c.Message().(string); ok {
+				if delay {
+					<-slow
+				}
+				c.Respond(msg + "!")
+			}
+		})
+	}
+	routees := []*actor.PID{
+		system.Root.Spawn(reply(false)),
+		system.Root.Spawn(reply(false)),
+		system.Root.Spawn(reply(true)),
+	}
+
+	cases := map[string]struct {
+		first    int
+		expected int
+	}{
+		"first k":      {2, 2},
+		"timeout":      {3, 2},
+		"all, timeout": {0, 2},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			pid := system.Root.Spawn(NewScatterGatherFirstGroup(tc.first, 100*time.Millisecond, routees...))
+			defer system.Root.Stop(pid)
+
+			res, err := system.Root.RequestFuture(pid, "hello", time.Second).Result()
+			assert.NoError(t, err)
+			assert.Equal(t, []interface{}{"hello!", "hello!"}, res.(*ScatterGatherResponse).Responses)
+		})
+	}
+}
+
+func TestScatterGather_RequestDuringShutdown(t *testing.T) {
+	system := actor.NewActorSystem()
+	routee := system.Root.Spawn(actor.PropsFromFunc(func(c actor.Context) {
+		if msg, ok := c.Message().(string); ok {
+			c.Respond(msg + "!")
+		}
+	}))
+	pid := system.Root.Spawn(NewScatterGatherFirstGroup(1, time.Second, routee))
+
+	// keep the drain busy until the request was answered
+	answered := make(chan struct{})
+	var responses []interface{}
+	var err error
+	requester := system.Root.Spawn(actor.PropsFromFunc(func(c actor.Context) {
+		if c.Message() == "request" {
+			var res interface{}
+			res, err = c.RequestFuture(pid, "hello", time.Second).Result()
+			if err == nil {
+				responses = res.(*ScatterGatherResponse).Responses
+			}
+			close(answered)
+		}
+	}))
+	system.Root.Send(requester, "request")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	system.ShutdownGracefully(ctx)
+	select {
+	case <-answered:
+	case <-time.After(time.Second):
+		t.Fatal("the request was not answered during shutdown")
+	}
+
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{"hello!"}, responses)
+}
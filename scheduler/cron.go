@@ -0,0 +1,274 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CronSchedule is a parsed standard cron expression of five fields: minute, hour, day of month, month and day of week.
+// Fields accept *, values, ranges, lists and steps (e.g. "*/15", "1-5", "MON,WED"), month and day names,
+// and 7 as Sunday. The descriptors @yearly, @annually, @monthly, @weekly, @daily, @midnight and @hourly are supported
+type CronSchedule struct {
+	minute, hour, dom, month, dow uint64
+
+	// a restricted day of month or day of week matches when either matches, as in standard cron
+	domStar, dowStar bool
+}
+
+type cronField struct {
+	min, max int
+	names    map[string]int
+}
+
+var (
+	minuteField = cronField{min: 0, max: 59}
+	hourField   = cronField{min: 0, max: 23}
+	domField    = cronField{min: 1, max: 31}
+	monthField  = cronField{min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	dowField = cronField{min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+
+	cronDescriptors = map[string]string{
+		"@yearly":   "0 0 1 1 *",
+		"@annually": "0 0 1 1 *",
+		"@monthly":  "0 0 1 * *",
+		"@weekly":   "0 0 * * 0",
+		"@daily":    "0 0 * * *",
+		"@midnight": "0 0 * * *",
+		"@hourly":   "0 * * * *",
+	}
+)
+
+// ParseCron parses a standard cron expression
+func ParseCron(expr string) (*CronSchedule, error) {
+	expr = strings.TrimSpace(expr)
+	if descriptor, ok := cronDescriptors[strings.ToLower(expr)]; ok {
+		expr = descriptor
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron expression %q: expected 5 fields, got %d", expr, len(fields))
+	}
+
+	var err error
+	s := &CronSchedule{
+		domStar: fields[2] == "*" || fields[2] == "?",
+		dowStar: fields[4] == "*" || fields[4] == "?",
+	}
+	if s.minute, err = minuteField.parse(fields[0]); err != nil {
+		return nil, fmt.Errorf("cron expression %q: minute: %w", expr, err)
+	}
+	if s.hour, err = hourField.parse(fields[1]); err != nil {
+		return nil, fmt.Errorf("cron expression %q: hour: %w", expr, err)
+	}
+	if s.dom, err = domField.parse(fields[2]); err != nil {
+		return nil, fmt.Errorf("cron expression %q: day of month: %w", expr, err)
+	}
+	if s.month, err = monthField.parse(fields[3]); err != nil {
+		return nil, fmt.Errorf("cron expression %q: month: %w", expr, err)
+	}
+	if s.dow, err = dowField.parse(fields[4]); err != nil {
+		return nil, fmt.Errorf("cron expression %q: day of week: %w", expr, err)
+	}
+	// 7 is Sunday as well
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+
+	return s, nil
+}
+
+func (f cronField) parse(field string) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		b, err := f.parsePart(part)
+		if err != nil {
+			return 0, err
+		}
+		bits |= b
+	}
+
+	return bits, nil
+}
+
+func (f cronField) parsePart(part string) (uint64, error) {
+	rangePart, step := part, 1
+	if i := strings.Index(part, "/"); i >= 0 {
+		var err error
+		rangePart = part[:i]
+		if step, err = strconv.Atoi(part[i+1:]); err != nil || step <= 0 {
+			return 0, fmt.Errorf("invalid step in %q", part)
+		}
+	}
+
+	var from, to int
+	switch {
+	case rangePart == "*" || rangePart == "?":
+		from, to = f.min, f.max
+	case strings.Contains(rangePart, "-"):
+		bounds := strings.SplitN(rangePart, "-", 2)
+		var err error
+		if from, err = f.value(bounds[0]); err != nil {
+			return 0, err
+		}
+		if to, err = f.value(bounds[1]); err != nil {
+			return 0, err
+		}
+	default:
+		var err error
+		if from, err = f.value(rangePart); err != nil {
+			return 0, err
+		}
+		to = from
+		// "5/15" means from 5 to the end of the range
+		if strings.Contains(part, "/") {
+			to = f.max
+		}
+	}
+
+	if from > to {
+		return 0, fmt.Errorf("invalid range %q", part)
+	}
+
+	var bits uint64
+	for v := from; v <= to; v += step {
+		bits |= 1 << uint(v)
+	}
+
+	return bits, nil
+}
+
+func (f cronField) value(s string) (int, error) {
+	if v, ok := f.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", s)
+	}
+	if v < f.min || v > f.max {
+		return 0, fmt.Errorf("value %d out of range [%d, %d]", v, f.min, f.max)
+	}
+
+	return v, nil
+}
+
+// Next returns the first time after t matching the schedule in the location of t, or the zero time if there is none
+// within five years.
+//
+// Matching happens on the wall clock, so a time skipped by a daylight saving shift fires at the first instant after
+// the shift, and a time repeated by a shift fires only once
+func (s *CronSchedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+
+	// search on the wall clock of t, which has no daylight saving shifts in UTC
+	wall := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, time.UTC)
+	for {
+		wall = s.nextWall(wall)
+		if wall.IsZero() {
+			return time.Time{}
+		}
+
+		next := time.Date(wall.Year(), wall.Month(), wall.Day(), wall.Hour(), wall.Minute(), 0, 0, loc)
+		if next.Hour() != wall.Hour() || next.Minute() != wall.Minute() {
+			// the wall time was skipped by a shift: move it forward by the length of the gap, as 2:30 becomes 3:30,
+			// by reading it with the offset in effect before the shift
+			_, before := next.Add(-12 * time.Hour).Zone()
+			next = wall.Add(-time.Duration(before) * time.Second).In(loc)
+		}
+		// the instant of a skipped wall time may be the one of a time which already fired
+		if next.After(t) {
+			return next
+		}
+	}
+}
+
+// nextWall returns the first matching wall time after wall
+func (s *CronSchedule) nextWall(wall time.Time) time.Time {
+	t := wall.Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		switch {
+		case s.month&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+		case !s.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+		case s.hour&(1<<uint(t.Hour())) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, time.UTC)
+		case s.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+
+	return time.Time{}
+}
+
+func (s *CronSchedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+
+	return domMatch || dowMatch
+}
+
+// startCronTimer calls fn at every time of the schedule. The next time is computed from the scheduled time of the
+// previous fire rather than from the clock, so a timer firing a little early can not fire twice for the same time
+func startCronTimer(schedule *CronSchedule, loc *time.Location, fn func()) CancelFunc {
+	var mu sync.Mutex
+	var t *time.Timer
+	stopped := false
+
+	var arm func(after time.Time)
+	arm = func(after time.Time) {
+		next := schedule.Next(after)
+		if next.IsZero() {
+			return
+		}
+
+		t = time.AfterFunc(time.Until(next), func() {
+			mu.Lock()
+			if stopped {
+				mu.Unlock()
+				return
+			}
+			mu.Unlock()
+
+			fn()
+
+			mu.Lock()
+			defer mu.Unlock()
+			if !stopped {
+				arm(next)
+			}
+		})
+	}
+
+	mu.Lock()
+	arm(time.Now().In(loc))
+	mu.Unlock()
+
+	return func() {
+		mu.Lock()
+		defer mu.Unlock()
+
+		stopped = true
+		if t != nil {
+			t.Stop()
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCron(t *testing.T) {
+	for _, expr := range []string{"* * * * *", "*/15 9-17 * * MON-FRI", "0 0 1,15 * *", "5/20 * * jan,Jul 7", "@daily"} {
+		_, err := ParseCron(expr)
+		assert.NoError(t, err, expr)
+	}
+
+	for _, expr := range []string{"* * * *", "60 * * * *", "* * * * 8", "*/0 * * * *", "5-1 * * * *", "@sometimes"} {
+		_, err := ParseCron(expr)
+		assert.Error(t, err, expr)
+	}
+}
+
+func TestCronSchedule_Next(t *testing.T) {
+	utc := func(s string) time.Time {
+		v, err := time.Parse("2006-01-02 15:04", s)
+		require.NoError(t, err)
+		return v
+	}
+
+	cases := []struct {
+		expr, from, next string
+	}{
+		{"0 9 * * MON-FRI", "2024-03-08 09:00", "2024-03-11 09:00"},
+		{"*/15 * * * *", "2024-03-08 09:07", "2024-03-08 09:15"},
+		{"0 0 29 2 *", "2024-03-01 00:00", "2028-02-29 00:00"},
+		// a restricted day of month or day of week matches either
+		{"0 0 13 * FRI", "2024-03-08 00:00", "2024-03-13 00:00"},
+	}
+	for _, c := range cases {
+		schedule, err := ParseCron(c.expr)
+		require.NoError(t, err)
+		assert.Equal(t, utc(c.next), schedule.Next(utc(c.from)), c.expr)
+	}
+}
+
+func TestCronSchedule_NextAcrossDaylightSavingShifts(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	// 2:30 does not exist on 2024-03-10, the clock jumps from 2:00 to 3:00
+	daily, _ := ParseCron("30 2 * * *")
+	next := daily.Next(time.Date(2024, 3, 9, 12, 0, 0, 0, loc))
+	assert.Equal(t, time.Date(2024, 3, 10, 3, 30, 0, 0, loc), next)
+	assert.Equal(t, time.Date(2024, 3, 11, 2, 30, 0, 0, loc), daily.Next(next))
+
+	// 1:30 happens twice on 2024-11-03, when the clock goes back from 2:00 to 1:00
+	daily, _ = ParseCron("30 1 * * *")
+	next = daily.Next(time.Date(2024, 11, 3, 0, 0, 0, 0, loc))
+	assert.Equal(t, 3, next.Day())
+	next = daily.Next(next)
+	assert.Equal(t, 4, next.Day())
+
+	// every half hour fires at each distinct wall clock time once
+	halfHourly, _ := ParseCron("*/30 * * * *")
+	var fires []time.Time
+	for at := time.Date(2024, 11, 3, 0, 0, 0, 0, loc); at.Before(time.Date(2024, 11, 3, 4, 0, 0, 0, loc)); {
+		at = halfHourly.Next(at)
+		fires = append(fires, at)
+	}
+	for i := 1; i < len(fires); i++ {
+		assert.True(t, fires[i].After(fires[i-1]))
+		assert.NotEqual(t, fires[i].Format("15:04"), fires[i-1].Format("15:04"))
+	}
+}
+
+func TestTimerScheduler_SendCron(t *testing.T) {
+	s := NewTimerScheduler(system.Root, WithLocation(time.UTC))
+
+	_, err := s.SendCron("not a cron", nil, "hello")
+	assert.Error(t, err)
+
+	cancel, err := s.SendCron("* * * * *", nil, "hello")
+	require.NoError(t, err)
+	cancel()
+	// cancelling twice is harmless
+	cancel()
+}
@@ -0,0 +1,118 @@
+package scheduler
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// RepeatOption configures the interval of a repeated send or request
+type RepeatOption func(*repeatConfig)
+
+type repeatConfig struct {
+	jitter  float64
+	backoff *Backoff
+}
+
+func newRepeatConfig(opts ...RepeatOption) *repeatConfig {
+	config := &repeatConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	return config
+}
+
+// WithJitter draws each interval uniformly within fraction of the interval around it, so timers started together
+// spread out while firing at the same average rate. The fraction is bounded to [0, 1].
+func WithJitter(fraction float64) RepeatOption {
+	return func(config *repeatConfig) {
+		switch {
+		case fraction < 0:
+			fraction = 0
+		case fraction > 1:
+			fraction = 1
+		}
+		config.jitter = fraction
+	}
+}
+
+// WithBackoff grows the interval after each fire as configured by the backoff, until Backoff.Reset is called.
+func WithBackoff(backoff *Backoff) RepeatOption {
+	return func(config *repeatConfig) {
+		config.backoff = backoff
+	}
+}
+
+func (config *repeatConfig) next(interval time.Duration) time.Duration {
+	if config.backoff != nil {
+		interval = config.backoff.next(interval)
+	}
+
+	return config.jittered(interval)
+}
+
+func (config *repeatConfig) jittered(interval time.Duration) time.Duration {
+	if config.jitter == 0 {
+		return interval
+	}
+
+	spread := config.jitter * float64(interval)
+
+	return interval + time.Duration((rand.Float64()*2-1)*spread)
+}
+
+// Backoff multiplies the interval of a repeated timer by factor after each fire, up to max, until it is reset.
+// It is meant for polling which should slow down while idle: the actor resets the backoff when the poll found work.
+// A Backoff drives a single timer.
+type Backoff struct {
+	mu      sync.Mutex
+	factor  float64
+	max     time.Duration
+	current time.Duration
+	reset   func()
+}
+
+// NewBackoff creates a Backoff growing the interval by factor, which is at least 1, up to max
+func NewBackoff(factor float64, max time.Duration) *Backoff {
+	if factor < 1 {
+		factor = 1
+	}
+
+	return &Backoff{factor: factor, max: max}
+}
+
+// Reset brings the interval back to the initial one, rescheduling the pending fire of the timer
+func (b *Backoff) Reset() {
+	b.mu.Lock()
+	b.current = 0
+	reset := b.reset
+	b.mu.Unlock()
+
+	if reset != nil {
+		reset()
+	}
+}
+
+func (b *Backoff) onReset(reset func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.reset = reset
+}
+
+func (b *Backoff) next(interval time.Duration) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.current == 0 {
+		b.current = interval
+	} else {
+		b.current = time.Duration(float64(b.current) * b.factor)
+	}
+	if b.max > 0 && b.current > b.max {
+		b.current = b.max
+	}
+
+	return b.current
+}
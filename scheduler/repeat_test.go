@@ -0,0 +1,58 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithJitter_Bounded(t *testing.T) {
+	config := newRepeatConfig(WithJitter(0.2))
+	for i := 0; i < 1000; i++ {
+		d := config.next(100 * time.Millisecond)
+		assert.GreaterOrEqual(t, d, 80*time.Millisecond)
+		assert.LessOrEqual(t, d, 120*time.Millisecond)
+	}
+
+	assert.Equal(t, 1.0, newRepeatConfig(WithJitter(3)).jitter)
+	assert.Equal(t, 0.0, newRepeatConfig(WithJitter(-1)).jitter)
+}
+
+func TestBackoff_GrowsToMaxAndResets(t *testing.T) {
+	b := NewBackoff(2, 500*time.Millisecond)
+	config := newRepeatConfig(WithBackoff(b))
+
+	var got []time.Duration
+	for i := 0; i < 5; i++ {
+		got = append(got, config.next(100*time.Millisecond))
+	}
+	assert.Equal(t, []time.Duration{
+		100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond,
+		500 * time.Millisecond, 500 * time.Millisecond,
+	}, got)
+
+	b.Reset()
+	assert.Equal(t, 100*time.Millisecond, config.next(100*time.Millisecond))
+}
+
+func TestBackoff_ResetReschedules(t *testing.T) {
+	b := NewBackoff(2, time.Hour)
+	// grow the interval far beyond the test timeout
+	for i := 0; i < 10; i++ {
+		b.next(10 * time.Millisecond)
+	}
+
+	fired := make(chan struct{}, 10)
+	cancel := startTimer(time.Millisecond, 10*time.Millisecond, func() { fired <- struct{}{} }, WithBackoff(b))
+	defer cancel()
+	<-fired
+
+	// a reset brings the pending fire back to the initial interval
+	b.Reset()
+	select {
+	case <-fired:
+	case <-time.After(time.Second):
+		assert.Fail(t, "reset did not reschedule the timer")
+	}
+}
@@ -20,7 +20,9 @@ const (
 	stateDone
 )
 
-func startTimer(delay, interval time.Duration, fn func()) CancelFunc {
+func startTimer(delay, interval time.Duration, fn func(), opts ...RepeatOption) CancelFunc {
+	config := newRepeatConfig(opts...)
+
 	var t *time.Timer
 	var state int32
 	t = time.AfterFunc(delay, func() {
@@ -28,14 +30,22 @@ func startTimer(delay, interval time.Duration, fn func()) CancelFunc {
 			runtime.Gosched()
 		}
 
-		if state == stateDone {
+		if atomic.LoadInt32(&state) == stateDone {
 			return
 		}
 
 		fn()
-		t.Reset(interval)
+		t.Reset(config.next(interval))
 	})
 
+	if config.backoff != nil {
+		config.backoff.onReset(func() {
+			if atomic.LoadInt32(&state) != stateDone {
+				t.Reset(config.jittered(interval))
+			}
+		})
+	}
+
 	// ensures t != nil and is required to avoid data race in
 	// AfterFunc calling t.Reset
 	atomic.StoreInt32(&state, stateReady)
@@ -49,7 +59,8 @@ func startTimer(delay, interval time.Duration, fn func()) CancelFunc {
 
 // A scheduler utilizing timers to send messages in the future and at regular intervals.
 type TimerScheduler struct {
-	ctx actor.SenderContext
+	ctx      actor.SenderContext
+	location *time.Location
 }
 
 type timerOptionFunc func(*TimerScheduler)
@@ -62,10 +73,17 @@ func WithContext(ctx actor.SenderContext) timerOptionFunc {
 	}
 }
 
+// WithLocation configures the time zone the cron expressions are evaluated in, rather than the default, time.Local.
+func WithLocation(loc *time.Location) timerOptionFunc {
+	return func(s *TimerScheduler) {
+		s.location = loc
+	}
+}
+
 // NewTimerScheduler creates a new scheduler using the EmptyRootContext.
 // Additional options may be specified to override the default behavior.
 func NewTimerScheduler(sender actor.SenderContext, opts ...timerOptionFunc) *TimerScheduler {
-	s := &TimerScheduler{ctx: sender}
+	s := &TimerScheduler{ctx: sender, location: time.Local}
 	for _, opt := range opts {
 		opt(s)
 	}
@@ -82,11 +100,11 @@ func (s *TimerScheduler) SendOnce(delay time.Duration, pid *actor.PID, message i
 }
 
 // SendRepeatedly waits for the initial duration to elapse and then calls Send to forward the message to pid
-// repeatedly for each interval.
-func (s *TimerScheduler) SendRepeatedly(initial, interval time.Duration, pid *actor.PID, message interface{}) CancelFunc {
+// repeatedly for each interval. The options may add jitter to, or back off, the interval.
+func (s *TimerScheduler) SendRepeatedly(initial, interval time.Duration, pid *actor.PID, message interface{}, opts ...RepeatOption) CancelFunc {
 	return startTimer(initial, interval, func() {
 		s.ctx.Send(pid, message)
-	})
+	}, opts...)
 }
 
 // RequestOnce waits for the duration to elapse and then calls actor.SenderContext.Request to forward the message to
@@ -100,9 +118,35 @@ func (s *TimerScheduler) RequestOnce(delay time.Duration, pid *actor.PID, messag
 }
 
 // RequestRepeatedly waits for the initial duration to elapse and then calls Request to forward the message to pid
-// repeatedly for each interval.
-func (s *TimerScheduler) RequestRepeatedly(delay, interval time.Duration, pid *actor.PID, message interface{}) CancelFunc {
+// repeatedly for each interval. The options may add jitter to, or back off, the interval.
+func (s *TimerScheduler) RequestRepeatedly(delay, interval time.Duration, pid *actor.PID, message interface{}, opts ...RepeatOption) CancelFunc {
 	return startTimer(delay, interval, func() {
 		s.ctx.Request(pid, message)
-	})
+	}, opts...)
+}
+
+// SendCron calls Send to forward the message to pid at every time matching the cron expression, in the location of
+// the scheduler. See CronSchedule for the supported expressions.
+func (s *TimerScheduler) SendCron(cronExpr string, pid *actor.PID, message interface{}) (CancelFunc, error) {
+	schedule, err := ParseCron(cronExpr)
+	if err != nil {
+		return nil, err
+	}
+
+	return startCronTimer(schedule, s.location, func() {
+		s.ctx.Send(pid, message)
+	}), nil
+}
+
+// RequestCron calls Request to forward the message to pid at every time matching the cron expression, in the
+// location of the scheduler.
+func (s *TimerScheduler) RequestCron(cronExpr string, pid *actor.PID, message interface{}) (CancelFunc, error) {
+	schedule, err := ParseCron(cronExpr)
+	if err != nil {
+		return nil, err
+	}
+
+	return startCronTimer(schedule, s.location, func() {
+		s.ctx.Request(pid, message)
+	}), nil
 }